@@ -3,34 +3,123 @@ package container
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
 
 	"github.com/refortunato/go_app_base/configs"
 	exampleInfra "github.com/refortunato/go_app_base/internal/example/infra"
+	exampleWeb "github.com/refortunato/go_app_base/internal/example/infra/web"
 	healthInfra "github.com/refortunato/go_app_base/internal/health/infra"
+	healthWeb "github.com/refortunato/go_app_base/internal/health/infra/web"
+	shareddb "github.com/refortunato/go_app_base/internal/shared/db"
 	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"github.com/refortunato/go_app_base/internal/shared/messaging/kafka"
+	"github.com/refortunato/go_app_base/internal/shared/messaging/outbox"
+	"github.com/refortunato/go_app_base/internal/shared/module"
 	"github.com/refortunato/go_app_base/internal/shared/observability"
+	"github.com/refortunato/go_app_base/internal/shared/workerpool"
 	"github.com/refortunato/go_app_base/internal/simple_module"
 )
 
+// Loki log batching: entries are pushed every lokiBatchSize entries or lokiFlushInterval
+const (
+	lokiBatchSize     = 100
+	lokiFlushInterval = time.Second
+)
+
 // Container holds all application dependencies
 // This is the Composition Root of the application
 type Container struct {
-	// Modules
-	ExampleModule *exampleInfra.ExampleModule
-	HealthModule  *healthInfra.HealthModule
-	SimpleModule  *simple_module.SimpleModule
-
 	// Shared infrastructure
+	Config         *configs.Conf
 	Logger         logger.Logger
 	TracerProvider *observability.TracerProvider
 	MeterProvider  *observability.MeterProvider
+	LogProvider    *observability.LogProvider
+	// StmtCache shares prepared statements between repositories (closed on shutdown)
+	StmtCache *shareddb.StmtCache
+	// WorkerPool runs CPU-intensive jobs off the request goroutines (drained on shutdown)
+	WorkerPool *workerpool.WorkerPool
+
+	// Optional metrics push (nil when SERVER_APP_METRICS_PUSH_GATEWAY_URL is empty)
+	PushGatewayReporter *observability.PushGatewayReporter
+	// Publishes pending outbox events (nil when SERVER_APP_OUTBOX_POLL_INTERVAL_SECONDS is 0)
+	OutboxPoller *outbox.OutboxPoller
+
+	// Modules, read through the Get*Module methods (with WithLazyInit they are built on the first call)
+	exampleModule *lazy[*exampleInfra.ExampleModule]
+	healthModule  *lazy[*healthInfra.HealthModule]
+	simpleModule  *lazy[*simple_module.SimpleModule]
+
+	// mu guards modules and shutdownHooks, which lazy initialization may extend concurrently
+	mu sync.Mutex
+	// modules are registered in order and mounted by the route orchestrator
+	modules []module.Module
+	// shutdownHooks run in reverse registration order by Shutdown
+	shutdownHooks []func(ctx context.Context) error
+}
+
+// ContainerOption customizes New
+type ContainerOption func(*containerOptions)
+
+type containerOptions struct {
+	lazyInit      bool
+	kafkaProducer kafka.Producer
+}
+
+// WithLazyInit defers building each module until its Get*Module method is first called,
+// so single-mode deployments (e.g. grpc) only pay for the modules they use
+func WithLazyInit() ContainerOption {
+	return func(o *containerOptions) {
+		o.lazyInit = true
+	}
+}
+
+// WithKafkaProducer makes the outbox poller publish events to Kafka through producer
+// (see outbox.NewKafkaPublisher); without it the events are only logged
+func WithKafkaProducer(producer kafka.Producer) ContainerOption {
+	return func(o *containerOptions) {
+		o.kafkaProducer = producer
+	}
 }
 
 // New creates and wires all application dependencies
 // This is the only place where dependencies are composed
-func New(db *sql.DB, cfg *configs.Conf, tracerProvider *observability.TracerProvider, meterProvider *observability.MeterProvider) (*Container, error) {
+func New(db *sql.DB, cfg *configs.Conf, tracerProvider *observability.TracerProvider, meterProvider *observability.MeterProvider, opts ...ContainerOption) (*Container, error) {
+	var options containerOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// OpenTelemetry log export (noop unless SERVER_APP_OTEL_LOGS_ENABLED=true)
+	logProvider, err := observability.NewLogExporter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	// Logger
-	log := logger.NewSlogLogger(cfg.ImageName, cfg.ImageVersion)
+	var logOptions []logger.SlogLoggerOption
+	if logProvider.Enabled() {
+		logOptions = append(logOptions, logger.WithOTelLoggerProvider(logProvider.GetProvider()))
+	}
+	log := logger.NewSlogLogger(cfg.ImageName, cfg.ImageVersion, logOptions...)
+	var lokiLogger *logger.LokiLogger
+	if cfg.LogLokiEnabled {
+		lokiLogger = logger.NewLokiLogger(cfg.LogLokiURL, cfg.LogLokiTenantID, lokiBatchSize, lokiFlushInterval,
+			logger.WithLokiImage(cfg.ImageName, cfg.ImageVersion),
+			logger.WithLokiLabels(map[string]string{
+				"app":         cfg.AppName,
+				"environment": cfg.Environment,
+				"imageName":   cfg.ImageName,
+			}),
+		)
+		log = logger.NewMultiLogger(log, lokiLogger)
+	}
+	if cfg.LogSampleRateDebug < 1 || cfg.LogSampleRateInfo < 1 {
+		log = logger.NewSampledLogger(log, cfg.LogSampleRateDebug, cfg.LogSampleRateInfo)
+	}
 	logger.SetGlobalLogger(log)
 
 	// Use context.Background() for initialization logs (no HTTP request context)
@@ -43,17 +132,201 @@ func New(db *sql.DB, cfg *configs.Conf, tracerProvider *observability.TracerProv
 		logger.Info(ctx, "Database tracing enabled (via repository helpers)")
 	}
 
-	// Initialize modules (each module wires its own dependencies)
-	exampleModule := exampleInfra.NewExampleModule(db)
-	healthModule := healthInfra.NewHealthModule(db)
-	simpleModule := simple_module.NewSimpleModule(db)
+	// Prepared statements are reused across requests and repositories
+	stmtCache := shareddb.NewStmtCache(db, cfg.DBStmtCacheSize)
+
+	// Background jobs (CSV imports, reports) run here instead of on HTTP goroutines
+	workers := cfg.WorkerPoolWorkers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	workerPool := workerpool.New(workers, cfg.WorkerPoolQueueSize)
+
+	// Transactional outbox shared by the modules that publish events
+	outboxRepo := outbox.NewOutboxRepository(db)
 
-	return &Container{
-		ExampleModule:  exampleModule,
-		HealthModule:   healthModule,
-		SimpleModule:   simpleModule,
+	c := &Container{
+		Config:         cfg,
 		Logger:         log,
 		TracerProvider: tracerProvider,
 		MeterProvider:  meterProvider,
-	}, nil
+		LogProvider:    logProvider,
+		StmtCache:      stmtCache,
+		WorkerPool:     workerPool,
+	}
+
+	// Flush pending log records last (hooks run in reverse order)
+	c.OnShutdown(logProvider.Shutdown)
+	if lokiLogger != nil {
+		c.OnShutdown(lokiLogger.Flush)
+	}
+	c.OnShutdown(func(ctx context.Context) error {
+		return stmtCache.Close()
+	})
+	// Finish queued jobs before the statements and log exporter they may use are closed
+	c.OnShutdown(workerPool.Shutdown)
+
+	// Modules (each module wires its own dependencies) register themselves when built,
+	// so their routes and health checks are picked up automatically
+	c.healthModule = newLazy(func() (*healthInfra.HealthModule, error) {
+		healthModule := healthInfra.NewHealthModule(db, cfg)
+		c.RegisterModule(healthWeb.NewModule(healthModule))
+		return healthModule, nil
+	})
+	c.exampleModule = newLazy(func() (*exampleInfra.ExampleModule, error) {
+		exampleModule := exampleInfra.NewExampleModule(db, stmtCache, cfg.ExampleEventSourcingEnabled)
+		c.RegisterModule(exampleWeb.NewModule(exampleModule))
+		return exampleModule, nil
+	})
+	c.simpleModule = newLazy(func() (*simple_module.SimpleModule, error) {
+		simpleModule := simple_module.NewSimpleModule(db, cfg, outboxRepo)
+		c.RegisterModule(simpleModule)
+
+		// Periodic product stock check (product.stock.levels gauge and low-stock alerts)
+		if simpleModule.StockMonitor != nil {
+			simpleModule.StockMonitor.Start(context.Background())
+			c.OnShutdown(func(ctx context.Context) error {
+				simpleModule.StockMonitor.Stop()
+				return nil
+			})
+		}
+
+		// Periodic product count for paginated lists (stats.aggregation.runs counter)
+		if simpleModule.StatsAggregator != nil {
+			simpleModule.StatsAggregator.Start(context.Background())
+			c.OnShutdown(func(ctx context.Context) error {
+				simpleModule.StatsAggregator.Stop()
+				return nil
+			})
+		}
+
+		// Out-of-stock email alerts, sent from a background goroutine
+		if simpleModule.EmailNotifier != nil {
+			simpleModule.EmailNotifier.Start(context.Background(), simpleModule.ProductService)
+			c.OnShutdown(func(ctx context.Context) error {
+				simpleModule.EmailNotifier.Stop()
+				return nil
+			})
+		}
+		return simpleModule, nil
+	})
+
+	if options.lazyInit {
+		logger.Info(ctx, "Modules will be initialized on first use")
+	} else if err := c.initModules(); err != nil {
+		return nil, err
+	}
+
+	// Publish outbox events to Kafka, or only log them when no producer is configured
+	if cfg.OutboxPollIntervalSeconds > 0 {
+		publisher := outbox.NewLogPublisher()
+		if options.kafkaProducer != nil {
+			publisher = outbox.NewKafkaPublisher(options.kafkaProducer, cfg.AppName)
+		}
+		c.OutboxPoller = outbox.NewOutboxPoller(
+			outboxRepo,
+			publisher,
+			time.Duration(cfg.OutboxPollIntervalSeconds)*time.Second,
+		)
+		c.OutboxPoller.Start(ctx)
+		c.OnShutdown(func(ctx context.Context) error {
+			c.OutboxPoller.Stop()
+			return nil
+		})
+	}
+
+	// Push metrics to a Prometheus Pushgateway (flushed once more on shutdown)
+	if cfg.MetricsPushGatewayURL != "" {
+		c.PushGatewayReporter = observability.NewPushGatewayReporter(
+			cfg.MetricsPushGatewayURL,
+			cfg.AppName,
+			time.Duration(cfg.MetricsPushIntervalSeconds)*time.Second,
+			meterProvider,
+		)
+		c.PushGatewayReporter.Start(ctx)
+		c.OnShutdown(func(ctx context.Context) error {
+			c.PushGatewayReporter.Stop()
+			return nil
+		})
+	}
+
+	return c, nil
+}
+
+// initModules builds every module in route registration order
+func (c *Container) initModules() error {
+	if _, err := c.GetHealthModule(); err != nil {
+		return err
+	}
+	if _, err := c.GetExampleModule(); err != nil {
+		return err
+	}
+	if _, err := c.GetSimpleModule(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetExampleModule returns the example module, building it on first use
+func (c *Container) GetExampleModule() (*exampleInfra.ExampleModule, error) {
+	m, err := c.exampleModule.get()
+	if err != nil {
+		return nil, fmt.Errorf("example module: %w", err)
+	}
+	return m, nil
+}
+
+// GetHealthModule returns the health module, building it on first use
+func (c *Container) GetHealthModule() (*healthInfra.HealthModule, error) {
+	m, err := c.healthModule.get()
+	if err != nil {
+		return nil, fmt.Errorf("health module: %w", err)
+	}
+	return m, nil
+}
+
+// GetSimpleModule returns the simple module, building it (and starting its stock monitor) on first use
+func (c *Container) GetSimpleModule() (*simple_module.SimpleModule, error) {
+	m, err := c.simpleModule.get()
+	if err != nil {
+		return nil, fmt.Errorf("simple module: %w", err)
+	}
+	return m, nil
+}
+
+// OnShutdown registers a hook executed by Shutdown
+func (c *Container) OnShutdown(hook func(ctx context.Context) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.shutdownHooks = append(c.shutdownHooks, hook)
+}
+
+// Shutdown runs the registered hooks in reverse order, returning the first error
+func (c *Container) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	hooks := append([]func(ctx context.Context) error(nil), c.shutdownHooks...)
+	c.mu.Unlock()
+
+	var firstErr error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := hooks[i](ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// RegisterModule adds a module whose routes are mounted by RegisterRoutes
+func (c *Container) RegisterModule(m module.Module) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.modules = append(c.modules, m)
+}
+
+// Modules returns the registered modules in registration order
+// With WithLazyInit only the modules built so far are included
+func (c *Container) Modules() []module.Module {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]module.Module(nil), c.modules...)
 }
@@ -0,0 +1,32 @@
+package container
+
+import (
+	"fmt"
+	"sync"
+)
+
+// lazy builds a value on first access and caches the outcome, so a failed
+// initialization returns the same error on every later call instead of retrying
+type lazy[T any] struct {
+	once  sync.Once
+	init  func() (T, error)
+	value T
+	err   error
+}
+
+func newLazy[T any](init func() (T, error)) *lazy[T] {
+	return &lazy[T]{init: init}
+}
+
+// get runs init at most once, even when called concurrently
+func (l *lazy[T]) get() (T, error) {
+	l.once.Do(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				l.err = fmt.Errorf("initialization panicked: %v", r)
+			}
+		}()
+		l.value, l.err = l.init()
+	})
+	return l.value, l.err
+}
@@ -0,0 +1,114 @@
+package container
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	healthInfra "github.com/refortunato/go_app_base/internal/health/infra"
+)
+
+func TestLazy_NotBuiltUntilAccessed(t *testing.T) {
+	calls := 0
+	l := newLazy(func() (int, error) {
+		calls++
+		return 42, nil
+	})
+
+	if calls != 0 {
+		t.Fatalf("init calls = %d before get, want 0", calls)
+	}
+
+	for i := 0; i < 3; i++ {
+		value, err := l.get()
+		if err != nil || value != 42 {
+			t.Fatalf("get() = %d, %v, want 42, nil", value, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("init calls = %d, want 1", calls)
+	}
+}
+
+func TestLazy_ConcurrentGetBuildsOnce(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	l := newLazy(func() (int, error) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		return 7, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if value, _ := l.get(); value != 7 {
+				t.Errorf("get() = %d, want 7", value)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("init calls = %d, want 1", calls)
+	}
+}
+
+func TestLazy_CachesInitError(t *testing.T) {
+	initErr := errors.New("database unreachable")
+	calls := 0
+	l := newLazy(func() (int, error) {
+		calls++
+		return 0, initErr
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := l.get(); !errors.Is(err, initErr) {
+			t.Fatalf("get() #%d err = %v, want the init error", i+1, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("init calls = %d, want 1 (the error is cached, not retried)", calls)
+	}
+}
+
+func TestLazy_PanicBecomesError(t *testing.T) {
+	l := newLazy(func() (int, error) {
+		panic("missing dependency")
+	})
+
+	for i := 0; i < 2; i++ {
+		_, err := l.get()
+		if err == nil || !strings.Contains(err.Error(), "initialization panicked: missing dependency") {
+			t.Errorf("get() #%d err = %v, want the recovered panic", i+1, err)
+		}
+	}
+}
+
+func TestGetHealthModule_ReturnsCachedError(t *testing.T) {
+	initErr := errors.New("database unreachable")
+	calls := 0
+	c := &Container{
+		healthModule: newLazy(func() (*healthInfra.HealthModule, error) {
+			calls++
+			return nil, initErr
+		}),
+	}
+
+	for i := 0; i < 2; i++ {
+		m, err := c.GetHealthModule()
+		if m != nil || !errors.Is(err, initErr) {
+			t.Fatalf("GetHealthModule() #%d = %v, %v, want nil and the init error", i+1, m, err)
+		}
+		if !strings.HasPrefix(err.Error(), "health module: ") {
+			t.Errorf("err = %q, want it prefixed with the module name", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("init calls = %d, want 1", calls)
+	}
+}
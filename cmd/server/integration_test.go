@@ -0,0 +1,339 @@
+//go:build integration
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/refortunato/go_app_base/cmd/server/container"
+	"github.com/refortunato/go_app_base/configs"
+	infraWeb "github.com/refortunato/go_app_base/internal/infra/web"
+	"github.com/refortunato/go_app_base/internal/shared/observability"
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+	"github.com/refortunato/go_app_base/internal/shared/web/server"
+	"github.com/refortunato/go_app_base/internal/simple_module"
+)
+
+// End-to-end tests against the fully wired server and a MySQL container (requires Docker)
+// Run with: go test -tags integration ./cmd/server/
+
+func TestMain(m *testing.M) {
+	// Paths such as the JSON schemas are relative to the repository root
+	if err := os.Chdir("../.."); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to change to the repository root: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+// startServer wires the application like main does and returns the base URL of the API
+func startServer(t *testing.T) string {
+	t.Helper()
+	ctx := context.Background()
+	cfg := testhelpers.NewMySQLConfigForTest(t)
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("invalid configuration: %v", err)
+	}
+
+	db, err := configs.NewDB(ctx, cfg)
+	if err != nil {
+		t.Fatalf("failed to connect to mysql: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+	})
+	if err := configs.ValidateSchema(db, expectedSchema()); err != nil {
+		t.Fatalf("schema.sql does not match the modules: %v", err)
+	}
+
+	tracerProvider, err := observability.NewTracerProvider(cfg)
+	if err != nil {
+		t.Fatalf("failed to create tracer provider: %v", err)
+	}
+	meterProvider, err := observability.NewMeterProvider(cfg)
+	if err != nil {
+		t.Fatalf("failed to create meter provider: %v", err)
+	}
+
+	c, err := container.New(db, cfg, tracerProvider, meterProvider)
+	if err != nil {
+		t.Fatalf("failed to create container: %v", err)
+	}
+	t.Cleanup(func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := c.Shutdown(shutdownCtx); err != nil {
+			t.Errorf("container shutdown: %v", err)
+		}
+	})
+
+	ginServer := server.NewGinServerWithRoutes(
+		server.GinServerConfig{
+			Port:                  cfg.WebServerPort,
+			ServiceName:           cfg.OtelServiceName,
+			AppName:               cfg.AppName,
+			Logger:                c.Logger,
+			ContentTypeExceptions: simple_module.ContentTypeExceptions,
+			HTTP:                  httpServerConfig(cfg),
+		},
+		infraWeb.RegisterRoutes(c),
+	)
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- ginServer.Start()
+	}()
+	t.Cleanup(func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := ginServer.Shutdown(shutdownCtx); err != nil {
+			t.Errorf("server shutdown: %v", err)
+		}
+	})
+
+	select {
+	case <-ginServer.Listening():
+	case err := <-serverErr:
+		t.Fatalf("server failed to start: %v", err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("server did not start listening")
+	}
+	return fmt.Sprintf("http://127.0.0.1:%d", ginServer.Addr().(*net.TCPAddr).Port)
+}
+
+// apiResponse is a decoded response; body is nil for empty bodies
+type apiResponse struct {
+	status int
+	body   map[string]any
+}
+
+func doRequest(t *testing.T, method, url string, body any) apiResponse {
+	t.Helper()
+
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case string:
+		reader = bytes.NewBufferString(b)
+	default:
+		encoded, err := json.Marshal(b)
+		if err != nil {
+			t.Fatalf("failed to encode request body: %v", err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if reader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read response body: %v", err)
+	}
+	result := apiResponse{status: resp.StatusCode}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &result.body); err != nil {
+			t.Fatalf("%s %s: invalid JSON response %q: %v", method, url, raw, err)
+		}
+	}
+	return result
+}
+
+func expectStatus(t *testing.T, resp apiResponse, want int) {
+	t.Helper()
+	if resp.status != want {
+		t.Fatalf("status = %d, want %d (body: %v)", resp.status, want, resp.body)
+	}
+}
+
+func expectCode(t *testing.T, resp apiResponse, status int, code string) {
+	t.Helper()
+	expectStatus(t, resp, status)
+	if resp.body["code"] != code {
+		t.Fatalf("code = %v, want %s (body: %v)", resp.body["code"], code, resp.body)
+	}
+}
+
+func TestIntegration(t *testing.T) {
+	baseURL := startServer(t)
+	missingID := uuid.NewString()
+
+	t.Run("health", func(t *testing.T) {
+		resp := doRequest(t, http.MethodGet, baseURL+"/health", nil)
+		expectStatus(t, resp, http.StatusOK)
+		if resp.body["database"] != "up" {
+			t.Fatalf("database = %v, want up", resp.body["database"])
+		}
+	})
+
+	// Happy path: create -> get -> list -> update -> delete
+	var productID string
+	t.Run("create product", func(t *testing.T) {
+		resp := doRequest(t, http.MethodPost, baseURL+"/products", map[string]any{
+			"name":        "Integration laptop",
+			"description": "Created by the integration test",
+			"price":       4999.9,
+			"stock":       7,
+		})
+		expectStatus(t, resp, http.StatusCreated)
+		productID, _ = resp.body["id"].(string)
+		if uuid.Validate(productID) != nil {
+			t.Fatalf("invalid product id %v", resp.body["id"])
+		}
+		if resp.body["name"] != "Integration laptop" || resp.body["stock"] != float64(7) {
+			t.Fatalf("unexpected product %v", resp.body)
+		}
+	})
+	if productID == "" {
+		t.Fatal("product was not created, skipping the remaining steps")
+	}
+
+	t.Run("get product", func(t *testing.T) {
+		resp := doRequest(t, http.MethodGet, baseURL+"/products/"+productID, nil)
+		expectStatus(t, resp, http.StatusOK)
+		if resp.body["id"] != productID || resp.body["price"] != 4999.9 {
+			t.Fatalf("unexpected product %v", resp.body)
+		}
+		if _, ok := resp.body["_links"]; !ok {
+			t.Fatalf("missing _links in %v", resp.body)
+		}
+	})
+
+	t.Run("list products", func(t *testing.T) {
+		resp := doRequest(t, http.MethodGet, baseURL+"/products?page=1&limit=100", nil)
+		expectStatus(t, resp, http.StatusOK)
+		items, _ := resp.body["items"].([]any)
+		found := false
+		for _, item := range items {
+			if product, ok := item.(map[string]any); ok && product["id"] == productID {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("product %s not listed in %v", productID, resp.body)
+		}
+		pagination, _ := resp.body["pagination"].(map[string]any)
+		if pagination["page"] != float64(1) || pagination["limit"] != float64(100) {
+			t.Fatalf("unexpected pagination %v", pagination)
+		}
+	})
+
+	t.Run("update product", func(t *testing.T) {
+		resp := doRequest(t, http.MethodPut, baseURL+"/products/"+productID, map[string]any{
+			"name":        "Integration laptop v2",
+			"description": "Updated by the integration test",
+			"price":       4599.9,
+			"stock":       3,
+		})
+		expectStatus(t, resp, http.StatusOK)
+		if resp.body["name"] != "Integration laptop v2" || resp.body["stock"] != float64(3) {
+			t.Fatalf("unexpected product %v", resp.body)
+		}
+	})
+
+	t.Run("get updated product", func(t *testing.T) {
+		resp := doRequest(t, http.MethodGet, baseURL+"/products/"+productID, nil)
+		expectStatus(t, resp, http.StatusOK)
+		if resp.body["name"] != "Integration laptop v2" || resp.body["price"] != 4599.9 {
+			t.Fatalf("update not persisted: %v", resp.body)
+		}
+	})
+
+	t.Run("delete product", func(t *testing.T) {
+		resp := doRequest(t, http.MethodDelete, baseURL+"/products/"+productID, nil)
+		expectStatus(t, resp, http.StatusNoContent)
+	})
+
+	t.Run("get deleted product", func(t *testing.T) {
+		resp := doRequest(t, http.MethodGet, baseURL+"/products/"+productID, nil)
+		expectCode(t, resp, http.StatusNotFound, "SIP1002")
+	})
+
+	// Stock of a product with variants is the sum of the variant stock
+	t.Run("stock change on a product with variants", func(t *testing.T) {
+		created := doRequest(t, http.MethodPost, baseURL+"/products", map[string]any{
+			"name":  "Integration t-shirt",
+			"price": 59.9,
+			"stock": 10,
+		})
+		expectStatus(t, created, http.StatusCreated)
+		id, _ := created.body["id"].(string)
+
+		variant := doRequest(t, http.MethodPost, baseURL+"/products/"+id+"/variants", map[string]any{
+			"attributes": map[string]string{"color": "black"},
+			"price":      59.9,
+			"stock":      4,
+		})
+		expectStatus(t, variant, http.StatusCreated)
+
+		product := doRequest(t, http.MethodGet, baseURL+"/products/"+id, nil)
+		expectStatus(t, product, http.StatusOK)
+		if product.body["stock"] != float64(4) {
+			t.Fatalf("stock = %v, want the variant stock 4", product.body["stock"])
+		}
+
+		resp := doRequest(t, http.MethodPut, baseURL+"/products/"+id, map[string]any{
+			"name":  "Integration t-shirt",
+			"price": 59.9,
+			"stock": 99,
+		})
+		expectCode(t, resp, http.StatusUnprocessableEntity, "SIP1020")
+	})
+
+	errorCases := []struct {
+		name   string
+		method string
+		path   string
+		body   any
+		status int
+		code   string
+	}{
+		{name: "get unknown product", method: http.MethodGet, path: "/products/" + missingID, status: http.StatusNotFound, code: "SIP1002"},
+		{name: "update unknown product", method: http.MethodPut, path: "/products/" + missingID,
+			body: map[string]any{"name": "Ghost", "price": 1, "stock": 1}, status: http.StatusNotFound, code: "SIP1002"},
+		{name: "delete unknown product", method: http.MethodDelete, path: "/products/" + missingID, status: http.StatusNotFound, code: "SIP1002"},
+		{name: "get product with invalid id", method: http.MethodGet, path: "/products/not-a-uuid", status: http.StatusBadRequest, code: "VAL0002"},
+		{name: "delete product with invalid id", method: http.MethodDelete, path: "/products/not-a-uuid", status: http.StatusBadRequest, code: "VAL0002"},
+		{name: "create with malformed JSON", method: http.MethodPost, path: "/products", body: `{"name": "Broken"`, status: http.StatusBadRequest},
+		{name: "create without name", method: http.MethodPost, path: "/products",
+			body: map[string]any{"price": 10}, status: http.StatusUnprocessableEntity},
+		{name: "create with negative price", method: http.MethodPost, path: "/products",
+			body: map[string]any{"name": "Negative", "price": -1}, status: http.StatusUnprocessableEntity},
+		{name: "create with unknown field", method: http.MethodPost, path: "/products",
+			body: map[string]any{"name": "Extra", "price": 1, "color": "red"}, status: http.StatusUnprocessableEntity},
+		{name: "list above the page size limit", method: http.MethodGet, path: "/products?limit=1000", status: http.StatusBadRequest},
+		{name: "list with invalid page", method: http.MethodGet, path: "/products?page=abc", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range errorCases {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doRequest(t, tt.method, baseURL+tt.path, tt.body)
+			if tt.code != "" {
+				expectCode(t, resp, tt.status, tt.code)
+				return
+			}
+			expectStatus(t, resp, tt.status)
+		})
+	}
+}
@@ -11,9 +11,15 @@ import (
 
 	"github.com/refortunato/go_app_base/cmd/server/container"
 	"github.com/refortunato/go_app_base/configs"
+	exampleInfra "github.com/refortunato/go_app_base/internal/example/infra"
+	healthInfra "github.com/refortunato/go_app_base/internal/health/infra"
+	infraGrpc "github.com/refortunato/go_app_base/internal/infra/grpc"
 	infraWeb "github.com/refortunato/go_app_base/internal/infra/web"
+	"github.com/refortunato/go_app_base/internal/shared/lifecycle"
+	"github.com/refortunato/go_app_base/internal/shared/messaging/outbox"
 	"github.com/refortunato/go_app_base/internal/shared/observability"
 	"github.com/refortunato/go_app_base/internal/shared/web/server"
+	"github.com/refortunato/go_app_base/internal/simple_module"
 
 	// mysql
 	_ "github.com/go-sql-driver/mysql"
@@ -39,18 +45,31 @@ import (
 
 // @schemes http https
 
+// @securityDefinitions.apikey  ApiKeyAuth
+// @in                          header
+// @name                        X-API-Key
+// @description                 Required when SERVER_APP_AUTH_ENABLED=true
+
 func main() {
 	cfg, err := configs.LoadConfig(".")
 	if err != nil {
 		panic(err)
 	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
 
-	db, err := configs.NewMySQL(cfg)
+	db, err := configs.NewDB(context.Background(), cfg)
 	if err != nil {
 		panic(err)
 	}
 	defer db.Close()
 
+	// Falha na inicialização se alguma migração não foi aplicada (tabela ou coluna faltando)
+	if err := configs.ValidateSchema(db, expectedSchema()); err != nil {
+		panic(err)
+	}
+
 	// Initialize OpenTelemetry tracer provider
 	tracerProvider, err := observability.NewTracerProvider(cfg)
 	if err != nil {
@@ -65,7 +84,12 @@ func main() {
 	}()
 
 	// Initialize OpenTelemetry meter provider (non-blocking metrics)
-	meterProvider, err := observability.NewMeterProvider(cfg)
+	// O protocolo OTLP (http ou grpc) vem de SERVER_APP_OTEL_EXPORTER_PROTOCOL
+	var meterOptions []observability.MeterProviderOption
+	if cfg.MetricsPushGatewayURL != "" {
+		meterOptions = append(meterOptions, observability.WithPrometheusRegistry())
+	}
+	meterProvider, err := observability.NewMeterProvider(cfg, meterOptions...)
 	if err != nil {
 		log.Fatalf("Failed to initialize meter provider: %v", err)
 	}
@@ -77,18 +101,31 @@ func main() {
 		}
 	}()
 
-	// Initialize dependency container
-	c, err := container.New(db, cfg, tracerProvider, meterProvider)
-	if err != nil {
-		panic(err)
-	}
-
 	// Determina qual serviço iniciar baseado nos argumentos
 	mode := "api" // padrão
 	if len(os.Args) > 1 {
 		mode = os.Args[1]
 	}
 
+	// Initialize dependency container
+	// A API monta as rotas de todos os módulos; os outros modos criam só os módulos que usam
+	var containerOptions []container.ContainerOption
+	if mode != "api" {
+		containerOptions = append(containerOptions, container.WithLazyInit())
+	}
+	c, err := container.New(db, cfg, tracerProvider, meterProvider, containerOptions...)
+	if err != nil {
+		panic(err)
+	}
+
+	// SIGHUP relê os segredos do Vault (no máximo uma vez por SERVER_APP_VAULT_SECRET_TTL_SECONDS)
+	configWatcher := configs.NewConfigWatcher(cfg)
+	configWatcher.OnChange(func(*configs.Conf) {
+		log.Println("Secrets reloaded from Vault")
+	})
+	configWatcher.Start()
+	defer configWatcher.Stop()
+
 	// Canal para capturar sinais de interrupção
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -101,13 +138,49 @@ func main() {
 	switch mode {
 	case "api":
 		fmt.Println("Starting API server...")
-		srv = server.NewGinServerWithRoutes(
-			cfg.WebServerPort,
+		startupGate := lifecycle.NewReadinessGate()
+		// Espelhamento de tráfego para um ambiente de teste (desligado por padrão)
+		mirrorTarget := ""
+		if cfg.MirrorEnabled {
+			mirrorTarget = cfg.MirrorTargetURL
+		}
+		// Profiler de alocação por requisição, apenas em modo debug
+		allocProfilerThreshold := uint64(0)
+		if cfg.DebugMode && cfg.AllocProfilerBytes > 0 {
+			allocProfilerThreshold = uint64(cfg.AllocProfilerBytes)
+		}
+		ginServer := server.NewGinServerWithRoutes(
+			server.GinServerConfig{
+				Port:                   cfg.WebServerPort,
+				ServiceName:            cfg.OtelServiceName,
+				AppName:                cfg.AppName,
+				OtelEnabled:            cfg.OtelEnabled,
+				B3Enabled:              cfg.OtelB3Enabled,
+				DebugMode:              cfg.DebugMode,
+				AccessLogEnabled:       cfg.AccessLogEnabled,
+				AccessLogSamplingRates: cfg.GetAccessLogSamplingRates(),
+				Logger:                 c.Logger,
+				StartupGate:            startupGate,
+				TrustedProxies:         cfg.TrustedProxies,
+				ForwardedByClientIP:    cfg.ForwardedByClientIP,
+				RemoteIPHeaders:        cfg.TrustedHeaders,
+				PanicAlertThreshold:    cfg.PanicAlertThreshold,
+				PanicAlertWindow:       time.Duration(cfg.PanicAlertWindowSeconds) * time.Second,
+				MirrorTargetURL:        mirrorTarget,
+				MirrorTimeout:          time.Duration(cfg.MirrorTimeoutMs) * time.Millisecond,
+				ContentTypeExceptions:  simple_module.ContentTypeExceptions,
+				AllocProfilerThreshold: allocProfilerThreshold,
+				HTTP:                   httpServerConfig(cfg),
+			},
 			infraWeb.RegisterRoutes(c),
-			cfg.OtelServiceName,
-			cfg.AppName,
-			cfg.OtelEnabled,
 		)
+		srv = ginServer
+
+		// /startupz responde 200 somente depois que a porta foi aberta
+		go func() {
+			<-ginServer.Listening()
+			startupGate.SetReady()
+		}()
 
 		// Inicia o servidor em uma goroutine
 		go func() {
@@ -142,15 +215,27 @@ func main() {
 
 	case "grpc":
 		fmt.Println("Starting gRPC server...")
-		// TODO: Implementar servidor gRPC
-		// server = grpc.NewServer(cfg)
-		// go func() {
-		//     if err := server.Start(); err != nil {
-		//         serverErr <- fmt.Errorf("gRPC server error: %w", err)
-		//     }
-		// }()
-		fmt.Println("gRPC server not implemented yet")
-		os.Exit(1)
+		// Com inicialização lazy, cria agora o módulo usado pelos serviços gRPC para falhar cedo
+		if _, err := c.GetSimpleModule(); err != nil {
+			log.Fatalf("Failed to initialize module: %v", err)
+		}
+		srv = server.NewGRPCServer(
+			server.GRPCServerConfig{
+				Port:              cfg.GRPCServerPort,
+				OtelEnabled:       cfg.OtelEnabled,
+				ReflectionEnabled: cfg.DebugMode || cfg.GRPCReflectionEnabled,
+				Environment:       cfg.Environment,
+				Validator:         nil, // plug a server.JWTValidator here to require bearer tokens
+			},
+			infraGrpc.RegisterServices(c),
+		)
+
+		// Inicia o servidor em uma goroutine
+		go func() {
+			if err := srv.Start(); err != nil {
+				serverErr <- fmt.Errorf("gRPC server error: %w", err)
+			}
+		}()
 
 	default:
 		fmt.Printf("Unknown mode: %s\n", mode)
@@ -179,6 +264,11 @@ func main() {
 			}
 		}
 
+		// Executa os hooks de shutdown do container (ex.: último push de métricas)
+		if err := c.Shutdown(ctx); err != nil {
+			fmt.Printf("Error during container shutdown: %v\n", err)
+		}
+
 		// Fecha a conexão com o banco de dados
 		if err := db.Close(); err != nil {
 			fmt.Printf("Error closing database: %v\n", err)
@@ -188,3 +278,30 @@ func main() {
 		fmt.Println("Server stopped gracefully")
 	}
 }
+
+// expectedSchema merges the tables and columns used by every module
+func expectedSchema() map[string][]string {
+	schema := make(map[string][]string)
+	for _, tables := range []map[string][]string{
+		healthInfra.ExpectedSchema(),
+		exampleInfra.ExpectedSchema(),
+		simple_module.ExpectedSchema(),
+		outbox.ExpectedSchema(),
+	} {
+		for table, columns := range tables {
+			schema[table] = append(schema[table], columns...)
+		}
+	}
+	return schema
+}
+
+// httpServerConfig converts the HTTP server limits of cfg (milliseconds) to the server settings
+func httpServerConfig(cfg *configs.Conf) server.HTTPServerConfig {
+	return server.HTTPServerConfig{
+		ReadTimeout:       time.Duration(cfg.HTTPReadTimeoutMs) * time.Millisecond,
+		WriteTimeout:      time.Duration(cfg.HTTPWriteTimeoutMs) * time.Millisecond,
+		IdleTimeout:       time.Duration(cfg.HTTPIdleTimeoutMs) * time.Millisecond,
+		ReadHeaderTimeout: time.Duration(cfg.HTTPReadHeaderTimeoutMs) * time.Millisecond,
+		MaxHeaderBytes:    cfg.HTTPMaxHeaderBytes,
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/configs"
+	"github.com/refortunato/go_app_base/internal/shared/web/server"
+)
+
+func TestHTTPServerConfig_GinServerUsesConfiguredTimeouts(t *testing.T) {
+	cfg := &configs.Conf{
+		HTTPReadTimeoutMs:       2500,
+		HTTPWriteTimeoutMs:      8000,
+		HTTPIdleTimeoutMs:       30000,
+		HTTPReadHeaderTimeoutMs: 500,
+		HTTPMaxHeaderBytes:      8192,
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("invalid test config: %v", err)
+	}
+
+	srv := server.NewGinServer(gin.New(), "0", httpServerConfig(cfg))
+
+	// GinServer does not expose its http.Server, so read it through reflection
+	httpServer := reflect.ValueOf(srv).Elem().FieldByName("httpServer").Elem()
+	want := map[string]time.Duration{
+		"ReadTimeout":       2500 * time.Millisecond,
+		"WriteTimeout":      8 * time.Second,
+		"IdleTimeout":       30 * time.Second,
+		"ReadHeaderTimeout": 500 * time.Millisecond,
+	}
+	for field, expected := range want {
+		if got := time.Duration(httpServer.FieldByName(field).Int()); got != expected {
+			t.Errorf("http.Server.%s = %s, want %s", field, got, expected)
+		}
+	}
+	if got := httpServer.FieldByName("MaxHeaderBytes").Int(); got != 8192 {
+		t.Errorf("http.Server.MaxHeaderBytes = %d, want 8192", got)
+	}
+}
@@ -0,0 +1,309 @@
+package configs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/refortunato/go_app_base/internal/shared/observability"
+)
+
+func TestValidate_AdminCIDRs(t *testing.T) {
+	cfg := &Conf{AdminAllowCIDRs: "*,10.0.0.0/8,192.168.1.1,fd00::/8", AdminBlockCIDRs: "10.0.13.0/24,::1"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("valid CIDRs: unexpected error %v", err)
+	}
+
+	cfg = &Conf{AdminAllowCIDRs: "10.0.0.0/33", AdminBlockCIDRs: "*,not-an-ip"}
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("invalid CIDRs: expected an error")
+	}
+	for _, want := range []string{
+		`SERVER_APP_ADMIN_ALLOW_CIDRS: "10.0.0.0/33"`,
+		`SERVER_APP_ADMIN_BLOCK_CIDRS: "*"`,
+		`SERVER_APP_ADMIN_BLOCK_CIDRS: "not-an-ip"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+}
+
+func TestValidate_HTTPTimeouts(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      Conf
+		wantErrs []string
+	}{
+		{name: "read below write", cfg: Conf{HTTPReadTimeoutMs: 5000, HTTPWriteTimeoutMs: 10000, HTTPIdleTimeoutMs: 60000}},
+		{name: "read equal to write", cfg: Conf{HTTPReadTimeoutMs: 10000, HTTPWriteTimeoutMs: 10000, HTTPIdleTimeoutMs: 60000},
+			wantErrs: []string{"SERVER_APP_HTTP_READ_TIMEOUT_MS (10000) must be less than"}},
+		{name: "idle equal to write", cfg: Conf{HTTPReadTimeoutMs: 5000, HTTPWriteTimeoutMs: 10000, HTTPIdleTimeoutMs: 10000}},
+		{name: "idle below write", cfg: Conf{HTTPReadTimeoutMs: 5000, HTTPWriteTimeoutMs: 10000, HTTPIdleTimeoutMs: 9500},
+			wantErrs: []string{"SERVER_APP_HTTP_IDLE_TIMEOUT_MS (9500) must be at least"}},
+		{name: "read above write and idle below write", cfg: Conf{HTTPReadTimeoutMs: 20000, HTTPWriteTimeoutMs: 10000, HTTPIdleTimeoutMs: 5000},
+			wantErrs: []string{"SERVER_APP_HTTP_READ_TIMEOUT_MS (20000)", "SERVER_APP_HTTP_IDLE_TIMEOUT_MS (5000)"}},
+		{name: "unset timeouts use the server defaults", cfg: Conf{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidate_HistogramBuckets(t *testing.T) {
+	tests := []struct {
+		name    string
+		buckets []float64
+		wantErr string
+	}{
+		{name: "unset keeps the SDK defaults"},
+		{name: "single boundary", buckets: []float64{0.5}},
+		{name: "strictly increasing", buckets: []float64{0.005, 0.01, 0.1, 1, 10}},
+		{name: "repeated boundary", buckets: []float64{0.1, 0.5, 0.5, 1}, wantErr: "0.5 <= 0.5 at position 2"},
+		{name: "decreasing boundary", buckets: []float64{1, 0.5}, wantErr: "0.5 <= 1 at position 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Conf{OtelHistogramBuckets: tt.buckets}).Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range []string{"SERVER_APP_OTEL_HISTOGRAM_BUCKETS", tt.wantErr} {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadConfig_DefaultsAreValid(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	got := []int{cfg.HTTPReadTimeoutMs, cfg.HTTPWriteTimeoutMs, cfg.HTTPIdleTimeoutMs, cfg.HTTPReadHeaderTimeoutMs}
+	want := []int{5000, 10000, 60000, 5000}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("read, write, idle, read header = %v ms, want %v", got, want)
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("default configuration is invalid: %v", err)
+	}
+}
+
+func TestLoadConfig_HTTPTimeoutVariables(t *testing.T) {
+	tests := []struct {
+		name           string
+		env            map[string]string
+		wantIdle       int
+		wantReadHeader int
+	}{
+		{name: "milliseconds are kept as is",
+			env:      map[string]string{"SERVER_APP_HTTP_IDLE_TIMEOUT_MS": "90500", "SERVER_APP_HTTP_READ_HEADER_TIMEOUT_MS": "500"},
+			wantIdle: 90500, wantReadHeader: 500},
+		{name: "seconds are accepted when milliseconds are unset",
+			env:      map[string]string{"SERVER_APP_HTTP_IDLE_TIMEOUT_SECONDS": "90", "SERVER_APP_HTTP_READ_HEADER_TIMEOUT_SECONDS": "2"},
+			wantIdle: 90000, wantReadHeader: 2000},
+		{name: "milliseconds win over seconds",
+			env: map[string]string{
+				"SERVER_APP_HTTP_IDLE_TIMEOUT_MS": "45000", "SERVER_APP_HTTP_IDLE_TIMEOUT_SECONDS": "90",
+				"SERVER_APP_HTTP_READ_HEADER_TIMEOUT_MS": "1500", "SERVER_APP_HTTP_READ_HEADER_TIMEOUT_SECONDS": "2",
+			},
+			wantIdle: 45000, wantReadHeader: 1500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			cfg, err := LoadConfig(t.TempDir())
+			if err != nil {
+				t.Fatalf("LoadConfig: %v", err)
+			}
+			if cfg.HTTPIdleTimeoutMs != tt.wantIdle || cfg.HTTPReadHeaderTimeoutMs != tt.wantReadHeader {
+				t.Errorf("idle, read header = %d, %d ms, want %d, %d", cfg.HTTPIdleTimeoutMs, cfg.HTTPReadHeaderTimeoutMs, tt.wantIdle, tt.wantReadHeader)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_OtelMetricExporterVariables(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.GetOtelMetricExportInterval() != 10 {
+		t.Errorf("default metric export interval = %d, want 10", cfg.GetOtelMetricExportInterval())
+	}
+	if cfg.GetOtelGRPCEndpoint() != cfg.GetJaegerEndpoint() {
+		t.Errorf("default gRPC endpoint = %q, want the Jaeger endpoint %q", cfg.GetOtelGRPCEndpoint(), cfg.GetJaegerEndpoint())
+	}
+
+	t.Setenv("SERVER_APP_OTEL_METRIC_EXPORT_INTERVAL", "60")
+	t.Setenv("SERVER_APP_OTEL_GRPC_ENDPOINT", "collector:4317")
+	cfg, err = LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.GetOtelMetricExportInterval() != 60 || cfg.GetOtelGRPCEndpoint() != "collector:4317" {
+		t.Errorf("interval, endpoint = %d, %q, want 60, collector:4317", cfg.GetOtelMetricExportInterval(), cfg.GetOtelGRPCEndpoint())
+	}
+}
+
+func TestLoadConfig_ModulePrefixes(t *testing.T) {
+	t.Setenv("SERVER_APP_MODULE_PREFIXES", "simple:/v1, example : /api/v1,invalid,:/orphan")
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	want := map[string]string{"simple": "/v1", "example": "/api/v1"}
+	if !reflect.DeepEqual(cfg.ModulePrefixes, want) {
+		t.Errorf("ModulePrefixes = %v, want %v", cfg.ModulePrefixes, want)
+	}
+}
+
+func TestLoadConfig_TrustedProxies(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if len(cfg.TrustedProxies) != 0 || !cfg.ForwardedByClientIP {
+		t.Errorf("default proxies, forwarded = %v, %v, want none and true", cfg.TrustedProxies, cfg.ForwardedByClientIP)
+	}
+	if want := []string{"X-Forwarded-For", "X-Real-IP"}; !reflect.DeepEqual(cfg.TrustedHeaders, want) {
+		t.Errorf("default headers = %v, want %v", cfg.TrustedHeaders, want)
+	}
+
+	t.Setenv("SERVER_APP_TRUSTED_PROXIES", "10.0.0.0/8, 172.16.0.1")
+	t.Setenv("SERVER_APP_TRUSTED_HEADERS", "CF-Connecting-IP")
+	cfg, err = LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if want := []string{"10.0.0.0/8", "172.16.0.1"}; !reflect.DeepEqual(cfg.TrustedProxies, want) {
+		t.Errorf("proxies = %v, want %v", cfg.TrustedProxies, want)
+	}
+	if want := []string{"CF-Connecting-IP"}; !reflect.DeepEqual(cfg.TrustedHeaders, want) {
+		t.Errorf("headers = %v, want %v", cfg.TrustedHeaders, want)
+	}
+}
+
+func TestLoadConfig_LogSampleRates(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.LogSampleRateDebug != 1 || cfg.LogSampleRateInfo != 1 {
+		t.Errorf("default debug, info rates = %v, %v, want 1, 1", cfg.LogSampleRateDebug, cfg.LogSampleRateInfo)
+	}
+
+	t.Setenv("SERVER_APP_LOG_SAMPLE_RATE_DEBUG", "0.01")
+	t.Setenv("SERVER_APP_LOG_SAMPLE_RATE_INFO", "invalid")
+	cfg, err = LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.LogSampleRateDebug != 0.01 || cfg.LogSampleRateInfo != 1 {
+		t.Errorf("debug, info rates = %v, %v, want 0.01 and the default for an invalid value", cfg.LogSampleRateDebug, cfg.LogSampleRateInfo)
+	}
+}
+
+func TestLoadConfig_DBConnectRetryVariables(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.DBConnectMaxRetries != 5 || cfg.DBConnectRetryDelayMs != 1000 {
+		t.Errorf("default retries, delay = %d, %d ms, want 5, 1000", cfg.DBConnectMaxRetries, cfg.DBConnectRetryDelayMs)
+	}
+
+	t.Setenv("SERVER_APP_DB_CONNECT_MAX_RETRIES", "10")
+	t.Setenv("SERVER_APP_DB_CONNECT_RETRY_DELAY_MS", "250")
+	cfg, err = LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.DBConnectMaxRetries != 10 || cfg.DBConnectRetryDelayMs != 250 {
+		t.Errorf("retries, delay = %d, %d ms, want 10, 250", cfg.DBConnectMaxRetries, cfg.DBConnectRetryDelayMs)
+	}
+}
+
+func TestLoadConfig_OtelSamplingRules(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	want := []observability.SamplingRule{{Pattern: "/health", Rate: 0.01}}
+	if !reflect.DeepEqual(cfg.OtelSamplingRules, want) {
+		t.Errorf("default rules = %v, want %v", cfg.OtelSamplingRules, want)
+	}
+
+	tests := []struct {
+		value string
+		want  []observability.SamplingRule
+	}{
+		{value: `[{"pattern":"/api/*","rate":0.5},{"pattern":"*","rate":1}]`,
+			want: []observability.SamplingRule{{Pattern: "/api/*", Rate: 0.5}, {Pattern: "*", Rate: 1}}},
+		{value: `[]`, want: []observability.SamplingRule{}},
+		// Invalid JSON keeps the default
+		{value: `{"pattern":`, want: want},
+	}
+	for _, tt := range tests {
+		t.Setenv("SERVER_APP_OTEL_SAMPLING_RULES", tt.value)
+		cfg, err := LoadConfig(t.TempDir())
+		if err != nil {
+			t.Fatalf("LoadConfig: %v", err)
+		}
+		if !reflect.DeepEqual(cfg.OtelSamplingRules, tt.want) {
+			t.Errorf("%s: rules = %v, want %v", tt.value, cfg.OtelSamplingRules, tt.want)
+		}
+	}
+}
+
+func TestGetAccessLogSamplingRates(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if want := map[string]float64{"/health": 0.01, "/livez": 0.001}; !reflect.DeepEqual(cfg.GetAccessLogSamplingRates(), want) {
+		t.Errorf("default rates = %v, want %v", cfg.GetAccessLogSamplingRates(), want)
+	}
+
+	cfg = &Conf{AccessLogSamplingRates: `{"/metrics":0.1}`}
+	if want := map[string]float64{"/metrics": 0.1}; !reflect.DeepEqual(cfg.GetAccessLogSamplingRates(), want) {
+		t.Errorf("rates = %v, want %v", cfg.GetAccessLogSamplingRates(), want)
+	}
+	cfg = &Conf{AccessLogSamplingRates: `not json`}
+	if got := cfg.GetAccessLogSamplingRates(); got["/health"] != 0.01 {
+		t.Errorf("invalid JSON: rates = %v, want the defaults", got)
+	}
+}
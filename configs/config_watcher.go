@@ -0,0 +1,108 @@
+package configs
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// ConfigWatcher reloads the Vault secrets of a Conf when the process receives SIGHUP
+// Reads go through the loader cache, so Vault is only queried again once the secrets are older
+// than SERVER_APP_VAULT_SECRET_TTL_SECONDS. Components that copied a secret at startup keep it;
+// those able to switch at runtime subscribe with OnChange
+type ConfigWatcher struct {
+	loader SecretLoader
+
+	mu       sync.RWMutex
+	current  *Conf
+	onChange []func(*Conf)
+
+	signals chan os.Signal
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewConfigWatcher creates a watcher for cfg; without VAULT_ADDR there is nothing to reload
+func NewConfigWatcher(cfg *Conf) *ConfigWatcher {
+	return &ConfigWatcher{
+		loader:  cfg.secretLoader,
+		current: cfg,
+	}
+}
+
+// Current returns the latest configuration; callers must not modify it
+func (w *ConfigWatcher) Current() *Conf {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.current
+}
+
+// OnChange registers fn to be called with the new configuration whenever a reload changes a secret
+func (w *ConfigWatcher) OnChange(fn func(*Conf)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onChange = append(w.onChange, fn)
+}
+
+// Reload reads the secrets and publishes a new configuration if any of them changed
+// On error the current configuration is kept
+func (w *ConfigWatcher) Reload() error {
+	if w.loader == nil {
+		return nil
+	}
+
+	secrets, err := w.loader.Load()
+	if err != nil {
+		return fmt.Errorf("could not reload secrets from Vault: %w", err)
+	}
+
+	w.mu.Lock()
+	next := *w.current
+	if !next.applySecrets(secrets) {
+		w.mu.Unlock()
+		return nil
+	}
+	w.current = &next
+	subscribers := append([]func(*Conf){}, w.onChange...)
+	w.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(&next)
+	}
+	return nil
+}
+
+// Start reloads the secrets on every SIGHUP until Stop is called
+func (w *ConfigWatcher) Start() {
+	w.signals = make(chan os.Signal, 1)
+	w.stop = make(chan struct{})
+	w.done = make(chan struct{})
+	signal.Notify(w.signals, syscall.SIGHUP)
+
+	go func() {
+		defer close(w.done)
+		for {
+			select {
+			case <-w.signals:
+				if err := w.Reload(); err != nil {
+					log.Printf("WARNING: %v; keeping the current secrets", err)
+				}
+			case <-w.stop:
+				signal.Stop(w.signals)
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops listening for SIGHUP and waits for a reload in progress
+func (w *ConfigWatcher) Stop() {
+	if w.stop == nil {
+		return
+	}
+	close(w.stop)
+	<-w.done
+}
@@ -0,0 +1,106 @@
+package configs
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// newWatchedConfig returns a config whose secrets come from a Vault stub without cache
+func newWatchedConfig(t *testing.T, srv *vaultStub) *Conf {
+	t.Helper()
+	cfg := &Conf{DBPassword: "env-db-pass", JWTSecret: "env-jwt-secret"}
+	cfg.secretLoader = NewVaultSecretLoader(srv.URL, vaultTestToken, "go_app_base", WithSecretTTL(0))
+	return cfg
+}
+
+func TestConfigWatcher_ReloadPublishesChangedSecrets(t *testing.T) {
+	srv := newVaultServer(t, "go_app_base", `{"data":{"data":{"SERVER_APP_JWT_SECRET":"rotated-jwt"}}}`)
+	cfg := newWatchedConfig(t, srv)
+	watcher := NewConfigWatcher(cfg)
+
+	var notified []*Conf
+	watcher.OnChange(func(c *Conf) { notified = append(notified, c) })
+
+	if err := watcher.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	current := watcher.Current()
+	if current.JWTSecret != "rotated-jwt" {
+		t.Errorf("JWTSecret = %q, want rotated-jwt", current.JWTSecret)
+	}
+	if current.DBPassword != "env-db-pass" {
+		t.Errorf("DBPassword = %q, want the value kept when missing from Vault", current.DBPassword)
+	}
+	if len(notified) != 1 || notified[0] != current {
+		t.Fatalf("OnChange calls = %d, want 1 with the current config", len(notified))
+	}
+	// The startup config handed to the components is never modified
+	if cfg.JWTSecret != "env-jwt-secret" {
+		t.Errorf("original JWTSecret = %q, want it unchanged", cfg.JWTSecret)
+	}
+
+	// Reading the same secrets again publishes nothing
+	if err := watcher.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if len(notified) != 1 {
+		t.Errorf("OnChange calls = %d, want 1 when nothing changed", len(notified))
+	}
+}
+
+func TestConfigWatcher_ReloadKeepsCurrentWhenVaultUnavailable(t *testing.T) {
+	srv := newVaultServer(t, "go_app_base", `{"data":{"data":{}}}`)
+	cfg := newWatchedConfig(t, srv)
+	watcher := NewConfigWatcher(cfg)
+	watcher.OnChange(func(*Conf) { t.Error("OnChange called after a failed reload") })
+	srv.Close()
+
+	if err := watcher.Reload(); err == nil {
+		t.Error("expected an error from the unreachable Vault")
+	}
+	if watcher.Current() != cfg {
+		t.Error("current config replaced after a failed reload")
+	}
+}
+
+func TestConfigWatcher_WithoutVaultDoesNothing(t *testing.T) {
+	cfg := &Conf{DBPassword: "env-db-pass"}
+	watcher := NewConfigWatcher(cfg)
+
+	if err := watcher.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if watcher.Current() != cfg {
+		t.Error("current config replaced without a secret loader")
+	}
+}
+
+func TestConfigWatcher_ReloadsOnSIGHUP(t *testing.T) {
+	srv := newVaultServer(t, "go_app_base", `{"data":{"data":{"SERVER_APP_DB_PASSWORD":"rotated-db-pass"}}}`)
+	watcher := NewConfigWatcher(newWatchedConfig(t, srv))
+
+	changed := make(chan *Conf, 1)
+	watcher.OnChange(func(c *Conf) { changed <- c })
+	watcher.Start()
+	defer watcher.Stop()
+
+	process, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatalf("FindProcess: %v", err)
+	}
+	if err := process.Signal(syscall.SIGHUP); err != nil {
+		t.Skipf("SIGHUP not supported: %v", err)
+	}
+
+	select {
+	case c := <-changed:
+		if c.DBPassword != "rotated-db-pass" {
+			t.Errorf("DBPassword = %q, want rotated-db-pass", c.DBPassword)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("secrets not reloaded after SIGHUP")
+	}
+}
@@ -1,8 +1,10 @@
 package configs
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/XSAM/otelsql"
@@ -10,7 +12,22 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 )
 
-func NewMySQL(cfg *Conf) (*sql.DB, error) {
+// NewDB opens the database selected by cfg.DBDriver ("mysql" or "sqlite")
+// ctx bounds the total time spent waiting for the database to accept connections
+func NewDB(ctx context.Context, cfg *Conf) (*sql.DB, error) {
+	switch cfg.DBDriver {
+	case "", "mysql":
+		return NewMySQL(ctx, cfg)
+	case "sqlite":
+		return NewSQLite(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", cfg.DBDriver)
+	}
+}
+
+// NewMySQL opens the MySQL connection pool, retrying the first ping while the server starts up
+// (SERVER_APP_DB_CONNECT_MAX_RETRIES, SERVER_APP_DB_CONNECT_RETRY_DELAY_MS)
+func NewMySQL(ctx context.Context, cfg *Conf) (*sql.DB, error) {
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&loc=UTC", cfg.DBUser, cfg.DBPassword, cfg.DBHost, cfg.DBPort, cfg.DBName)
 
 	// Register instrumented driver if observability is enabled
@@ -45,10 +62,40 @@ func NewMySQL(cfg *Conf) (*sql.DB, error) {
 	db.SetConnMaxLifetime(time.Duration(cfg.DBConnMaxLifetime) * time.Hour)   // recicla conexões a cada X tempo
 	db.SetConnMaxIdleTime(time.Duration(cfg.DBConnMaxIdleTime) * time.Minute) // idle máximo antes de destruir conexão
 
-	// Testa conexão
-	if err := db.Ping(); err != nil {
+	// Testa conexão (com retry enquanto o MySQL sobe)
+	retryDelay := time.Duration(cfg.DBConnectRetryDelayMs) * time.Millisecond
+	if err := retryPing(ctx, db, cfg.DBConnectMaxRetries+1, retryDelay); err != nil {
+		db.Close()
 		return nil, err
 	}
 
 	return db, nil
 }
+
+// retryPing pings db up to maxAttempts times, doubling delay after each failure
+// Only the driver error is logged (the DSN with credentials never is)
+func retryPing(ctx context.Context, db *sql.DB, maxAttempts int, delay time.Duration) error {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if err = db.PingContext(ctx); err == nil {
+			return nil
+		}
+		if attempt == maxAttempts {
+			break
+		}
+
+		log.Printf("Database not ready (attempt %d/%d): %v; retrying in %s", attempt, maxAttempts, err, delay)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("database connection aborted after %d attempts: %w", attempt, ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+
+	return fmt.Errorf("database not reachable after %d attempts: %w", maxAttempts, err)
+}
@@ -0,0 +1,92 @@
+package configs
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// flakyConnector fails the first failures connection attempts, counting every attempt
+type flakyConnector struct {
+	failures int
+	attempts int
+}
+
+func (c *flakyConnector) Connect(context.Context) (driver.Conn, error) {
+	c.attempts++
+	if c.attempts <= c.failures {
+		return nil, errors.New("dial tcp 127.0.0.1:3306: connect: connection refused")
+	}
+	return fakeConn{}, nil
+}
+
+func (c *flakyConnector) Driver() driver.Driver { return nil }
+
+// fakeConn is a connection that only supports being pinged and closed
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func TestRetryPing(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int
+		maxAttempts  int
+		wantAttempts int
+		wantErr      bool
+	}{
+		{name: "ready at once", failures: 0, maxAttempts: 5, wantAttempts: 1},
+		{name: "ready after 3 failures", failures: 3, maxAttempts: 5, wantAttempts: 4},
+		{name: "never ready", failures: 100, maxAttempts: 3, wantAttempts: 3, wantErr: true},
+		{name: "at least one attempt", failures: 100, maxAttempts: 0, wantAttempts: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connector := &flakyConnector{failures: tt.failures}
+			db := sql.OpenDB(connector)
+			defer db.Close()
+
+			err := retryPing(context.Background(), db, tt.maxAttempts, time.Millisecond)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("retryPing: %v, want error %v", err, tt.wantErr)
+			}
+			if connector.attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", connector.attempts, tt.wantAttempts)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), "connection refused") {
+				t.Errorf("error = %v, want the driver error", err)
+			}
+		})
+	}
+}
+
+func TestRetryPing_StopsAtContextDeadline(t *testing.T) {
+	connector := &flakyConnector{failures: 100}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := retryPing(ctx, db, 10, 20*time.Millisecond)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("retryPing: %v, want the context deadline", err)
+	}
+	// 10 attempts doubling from 20ms would take over 10s
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("retryPing took %s, want it to stop at the deadline", elapsed)
+	}
+}
+
+func TestNewDB_UnsupportedDriver(t *testing.T) {
+	if _, err := NewDB(context.Background(), &Conf{DBDriver: "postgres"}); err == nil || !strings.Contains(err.Error(), "unsupported database driver") {
+		t.Errorf("NewDB(postgres) error = %v, want an unsupported driver error", err)
+	}
+}
@@ -0,0 +1,53 @@
+//go:build sqlite
+
+package configs
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/XSAM/otelsql"
+	_ "github.com/mattn/go-sqlite3"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// NewSQLite opens the SQLite database at cfg.DBName (file path or ":memory:")
+// Only available when built with -tags sqlite (go-sqlite3 requires cgo)
+func NewSQLite(cfg *Conf) (*sql.DB, error) {
+	driverName := "sqlite3"
+	if cfg.OtelEnabled {
+		var err error
+		driverName, err = otelsql.Register("sqlite3",
+			otelsql.WithAttributes(
+				semconv.DBSystemSqlite,
+			),
+			otelsql.WithSpanOptions(otelsql.SpanOptions{
+				OmitRows:        true,
+				OmitConnPrepare: true,
+			}),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to register instrumented driver: %w", err)
+		}
+	}
+
+	db, err := sql.Open(driverName, cfg.DBName)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite allows a single writer at a time, and every connection to ":memory:"
+	// opens its own empty database, so the pool must hold exactly one connection.
+	// The idle connection is never recycled for the same reason (closing it would
+	// drop an in-memory database)
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
@@ -0,0 +1,13 @@
+//go:build !sqlite
+
+package configs
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// NewSQLite is unavailable unless the binary is built with -tags sqlite
+func NewSQLite(cfg *Conf) (*sql.DB, error) {
+	return nil, errors.New("sqlite driver not available: build with -tags sqlite")
+}
@@ -0,0 +1,40 @@
+//go:build sqlite
+
+package configs
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewDB_SQLite(t *testing.T) {
+	for name, dsn := range map[string]string{
+		"file":      filepath.Join(t.TempDir(), "app.db"),
+		"in-memory": ":memory:",
+	} {
+		t.Run(name, func(t *testing.T) {
+			db, err := NewDB(context.Background(), &Conf{DBDriver: "sqlite", DBName: dsn, DBMaxOpenConnections: 25})
+			if err != nil {
+				t.Fatalf("NewDB: %v", err)
+			}
+			defer db.Close()
+
+			if max := db.Stats().MaxOpenConnections; max != 1 {
+				t.Errorf("MaxOpenConnections = %d, want 1 whatever the pool configuration", max)
+			}
+
+			// Every statement sees the same database, even in memory
+			if _, err := db.Exec(`CREATE TABLE items (id TEXT PRIMARY KEY)`); err != nil {
+				t.Fatalf("CREATE TABLE: %v", err)
+			}
+			if _, err := db.Exec(`INSERT INTO items (id) VALUES ('a'), ('b')`); err != nil {
+				t.Fatalf("INSERT: %v", err)
+			}
+			var count int
+			if err := db.QueryRow(`SELECT COUNT(*) FROM items`).Scan(&count); err != nil || count != 2 {
+				t.Errorf("COUNT = %d, %v, want 2", count, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package configs
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// mysqlColumnsQuery lists the columns of a table in the current MySQL database
+const mysqlColumnsQuery = "SELECT COLUMN_NAME FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?"
+
+// sqliteColumnsQuery is used when INFORMATION_SCHEMA is not available (SQLite)
+const sqliteColumnsQuery = "SELECT name FROM pragma_table_info(?)"
+
+// ValidateSchema checks that every table in expectedTables exists with at least the listed columns,
+// so a missed migration stops the startup instead of failing requests later
+// The returned error joins one error per missing table or column
+func ValidateSchema(db *sql.DB, expectedTables map[string][]string) error {
+	tables := make([]string, 0, len(expectedTables))
+	for table := range expectedTables {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	var errs []error
+	for _, table := range tables {
+		columns, err := tableColumns(db, table)
+		if err != nil {
+			return fmt.Errorf("failed to read the columns of %s: %w", table, err)
+		}
+		if len(columns) == 0 {
+			errs = append(errs, fmt.Errorf("missing table %s", table))
+			continue
+		}
+		for _, column := range expectedTables[table] {
+			if !columns[column] {
+				errs = append(errs, fmt.Errorf("missing column %s.%s", table, column))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("database schema is out of date: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// tableColumns returns the column names of table (empty when the table does not exist)
+func tableColumns(db *sql.DB, table string) (map[string]bool, error) {
+	rows, err := db.Query(mysqlColumnsQuery, table)
+	if err != nil {
+		var sqliteErr error
+		if rows, sqliteErr = db.Query(sqliteColumnsQuery, table); sqliteErr != nil {
+			return nil, err
+		}
+	}
+	defer rows.Close()
+
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		columns[name] = true
+	}
+	return columns, rows.Err()
+}
@@ -0,0 +1,56 @@
+//go:build sqlite
+
+package configs_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/refortunato/go_app_base/configs"
+	exampleInfra "github.com/refortunato/go_app_base/internal/example/infra"
+	healthInfra "github.com/refortunato/go_app_base/internal/health/infra"
+	"github.com/refortunato/go_app_base/internal/shared/messaging/outbox"
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+	"github.com/refortunato/go_app_base/internal/simple_module"
+)
+
+func TestValidateSchema_FixtureMatchesModules(t *testing.T) {
+	db := testhelpers.NewSQLiteForTest(t)
+
+	for name, schema := range map[string]map[string][]string{
+		"health":        healthInfra.ExpectedSchema(),
+		"example":       exampleInfra.ExpectedSchema(),
+		"simple_module": simple_module.ExpectedSchema(),
+		"outbox":        outbox.ExpectedSchema(),
+	} {
+		if err := configs.ValidateSchema(db, schema); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestValidateSchema_ReportsMissingTablesAndColumns(t *testing.T) {
+	db := testhelpers.NewSQLiteForTest(t)
+
+	err := configs.ValidateSchema(db, map[string][]string{
+		"products":       {"id", "name", "not_migrated"},
+		"missing_table":  {"id"},
+		"product_images": {"id", "other_column"},
+	})
+	if err == nil {
+		t.Fatal("expected an error for the missing table and columns")
+	}
+
+	for _, want := range []string{
+		"missing column products.not_migrated",
+		"missing table missing_table",
+		"missing table product_images",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %q", want, err.Error())
+		}
+	}
+	if strings.Contains(err.Error(), "products.name") {
+		t.Errorf("existing column reported as missing: %v", err)
+	}
+}
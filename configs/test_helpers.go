@@ -0,0 +1,133 @@
+//go:build test || integration
+
+package configs
+
+import "github.com/refortunato/go_app_base/internal/shared/observability"
+
+// TestConfigOption customizes the configuration built by NewTestConfig
+type TestConfigOption func(*Conf)
+
+// NewTestConfig returns a configuration for tests that does not read the environment:
+// observability disabled, random ports ("0") and an in-memory SQLite database
+// Run with: go test -tags test ./...
+func NewTestConfig(opts ...TestConfigOption) *Conf {
+	cfg := &Conf{
+		AppName:                  "go_app_base_test",
+		Environment:              "test",
+		WebServerPort:            "0",
+		GRPCServerPort:           "0",
+		DBDriver:                 "sqlite",
+		DBName:                   ":memory:",
+		DBStmtCacheSize:          50,
+		MaxImportBatchSize:       100,
+		MaxBatchLookupSize:       100,
+		PaginationMaxLimit:       100,
+		UploadDirectory:          "uploads",
+		MaxUploadSizeMB:          5,
+		CacheControlMaxAge:       60,
+		LogSampleRateDebug:       1.0,
+		LogSampleRateInfo:        1.0,
+		AdminAllowCIDRs:          "*",
+		KafkaDLQTopicSuffix:      ".dlq",
+		KafkaMaxRetries:          3,
+		OtelServiceName:          "go_app_base_test",
+		OtelBatchTimeout:         5,
+		OtelMaxExportBatchSize:   512,
+		OtelMaxQueueSize:         2048,
+		OtelExportTimeout:        30,
+		OtelMetricExportInterval: 10,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithOtelEnabled enables tracing, metrics and their exporters
+func WithOtelEnabled() TestConfigOption {
+	return func(c *Conf) {
+		c.OtelEnabled = true
+	}
+}
+
+// WithDebugMode enables debug mode (e.g. stack traces in panic responses)
+func WithDebugMode() TestConfigOption {
+	return func(c *Conf) {
+		c.DebugMode = true
+	}
+}
+
+// WithEnvironment sets the deployment environment name
+func WithEnvironment(env string) TestConfigOption {
+	return func(c *Conf) {
+		c.Environment = env
+	}
+}
+
+// fakeConfigProvider holds only the observability settings
+type fakeConfigProvider struct {
+	otelEnabled              bool
+	otelServiceName          string
+	jaegerEndpoint           string
+	environment              string
+	otelBatchTimeout         int
+	otelMaxExportBatchSize   int
+	otelMaxQueueSize         int
+	otelExportTimeout        int
+	otelMetricExportInterval int
+	otelGRPCEndpoint         string
+	otelExporterProtocol     string
+	otelLogsEnabled          bool
+	otelResourceAutoDetect   bool
+	otelExemplarsEnabled     bool
+	otelB3Enabled            bool
+	otelHistogramBuckets     []float64
+	otelSamplingRules        []observability.SamplingRule
+}
+
+var _ observability.ConfigProvider = (*fakeConfigProvider)(nil)
+
+// NewFakeConfigProvider returns an observability.ConfigProvider with the observability
+// settings of NewTestConfig(opts...), for code that does not need a full Conf
+func NewFakeConfigProvider(opts ...TestConfigOption) observability.ConfigProvider {
+	cfg := NewTestConfig(opts...)
+	return &fakeConfigProvider{
+		otelEnabled:              cfg.OtelEnabled,
+		otelServiceName:          cfg.OtelServiceName,
+		jaegerEndpoint:           cfg.JaegerEndpoint,
+		environment:              cfg.Environment,
+		otelBatchTimeout:         cfg.OtelBatchTimeout,
+		otelMaxExportBatchSize:   cfg.OtelMaxExportBatchSize,
+		otelMaxQueueSize:         cfg.OtelMaxQueueSize,
+		otelExportTimeout:        cfg.OtelExportTimeout,
+		otelMetricExportInterval: cfg.OtelMetricExportInterval,
+		otelGRPCEndpoint:         cfg.OtelGRPCEndpoint,
+		otelExporterProtocol:     cfg.OtelExporterProtocol,
+		otelLogsEnabled:          cfg.OtelLogsEnabled,
+		otelResourceAutoDetect:   cfg.OtelResourceAutoDetect,
+		otelExemplarsEnabled:     cfg.OtelExemplarsEnabled,
+		otelB3Enabled:            cfg.OtelB3Enabled,
+		otelHistogramBuckets:     cfg.OtelHistogramBuckets,
+		otelSamplingRules:        cfg.OtelSamplingRules,
+	}
+}
+
+func (f *fakeConfigProvider) GetOtelEnabled() bool               { return f.otelEnabled }
+func (f *fakeConfigProvider) GetOtelServiceName() string         { return f.otelServiceName }
+func (f *fakeConfigProvider) GetJaegerEndpoint() string          { return f.jaegerEndpoint }
+func (f *fakeConfigProvider) GetEnvironment() string             { return f.environment }
+func (f *fakeConfigProvider) GetOtelBatchTimeout() int           { return f.otelBatchTimeout }
+func (f *fakeConfigProvider) GetOtelMaxExportBatchSize() int     { return f.otelMaxExportBatchSize }
+func (f *fakeConfigProvider) GetOtelMaxQueueSize() int           { return f.otelMaxQueueSize }
+func (f *fakeConfigProvider) GetOtelExportTimeout() int          { return f.otelExportTimeout }
+func (f *fakeConfigProvider) GetOtelMetricExportInterval() int   { return f.otelMetricExportInterval }
+func (f *fakeConfigProvider) GetOtelGRPCEndpoint() string        { return f.otelGRPCEndpoint }
+func (f *fakeConfigProvider) GetOtelExporterProtocol() string    { return f.otelExporterProtocol }
+func (f *fakeConfigProvider) GetOtelLogsEnabled() bool           { return f.otelLogsEnabled }
+func (f *fakeConfigProvider) GetOtelResourceAutoDetect() bool    { return f.otelResourceAutoDetect }
+func (f *fakeConfigProvider) GetOtelExemplarsEnabled() bool      { return f.otelExemplarsEnabled }
+func (f *fakeConfigProvider) GetOtelB3Enabled() bool             { return f.otelB3Enabled }
+func (f *fakeConfigProvider) GetOtelHistogramBuckets() []float64 { return f.otelHistogramBuckets }
+func (f *fakeConfigProvider) GetOtelSamplingRules() []observability.SamplingRule {
+	return f.otelSamplingRules
+}
@@ -0,0 +1,51 @@
+//go:build test
+
+package configs
+
+import "testing"
+
+func TestNewTestConfig_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []TestConfigOption
+	}{
+		{name: "defaults"},
+		{name: "otel enabled", opts: []TestConfigOption{WithOtelEnabled()}},
+		{name: "debug mode", opts: []TestConfigOption{WithDebugMode()}},
+		{name: "production environment", opts: []TestConfigOption{WithEnvironment("production")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := NewTestConfig(tt.opts...).Validate(); err != nil {
+				t.Fatalf("NewTestConfig() does not pass Validate(): %v", err)
+			}
+		})
+	}
+}
+
+func TestNewTestConfig_AppliesOptions(t *testing.T) {
+	cfg := NewTestConfig(WithOtelEnabled(), WithDebugMode(), WithEnvironment("staging"))
+	if !cfg.OtelEnabled || !cfg.DebugMode || cfg.Environment != "staging" {
+		t.Fatalf("options not applied: otel=%v debug=%v environment=%q", cfg.OtelEnabled, cfg.DebugMode, cfg.Environment)
+	}
+	if NewTestConfig().OtelEnabled {
+		t.Fatal("options leaked into a new config")
+	}
+}
+
+func TestNewFakeConfigProvider_MatchesTestConfig(t *testing.T) {
+	cfg := NewTestConfig(WithOtelEnabled(), WithEnvironment("staging"))
+	provider := NewFakeConfigProvider(WithOtelEnabled(), WithEnvironment("staging"))
+
+	if provider.GetOtelEnabled() != cfg.GetOtelEnabled() ||
+		provider.GetOtelServiceName() != cfg.GetOtelServiceName() ||
+		provider.GetEnvironment() != cfg.GetEnvironment() ||
+		provider.GetOtelBatchTimeout() != cfg.GetOtelBatchTimeout() ||
+		provider.GetOtelMaxExportBatchSize() != cfg.GetOtelMaxExportBatchSize() ||
+		provider.GetOtelMaxQueueSize() != cfg.GetOtelMaxQueueSize() ||
+		provider.GetOtelExportTimeout() != cfg.GetOtelExportTimeout() ||
+		provider.GetOtelMetricExportInterval() != cfg.GetOtelMetricExportInterval() {
+		t.Fatalf("fake provider does not mirror NewTestConfig: %+v", provider)
+	}
+}
@@ -0,0 +1,140 @@
+package configs
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultSecretTTL is how long secrets loaded from Vault are cached
+const DefaultSecretTTL = time.Hour
+
+// SecretLoader loads secrets as key/value pairs from an external store
+type SecretLoader interface {
+	Load() (map[string]string, error)
+}
+
+// vaultSecretLoader reads secrets from a Vault KV v2 engine mounted at "secret/"
+type vaultSecretLoader struct {
+	addr       string
+	token      string
+	secretPath string
+	ttl        time.Duration
+	client     *http.Client
+
+	mu        sync.Mutex
+	cached    map[string]string
+	expiresAt time.Time
+}
+
+// VaultOption customizes the loader created by NewVaultSecretLoader
+type VaultOption func(*vaultSecretLoader)
+
+// WithSecretTTL sets how long loaded secrets are reused before Vault is read again (0 disables the cache)
+func WithSecretTTL(ttl time.Duration) VaultOption {
+	return func(l *vaultSecretLoader) {
+		l.ttl = ttl
+	}
+}
+
+// NewVaultSecretLoader creates a SecretLoader reading <vaultAddr>/v1/secret/data/<secretPath>
+// Secrets are cached for DefaultSecretTTL unless WithSecretTTL is given
+func NewVaultSecretLoader(vaultAddr, vaultToken, secretPath string, opts ...VaultOption) SecretLoader {
+	l := &vaultSecretLoader{
+		addr:       strings.TrimSuffix(vaultAddr, "/"),
+		token:      vaultToken,
+		secretPath: strings.Trim(secretPath, "/"),
+		ttl:        DefaultSecretTTL,
+		client:     &http.Client{Timeout: 5 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// vaultKVResponse is the relevant part of a KV v2 read response
+// KV entries may hold any JSON value, so values are decoded as any
+type vaultKVResponse struct {
+	Data struct {
+		Data map[string]any `json:"data"`
+	} `json:"data"`
+}
+
+// Load returns the cached secrets while they are younger than the TTL, otherwise reads them from Vault
+// A failed read returns the error and leaves the previous cache in place
+func (l *vaultSecretLoader) Load() (map[string]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.cached != nil && time.Now().Before(l.expiresAt) {
+		return maps.Clone(l.cached), nil
+	}
+
+	secrets, err := l.fetch()
+	if err != nil {
+		return nil, err
+	}
+	l.cached = secrets
+	l.expiresAt = time.Now().Add(l.ttl)
+	return maps.Clone(secrets), nil
+}
+
+// fetch reads the secret from Vault; values that are not strings are skipped
+func (l *vaultSecretLoader) fetch() (map[string]string, error) {
+	req, err := http.NewRequest(http.MethodGet, l.addr+"/v1/secret/data/"+l.secretPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", l.token)
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned status %d for %s", resp.StatusCode, l.secretPath)
+	}
+
+	var body vaultKVResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("invalid vault response: %w", err)
+	}
+
+	secrets := make(map[string]string, len(body.Data.Data))
+	for key, value := range body.Data.Data {
+		if str, ok := value.(string); ok {
+			secrets[key] = str
+		}
+	}
+	return secrets, nil
+}
+
+// secretFields maps the Vault keys (named after the env vars) to the Conf fields they override
+func (c *Conf) secretFields() map[string]*string {
+	return map[string]*string{
+		"SERVER_APP_DB_PASSWORD":    &c.DBPassword,
+		"SERVER_APP_SWAGGER_PASS":   &c.SwaggerPass,
+		"SERVER_APP_JWT_SECRET":     &c.JWTSecret,
+		"SERVER_APP_WEBHOOK_SECRET": &c.WebhookSecret,
+		"SERVER_APP_SMTP_PASSWORD":  &c.SMTPPassword,
+	}
+}
+
+// applySecrets overrides credentials with the non-empty values found in secrets and reports whether any changed
+func (c *Conf) applySecrets(secrets map[string]string) bool {
+	changed := false
+	for key, field := range c.secretFields() {
+		if value := secrets[key]; value != "" && value != *field {
+			*field = value
+			changed = true
+		}
+	}
+	return changed
+}
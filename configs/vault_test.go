@@ -0,0 +1,199 @@
+package configs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+const vaultTestToken = "test-token"
+
+// vaultStub simulates the KV v2 read endpoint /v1/secret/data/<path> and counts the reads
+type vaultStub struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	body     string
+	requests int
+}
+
+func newVaultServer(t *testing.T, path, body string) *vaultStub {
+	t.Helper()
+	stub := &vaultStub{body: body}
+	stub.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stub.mu.Lock()
+		defer stub.mu.Unlock()
+		stub.requests++
+
+		if r.Header.Get("X-Vault-Token") != vaultTestToken {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/v1/secret/data/"+path {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(stub.body))
+	}))
+	t.Cleanup(stub.Close)
+	return stub
+}
+
+// setBody changes the secret returned by the next reads (a rotation in Vault)
+func (s *vaultStub) setBody(body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.body = body
+}
+
+func (s *vaultStub) requestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func TestVaultSecretLoader_Load(t *testing.T) {
+	srv := newVaultServer(t, "go_app_base", `{"data":{"data":{
+		"SERVER_APP_DB_PASSWORD":"vault-db-pass",
+		"SERVER_APP_SWAGGER_PASS":"vault-swagger-pass",
+		"max_connections":10,
+		"rotation":{"enabled":true}
+	},"metadata":{"version":3}}}`)
+
+	secrets, err := NewVaultSecretLoader(srv.URL+"/", vaultTestToken, "/go_app_base/").Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if got := secrets["SERVER_APP_DB_PASSWORD"]; got != "vault-db-pass" {
+		t.Errorf("SERVER_APP_DB_PASSWORD = %q, want vault-db-pass", got)
+	}
+	if got := secrets["SERVER_APP_SWAGGER_PASS"]; got != "vault-swagger-pass" {
+		t.Errorf("SERVER_APP_SWAGGER_PASS = %q, want vault-swagger-pass", got)
+	}
+	// Non-string values are skipped instead of failing the whole entry
+	if len(secrets) != 2 {
+		t.Errorf("secrets = %v, want only the 2 string values", secrets)
+	}
+}
+
+func TestVaultSecretLoader_Errors(t *testing.T) {
+	srv := newVaultServer(t, "go_app_base", `{"data":{"data":{}}}`)
+
+	if _, err := NewVaultSecretLoader(srv.URL, "wrong-token", "go_app_base").Load(); err == nil {
+		t.Error("forbidden: expected an error")
+	}
+	if _, err := NewVaultSecretLoader(srv.URL, vaultTestToken, "other").Load(); err == nil {
+		t.Error("unknown path: expected an error")
+	}
+	if _, err := NewVaultSecretLoader("http://127.0.0.1:1", vaultTestToken, "go_app_base").Load(); err == nil {
+		t.Error("unreachable vault: expected an error")
+	}
+}
+
+func TestVaultSecretLoader_CachesForTTL(t *testing.T) {
+	srv := newVaultServer(t, "go_app_base", `{"data":{"data":{"SERVER_APP_DB_PASSWORD":"first"}}}`)
+	loader := NewVaultSecretLoader(srv.URL, vaultTestToken, "go_app_base")
+
+	first, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	srv.setBody(`{"data":{"data":{"SERVER_APP_DB_PASSWORD":"rotated"}}}`)
+	// Callers may modify the returned map without corrupting the cache
+	first["SERVER_APP_DB_PASSWORD"] = "modified"
+
+	second, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := second["SERVER_APP_DB_PASSWORD"]; got != "first" {
+		t.Errorf("cached SERVER_APP_DB_PASSWORD = %q, want first", got)
+	}
+	if got := srv.requestCount(); got != 1 {
+		t.Errorf("vault requests = %d, want 1 within the TTL", got)
+	}
+}
+
+func TestVaultSecretLoader_ReloadsAfterTTL(t *testing.T) {
+	srv := newVaultServer(t, "go_app_base", `{"data":{"data":{"SERVER_APP_DB_PASSWORD":"first"}}}`)
+	loader := NewVaultSecretLoader(srv.URL, vaultTestToken, "go_app_base", WithSecretTTL(10*time.Millisecond))
+
+	if _, err := loader.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	srv.setBody(`{"data":{"data":{"SERVER_APP_DB_PASSWORD":"rotated"}}}`)
+	time.Sleep(20 * time.Millisecond)
+
+	secrets, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := secrets["SERVER_APP_DB_PASSWORD"]; got != "rotated" {
+		t.Errorf("SERVER_APP_DB_PASSWORD = %q, want the rotated value after the TTL", got)
+	}
+	if got := srv.requestCount(); got != 2 {
+		t.Errorf("vault requests = %d, want 2", got)
+	}
+}
+
+func TestVaultSecretLoader_FailedReloadReturnsError(t *testing.T) {
+	srv := newVaultServer(t, "go_app_base", `{"data":{"data":{"SERVER_APP_DB_PASSWORD":"first"}}}`)
+	loader := NewVaultSecretLoader(srv.URL, vaultTestToken, "go_app_base", WithSecretTTL(0))
+
+	if _, err := loader.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	srv.Close()
+
+	if _, err := loader.Load(); err == nil {
+		t.Error("expected an error once Vault is unreachable and the cache expired")
+	}
+}
+
+func TestLoadConfig_VaultOverridesSecrets(t *testing.T) {
+	srv := newVaultServer(t, "go_app_base", `{"data":{"data":{
+		"SERVER_APP_DB_PASSWORD":"vault-db-pass",
+		"SERVER_APP_JWT_SECRET":"vault-jwt-secret"
+	}}}`)
+	t.Setenv("VAULT_ADDR", srv.URL)
+	t.Setenv("VAULT_TOKEN", vaultTestToken)
+	t.Setenv("SERVER_APP_DB_PASSWORD", "env-db-pass")
+	t.Setenv("SERVER_APP_SWAGGER_PASS", "env-swagger-pass")
+	t.Setenv("SERVER_APP_JWT_SECRET", "env-jwt-secret")
+
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.DBPassword != "vault-db-pass" {
+		t.Errorf("DBPassword = %q, want the Vault value", cfg.DBPassword)
+	}
+	if cfg.JWTSecret != "vault-jwt-secret" {
+		t.Errorf("JWTSecret = %q, want the Vault value", cfg.JWTSecret)
+	}
+	if cfg.SwaggerPass != "env-swagger-pass" {
+		t.Errorf("SwaggerPass = %q, want the env value (not in Vault)", cfg.SwaggerPass)
+	}
+}
+
+func TestLoadConfig_VaultUnavailableFallsBackToEnv(t *testing.T) {
+	t.Setenv("VAULT_ADDR", "http://127.0.0.1:1")
+	t.Setenv("VAULT_TOKEN", vaultTestToken)
+	t.Setenv("SERVER_APP_DB_PASSWORD", "env-db-pass")
+	t.Setenv("SERVER_APP_JWT_SECRET", "env-jwt-secret")
+
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.DBPassword != "env-db-pass" {
+		t.Errorf("DBPassword = %q, want the env value", cfg.DBPassword)
+	}
+	if cfg.JWTSecret != "env-jwt-secret" {
+		t.Errorf("JWTSecret = %q, want the env value", cfg.JWTSecret)
+	}
+}
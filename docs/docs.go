@@ -24,6 +24,81 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/db-stats": {
+            "get": {
+                "description": "Returns the current database connection pool statistics. Counters are cumulative since startup",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get database connection pool stats",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Request a stats reset (not supported, only logged)",
+                        "name": "resetPoolStats",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.GetDBStatsOutputDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/debug/config": {
+            "get": {
+                "description": "Returns the live configuration with passwords, tokens and keys replaced by \"***\". Only available when SERVER_APP_DEBUG_MODE=true",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "debug"
+                ],
+                "summary": "Get current configuration",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/configs.Conf"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/examples/{id}": {
             "get": {
                 "description": "Retrieves a specific example entity from the database",
@@ -66,11 +141,99 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "delete": {
+                "description": "Soft-deletes an example (it can be restored later)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "examples"
+                ],
+                "summary": "Delete example",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Example ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Example deleted"
+                    },
+                    "404": {
+                        "description": "Example not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/examples/{id}/restore": {
+            "post": {
+                "description": "Restores a soft-deleted example",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "examples"
+                ],
+                "summary": "Restore example",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Example ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.RestoreExampleOutputDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Example not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Example is not deleted",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    }
+                }
             }
         },
         "/products": {
             "get": {
-                "description": "Returns a paginated list of products",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns a paginated list of products, optionally filtered by tag",
                 "produces": [
                     "application/json"
                 ],
@@ -89,21 +252,44 @@ const docTemplate = `{
                     {
                         "type": "integer",
                         "default": 10,
-                        "description": "Items per page",
+                        "description": "Items per page (max SERVER_APP_PAGINATION_MAX_LIMIT)",
                         "name": "limit",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products labeled with this tag",
+                        "name": "tag",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Paginated product list",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/controllers.ProductListResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Pagination links (rel=first, prev, next, last)"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid pagination parameters",
+                        "description": "Invalid pagination parameters or tag",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing required scope",
                         "schema": {
                             "$ref": "#/definitions/errors.ProblemDetails"
                         }
@@ -116,8 +302,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Creates a new product in the system",
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates the product or, when a product with the given ID exists, replaces its data. Without an ID a new product is created",
                 "consumes": [
                     "application/json"
                 ],
@@ -127,7 +318,7 @@ const docTemplate = `{
                 "tags": [
                     "products"
                 ],
-                "summary": "Create new product",
+                "summary": "Create or replace product",
                 "parameters": [
                     {
                         "description": "Product data",
@@ -135,60 +326,43 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controllers.CreateProductRequest"
+                            "$ref": "#/definitions/services.UpsertProductRequest"
                         }
                     }
                 ],
                 "responses": {
+                    "200": {
+                        "description": "Product updated",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
                     "201": {
-                        "description": "Created",
+                        "description": "Product created",
                         "schema": {
                             "$ref": "#/definitions/models.Product"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid input or ID (not a UUID)",
                         "schema": {
                             "$ref": "#/definitions/errors.ProblemDetails"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "401": {
+                        "description": "Authentication required",
                         "schema": {
                             "$ref": "#/definitions/errors.ProblemDetails"
                         }
-                    }
-                }
-            }
-        },
-        "/products/{id}": {
-            "get": {
-                "description": "Retrieves a specific product from the database",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "products"
-                ],
-                "summary": "Get product by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Product ID (UUID format)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Missing required scope",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/errors.ProblemDetails"
                         }
                     },
-                    "404": {
-                        "description": "Product not found",
+                    "422": {
+                        "description": "Stock change on a product with variants",
                         "schema": {
                             "$ref": "#/definitions/errors.ProblemDetails"
                         }
@@ -201,8 +375,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Updates an existing product",
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates a new product in the system\nThe body is validated against schemas/CreateProductSchema.json before the handler runs",
                 "consumes": [
                     "application/json"
                 ],
@@ -212,28 +391,21 @@ const docTemplate = `{
                 "tags": [
                     "products"
                 ],
-                "summary": "Update product",
+                "summary": "Create new product",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated product data",
+                        "description": "Product data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controllers.UpdateProductRequest"
+                            "$ref": "#/definitions/services.CreateProductRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/models.Product"
                         }
@@ -244,8 +416,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.ProblemDetails"
                         }
                     },
-                    "404": {
-                        "description": "Product not found",
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing required scope",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Product already exists",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "422": {
+                        "description": "Body does not match the schema (see validation_errors)",
                         "schema": {
                             "$ref": "#/definitions/errors.ProblemDetails"
                         }
@@ -257,49 +447,1836 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Removes a product from the system",
-                "tags": [
-                    "products"
-                ],
-                "summary": "Delete product",
-                "parameters": [
+            }
+        },
+        "/products/export": {
+            "get": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "ApiKeyAuth": []
                     }
                 ],
+                "description": "Streams every product as NDJSON (one JSON object per line). The response is chunked, so a failure after the first line truncates the stream instead of returning an error status",
+                "produces": [
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Export all products",
                 "responses": {
-                    "204": {
-                        "description": "No content"
+                    "200": {
+                        "description": "One product per line",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
                     },
-                    "404": {
-                        "description": "Product not found",
+                    "401": {
+                        "description": "Authentication required",
                         "schema": {
                             "$ref": "#/definitions/errors.ProblemDetails"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "403": {
+                        "description": "Missing required scope",
                         "schema": {
                             "$ref": "#/definitions/errors.ProblemDetails"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "controllers.CreateProductRequest": {
-            "type": "object",
-            "properties": {
+        },
+        "/products/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates products in bulk from a CSV file with the header: name,description,price,stock and an optional external_id column. Rows whose external_id was already imported update that product instead (reported as updated), so a retried import does not duplicate products. Invalid rows are skipped and reported by line number",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Import products from CSV",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file (max 10 MB)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/services.BulkImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing file or invalid CSV header",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing required scope",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/latest": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Redirects (302) to the URL of the most recently created product",
+                "tags": [
+                    "products"
+                ],
+                "summary": "Redirect to the latest product",
+                "responses": {
+                    "302": {
+                        "description": "Location: URL of the latest product",
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the latest product"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing required scope",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "No product exists",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a specific product from the database (JSON or XML, based on the Accept header)\nResponses carry Cache-Control, Last-Modified and ETag; If-Modified-Since returns 304 when the product did not change",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get product by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HTTP date of the cached copy",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProductResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Weak validator of the product version"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Last product update (RFC 1123)"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    },
+                    "400": {
+                        "description": "Invalid product ID (not a UUID)",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing required scope",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "406": {
+                        "description": "Requested media type not supported",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Updates an existing product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Update product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated product data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.UpdateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or product ID",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing required scope",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "422": {
+                        "description": "Stock change on a product with variants",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes a product from the system",
+                "tags": [
+                    "products"
+                ],
+                "summary": "Delete product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid product ID (not a UUID)",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing required scope",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Applies a JSON Merge Patch (RFC 7396) to an existing product. Only fields present in the body are changed; null clears a field",
+                "consumes": [
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Partially update product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.PatchProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or product ID",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing required scope",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "422": {
+                        "description": "Stock change on a product with variants",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/image": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stores an image (.jpg, .jpeg, .png or .webp, max SERVER_APP_MAX_UPLOAD_SIZE_MB) for a product and returns the stored file path",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Upload product image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProductImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing file, unsupported image type or invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing required scope",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/price-history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the price changes of a product, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get product price history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page (max SERVER_APP_PAGINATION_MAX_LIMIT)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.PriceHistoryResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Pagination links (rel=first, prev, next, last)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pagination parameters or product ID",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing required scope",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/stock-threshold": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sets the stock level below which the product raises low-stock alerts (product.stock.low_threshold metric and a warning log). 0 disables the alerts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Set product stock threshold",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stock threshold",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.SetStockThresholdRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid threshold or product ID",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing required scope",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/subscribe": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upgrades the connection to WebSocket and sends a JSON text message (services.ProductStockChangedPayload) each time the product stock changes through this instance. Messages sent by the client are ignored",
+                "tags": [
+                    "products"
+                ],
+                "summary": "Subscribe to product stock changes (WebSocket)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols, then one message per stock change",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductStockChangedPayload"
+                        }
+                    },
+                    "400": {
+                        "description": "Not a WebSocket handshake, or invalid product ID (VAL0002)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing required scope",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/tags": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Labels a product with one or more tags. Tags are lowercased and created on first use; tags the product already has are ignored",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Tag product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tags to add",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.AddProductTagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tags or product ID",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing required scope",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/tags/{tag}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes a tag from a product. Removing a tag the product does not have succeeds without changes",
+                "tags": [
+                    "products"
+                ],
+                "summary": "Untag product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag name",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid tag or product ID",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing required scope",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/variants": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the variants of a product, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "List product variants",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProductVariant"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID (not a UUID)",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing required scope",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds a variant (e.g. color and size) with its own price and stock. The product stock becomes the sum of its variant stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Add product variant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variant data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.AddVariantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductVariant"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid attributes, price, stock or product ID",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing required scope",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/variants/{variantId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes a variant. The product stock becomes the sum of the remaining variants (0 when none is left)",
+                "tags": [
+                    "products"
+                ],
+                "summary": "Delete product variant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Variant ID",
+                        "name": "variantId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid product ID (not a UUID)",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing required scope",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Product or variant not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/routes": {
+            "get": {
+                "description": "Returns every public route (method, path and tags) sorted by path. Admin, debug and Swagger routes are not listed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routes"
+                ],
+                "summary": "List API routes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/routes.RouteInfo"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{source}": {
+            "post": {
+                "description": "Accepts a webhook from an external service. The X-Signature header must hold the hex-encoded HMAC-SHA256 of the raw body computed with SERVER_APP_WEBHOOK_SECRET. Only mounted when the secret is configured",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Receive a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook source (e.g. payments)",
+                        "name": "source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hex-encoded HMAC-SHA256 of the body",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid signature",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "configs.Conf": {
+            "type": "object",
+            "properties": {
+                "accessLogEnabled": {
+                    "type": "boolean"
+                },
+                "adminAllowCIDRs": {
+                    "description": "comma-separated, \"*\" allows all",
+                    "type": "string"
+                },
+                "adminBlockCIDRs": {
+                    "description": "comma-separated",
+                    "type": "string"
+                },
+                "allocProfilerBytes": {
+                    "description": "debug mode only",
+                    "type": "integer"
+                },
+                "apikeys": {
+                    "description": "key1=scope1|scope2,key2=scope3",
+                    "type": "string"
+                },
+                "appName": {
+                    "type": "string"
+                },
+                "authEnabled": {
+                    "type": "boolean"
+                },
+                "baseURL": {
+                    "description": "public URL used in HATEOAS links",
+                    "type": "string"
+                },
+                "businessMetricsEnabled": {
+                    "description": "Product KPIs (products created/deleted, average price, out-of-stock count)",
+                    "type": "boolean"
+                },
+                "cacheControlMaxAge": {
+                    "description": "in seconds",
+                    "type": "integer"
+                },
+                "cdnbaseURL": {
+                    "description": "prefix for relative product image paths",
+                    "type": "string"
+                },
+                "dbcircuitBreakerIntervalSeconds": {
+                    "type": "integer"
+                },
+                "dbcircuitBreakerMaxRequests": {
+                    "description": "Product repository circuit breaker: opens after 5 consecutive database failures, stays open for\nTimeoutSeconds and then lets MaxRequests trial calls through; failure counts reset every IntervalSeconds",
+                    "type": "integer"
+                },
+                "dbcircuitBreakerTimeoutSeconds": {
+                    "type": "integer"
+                },
+                "dbconnMaxIdleTime": {
+                    "description": "in minutes",
+                    "type": "integer"
+                },
+                "dbconnMaxLifetime": {
+                    "description": "in hours",
+                    "type": "integer"
+                },
+                "dbconnectMaxRetries": {
+                    "description": "Startup ping retries (exponential backoff starting at the delay)",
+                    "type": "integer"
+                },
+                "dbconnectRetryDelayMs": {
+                    "type": "integer"
+                },
+                "dbdriver": {
+                    "type": "string"
+                },
+                "dbhost": {
+                    "type": "string"
+                },
+                "dbmaxIdleConnections": {
+                    "type": "integer"
+                },
+                "dbmaxOpenConnections": {
+                    "type": "integer"
+                },
+                "dbname": {
+                    "type": "string"
+                },
+                "dbpassword": {
+                    "type": "string"
+                },
+                "dbport": {
+                    "type": "string"
+                },
+                "dbstmtCacheSize": {
+                    "type": "integer"
+                },
+                "dbuser": {
+                    "type": "string"
+                },
+                "debugMode": {
+                    "type": "boolean"
+                },
+                "deduplicationWindow": {
+                    "description": "in seconds, 0 disables",
+                    "type": "integer"
+                },
+                "environment": {
+                    "type": "string"
+                },
+                "exampleEventSourcingEnabled": {
+                    "description": "Keep Example entities in an in-memory event store instead of MySQL (lost on restart)",
+                    "type": "boolean"
+                },
+                "forwardedByClientIP": {
+                    "type": "boolean"
+                },
+                "grpcreflectionEnabled": {
+                    "type": "boolean"
+                },
+                "grpcserverPort": {
+                    "description": "gRPC server configuration",
+                    "type": "string"
+                },
+                "healthCheckOtelEndpoint": {
+                    "description": "Health check: OTel collector health endpoint (checked when OtelEnabled, empty skips)",
+                    "type": "string"
+                },
+                "healthCheckSlowQueryMs": {
+                    "description": "Health check: database latency above this (ms) reports it as degraded, 0 disables",
+                    "type": "integer"
+                },
+                "httpidleTimeoutMs": {
+                    "type": "integer"
+                },
+                "httpmaxHeaderBytes": {
+                    "type": "integer"
+                },
+                "httpreadHeaderTimeoutMs": {
+                    "type": "integer"
+                },
+                "httpreadTimeoutMs": {
+                    "description": "HTTP server limits (milliseconds / bytes)",
+                    "type": "integer"
+                },
+                "httpwriteTimeoutMs": {
+                    "type": "integer"
+                },
+                "imageName": {
+                    "type": "string"
+                },
+                "imageVersion": {
+                    "type": "string"
+                },
+                "jaegerEndpoint": {
+                    "type": "string"
+                },
+                "kafkaBootstrapServer": {
+                    "description": "Kafka consumer configuration",
+                    "type": "string"
+                },
+                "kafkaDLQEnabled": {
+                    "type": "boolean"
+                },
+                "kafkaDLQTopicSuffix": {
+                    "type": "string"
+                },
+                "kafkaMaxRetries": {
+                    "type": "integer"
+                },
+                "logLokiEnabled": {
+                    "description": "Grafana Loki: also push logs to \u003cLogLokiURL\u003e/loki/api/v1/push (TenantID is sent as X-Scope-OrgID)",
+                    "type": "boolean"
+                },
+                "logLokiTenantID": {
+                    "type": "string"
+                },
+                "logLokiURL": {
+                    "type": "string"
+                },
+                "logSampleRateDebug": {
+                    "description": "Log sampling: fraction of DEBUG/INFO entries kept (0.0-1.0, WARN/ERROR are always kept)",
+                    "type": "number"
+                },
+                "logSampleRateInfo": {
+                    "type": "number"
+                },
+                "maxBatchLookupSize": {
+                    "type": "integer"
+                },
+                "maxImportBatchSize": {
+                    "type": "integer"
+                },
+                "maxUploadSizeMB": {
+                    "type": "integer"
+                },
+                "metricsPushGatewayURL": {
+                    "description": "Prometheus Pushgateway (leave URL empty to disable pushing)",
+                    "type": "string"
+                },
+                "metricsPushIntervalSeconds": {
+                    "type": "integer"
+                },
+                "mirrorEnabled": {
+                    "description": "Traffic shadowing: copy every request to MirrorTargetURL after responding",
+                    "type": "boolean"
+                },
+                "mirrorTargetURL": {
+                    "type": "string"
+                },
+                "mirrorTimeoutMs": {
+                    "type": "integer"
+                },
+                "modulePrefixes": {
+                    "description": "Route prefix overrides by module name (SERVER_APP_MODULE_PREFIXES=simple:/v1,example:/v1)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "otelB3Enabled": {
+                    "description": "Accept and forward Zipkin B3 headers in addition to W3C Trace Context",
+                    "type": "boolean"
+                },
+                "otelBatchTimeout": {
+                    "description": "Optional batching configuration (leave empty for defaults)",
+                    "type": "integer"
+                },
+                "otelEnabled": {
+                    "description": "Observability configuration",
+                    "type": "boolean"
+                },
+                "otelExemplarsEnabled": {
+                    "description": "Record exemplars (trace links) for every metric observation, not only sampled traces",
+                    "type": "boolean"
+                },
+                "otelExportTimeout": {
+                    "description": "Default: 30 seconds",
+                    "type": "integer"
+                },
+                "otelExporterProtocol": {
+                    "description": "OTLP protocol for traces and metrics: \"http\" (to JaegerEndpoint) or \"grpc\" (to OtelGRPCEndpoint)",
+                    "type": "string"
+                },
+                "otelGRPCEndpoint": {
+                    "description": "defaults to JaegerEndpoint",
+                    "type": "string"
+                },
+                "otelHistogramBuckets": {
+                    "description": "Bucket boundaries of the HTTP request duration (ms) and size (bytes) histograms, empty keeps the SDK defaults",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "otelLogsEnabled": {
+                    "description": "Export structured logs over OTLP HTTP to JaegerEndpoint (requires OtelEnabled)",
+                    "type": "boolean"
+                },
+                "otelMaxExportBatchSize": {
+                    "description": "Default: 512",
+                    "type": "integer"
+                },
+                "otelMaxQueueSize": {
+                    "description": "Default: 2048",
+                    "type": "integer"
+                },
+                "otelMetricExportInterval": {
+                    "description": "Default: 10 seconds",
+                    "type": "integer"
+                },
+                "otelResourceAutoDetect": {
+                    "description": "Detect host, container, GCP and Kubernetes resource attributes",
+                    "type": "boolean"
+                },
+                "otelSamplingRules": {
+                    "description": "Per-route trace sampling rates, first matching glob wins (JSON, \"[]\" samples every trace)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/observability.SamplingRule"
+                    }
+                },
+                "otelServiceName": {
+                    "type": "string"
+                },
+                "outboxPollIntervalSeconds": {
+                    "description": "Interval of the outbox poller publishing pending events, in seconds (0 disables it)",
+                    "type": "integer"
+                },
+                "paginationMaxLimit": {
+                    "type": "integer"
+                },
+                "panicAlertThreshold": {
+                    "description": "Panic alerting: alert when this many panics happen within the window (0 disables)",
+                    "type": "integer"
+                },
+                "panicAlertWindowSeconds": {
+                    "type": "integer"
+                },
+                "rabbitMQDSN": {
+                    "description": "RabbitMQ configuration",
+                    "type": "string"
+                },
+                "rateLimitBurst": {
+                    "type": "integer"
+                },
+                "rateLimitKeyOverrides": {
+                    "description": "key1=rps:burst,key2=rps:burst",
+                    "type": "string"
+                },
+                "rateLimitRPS": {
+                    "description": "Rate limiting per client (API key when auth is enabled, otherwise IP), RPS 0 disables\nThe overrides name API keys, so the field name keeps them masked in /debug/config",
+                    "type": "number"
+                },
+                "responseEnvelopeEnabled": {
+                    "description": "HTTP response configuration",
+                    "type": "boolean"
+                },
+                "statsAggregationIntervalSeconds": {
+                    "description": "Interval of the background product count used by paginated lists, in seconds (0 disables it)",
+                    "type": "integer"
+                },
+                "stockCheckIntervalMinutes": {
+                    "description": "Interval of the background product stock check, in minutes (0 disables it)",
+                    "type": "integer"
+                },
+                "swaggerEnabled": {
+                    "type": "boolean"
+                },
+                "swaggerPass": {
+                    "type": "string"
+                },
+                "swaggerUser": {
+                    "type": "string"
+                },
+                "trustedHeaders": {
+                    "description": "headers read for the client IP, in order",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "trustedProxies": {
+                    "description": "Reverse proxy configuration (client IP detection)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "uploadDirectory": {
+                    "type": "string"
+                },
+                "v1DeprecationLink": {
+                    "type": "string"
+                },
+                "v1SunsetDate": {
+                    "description": "Marks modules mounted under a /v1 prefix as deprecated (YYYY-MM-DD or RFC 3339, empty disables)",
+                    "type": "string"
+                },
+                "webServerPort": {
+                    "type": "string"
+                },
+                "webhookSecret": {
+                    "description": "HMAC-SHA256 key for POST /webhooks/*, empty disables",
+                    "type": "string"
+                },
+                "workerPoolQueueSize": {
+                    "type": "integer"
+                },
+                "workerPoolWorkers": {
+                    "description": "Background worker pool for CPU-intensive jobs (0 workers uses one per CPU)",
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.ProductImageResponse": {
+            "type": "object",
+            "properties": {
+                "path": {
+                    "type": "string",
+                    "example": "uploads/750e8400-e29b-41d4-a716-446655440000.jpg"
+                }
+            }
+        },
+        "controllers.ProductListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.ProductResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.PaginationResponseDTO"
+                }
+            }
+        },
+        "controllers.ProductResponse": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "$ref": "#/definitions/hateoas.Links"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T10:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "High-performance laptop for professionals"
+                },
+                "external_id": {
+                    "description": "ID in the source system of imports",
+                    "type": "string",
+                    "example": "ERP-1001"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://cdn.example.com/products/xps15.jpg"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Laptop Dell XPS 15"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 5499.99
+                },
+                "stock": {
+                    "description": "sum of the variant stock when the product has variants",
+                    "type": "integer",
+                    "example": 10
+                },
+                "stock_threshold": {
+                    "description": "low-stock alert threshold, 0 disables",
+                    "type": "integer",
+                    "example": 5
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "electronics",
+                        "laptops"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T10:00:00Z"
+                },
+                "variants": {
+                    "description": "only loaded by FindById",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductVariant"
+                    }
+                }
+            }
+        },
+        "controllers.UpdateProductRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Updated description"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://cdn.example.com/products/xps15-v2.jpg"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Laptop Dell XPS 15 (Updated)"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 4999.99
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 15
+                }
+            }
+        },
+        "dto.PaginationResponseDTO": {
+            "type": "object",
+            "properties": {
+                "has_next_page": {
+                    "description": "Navigation flags so clients don't have to compare page and total_pages",
+                    "type": "boolean"
+                },
+                "has_prev_page": {
+                    "type": "boolean"
+                },
+                "is_first_page": {
+                    "type": "boolean"
+                },
+                "is_last_page": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total_items": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "errors.ProblemDetails": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Código específico do erro",
+                    "type": "string"
+                },
+                "detail": {
+                    "description": "Descrição detalhada",
+                    "type": "string"
+                },
+                "error_context": {
+                    "description": "business ou infra",
+                    "type": "string"
+                },
+                "instance": {
+                    "description": "URI da ocorrência do erro",
+                    "type": "string"
+                },
+                "module": {
+                    "description": "Módulo que gerou o erro",
+                    "type": "string"
+                },
+                "operation": {
+                    "description": "Operação que gerou o erro",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Código HTTP",
+                    "type": "integer"
+                },
+                "supported_media_types": {
+                    "description": "Media types aceitos pelo endpoint (respostas 406)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "description": "Título curto do erro",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "URI identificando o tipo do erro",
+                    "type": "string"
+                },
+                "validation_errors": {
+                    "description": "Erros de validação por campo (ex.: JSON Schema)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/errors.ValidationError"
+                    }
+                }
+            }
+        },
+        "errors.ValidationError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "JSON Pointer do campo (vazio para o documento inteiro)",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Motivo da falha",
+                    "type": "string"
+                }
+            }
+        },
+        "hateoas.Links": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "models.PriceHistory": {
+            "type": "object",
+            "properties": {
+                "changed_at": {
+                    "type": "string",
+                    "example": "2024-01-01T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "750e8400-e29b-41d4-a716-446655440000"
+                },
+                "new_price": {
+                    "type": "number",
+                    "example": 4999.99
+                },
+                "old_price": {
+                    "type": "number",
+                    "example": 5499.99
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "models.Product": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T10:00:00Z"
+                },
                 "description": {
                     "type": "string",
-                    "example": "High-performance laptop"
+                    "example": "High-performance laptop for professionals"
+                },
+                "external_id": {
+                    "description": "ID in the source system of imports",
+                    "type": "string",
+                    "example": "ERP-1001"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://cdn.example.com/products/xps15.jpg"
                 },
                 "name": {
                     "type": "string",
@@ -310,80 +2287,303 @@ const docTemplate = `{
                     "example": 5499.99
                 },
                 "stock": {
+                    "description": "sum of the variant stock when the product has variants",
                     "type": "integer",
                     "example": 10
+                },
+                "stock_threshold": {
+                    "description": "low-stock alert threshold, 0 disables",
+                    "type": "integer",
+                    "example": 5
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "electronics",
+                        "laptops"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T10:00:00Z"
+                },
+                "variants": {
+                    "description": "only loaded by FindById",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductVariant"
+                    }
                 }
             }
         },
-        "controllers.UpdateProductRequest": {
+        "models.ProductVariant": {
             "type": "object",
             "properties": {
-                "description": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "color": "black",
+                        "size": "15-inch"
+                    }
+                },
+                "created_at": {
                     "type": "string",
-                    "example": "Updated description"
+                    "example": "2024-01-01T10:00:00Z"
                 },
-                "name": {
+                "id": {
                     "type": "string",
-                    "example": "Laptop Dell XPS 15 (Updated)"
+                    "example": "850e8400-e29b-41d4-a716-446655440000"
                 },
                 "price": {
                     "type": "number",
-                    "example": 4999.99
+                    "example": 5699.99
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
                 "stock": {
                     "type": "integer",
-                    "example": 15
+                    "example": 4
                 }
             }
         },
-        "errors.ProblemDetails": {
+        "observability.SamplingRule": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "Código específico do erro",
+                "pattern": {
                     "type": "string"
                 },
-                "detail": {
-                    "description": "Descrição detalhada",
-                    "type": "string"
+                "rate": {
+                    "type": "number"
+                }
+            }
+        },
+        "routes.RouteInfo": {
+            "type": "object",
+            "properties": {
+                "method": {
+                    "type": "string",
+                    "example": "GET"
                 },
-                "error_context": {
-                    "description": "business ou infra",
-                    "type": "string"
+                "path": {
+                    "type": "string",
+                    "example": "/v1/products/:id"
                 },
-                "instance": {
-                    "description": "URI da ocorrência do erro",
-                    "type": "string"
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "products"
+                    ]
+                }
+            }
+        },
+        "services.AddProductTagsRequest": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "electronics",
+                        "laptops"
+                    ]
+                }
+            }
+        },
+        "services.AddVariantRequest": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "color": "black",
+                        "size": "15-inch"
+                    }
                 },
-                "status": {
-                    "description": "Código HTTP",
-                    "type": "integer"
+                "price": {
+                    "type": "number",
+                    "example": 5699.99
                 },
-                "title": {
-                    "description": "Título curto do erro",
-                    "type": "string"
+                "stock": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "services.BulkImportError": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Product name is required"
                 },
-                "type": {
-                    "description": "URI identificando o tipo do erro",
-                    "type": "string"
+                "row": {
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "models.Product": {
+        "services.BulkImportResult": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "created": {
+                    "type": "integer",
+                    "example": 98
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.BulkImportError"
+                    }
+                },
+                "skipped": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "updated": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "services.CreateProductRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "High-performance laptop"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://cdn.example.com/products/xps15.jpg"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Laptop Dell XPS 15"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 5499.99
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "electronics",
+                        "laptops"
+                    ]
+                }
+            }
+        },
+        "services.PatchProductRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Patched description"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "/products/xps15-patched.jpg"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Laptop Dell XPS 15 (Patched)"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 4799.99
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "services.PriceHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PriceHistory"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.PaginationResponseDTO"
+                }
+            }
+        },
+        "services.ProductStockChangedPayload": {
+            "type": "object",
+            "properties": {
+                "changed_at": {
                     "type": "string",
                     "example": "2024-01-01T10:00:00Z"
                 },
+                "event": {
+                    "type": "string",
+                    "example": "product.stock_changed"
+                },
+                "previous_stock": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 8
+                }
+            }
+        },
+        "services.SetStockThresholdRequest": {
+            "type": "object",
+            "properties": {
+                "threshold": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "services.UpsertProductRequest": {
+            "type": "object",
+            "properties": {
                 "description": {
                     "type": "string",
-                    "example": "High-performance laptop for professionals"
+                    "example": "High-performance laptop"
                 },
                 "id": {
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://cdn.example.com/products/xps15.jpg"
+                },
                 "name": {
                     "type": "string",
                     "example": "Laptop Dell XPS 15"
@@ -396,29 +2596,99 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 10
                 },
+                "stock_threshold": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "usecases.GetDBStatsOutputDTO": {
+            "type": "object",
+            "properties": {
+                "idle": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "inUse": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "maxIdleClosed": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "maxLifetimeClosed": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "maxOpenConnections": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "openConnections": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "waitCount": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "waitDurationMs": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "usecases.GetExampleOutputDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T10:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Sample example description"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
                 "updated_at": {
                     "type": "string",
                     "example": "2024-01-01T10:00:00Z"
                 }
             }
         },
-        "usecases.GetExampleOutputDTO": {
+        "usecases.RestoreExampleOutputDTO": {
             "type": "object",
             "properties": {
                 "created_at": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "2024-01-01T10:00:00Z"
                 },
                 "description": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "Sample example description"
                 },
                 "id": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
                 "updated_at": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "2024-01-01T10:00:00Z"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "Required when SERVER_APP_AUTH_ENABLED=true",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        }
     }
 }`
 
@@ -6,7 +6,10 @@ import (
 
 type ExampleRepository interface {
 	Save(example *entities.Example) error
+	// FindById ignores soft-deleted examples
 	FindById(id string) (*entities.Example, error)
+	// FindByIdIncludingDeleted also returns soft-deleted examples (e.g. to restore them)
+	FindByIdIncludingDeleted(id string) (*entities.Example, error)
 	Update(example *entities.Example) error
 	Delete(id string) error
 }
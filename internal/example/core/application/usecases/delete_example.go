@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/refortunato/go_app_base/internal/example/core/application/repositories"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type DeleteExampleInputDTO struct {
+	Id string
+}
+
+// DeleteExampleUseCase soft-deletes an example (the row is kept with deleted_at set)
+type DeleteExampleUseCase struct {
+	exampleRepository repositories.ExampleRepository
+}
+
+func NewDeleteExampleUseCase(exampleRepository repositories.ExampleRepository) *DeleteExampleUseCase {
+	return &DeleteExampleUseCase{
+		exampleRepository: exampleRepository,
+	}
+}
+
+func (u *DeleteExampleUseCase) Execute(ctx context.Context, input DeleteExampleInputDTO) error {
+	tracer := otel.Tracer("example.usecase")
+	ctx, span := tracer.Start(ctx, "DeleteExampleUseCase.Execute")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("example.id", input.Id),
+		attribute.String("usecase", "DeleteExample"),
+	)
+
+	// Already deleted examples are not found here
+	example, err := u.exampleRepository.FindById(input.Id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to find example")
+		return err
+	}
+
+	if err := example.Delete(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to delete example")
+		return err
+	}
+
+	if err := u.exampleRepository.Update(example); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to persist deleted example")
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "Example deleted successfully")
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/refortunato/go_app_base/internal/example/core/application/repositories"
+	"github.com/refortunato/go_app_base/internal/shared/result"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -31,7 +32,8 @@ func NewGetExampleUseCase(exampleRepository repositories.ExampleRepository) *Get
 	}
 }
 
-func (u *GetExampleUseCase) Execute(ctx context.Context, input GetExampleInputDTO) (*GetExampleOutputDTO, error) {
+// Execute returns the example as a Result: Ok with the output or Err with the lookup error
+func (u *GetExampleUseCase) Execute(ctx context.Context, input GetExampleInputDTO) result.Result[*GetExampleOutputDTO] {
 	// Create a span for this use case execution
 	tracer := otel.Tracer("example.usecase")
 	ctx, span := tracer.Start(ctx, "GetExampleUseCase.Execute")
@@ -47,7 +49,7 @@ func (u *GetExampleUseCase) Execute(ctx context.Context, input GetExampleInputDT
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "Failed to find example")
-		return nil, err
+		return result.Err[*GetExampleOutputDTO](err)
 	}
 
 	output := &GetExampleOutputDTO{
@@ -58,5 +60,5 @@ func (u *GetExampleUseCase) Execute(ctx context.Context, input GetExampleInputDT
 	}
 
 	span.SetStatus(codes.Ok, "Example retrieved successfully")
-	return output, nil
+	return result.Ok(output)
 }
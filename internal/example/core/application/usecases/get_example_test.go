@@ -0,0 +1,54 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/example/core/domain/entities"
+	"github.com/refortunato/go_app_base/internal/example/core/domain/errors"
+)
+
+// fakeExampleRepository finds the examples it holds by ID
+type fakeExampleRepository struct {
+	examples map[string]*entities.Example
+}
+
+func (r *fakeExampleRepository) Save(*entities.Example) error   { return nil }
+func (r *fakeExampleRepository) Update(*entities.Example) error { return nil }
+func (r *fakeExampleRepository) Delete(string) error            { return nil }
+
+func (r *fakeExampleRepository) FindById(id string) (*entities.Example, error) {
+	if example, ok := r.examples[id]; ok {
+		return example, nil
+	}
+	return nil, errors.ErrExampleNotFound
+}
+
+func (r *fakeExampleRepository) FindByIdIncludingDeleted(id string) (*entities.Example, error) {
+	return r.FindById(id)
+}
+
+func TestGetExample_Execute(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	example, _ := entities.RestoreExample("example-1", "First example", createdAt, createdAt, nil)
+	useCase := NewGetExampleUseCase(&fakeExampleRepository{examples: map[string]*entities.Example{"example-1": example}})
+
+	t.Run("found", func(t *testing.T) {
+		r := useCase.Execute(context.Background(), GetExampleInputDTO{Id: "example-1"})
+		if !r.IsOk() {
+			t.Fatalf("Err(%v), want Ok", r.UnwrapErr())
+		}
+		want := GetExampleOutputDTO{Id: "example-1", Description: "First example", CreatedAt: createdAt, UpdatedAt: createdAt}
+		if got := *r.Unwrap(); got != want {
+			t.Errorf("output = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		r := useCase.Execute(context.Background(), GetExampleInputDTO{Id: "missing"})
+		if r.IsOk() || r.UnwrapErr() != errors.ErrExampleNotFound {
+			t.Errorf("result = ok %v, err %v, want ErrExampleNotFound", r.IsOk(), r.UnwrapErr())
+		}
+	})
+}
@@ -16,6 +16,7 @@ import (
 
 type CreateExampleMetricsDemo struct {
 	repository repositories.ExampleRepository
+	idVersion  string
 
 	// Metrics instruments (created once, reused many times)
 	metrics          *observability.CustomMetrics
@@ -24,7 +25,8 @@ type CreateExampleMetricsDemo struct {
 	activeCreations  metric.Int64UpDownCounter // In-progress operations
 }
 
-func NewCreateExampleMetricsDemo(repo repositories.ExampleRepository) *CreateExampleMetricsDemo {
+// idVersion selects the UUID version of new examples (see entities.NewExample)
+func NewCreateExampleMetricsDemo(repo repositories.ExampleRepository, idVersion string) *CreateExampleMetricsDemo {
 	metrics := observability.NewCustomMetrics("example_module")
 
 	// Initialize all metric instruments upfront (efficient reuse)
@@ -48,6 +50,7 @@ func NewCreateExampleMetricsDemo(repo repositories.ExampleRepository) *CreateExa
 
 	return &CreateExampleMetricsDemo{
 		repository:       repo,
+		idVersion:        idVersion,
 		metrics:          metrics,
 		creationCounter:  creationCounter,
 		creationDuration: creationDuration,
@@ -67,7 +70,7 @@ func (uc *CreateExampleMetricsDemo) Execute(ctx context.Context, name string) (*
 	start := time.Now()
 
 	// Create entity
-	example, err := entities.NewExample(name)
+	example, err := entities.NewExample(name, uc.idVersion)
 	if err != nil {
 		// Record failure metric
 		uc.creationCounter.Add(ctx, 1,
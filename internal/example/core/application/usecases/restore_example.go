@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/example/core/application/repositories"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type RestoreExampleInputDTO struct {
+	Id string
+}
+
+type RestoreExampleOutputDTO struct {
+	Id          string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Description string    `json:"description" example:"Sample example description"`
+	CreatedAt   time.Time `json:"created_at" example:"2024-01-01T10:00:00Z"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2024-01-01T10:00:00Z"`
+}
+
+// RestoreExampleUseCase reverts a soft delete
+type RestoreExampleUseCase struct {
+	exampleRepository repositories.ExampleRepository
+}
+
+func NewRestoreExampleUseCase(exampleRepository repositories.ExampleRepository) *RestoreExampleUseCase {
+	return &RestoreExampleUseCase{
+		exampleRepository: exampleRepository,
+	}
+}
+
+func (u *RestoreExampleUseCase) Execute(ctx context.Context, input RestoreExampleInputDTO) (*RestoreExampleOutputDTO, error) {
+	tracer := otel.Tracer("example.usecase")
+	ctx, span := tracer.Start(ctx, "RestoreExampleUseCase.Execute")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("example.id", input.Id),
+		attribute.String("usecase", "RestoreExample"),
+	)
+
+	example, err := u.exampleRepository.FindByIdIncludingDeleted(input.Id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to find example")
+		return nil, err
+	}
+
+	if err := example.Restore(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to restore example")
+		return nil, err
+	}
+
+	if err := u.exampleRepository.Update(example); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to persist restored example")
+		return nil, err
+	}
+
+	output := &RestoreExampleOutputDTO{
+		Id:          example.GetId(),
+		Description: example.GetDescription(),
+		CreatedAt:   example.GetCreatedAt(),
+		UpdatedAt:   example.GetUpdatedAt(),
+	}
+
+	span.SetStatus(codes.Ok, "Example restored successfully")
+	return output, nil
+}
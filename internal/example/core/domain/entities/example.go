@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/refortunato/go_app_base/internal/example/core/domain/errors"
+	"github.com/refortunato/go_app_base/internal/example/core/domain/events"
 	"github.com/refortunato/go_app_base/internal/shared"
 )
 
@@ -12,15 +13,23 @@ type Example struct {
 	description string
 	createdAt   time.Time
 	updatedAt   time.Time
+	deletedAt   *time.Time
+
+	// version is the number of events already stored for the example
+	version int
+	// uncommitted holds the events recorded since the example was loaded or last saved
+	uncommitted []events.DomainEvent
 }
 
-func NewExample(description string) (*Example, error) {
-	example := &Example{
-		id:          shared.GenerateId(),
-		description: description,
-		createdAt:   time.Now().UTC(),
-		updatedAt:   time.Now().UTC(),
-	}
+// NewExample creates an example with a new ID of the given UUID version ("v4" or "v7",
+// see shared.GenerateIdWithVersion)
+func NewExample(description, idVersion string) (*Example, error) {
+	example := &Example{}
+	example.record(events.ExampleCreated{
+		ID:          shared.GenerateIdWithVersion(idVersion),
+		Description: description,
+		At:          time.Now().UTC(),
+	})
 	if err := example.Validate(); err != nil {
 		return nil, err
 	}
@@ -31,15 +40,31 @@ func RestoreExample(
 	id,
 	description string,
 	createdAt,
-	updatedAt time.Time) (*Example, error) {
+	updatedAt time.Time,
+	deletedAt *time.Time) (*Example, error) {
 	return &Example{
 		id:          id,
 		description: description,
 		createdAt:   createdAt,
 		updatedAt:   updatedAt,
+		deletedAt:   deletedAt,
 	}, nil
 }
 
+// RestoreExampleFromEvents rebuilds an example by replaying its stored events in order
+func RestoreExampleFromEvents(history []events.DomainEvent) (*Example, error) {
+	if len(history) == 0 {
+		return nil, errors.ErrExampleNotFound
+	}
+	example := &Example{}
+	for _, event := range history {
+		if err := example.ApplyEvent(event); err != nil {
+			return nil, err
+		}
+	}
+	return example, nil
+}
+
 func (e *Example) Validate() error {
 	if e.description == "" {
 		return errors.ErrDescriptionIsRequired
@@ -65,9 +90,102 @@ func (e *Example) GetUpdatedAt() time.Time {
 	return e.updatedAt
 }
 
+// GetDeletedAt returns when the example was soft-deleted (nil if active)
+func (e *Example) GetDeletedAt() *time.Time {
+	return e.deletedAt
+}
+
+func (e *Example) IsDeleted() bool {
+	return e.deletedAt != nil
+}
+
+// GetVersion returns the number of events already stored for the example
+func (e *Example) GetVersion() int {
+	return e.version
+}
+
 // Setters
 
 func (e *Example) SetDescription(description string) {
-	e.description = description
-	e.updatedAt = time.Now().UTC()
+	e.record(events.ExampleDescriptionChanged{ID: e.id, Description: description, At: time.Now().UTC()})
+}
+
+// Soft delete
+
+// Delete marks the example as deleted without removing it
+func (e *Example) Delete() error {
+	if e.IsDeleted() {
+		return errors.ErrExampleAlreadyDeleted
+	}
+	e.record(events.ExampleDeleted{ID: e.id, At: time.Now().UTC()})
+	return nil
+}
+
+// Restore reverts a soft delete
+func (e *Example) Restore() error {
+	if !e.IsDeleted() {
+		return errors.ErrExampleNotDeleted
+	}
+	e.record(events.ExampleRestored{ID: e.id, At: time.Now().UTC()})
+	return nil
+}
+
+// Events
+
+// ApplyEvent replays a stored event on the example and increments its version
+// The event is not added to the uncommitted events
+func (e *Example) ApplyEvent(event events.DomainEvent) error {
+	switch event.(type) {
+	case events.ExampleCreated:
+		if e.id != "" {
+			return errors.ErrExampleInvalidEvent
+		}
+	case events.ExampleDescriptionChanged, events.ExampleDeleted, events.ExampleRestored:
+		if e.id == "" || e.id != event.AggregateID() {
+			return errors.ErrExampleInvalidEvent
+		}
+	default:
+		return errors.ErrExampleInvalidEvent
+	}
+	e.apply(event)
+	e.version++
+	return nil
+}
+
+// UncommittedEvents returns the events recorded since the example was loaded or last saved
+func (e *Example) UncommittedEvents() []events.DomainEvent {
+	return e.uncommitted
+}
+
+// MarkEventsCommitted is called once the uncommitted events were stored
+func (e *Example) MarkEventsCommitted() {
+	e.version += len(e.uncommitted)
+	e.uncommitted = nil
+}
+
+// record applies a new event and keeps it until the example is saved
+func (e *Example) record(event events.DomainEvent) {
+	e.apply(event)
+	e.uncommitted = append(e.uncommitted, event)
+}
+
+// apply changes the state according to the event; the event must already be valid
+func (e *Example) apply(event events.DomainEvent) {
+	switch ev := event.(type) {
+	case events.ExampleCreated:
+		e.id = ev.ID
+		e.description = ev.Description
+		e.createdAt = ev.At
+		e.updatedAt = ev.At
+	case events.ExampleDescriptionChanged:
+		e.description = ev.Description
+		e.updatedAt = ev.At
+	case events.ExampleDeleted:
+		deletedAt := ev.At
+		e.deletedAt = &deletedAt
+		e.updatedAt = ev.At
+	case events.ExampleRestored:
+		e.deletedAt = nil
+		e.updatedAt = ev.At
+	}
 }
@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/example/core/domain/errors"
+	"github.com/refortunato/go_app_base/internal/example/core/domain/events"
+)
+
+func TestExample_DeleteAndRestore(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	example, _ := RestoreExample("example-1", "First example", createdAt, createdAt, nil)
+
+	if err := example.Restore(); err != errors.ErrExampleNotDeleted {
+		t.Errorf("Restore of an active example: err = %v, want ErrExampleNotDeleted", err)
+	}
+
+	if err := example.Delete(); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if !example.IsDeleted() || example.GetDeletedAt() == nil {
+		t.Fatal("example not marked as deleted")
+	}
+	if !example.GetUpdatedAt().After(createdAt) || !example.GetUpdatedAt().Equal(*example.GetDeletedAt()) {
+		t.Errorf("updated_at = %v, want the deletion time %v", example.GetUpdatedAt(), example.GetDeletedAt())
+	}
+	if err := example.Delete(); err != errors.ErrExampleAlreadyDeleted {
+		t.Errorf("second Delete: err = %v, want ErrExampleAlreadyDeleted", err)
+	}
+
+	deletedAt := example.GetUpdatedAt()
+	if err := example.Restore(); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if example.IsDeleted() || example.GetDeletedAt() != nil {
+		t.Error("example still deleted after Restore")
+	}
+	if example.GetUpdatedAt().Before(deletedAt) {
+		t.Errorf("updated_at = %v, want it moved forward by Restore", example.GetUpdatedAt())
+	}
+}
+
+func TestRestoreExampleFromEvents_MatchesRecordedState(t *testing.T) {
+	example, err := NewExample("First example", "v7")
+	if err != nil {
+		t.Fatalf("NewExample: %v", err)
+	}
+	example.SetDescription("Renamed example")
+	if err := example.Delete(); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	history := example.UncommittedEvents()
+	if len(history) != 3 {
+		t.Fatalf("uncommitted events = %d, want created, changed and deleted", len(history))
+	}
+
+	replayed, err := RestoreExampleFromEvents(history)
+	if err != nil {
+		t.Fatalf("RestoreExampleFromEvents: %v", err)
+	}
+	if replayed.GetId() != example.GetId() || replayed.GetDescription() != "Renamed example" {
+		t.Errorf("replayed = %s %q, want %s %q", replayed.GetId(), replayed.GetDescription(), example.GetId(), "Renamed example")
+	}
+	if !replayed.GetCreatedAt().Equal(example.GetCreatedAt()) || !replayed.GetUpdatedAt().Equal(example.GetUpdatedAt()) {
+		t.Errorf("replayed timestamps = %v %v, want %v %v", replayed.GetCreatedAt(), replayed.GetUpdatedAt(), example.GetCreatedAt(), example.GetUpdatedAt())
+	}
+	if !replayed.IsDeleted() || !replayed.GetDeletedAt().Equal(*example.GetDeletedAt()) {
+		t.Errorf("replayed deleted_at = %v, want %v", replayed.GetDeletedAt(), example.GetDeletedAt())
+	}
+	if replayed.GetVersion() != 3 || len(replayed.UncommittedEvents()) != 0 {
+		t.Errorf("replayed version = %d with %d uncommitted events, want 3 and none", replayed.GetVersion(), len(replayed.UncommittedEvents()))
+	}
+}
+
+func TestRestoreExampleFromEvents_InvalidHistory(t *testing.T) {
+	at := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	created := events.ExampleCreated{ID: "example-1", Description: "First example", At: at}
+	tests := []struct {
+		name    string
+		history []events.DomainEvent
+		want    error
+	}{
+		{name: "empty", want: errors.ErrExampleNotFound},
+		{name: "created twice", history: []events.DomainEvent{created, created}, want: errors.ErrExampleInvalidEvent},
+		{name: "change before create", history: []events.DomainEvent{events.ExampleDescriptionChanged{ID: "example-1", Description: "x", At: at}}, want: errors.ErrExampleInvalidEvent},
+		{name: "other aggregate", history: []events.DomainEvent{created, events.ExampleDeleted{ID: "example-2", At: at}}, want: errors.ErrExampleInvalidEvent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RestoreExampleFromEvents(tt.history); err != tt.want {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
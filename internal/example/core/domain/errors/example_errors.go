@@ -19,4 +19,32 @@ var (
 		"EX1002",
 		sharedErrors.ErrorContextBusiness,
 	)
+	ErrExampleAlreadyDeleted = sharedErrors.NewProblemDetails(
+		409,
+		"Example already deleted",
+		"The example has already been deleted",
+		"EX1003",
+		sharedErrors.ErrorContextBusiness,
+	)
+	ErrExampleNotDeleted = sharedErrors.NewProblemDetails(
+		409,
+		"Example not deleted",
+		"Only deleted examples can be restored",
+		"EX1004",
+		sharedErrors.ErrorContextBusiness,
+	)
+	ErrExampleInvalidEvent = sharedErrors.NewProblemDetails(
+		500,
+		"Invalid example event",
+		"The event cannot be applied to the example",
+		"EX1005",
+		sharedErrors.ErrorContextBusiness,
+	)
+	ErrExampleVersionConflict = sharedErrors.NewProblemDetails(
+		409,
+		"Example version conflict",
+		"The example was changed by another request, reload it and try again",
+		"EX1006",
+		sharedErrors.ErrorContextBusiness,
+	)
 )
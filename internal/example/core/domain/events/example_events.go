@@ -0,0 +1,47 @@
+package events
+
+import "time"
+
+// DomainEvent is a state change of an aggregate, in the order it happened
+type DomainEvent interface {
+	AggregateID() string
+	OccurredAt() time.Time
+}
+
+// ExampleCreated is recorded when a new example is created
+type ExampleCreated struct {
+	ID          string
+	Description string
+	At          time.Time
+}
+
+func (e ExampleCreated) AggregateID() string   { return e.ID }
+func (e ExampleCreated) OccurredAt() time.Time { return e.At }
+
+// ExampleDescriptionChanged is recorded when the description of an example changes
+type ExampleDescriptionChanged struct {
+	ID          string
+	Description string
+	At          time.Time
+}
+
+func (e ExampleDescriptionChanged) AggregateID() string   { return e.ID }
+func (e ExampleDescriptionChanged) OccurredAt() time.Time { return e.At }
+
+// ExampleDeleted is recorded when an example is soft-deleted
+type ExampleDeleted struct {
+	ID string
+	At time.Time
+}
+
+func (e ExampleDeleted) AggregateID() string   { return e.ID }
+func (e ExampleDeleted) OccurredAt() time.Time { return e.At }
+
+// ExampleRestored is recorded when a soft delete is reverted
+type ExampleRestored struct {
+	ID string
+	At time.Time
+}
+
+func (e ExampleRestored) AggregateID() string   { return e.ID }
+func (e ExampleRestored) OccurredAt() time.Time { return e.At }
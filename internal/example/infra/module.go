@@ -1,32 +1,70 @@
 package infra
 
 import (
+	"context"
 	"database/sql"
 
+	appRepositories "github.com/refortunato/go_app_base/internal/example/core/application/repositories"
 	"github.com/refortunato/go_app_base/internal/example/core/application/usecases"
 	"github.com/refortunato/go_app_base/internal/example/infra/repositories"
 	"github.com/refortunato/go_app_base/internal/example/infra/web/controllers"
+	shareddb "github.com/refortunato/go_app_base/internal/shared/db"
 )
 
 // ExampleModule encapsulates all dependencies for the example module
 type ExampleModule struct {
-	ExampleController *controllers.ExampleController
-	GetExampleUseCase *usecases.GetExampleUseCase
+	ExampleController     *controllers.ExampleController
+	GetExampleUseCase     *usecases.GetExampleUseCase
+	DeleteExampleUseCase  *usecases.DeleteExampleUseCase
+	RestoreExampleUseCase *usecases.RestoreExampleUseCase
+
+	db *sql.DB
 }
 
 // NewExampleModule creates and wires all dependencies for the example module
-func NewExampleModule(db *sql.DB) *ExampleModule {
+// With eventSourcingEnabled examples are kept in an in-memory event store instead of MySQL
+func NewExampleModule(db *sql.DB, stmtCache *shareddb.StmtCache, eventSourcingEnabled bool) *ExampleModule {
 	// Repositories
-	exampleRepository := repositories.NewExampleMySQLRepository(db)
+	var exampleRepository appRepositories.ExampleRepository = repositories.NewExampleMySQLRepository(db, stmtCache)
+	if eventSourcingEnabled {
+		exampleRepository = repositories.NewEventSourcedExampleRepository(repositories.NewEventStore())
+	}
 
 	// Use Cases
 	getExampleUseCase := usecases.NewGetExampleUseCase(exampleRepository)
+	deleteExampleUseCase := usecases.NewDeleteExampleUseCase(exampleRepository)
+	restoreExampleUseCase := usecases.NewRestoreExampleUseCase(exampleRepository)
 
 	// Controllers
-	exampleController := controllers.NewExampleController(*getExampleUseCase)
+	exampleController := controllers.NewExampleController(*getExampleUseCase, *deleteExampleUseCase, *restoreExampleUseCase)
 
 	return &ExampleModule{
-		ExampleController: exampleController,
-		GetExampleUseCase: getExampleUseCase,
+		ExampleController:     exampleController,
+		GetExampleUseCase:     getExampleUseCase,
+		DeleteExampleUseCase:  deleteExampleUseCase,
+		RestoreExampleUseCase: restoreExampleUseCase,
+		db:                    db,
+	}
+}
+
+// ExpectedSchema lists the tables and columns used by the MySQL repository (see configs.ValidateSchema)
+func ExpectedSchema() map[string][]string {
+	return map[string][]string{
+		"examples": {"id", "description", "created_at", "updated_at", "deleted_at"},
 	}
 }
+
+// Name identifies the module in configuration (implements module.Module)
+func (m *ExampleModule) Name() string {
+	return "example"
+}
+
+// RoutePrefix is the default prefix for the module routes (implements module.Module)
+func (m *ExampleModule) RoutePrefix() string {
+	return "/"
+}
+
+// HealthCheck reports whether the module's database is reachable (implements module.Module)
+func (m *ExampleModule) HealthCheck(ctx context.Context) error {
+	return m.db.PingContext(ctx)
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"github.com/refortunato/go_app_base/internal/example/core/domain/entities"
+	"github.com/refortunato/go_app_base/internal/example/core/domain/errors"
+)
+
+// EventSourcedExampleRepository stores examples as event streams instead of rows
+// The current state is rebuilt by replaying the events on every read
+type EventSourcedExampleRepository struct {
+	store *EventStore
+}
+
+func NewEventSourcedExampleRepository(store *EventStore) *EventSourcedExampleRepository {
+	return &EventSourcedExampleRepository{store: store}
+}
+
+func (r *EventSourcedExampleRepository) Save(example *entities.Example) error {
+	return r.commit(example)
+}
+
+func (r *EventSourcedExampleRepository) FindById(id string) (*entities.Example, error) {
+	example, err := r.FindByIdIncludingDeleted(id)
+	if err != nil {
+		return nil, err
+	}
+	if example.IsDeleted() {
+		return nil, errors.ErrExampleNotFound
+	}
+	return example, nil
+}
+
+func (r *EventSourcedExampleRepository) FindByIdIncludingDeleted(id string) (*entities.Example, error) {
+	history, err := r.store.LoadEvents(id)
+	if err != nil {
+		return nil, err
+	}
+	return entities.RestoreExampleFromEvents(history)
+}
+
+func (r *EventSourcedExampleRepository) Update(example *entities.Example) error {
+	return r.commit(example)
+}
+
+// Delete records an ExampleDeleted event; the stream is kept since events are never removed
+func (r *EventSourcedExampleRepository) Delete(id string) error {
+	example, err := r.FindById(id)
+	if err != nil {
+		return err
+	}
+	if err := example.Delete(); err != nil {
+		return err
+	}
+	return r.commit(example)
+}
+
+// commit appends the uncommitted events of the example, checking its version
+func (r *EventSourcedExampleRepository) commit(example *entities.Example) error {
+	if err := r.store.AppendEvents(example.GetId(), example.UncommittedEvents(), example.GetVersion()); err != nil {
+		return err
+	}
+	example.MarkEventsCommitted()
+	return nil
+}
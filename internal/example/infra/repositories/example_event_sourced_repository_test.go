@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/refortunato/go_app_base/internal/example/core/domain/entities"
+	"github.com/refortunato/go_app_base/internal/example/core/domain/errors"
+)
+
+func TestEventSourcedExampleRepository_LoadsStateFromEvents(t *testing.T) {
+	store := NewEventStore()
+	repo := NewEventSourcedExampleRepository(store)
+
+	example, err := entities.NewExample("First example", "v7")
+	if err != nil {
+		t.Fatalf("NewExample: %v", err)
+	}
+	if err := repo.Save(example); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	example.SetDescription("Renamed example")
+	if err := repo.Update(example); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	history, _ := store.LoadEvents(example.GetId())
+	if len(history) != 2 {
+		t.Fatalf("stored events = %d, want created and changed", len(history))
+	}
+	loaded, err := repo.FindById(example.GetId())
+	if err != nil {
+		t.Fatalf("FindById: %v", err)
+	}
+	if loaded.GetDescription() != "Renamed example" || !loaded.GetUpdatedAt().Equal(example.GetUpdatedAt()) {
+		t.Errorf("loaded = %q at %v, want %q at %v", loaded.GetDescription(), loaded.GetUpdatedAt(), "Renamed example", example.GetUpdatedAt())
+	}
+	if loaded.GetVersion() != 2 {
+		t.Errorf("loaded version = %d, want 2", loaded.GetVersion())
+	}
+}
+
+func TestEventSourcedExampleRepository_StaleUpdateConflicts(t *testing.T) {
+	repo := NewEventSourcedExampleRepository(NewEventStore())
+	example, _ := entities.NewExample("First example", "v7")
+	if err := repo.Save(example); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	first, _ := repo.FindById(example.GetId())
+	second, _ := repo.FindById(example.GetId())
+	first.SetDescription("first writer")
+	if err := repo.Update(first); err != nil {
+		t.Fatalf("first Update: %v", err)
+	}
+	second.SetDescription("second writer")
+	if err := repo.Update(second); err != errors.ErrExampleVersionConflict {
+		t.Errorf("stale Update: err = %v, want ErrExampleVersionConflict", err)
+	}
+
+	loaded, _ := repo.FindById(example.GetId())
+	if loaded.GetDescription() != "first writer" {
+		t.Errorf("description = %q, want the first write kept", loaded.GetDescription())
+	}
+}
+
+func TestEventSourcedExampleRepository_DeleteAndRestore(t *testing.T) {
+	repo := NewEventSourcedExampleRepository(NewEventStore())
+	example, _ := entities.NewExample("First example", "v7")
+	if err := repo.Save(example); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	if err := repo.Delete(example.GetId()); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repo.FindById(example.GetId()); err != errors.ErrExampleNotFound {
+		t.Errorf("FindById after Delete: err = %v, want ErrExampleNotFound", err)
+	}
+	if err := repo.Delete(example.GetId()); err != errors.ErrExampleNotFound {
+		t.Errorf("second Delete: err = %v, want ErrExampleNotFound", err)
+	}
+
+	deleted, err := repo.FindByIdIncludingDeleted(example.GetId())
+	if err != nil || !deleted.IsDeleted() {
+		t.Fatalf("FindByIdIncludingDeleted = %v, %v, want the deleted example", deleted, err)
+	}
+	if err := deleted.Restore(); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if err := repo.Update(deleted); err != nil {
+		t.Fatalf("Update after Restore: %v", err)
+	}
+	if _, err := repo.FindById(example.GetId()); err != nil {
+		t.Errorf("FindById after Restore: %v", err)
+	}
+
+	if _, err := repo.FindById("missing"); err != errors.ErrExampleNotFound {
+		t.Errorf("FindById of a missing ID: err = %v, want ErrExampleNotFound", err)
+	}
+}
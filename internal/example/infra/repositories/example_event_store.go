@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"sync"
+
+	"github.com/refortunato/go_app_base/internal/example/core/domain/errors"
+	"github.com/refortunato/go_app_base/internal/example/core/domain/events"
+)
+
+// EventStore keeps the event stream of each aggregate in memory (lost on restart)
+type EventStore struct {
+	mu      sync.RWMutex
+	streams map[string][]events.DomainEvent
+}
+
+func NewEventStore() *EventStore {
+	return &EventStore{streams: make(map[string][]events.DomainEvent)}
+}
+
+// AppendEvents adds events to the stream of the aggregate id
+// expectedVersion is the number of events the caller loaded; when the stream has
+// grown since then the append is rejected with ErrExampleVersionConflict
+func (s *EventStore) AppendEvents(id string, newEvents []events.DomainEvent, expectedVersion int) error {
+	if len(newEvents) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.streams[id]) != expectedVersion {
+		return errors.ErrExampleVersionConflict
+	}
+	s.streams[id] = append(s.streams[id], newEvents...)
+	return nil
+}
+
+// LoadEvents returns a copy of the stream of the aggregate id (empty when unknown)
+func (s *EventStore) LoadEvents(id string) ([]events.DomainEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stream := s.streams[id]
+	loaded := make([]events.DomainEvent, len(stream))
+	copy(loaded, stream)
+	return loaded, nil
+}
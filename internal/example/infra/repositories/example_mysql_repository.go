@@ -1,58 +1,82 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
 	"github.com/refortunato/go_app_base/internal/example/core/domain/entities"
+	"github.com/refortunato/go_app_base/internal/example/core/domain/errors"
+	shareddb "github.com/refortunato/go_app_base/internal/shared/db"
+	"github.com/refortunato/go_app_base/internal/shared/observability"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type exampleEntity struct {
-	Id          string    `db:"id"`
-	Description string    `db:"description"`
-	CreatedAt   time.Time `db:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at"`
+	Id          string     `db:"id"`
+	Description string     `db:"description"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
+	DeletedAt   *time.Time `db:"deleted_at"`
 }
 
 type ExampleMySQLRepository struct {
 	db *sql.DB
+	// stmtCache reuses the prepared INSERT/UPDATE/DELETE statements
+	stmtCache *shareddb.StmtCache
+	// tracer records a span per statement (observability.TraceQuery/TraceExec), without otelsql
+	tracer trace.Tracer
 }
 
-func NewExampleMySQLRepository(db *sql.DB) *ExampleMySQLRepository {
-	return &ExampleMySQLRepository{db: db}
+func NewExampleMySQLRepository(db *sql.DB, stmtCache *shareddb.StmtCache) *ExampleMySQLRepository {
+	return &ExampleMySQLRepository{db: db, stmtCache: stmtCache, tracer: otel.Tracer("example.repository")}
 }
 
-func (r *ExampleMySQLRepository) Save(example *entities.Example) error {
-	stmt, err := r.db.Prepare("INSERT INTO examples (id, description, created_at, updated_at) VALUES (?,?,?,?)")
-	if err != nil {
-		return err
-	}
-	defer stmt.Close()
+// The ExampleRepository methods take no context, so the statement spans start new traces
 
-	_, err = stmt.Exec(
+func (r *ExampleMySQLRepository) Save(example *entities.Example) error {
+	return r.exec(
+		"INSERT INTO examples (id, description, created_at, updated_at) VALUES (?,?,?,?)",
 		example.GetId(),
 		example.GetDescription(),
 		example.GetCreatedAt(),
 		example.GetUpdatedAt(),
 	)
-	if err != nil {
-		return err
-	}
-	return nil
 }
 
 func (r *ExampleMySQLRepository) FindById(id string) (*entities.Example, error) {
-	row := r.db.QueryRow("SELECT id, description, created_at, updated_at FROM examples WHERE id = ?", id)
+	return r.findOne("SELECT id, description, created_at, updated_at, deleted_at FROM examples WHERE id = ? AND deleted_at IS NULL", id)
+}
+
+func (r *ExampleMySQLRepository) FindByIdIncludingDeleted(id string) (*entities.Example, error) {
+	return r.findOne("SELECT id, description, created_at, updated_at, deleted_at FROM examples WHERE id = ?", id)
+}
+
+func (r *ExampleMySQLRepository) findOne(query string, args ...any) (*entities.Example, error) {
 	var exampleEntity exampleEntity
-	err := row.Scan(
-		&exampleEntity.Id,
-		&exampleEntity.Description,
-		&exampleEntity.CreatedAt,
-		&exampleEntity.UpdatedAt,
-	)
+	found := true
+	err := observability.TraceQuery(context.Background(), r.tracer, query, func(ctx context.Context) error {
+		err := r.db.QueryRowContext(ctx, query, args...).Scan(
+			&exampleEntity.Id,
+			&exampleEntity.Description,
+			&exampleEntity.CreatedAt,
+			&exampleEntity.UpdatedAt,
+			&exampleEntity.DeletedAt,
+		)
+		if err == sql.ErrNoRows {
+			// Not found is an expected outcome, not a failed query
+			found = false
+			return nil
+		}
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+	if !found {
+		return nil, errors.ErrExampleNotFound
+	}
 	exampleDomain, err := r.mapToDomain(exampleEntity)
 	if err != nil {
 		return nil, err
@@ -61,35 +85,29 @@ func (r *ExampleMySQLRepository) FindById(id string) (*entities.Example, error)
 }
 
 func (r *ExampleMySQLRepository) Update(example *entities.Example) error {
-	stmt, err := r.db.Prepare("UPDATE examples SET description=?, updated_at=? WHERE id=?")
-	if err != nil {
-		return err
-	}
-	defer stmt.Close()
-
-	_, err = stmt.Exec(
+	return r.exec(
+		"UPDATE examples SET description=?, updated_at=?, deleted_at=? WHERE id=?",
 		example.GetDescription(),
 		example.GetUpdatedAt(),
+		example.GetDeletedAt(),
 		example.GetId(),
 	)
-	if err != nil {
-		return err
-	}
-	return nil
 }
 
 func (r *ExampleMySQLRepository) Delete(id string) error {
-	stmt, err := r.db.Prepare("DELETE FROM examples WHERE id = ?")
-	if err != nil {
-		return err
-	}
-	defer stmt.Close()
+	return r.exec("DELETE FROM examples WHERE id = ?", id)
+}
 
-	_, err = stmt.Exec(id)
-	if err != nil {
+// exec runs a cached prepared statement inside a db.exec span
+func (r *ExampleMySQLRepository) exec(query string, args ...any) error {
+	return observability.TraceExec(context.Background(), r.tracer, query, func(ctx context.Context) error {
+		stmt, err := r.stmtCache.Get(ctx, query)
+		if err != nil {
+			return err
+		}
+		_, err = stmt.ExecContext(ctx, args...)
 		return err
-	}
-	return nil
+	})
 }
 
 func (r *ExampleMySQLRepository) mapToDomain(entity exampleEntity) (*entities.Example, error) {
@@ -98,5 +116,6 @@ func (r *ExampleMySQLRepository) mapToDomain(entity exampleEntity) (*entities.Ex
 		entity.Description,
 		entity.CreatedAt,
 		entity.UpdatedAt,
+		entity.DeletedAt,
 	)
 }
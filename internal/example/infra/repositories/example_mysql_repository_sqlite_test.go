@@ -0,0 +1,46 @@
+//go:build sqlite
+
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/refortunato/go_app_base/internal/example/core/domain/entities"
+	shareddb "github.com/refortunato/go_app_base/internal/shared/db"
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+)
+
+func TestExampleMySQLRepository_SharesStatementCache(t *testing.T) {
+	db := testhelpers.NewSQLiteForTest(t)
+	cache := shareddb.NewStmtCache(db, 10)
+	t.Cleanup(func() { cache.Close() })
+	first := NewExampleMySQLRepository(db, cache)
+	second := NewExampleMySQLRepository(db, cache)
+
+	for i, repo := range []*ExampleMySQLRepository{first, second} {
+		example, err := entities.NewExample("cached insert", "v7")
+		if err != nil {
+			t.Fatalf("NewExample: %v", err)
+		}
+		if err := repo.Save(example); err != nil {
+			t.Fatalf("repository %d Save: %v", i, err)
+		}
+		if _, err := repo.FindById(example.GetId()); err != nil {
+			t.Errorf("repository %d FindById: %v", i, err)
+		}
+	}
+
+	// Both repositories ran the same INSERT through a single prepared statement
+	if cache.Len() != 1 {
+		t.Errorf("cached statements = %d, want the INSERT prepared once", cache.Len())
+	}
+	insert := "INSERT INTO examples (id, description, created_at, updated_at) VALUES (?,?,?,?)"
+	stmt, err := cache.Get(context.Background(), insert)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if again, _ := cache.Get(context.Background(), insert); again != stmt || cache.Len() != 1 {
+		t.Error("the INSERT statement was not reused from the cache")
+	}
+}
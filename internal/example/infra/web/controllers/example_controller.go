@@ -10,12 +10,20 @@ import (
 )
 
 type ExampleController struct {
-	GetExampleUseCase usecases.GetExampleUseCase
+	GetExampleUseCase     usecases.GetExampleUseCase
+	DeleteExampleUseCase  usecases.DeleteExampleUseCase
+	RestoreExampleUseCase usecases.RestoreExampleUseCase
 }
 
-func NewExampleController(getExampleUseCase usecases.GetExampleUseCase) *ExampleController {
+func NewExampleController(
+	getExampleUseCase usecases.GetExampleUseCase,
+	deleteExampleUseCase usecases.DeleteExampleUseCase,
+	restoreExampleUseCase usecases.RestoreExampleUseCase,
+) *ExampleController {
 	return &ExampleController{
-		GetExampleUseCase: getExampleUseCase,
+		GetExampleUseCase:     getExampleUseCase,
+		DeleteExampleUseCase:  deleteExampleUseCase,
+		RestoreExampleUseCase: restoreExampleUseCase,
 	}
 }
 
@@ -33,9 +41,10 @@ func NewExampleController(getExampleUseCase usecases.GetExampleUseCase) *Example
 func (controller *ExampleController) GetExample(c webcontext.WebContext) {
 	id := c.Param("id")
 	ctx := c.GetContext()
+	log := logger.FromContext(ctx)
 
 	// Log the incoming request with custom fields
-	logger.Info(ctx, "Processing GetExample request", logger.CustomFields{
+	log.Info(ctx, "Processing GetExample request", logger.CustomFields{
 		"exampleId": id,
 		"endpoint":  "GET /examples/:id",
 	})
@@ -45,19 +54,99 @@ func (controller *ExampleController) GetExample(c webcontext.WebContext) {
 	}
 
 	// Pass context from request for trace propagation
-	output, err := controller.GetExampleUseCase.Execute(ctx, input)
-	if err != nil {
+	res := controller.GetExampleUseCase.Execute(ctx, input)
+	if !res.IsOk() {
+		err := res.UnwrapErr()
 		// Log error with custom context
-		logger.Error(ctx, "Failed to get example", logger.CustomFields{
+		log.WithError(err).Error(ctx, "Failed to get example", logger.CustomFields{
 			"exampleId": id,
-			"error":     err.Error(),
 		})
 		advisor.ReturnApplicationError(c, err)
 		return
 	}
 
 	// Log successful response
-	logger.Info(ctx, "Example retrieved successfully", logger.CustomFields{
+	log.Info(ctx, "Example retrieved successfully", logger.CustomFields{
+		"exampleId": id,
+	})
+
+	c.JSON(http.StatusOK, res.Unwrap())
+}
+
+// DeleteExample godoc
+// @Summary      Delete example
+// @Description  Soft-deletes an example (it can be restored later)
+// @Tags         examples
+// @Produce      json
+// @Param        id   path      string  true  "Example ID (UUID format)"
+// @Success      204  "Example deleted"
+// @Failure      404  {object}  errors.ProblemDetails  "Example not found"
+// @Failure      500  {object}  errors.ProblemDetails  "Internal server error"
+// @Router       /examples/{id} [delete]
+func (controller *ExampleController) DeleteExample(c webcontext.WebContext) {
+	id := c.Param("id")
+	ctx := c.GetContext()
+	log := logger.FromContext(ctx)
+
+	log.Info(ctx, "Processing DeleteExample request", logger.CustomFields{
+		"exampleId": id,
+		"endpoint":  "DELETE /examples/:id",
+	})
+
+	input := usecases.DeleteExampleInputDTO{
+		Id: id,
+	}
+
+	if err := controller.DeleteExampleUseCase.Execute(ctx, input); err != nil {
+		log.WithError(err).Error(ctx, "Failed to delete example", logger.CustomFields{
+			"exampleId": id,
+		})
+		advisor.ReturnApplicationError(c, err)
+		return
+	}
+
+	log.Info(ctx, "Example deleted successfully", logger.CustomFields{
+		"exampleId": id,
+	})
+
+	c.SetStatus(http.StatusNoContent)
+}
+
+// RestoreExample godoc
+// @Summary      Restore example
+// @Description  Restores a soft-deleted example
+// @Tags         examples
+// @Produce      json
+// @Param        id   path      string  true  "Example ID (UUID format)"
+// @Success      200  {object}  usecases.RestoreExampleOutputDTO
+// @Failure      404  {object}  errors.ProblemDetails  "Example not found"
+// @Failure      409  {object}  errors.ProblemDetails  "Example is not deleted"
+// @Failure      500  {object}  errors.ProblemDetails  "Internal server error"
+// @Router       /examples/{id}/restore [post]
+func (controller *ExampleController) RestoreExample(c webcontext.WebContext) {
+	id := c.Param("id")
+	ctx := c.GetContext()
+	log := logger.FromContext(ctx)
+
+	log.Info(ctx, "Processing RestoreExample request", logger.CustomFields{
+		"exampleId": id,
+		"endpoint":  "POST /examples/:id/restore",
+	})
+
+	input := usecases.RestoreExampleInputDTO{
+		Id: id,
+	}
+
+	output, err := controller.RestoreExampleUseCase.Execute(ctx, input)
+	if err != nil {
+		log.WithError(err).Error(ctx, "Failed to restore example", logger.CustomFields{
+			"exampleId": id,
+		})
+		advisor.ReturnApplicationError(c, err)
+		return
+	}
+
+	log.Info(ctx, "Example restored successfully", logger.CustomFields{
 		"exampleId": id,
 	})
 
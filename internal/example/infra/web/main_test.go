@@ -0,0 +1,16 @@
+//go:build sqlite
+
+package web
+
+import (
+	"os"
+	"testing"
+
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+)
+
+func TestMain(m *testing.M) {
+	// Controller errors are logged through the global logger
+	logger.SetGlobalLogger(logger.NewMultiLogger())
+	os.Exit(m.Run())
+}
@@ -0,0 +1,22 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/example/infra"
+)
+
+// Module adapts infra.ExampleModule to module.Module
+// Routes live in this package, which infra cannot import without a cycle
+type Module struct {
+	*infra.ExampleModule
+}
+
+// NewModule wraps the example module so it can be registered in the container
+func NewModule(m *infra.ExampleModule) *Module {
+	return &Module{ExampleModule: m}
+}
+
+// RegisterRoutes registers the module routes on group
+func (m *Module) RegisterRoutes(group *gin.RouterGroup) {
+	RegisterRoutes(group, m.ExampleModule)
+}
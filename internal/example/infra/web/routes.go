@@ -1,14 +1,29 @@
 package web
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/refortunato/go_app_base/internal/example/infra"
 	"github.com/refortunato/go_app_base/internal/shared/web/context"
+	"github.com/refortunato/go_app_base/internal/shared/web/routes"
 )
 
 // RegisterRoutes registers all routes for the example module
-func RegisterRoutes(router *gin.Engine, module *infra.ExampleModule) {
+func RegisterRoutes(router gin.IRoutes, module *infra.ExampleModule) {
+	// Tags listed by GET /routes
+	tag := routes.Tagger(router, "examples")
+
 	router.GET("/examples/:id", func(ctx *gin.Context) {
 		module.ExampleController.GetExample(context.NewGinContextAdapter(ctx))
 	})
+	tag(http.MethodGet, "/examples/:id")
+	router.DELETE("/examples/:id", func(ctx *gin.Context) {
+		module.ExampleController.DeleteExample(context.NewGinContextAdapter(ctx))
+	})
+	tag(http.MethodDelete, "/examples/:id")
+	router.POST("/examples/:id/restore", func(ctx *gin.Context) {
+		module.ExampleController.RestoreExample(context.NewGinContextAdapter(ctx))
+	})
+	tag(http.MethodPost, "/examples/:id/restore")
 }
@@ -0,0 +1,70 @@
+//go:build sqlite
+
+package web
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/example/infra"
+	shareddb "github.com/refortunato/go_app_base/internal/shared/db"
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+)
+
+// newExampleRouter mounts the module routes over an in-memory database, returned to seed examples
+func newExampleRouter(t *testing.T) (*gin.Engine, *sql.DB) {
+	t.Helper()
+	db := testhelpers.NewSQLiteForTest(t)
+	stmtCache := shareddb.NewStmtCache(db, 10)
+	t.Cleanup(func() { stmtCache.Close() })
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	RegisterRoutes(router, infra.NewExampleModule(db, stmtCache, false))
+	return router, db
+}
+
+func serve(router *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w
+}
+
+func TestRoutes_SoftDeleteAndRestore(t *testing.T) {
+	router, db := newExampleRouter(t)
+	if _, err := db.Exec(`INSERT INTO examples (id, description) VALUES ('example-1', 'First example')`); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	steps := []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodGet, "/examples/example-1", http.StatusOK},
+		{http.MethodPost, "/examples/example-1/restore", http.StatusConflict},
+		{http.MethodDelete, "/examples/example-1", http.StatusNoContent},
+		{http.MethodGet, "/examples/example-1", http.StatusNotFound},
+		// A deleted example can no longer be deleted: it is not found
+		{http.MethodDelete, "/examples/example-1", http.StatusNotFound},
+		{http.MethodPost, "/examples/example-1/restore", http.StatusOK},
+		{http.MethodGet, "/examples/example-1", http.StatusOK},
+		{http.MethodPost, "/examples/unknown/restore", http.StatusNotFound},
+	}
+	for _, step := range steps {
+		if w := serve(router, step.method, step.path); w.Code != step.want {
+			t.Fatalf("%s %s = %d, want %d: %s", step.method, step.path, w.Code, step.want, w.Body.String())
+		}
+	}
+
+	// The row is kept while deleted, only deleted_at changes
+	var deletedAt sql.NullTime
+	if err := db.QueryRow(`SELECT deleted_at FROM examples WHERE id = 'example-1'`).Scan(&deletedAt); err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if deletedAt.Valid {
+		t.Errorf("deleted_at = %v after restore, want NULL", deletedAt.Time)
+	}
+}
@@ -1,5 +1,18 @@
 package repositories
 
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
 type HealthRepository interface {
 	CheckDatabaseConnection() error
+	// CheckDatabaseLatency runs a query on the database and returns its round-trip time
+	CheckDatabaseLatency(ctx context.Context) (time.Duration, error)
+	GetConnectionPoolStats() sql.DBStats
+	// CheckRabbitMQ connects to the configured RabbitMQ broker and closes the connection
+	CheckRabbitMQ(ctx context.Context) error
+	// CheckKafka connects to the configured Kafka bootstrap server and closes the connection
+	CheckKafka(ctx context.Context) error
 }
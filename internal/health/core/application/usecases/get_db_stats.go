@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/refortunato/go_app_base/internal/health/core/application/repositories"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+)
+
+type GetDBStatsInputDTO struct {
+	ResetPoolStats bool `json:"resetPoolStats"`
+}
+
+type GetDBStatsOutputDTO struct {
+	MaxOpenConnections int   `json:"maxOpenConnections" example:"20"`
+	OpenConnections    int   `json:"openConnections" example:"4"`
+	InUse              int   `json:"inUse" example:"1"`
+	Idle               int   `json:"idle" example:"3"`
+	WaitCount          int64 `json:"waitCount" example:"0"`
+	WaitDurationMs     int64 `json:"waitDurationMs" example:"0"`
+	MaxIdleClosed      int64 `json:"maxIdleClosed" example:"0"`
+	MaxLifetimeClosed  int64 `json:"maxLifetimeClosed" example:"0"`
+}
+
+type GetDBStatsUseCase struct {
+	healthRepository repositories.HealthRepository
+}
+
+func NewGetDBStatsUseCase(healthRepository repositories.HealthRepository) *GetDBStatsUseCase {
+	return &GetDBStatsUseCase{
+		healthRepository: healthRepository,
+	}
+}
+
+func (u *GetDBStatsUseCase) Execute(ctx context.Context, input GetDBStatsInputDTO) (*GetDBStatsOutputDTO, error) {
+	if input.ResetPoolStats {
+		// database/sql keeps cumulative counters and offers no way to reset them
+		logger.Info(ctx, "Connection pool stats reset requested but not supported; stats are cumulative")
+	}
+
+	stats := u.healthRepository.GetConnectionPoolStats()
+
+	return &GetDBStatsOutputDTO{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}, nil
+}
@@ -2,24 +2,50 @@ package usecases
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/refortunato/go_app_base/internal/health/core/application/repositories"
 	"github.com/refortunato/go_app_base/internal/shared/observability"
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
 )
 
+// Component statuses reported by the health check
+const (
+	ComponentStatusUp       = "up"
+	ComponentStatusDegraded = "degraded"
+	ComponentStatusDown     = "down"
+	ComponentStatusSkipped  = "skipped"
+)
+
 type HealthCheckOutputDTO struct {
-	Status string `json:"status"`
+	Status      string `json:"status" example:"OK"`
+	Database    string `json:"database" example:"up"`
+	DBLatencyMs int64  `json:"db_latency_ms" example:"2"`
+	// Warning is set when a component is degraded or down (the service still answers 200)
+	Warning bool `json:"warning,omitempty" example:"false"`
+	// Components holds the optional dependency checks by name (e.g. "otel_collector", "kafka_broker")
+	Components map[string]ComponentStatus `json:"components,omitempty"`
 }
 
 type HealthCheckUseCase struct {
-	healthRepository repositories.HealthRepository
-	metrics          *observability.CustomMetrics
-	healthCounter    metric.Int64Counter
+	healthRepository   repositories.HealthRepository
+	dbSystem           string
+	slowQueryThreshold time.Duration
+	metrics            *observability.CustomMetrics
+	healthCounter      metric.Int64Counter
+	healthDuration     metric.Float64Histogram
+	components         []HealthComponent
 }
 
-func NewHealthCheckUseCase(healthRepository repositories.HealthRepository) *HealthCheckUseCase {
+// NewHealthCheckUseCase creates the health check use case
+// dbSystem is the database driver recorded as the "db.system" span attribute (e.g. "mysql")
+// slowQueryThreshold marks the database as degraded when exceeded (0 disables)
+// components are optional dependencies reported under Components; they never fail the check
+func NewHealthCheckUseCase(healthRepository repositories.HealthRepository, dbSystem string, slowQueryThreshold time.Duration, components ...HealthComponent) *HealthCheckUseCase {
 	metrics := observability.NewCustomMetrics("health_module")
 
 	// Create counter for health checks (reuse across all calls)
@@ -29,37 +55,96 @@ func NewHealthCheckUseCase(healthRepository repositories.HealthRepository) *Heal
 		"{check}",
 	)
 
+	healthDuration, _ := metrics.Histogram(
+		"health.check.duration",
+		"Time taken to run a health check",
+		"ms",
+	)
+
 	return &HealthCheckUseCase{
-		healthRepository: healthRepository,
-		metrics:          metrics,
-		healthCounter:    healthCounter,
+		healthRepository:   healthRepository,
+		dbSystem:           dbSystem,
+		slowQueryThreshold: slowQueryThreshold,
+		metrics:            metrics,
+		healthCounter:      healthCounter,
+		healthDuration:     healthDuration,
+		components:         components,
 	}
 }
 
-func (u *HealthCheckUseCase) Execute() (*HealthCheckOutputDTO, error) {
-	ctx := context.Background()
+func (u *HealthCheckUseCase) Execute(ctx context.Context) (*HealthCheckOutputDTO, error) {
+	tracer := otel.Tracer("health_module")
+	ctx, span := tracer.Start(ctx, "HealthCheckUseCase.Execute")
+	defer span.End()
 
-	err := u.healthRepository.CheckDatabaseConnection()
+	span.SetAttributes(attribute.String("db.system", u.dbSystem))
 
-	// Record health check metric (non-blocking)
+	start := time.Now()
+	latency, err := u.healthRepository.CheckDatabaseLatency(ctx)
+
+	// Record health check metrics (non-blocking); the duration includes the component checks
 	status := "success"
 	if err != nil {
 		status = "failure"
 	}
 
-	u.healthCounter.Add(ctx, 1,
-		metric.WithAttributes(
-			attribute.String("status", status),
-		),
-	)
+	statusAttr := metric.WithAttributes(attribute.String("status", status))
+	u.healthCounter.Add(ctx, 1, statusAttr)
+	defer func() {
+		u.healthDuration.Record(ctx, float64(time.Since(start).Microseconds())/1000.0, statusAttr)
+	}()
 
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Database health check failed")
 		return nil, err
 	}
 
 	output := &HealthCheckOutputDTO{
-		Status: "OK",
+		Status:      "OK",
+		Database:    ComponentStatusUp,
+		DBLatencyMs: latency.Milliseconds(),
+	}
+
+	// A slow database is degraded, not down
+	if u.slowQueryThreshold > 0 && latency > u.slowQueryThreshold {
+		output.Database = ComponentStatusDegraded
+		output.Warning = true
+	}
+	span.SetAttributes(
+		attribute.Int64("db.latency_ms", output.DBLatencyMs),
+		attribute.String("health.database", output.Database),
+	)
+
+	if len(u.components) > 0 {
+		output.Components = u.checkComponents(ctx)
+		for _, component := range output.Components {
+			if component.Status == ComponentStatusDown || component.Status == ComponentStatusDegraded {
+				output.Warning = true
+			}
+		}
 	}
 
+	span.SetStatus(codes.Ok, "Health check completed")
 	return output, nil
 }
+
+// checkComponents runs the component checks concurrently
+func (u *HealthCheckUseCase) checkComponents(ctx context.Context) map[string]ComponentStatus {
+	statuses := make([]ComponentStatus, len(u.components))
+	var wg sync.WaitGroup
+	for i, component := range u.components {
+		wg.Add(1)
+		go func(i int, component HealthComponent) {
+			defer wg.Done()
+			statuses[i] = component.Check(ctx)
+		}(i, component)
+	}
+	wg.Wait()
+
+	result := make(map[string]ComponentStatus, len(u.components))
+	for i, component := range u.components {
+		result[component.Name()] = statuses[i]
+	}
+	return result
+}
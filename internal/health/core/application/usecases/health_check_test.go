@@ -0,0 +1,254 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// fakeHealthRepository answers the database checks with a fixed latency or error
+type fakeHealthRepository struct {
+	latency time.Duration
+	err     error
+}
+
+func (r *fakeHealthRepository) CheckDatabaseConnection() error { return r.err }
+
+func (r *fakeHealthRepository) CheckDatabaseLatency(context.Context) (time.Duration, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	return r.latency, nil
+}
+
+func (r *fakeHealthRepository) GetConnectionPoolStats() sql.DBStats { return sql.DBStats{} }
+func (r *fakeHealthRepository) CheckRabbitMQ(context.Context) error { return nil }
+func (r *fakeHealthRepository) CheckKafka(context.Context) error    { return nil }
+
+func TestHealthCheck_DatabaseLatency(t *testing.T) {
+	tests := []struct {
+		name         string
+		latency      time.Duration
+		threshold    time.Duration
+		wantDatabase string
+		wantWarning  bool
+	}{
+		{name: "fast query", latency: 5 * time.Millisecond, threshold: 100 * time.Millisecond, wantDatabase: ComponentStatusUp},
+		{name: "at the threshold", latency: 100 * time.Millisecond, threshold: 100 * time.Millisecond, wantDatabase: ComponentStatusUp},
+		{name: "slow query", latency: 250 * time.Millisecond, threshold: 100 * time.Millisecond, wantDatabase: ComponentStatusDegraded, wantWarning: true},
+		{name: "threshold disabled", latency: 5 * time.Second, wantDatabase: ComponentStatusUp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := NewHealthCheckUseCase(&fakeHealthRepository{latency: tt.latency}, "mysql", tt.threshold)
+
+			output, err := useCase.Execute(context.Background())
+			if err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+			if output.Status != "OK" || output.Database != tt.wantDatabase || output.Warning != tt.wantWarning {
+				t.Errorf("status, database, warning = %s, %s, %v, want OK, %s, %v",
+					output.Status, output.Database, output.Warning, tt.wantDatabase, tt.wantWarning)
+			}
+			if output.DBLatencyMs != tt.latency.Milliseconds() {
+				t.Errorf("db_latency_ms = %d, want %d", output.DBLatencyMs, tt.latency.Milliseconds())
+			}
+		})
+	}
+}
+
+func TestHealthCheck_DatabaseDown(t *testing.T) {
+	dbDown := errors.New("connection refused")
+	useCase := NewHealthCheckUseCase(&fakeHealthRepository{err: dbDown}, "mysql", 100*time.Millisecond)
+
+	if _, err := useCase.Execute(context.Background()); !errors.Is(err, dbDown) {
+		t.Errorf("err = %v, want the database error", err)
+	}
+}
+
+// fixedComponent reports a fixed status
+type fixedComponent struct {
+	name   string
+	status ComponentStatus
+}
+
+func (c fixedComponent) Name() string                          { return c.name }
+func (c fixedComponent) Check(context.Context) ComponentStatus { return c.status }
+
+func TestHealthCheck_Components(t *testing.T) {
+	tests := []struct {
+		name        string
+		components  []HealthComponent
+		wantWarning bool
+	}{
+		{name: "all up", components: []HealthComponent{
+			fixedComponent{"otel_collector", ComponentStatus{Status: ComponentStatusUp}},
+			fixedComponent{"kafka_broker", ComponentStatus{Status: ComponentStatusUp, LatencyMs: 3}},
+		}},
+		{name: "skipped is not a warning", components: []HealthComponent{
+			fixedComponent{"otel_collector", ComponentStatus{Status: ComponentStatusSkipped}},
+			fixedComponent{"kafka_broker", ComponentStatus{Status: ComponentStatusUp}},
+		}},
+		{name: "down is a warning", components: []HealthComponent{
+			fixedComponent{"otel_collector", ComponentStatus{Status: ComponentStatusUp}},
+			fixedComponent{"kafka_broker", ComponentStatus{Status: ComponentStatusDown, Error: "connection refused"}},
+		}, wantWarning: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := NewHealthCheckUseCase(&fakeHealthRepository{latency: time.Millisecond}, "mysql", 0, tt.components...)
+
+			output, err := useCase.Execute(context.Background())
+			if err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+			// A failing dependency never fails the check itself
+			if output.Status != "OK" || output.Warning != tt.wantWarning {
+				t.Errorf("status, warning = %s, %v, want OK, %v", output.Status, output.Warning, tt.wantWarning)
+			}
+			for _, component := range tt.components {
+				if got := output.Components[component.Name()]; got != component.Check(context.Background()) {
+					t.Errorf("components[%s] = %+v, want %+v", component.Name(), got, component.Check(context.Background()))
+				}
+			}
+		})
+	}
+}
+
+func TestBrokerComponent(t *testing.T) {
+	refused := errors.New("connection refused")
+	tests := []struct {
+		name       string
+		configured bool
+		err        error
+		want       ComponentStatus
+	}{
+		{name: "not configured", want: ComponentStatus{Status: ComponentStatusSkipped}},
+		{name: "up", configured: true, want: ComponentStatus{Status: ComponentStatusUp}},
+		{name: "down", configured: true, err: refused, want: ComponentStatus{Status: ComponentStatusDown, Error: "connection refused"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			component := NewBrokerComponent("kafka_broker", tt.configured, func(context.Context) error { return tt.err })
+
+			if got := component.Check(context.Background()); got != tt.want {
+				t.Errorf("Check = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// useInMemoryTelemetry installs global tracer and meter providers that keep spans and metrics in memory
+// It must run before NewHealthCheckUseCase, which creates the instruments
+func useInMemoryTelemetry(t *testing.T) (*tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	t.Helper()
+	spans := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	previousTracer, previousMeter := otel.GetTracerProvider(), otel.GetMeterProvider()
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetMeterProvider(meterProvider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousTracer)
+		otel.SetMeterProvider(previousMeter)
+		tracerProvider.Shutdown(context.Background())
+		meterProvider.Shutdown(context.Background())
+	})
+	return spans, reader
+}
+
+// healthMetrics returns the data points of the health check count and duration by status
+func healthMetrics(t *testing.T, reader *sdkmetric.ManualReader) (counts map[string]int64, durations map[string]uint64) {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	counts, durations = map[string]int64{}, map[string]uint64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				if m.Name == "health.check.count" {
+					for _, dp := range data.DataPoints {
+						status, _ := dp.Attributes.Value("status")
+						counts[status.AsString()] += dp.Value
+					}
+				}
+			case metricdata.Histogram[float64]:
+				if m.Name == "health.check.duration" {
+					for _, dp := range data.DataPoints {
+						status, _ := dp.Attributes.Value("status")
+						durations[status.AsString()] += dp.Count
+					}
+				}
+			}
+		}
+	}
+	return counts, durations
+}
+
+func TestHealthCheck_Telemetry(t *testing.T) {
+	tests := []struct {
+		name       string
+		repo       *fakeHealthRepository
+		wantStatus string
+		wantCode   codes.Code
+	}{
+		{name: "success", repo: &fakeHealthRepository{latency: 2 * time.Millisecond}, wantStatus: "success", wantCode: codes.Ok},
+		{name: "failure", repo: &fakeHealthRepository{err: errors.New("connection refused")}, wantStatus: "failure", wantCode: codes.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spans, reader := useInMemoryTelemetry(t)
+			useCase := NewHealthCheckUseCase(tt.repo, "mysql", 0)
+
+			// The controller passes the request context, so the span joins the request trace
+			ctx, parent := otel.Tracer("test").Start(context.Background(), "GET /health")
+			useCase.Execute(ctx)
+			parent.End()
+
+			var span sdktrace.ReadOnlySpan
+			for _, s := range spans.Ended() {
+				if s.Name() == "HealthCheckUseCase.Execute" {
+					span = s
+				}
+			}
+			if span == nil {
+				t.Fatal("no HealthCheckUseCase.Execute span")
+			}
+			if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+				t.Error("span is not a child of the request span")
+			}
+			if span.Status().Code != tt.wantCode {
+				t.Errorf("span status = %v, want %v", span.Status().Code, tt.wantCode)
+			}
+			found := false
+			for _, attr := range span.Attributes() {
+				if attr == attribute.String("db.system", "mysql") {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("attributes = %v, want db.system=mysql", span.Attributes())
+			}
+
+			counts, durations := healthMetrics(t, reader)
+			if counts[tt.wantStatus] != 1 || durations[tt.wantStatus] != 1 {
+				t.Errorf("count, duration observations with status %s = %d, %d, want 1, 1", tt.wantStatus, counts[tt.wantStatus], durations[tt.wantStatus])
+			}
+		})
+	}
+}
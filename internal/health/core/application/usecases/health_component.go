@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"context"
+	"time"
+)
+
+// ComponentStatus is the result of checking one external dependency
+type ComponentStatus struct {
+	Status    string `json:"status" example:"up"`
+	LatencyMs int64  `json:"latency_ms" example:"3"`
+	Error     string `json:"error,omitempty" example:"dial tcp 10.0.0.5:9092: connect: connection refused"`
+}
+
+// HealthComponent is an optional dependency checked by the health check
+// New dependencies are added by implementing it and passing it to NewHealthCheckUseCase
+type HealthComponent interface {
+	// Name is the key of the component in the health check output
+	Name() string
+	// Check reports the component status (ComponentStatusSkipped when it is not configured)
+	Check(ctx context.Context) ComponentStatus
+}
+
+// brokerComponent reports a message broker check of the health repository
+type brokerComponent struct {
+	name       string
+	configured bool
+	check      func(ctx context.Context) error
+}
+
+// NewBrokerComponent times check and reports it under name; the check is only run when
+// the broker is configured, otherwise the component is "skipped"
+func NewBrokerComponent(name string, configured bool, check func(ctx context.Context) error) HealthComponent {
+	return &brokerComponent{name: name, configured: configured, check: check}
+}
+
+func (b *brokerComponent) Name() string {
+	return b.name
+}
+
+func (b *brokerComponent) Check(ctx context.Context) ComponentStatus {
+	if !b.configured {
+		return ComponentStatus{Status: ComponentStatusSkipped}
+	}
+
+	start := time.Now()
+	err := b.check(ctx)
+	latency := time.Since(start)
+	if err != nil {
+		return ComponentStatus{Status: ComponentStatusDown, LatencyMs: latency.Milliseconds(), Error: err.Error()}
+	}
+	return ComponentStatus{Status: ComponentStatusUp, LatencyMs: latency.Milliseconds()}
+}
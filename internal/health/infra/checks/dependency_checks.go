@@ -0,0 +1,73 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/health/core/application/usecases"
+)
+
+// defaultCheckTimeout bounds each dependency check so a hanging dependency cannot stall /health
+const defaultCheckTimeout = 2 * time.Second
+
+// CheckOtelEndpoint sends a GET to the collector health endpoint (e.g. the health_check
+// extension at http://otel-collector:13133/) and fails on transport errors or non-2xx answers
+func CheckOtelEndpoint(ctx context.Context, endpoint string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	latency := time.Since(start)
+	if err != nil {
+		return latency, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return latency, fmt.Errorf("otel collector health endpoint returned status %d", resp.StatusCode)
+	}
+	return latency, nil
+}
+
+// checkFunc matches CheckOtelEndpoint
+type checkFunc func(ctx context.Context, target string) (time.Duration, error)
+
+// dependencyComponent adapts a check function to usecases.HealthComponent
+type dependencyComponent struct {
+	name   string
+	target string
+	check  checkFunc
+}
+
+// NewOtelCollectorComponent checks the collector health endpoint ("skipped" when endpoint is empty)
+func NewOtelCollectorComponent(endpoint string) usecases.HealthComponent {
+	return &dependencyComponent{name: "otel_collector", target: endpoint, check: CheckOtelEndpoint}
+}
+
+func (d *dependencyComponent) Name() string {
+	return d.name
+}
+
+func (d *dependencyComponent) Check(ctx context.Context) usecases.ComponentStatus {
+	if d.target == "" {
+		return usecases.ComponentStatus{Status: usecases.ComponentStatusSkipped}
+	}
+
+	latency, err := d.check(ctx, d.target)
+	if err != nil {
+		return usecases.ComponentStatus{
+			Status:    usecases.ComponentStatusDown,
+			LatencyMs: latency.Milliseconds(),
+			Error:     err.Error(),
+		}
+	}
+	return usecases.ComponentStatus{Status: usecases.ComponentStatusUp, LatencyMs: latency.Milliseconds()}
+}
@@ -0,0 +1,73 @@
+package checks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/refortunato/go_app_base/internal/health/core/application/usecases"
+)
+
+// newCollector serves the collector health endpoint, answering with status
+func newCollector(t *testing.T, status int) *httptest.Server {
+	t.Helper()
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(collector.Close)
+	return collector
+}
+
+func TestCheckOtelEndpoint(t *testing.T) {
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name     string
+		endpoint string
+		wantErr  bool
+	}{
+		{name: "healthy", endpoint: newCollector(t, http.StatusOK).URL},
+		{name: "unhealthy", endpoint: newCollector(t, http.StatusServiceUnavailable).URL, wantErr: true},
+		{name: "unreachable", endpoint: closed.URL, wantErr: true},
+		{name: "invalid endpoint", endpoint: "://collector", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			latency, err := CheckOtelEndpoint(context.Background(), tt.endpoint)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if latency < 0 {
+				t.Errorf("latency = %v, want it non-negative", latency)
+			}
+		})
+	}
+}
+
+func TestOtelCollectorComponent(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoint  string
+		want      string
+		wantError bool
+	}{
+		{name: "not configured", want: usecases.ComponentStatusSkipped},
+		{name: "up", endpoint: newCollector(t, http.StatusOK).URL, want: usecases.ComponentStatusUp},
+		{name: "down", endpoint: newCollector(t, http.StatusInternalServerError).URL, want: usecases.ComponentStatusDown, wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			component := NewOtelCollectorComponent(tt.endpoint)
+			if component.Name() != "otel_collector" {
+				t.Errorf("Name = %q, want otel_collector", component.Name())
+			}
+
+			status := component.Check(context.Background())
+			if status.Status != tt.want || (status.Error != "") != tt.wantError {
+				t.Errorf("status = %+v, want %s (error %v)", status, tt.want, tt.wantError)
+			}
+		})
+	}
+}
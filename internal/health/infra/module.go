@@ -1,9 +1,13 @@
 package infra
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
+	"github.com/refortunato/go_app_base/configs"
 	"github.com/refortunato/go_app_base/internal/health/core/application/usecases"
+	"github.com/refortunato/go_app_base/internal/health/infra/checks"
 	"github.com/refortunato/go_app_base/internal/health/infra/repositories"
 	"github.com/refortunato/go_app_base/internal/health/infra/web/controllers"
 )
@@ -12,21 +16,62 @@ import (
 type HealthModule struct {
 	HealthController   *controllers.HealthController
 	HealthCheckUseCase *usecases.HealthCheckUseCase
+	GetDBStatsUseCase  *usecases.GetDBStatsUseCase
+
+	db *sql.DB
 }
 
 // NewHealthModule creates and wires all dependencies for the health module
-func NewHealthModule(db *sql.DB) *HealthModule {
+func NewHealthModule(db *sql.DB, cfg *configs.Conf) *HealthModule {
 	// Repositories
-	healthRepository := repositories.NewHealthMySQLRepository(db)
+	healthRepository := repositories.NewHealthMySQLRepository(db, cfg.RabbitMQDSN, cfg.KafkaBootstrapServer)
 
 	// Use Cases
-	healthCheckUseCase := usecases.NewHealthCheckUseCase(healthRepository)
+	slowQueryThreshold := time.Duration(cfg.HealthCheckSlowQueryMs) * time.Millisecond
+	healthCheckUseCase := usecases.NewHealthCheckUseCase(healthRepository, cfg.DBDriver, slowQueryThreshold, newDependencyComponents(cfg, healthRepository)...)
+	getDBStatsUseCase := usecases.NewGetDBStatsUseCase(healthRepository)
 
 	// Controllers
-	healthController := controllers.NewHealthController(*healthCheckUseCase)
+	healthController := controllers.NewHealthController(*healthCheckUseCase, *getDBStatsUseCase)
 
 	return &HealthModule{
 		HealthController:   healthController,
 		HealthCheckUseCase: healthCheckUseCase,
+		GetDBStatsUseCase:  getDBStatsUseCase,
+		db:                 db,
+	}
+}
+
+// newDependencyComponents builds the optional dependency checks; unconfigured ones report "skipped"
+func newDependencyComponents(cfg *configs.Conf, healthRepository *repositories.HealthMySQLRepository) []usecases.HealthComponent {
+	otelEndpoint := ""
+	if cfg.OtelEnabled {
+		otelEndpoint = cfg.HealthCheckOtelEndpoint
 	}
+	return []usecases.HealthComponent{
+		checks.NewOtelCollectorComponent(otelEndpoint),
+		usecases.NewBrokerComponent("kafka_broker", cfg.KafkaBootstrapServer != "", healthRepository.CheckKafka),
+		usecases.NewBrokerComponent("rabbitmq", cfg.RabbitMQDSN != "", healthRepository.CheckRabbitMQ),
+	}
+}
+
+// ExpectedSchema lists the tables and columns used by the module (see configs.ValidateSchema)
+// The health checks only run "SELECT 1", so no table is required
+func ExpectedSchema() map[string][]string {
+	return map[string][]string{}
+}
+
+// Name identifies the module in configuration (implements module.Module)
+func (m *HealthModule) Name() string {
+	return "health"
+}
+
+// RoutePrefix is the default prefix for the module routes (implements module.Module)
+func (m *HealthModule) RoutePrefix() string {
+	return "/"
+}
+
+// HealthCheck reports whether the module's database is reachable (implements module.Module)
+func (m *HealthModule) HealthCheck(ctx context.Context) error {
+	return m.db.PingContext(ctx)
 }
@@ -0,0 +1,62 @@
+package infra
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/refortunato/go_app_base/configs"
+	"github.com/refortunato/go_app_base/internal/health/core/application/usecases"
+	"github.com/refortunato/go_app_base/internal/health/infra/repositories"
+)
+
+// listenBroker accepts and closes TCP connections, standing in for a Kafka broker
+func listenBroker(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestNewDependencyComponents(t *testing.T) {
+	broker := listenBroker(t)
+	tests := []struct {
+		name string
+		cfg  *configs.Conf
+		want map[string]string
+	}{
+		{name: "nothing configured",
+			cfg:  &configs.Conf{HealthCheckOtelEndpoint: "http://otel-collector:13133/"},
+			want: map[string]string{"otel_collector": usecases.ComponentStatusSkipped, "kafka_broker": usecases.ComponentStatusSkipped, "rabbitmq": usecases.ComponentStatusSkipped}},
+		{name: "kafka broker listening",
+			cfg:  &configs.Conf{KafkaBootstrapServer: broker},
+			want: map[string]string{"otel_collector": usecases.ComponentStatusSkipped, "kafka_broker": usecases.ComponentStatusUp, "rabbitmq": usecases.ComponentStatusSkipped}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repositories.NewHealthMySQLRepository(nil, tt.cfg.RabbitMQDSN, tt.cfg.KafkaBootstrapServer)
+
+			got := map[string]string{}
+			for _, component := range newDependencyComponents(tt.cfg, repo) {
+				got[component.Name()] = component.Check(context.Background()).Status
+			}
+			for name, want := range tt.want {
+				if got[name] != want {
+					t.Errorf("%s = %q, want %q", name, got[name], want)
+				}
+			}
+		})
+	}
+}
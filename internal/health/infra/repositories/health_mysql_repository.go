@@ -1,15 +1,41 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
+	"io"
+	"net"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+// brokerCheckTimeout bounds a broker check when the context has no earlier deadline
+const brokerCheckTimeout = 2 * time.Second
+
+// brokerConnector opens a connection to a broker address, honouring the context deadline
+type brokerConnector func(ctx context.Context, address string) (io.Closer, error)
+
 type HealthMySQLRepository struct {
-	db *sql.DB
+	db                   *sql.DB
+	rabbitMQDSN          string
+	kafkaBootstrapServer string
+
+	// Connectors used by the broker checks (replaced by fakes in tests)
+	rabbitMQConnector brokerConnector
+	kafkaConnector    brokerConnector
 }
 
-func NewHealthMySQLRepository(db *sql.DB) *HealthMySQLRepository {
-	return &HealthMySQLRepository{db: db}
+// NewHealthMySQLRepository creates the repository; the broker addresses are only
+// used by CheckRabbitMQ and CheckKafka
+func NewHealthMySQLRepository(db *sql.DB, rabbitMQDSN string, kafkaBootstrapServer string) *HealthMySQLRepository {
+	return &HealthMySQLRepository{
+		db:                   db,
+		rabbitMQDSN:          rabbitMQDSN,
+		kafkaBootstrapServer: kafkaBootstrapServer,
+		rabbitMQConnector:    dialRabbitMQ,
+		kafkaConnector:       dialKafka,
+	}
 }
 
 func (r *HealthMySQLRepository) CheckDatabaseConnection() error {
@@ -21,3 +47,55 @@ func (r *HealthMySQLRepository) CheckDatabaseConnection() error {
 	}
 	return nil
 }
+
+// CheckDatabaseLatency measures a "SELECT 1" round trip, which (unlike Ping)
+// requires the database engine to actually execute a query
+func (r *HealthMySQLRepository) CheckDatabaseLatency(ctx context.Context) (time.Duration, error) {
+	var result int
+	start := time.Now()
+	if err := r.db.QueryRowContext(ctx, "SELECT 1").Scan(&result); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+func (r *HealthMySQLRepository) GetConnectionPoolStats() sql.DBStats {
+	return r.db.Stats()
+}
+
+// CheckRabbitMQ opens (and closes) an AMQP connection, which includes the handshake
+// and authentication, not just the TCP dial
+func (r *HealthMySQLRepository) CheckRabbitMQ(ctx context.Context) error {
+	return checkBroker(ctx, r.rabbitMQConnector, r.rabbitMQDSN)
+}
+
+// CheckKafka opens (and closes) a TCP connection to the bootstrap server (host:port)
+func (r *HealthMySQLRepository) CheckKafka(ctx context.Context) error {
+	return checkBroker(ctx, r.kafkaConnector, r.kafkaBootstrapServer)
+}
+
+// checkBroker connects to address within brokerCheckTimeout and closes the connection
+func checkBroker(ctx context.Context, connect brokerConnector, address string) error {
+	ctx, cancel := context.WithTimeout(ctx, brokerCheckTimeout)
+	defer cancel()
+
+	conn, err := connect(ctx, address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// dialRabbitMQ opens an AMQP connection; the AMQP client only takes a dial timeout, not a context
+func dialRabbitMQ(ctx context.Context, dsn string) (io.Closer, error) {
+	deadline, _ := ctx.Deadline()
+	return amqp.DialConfig(dsn, amqp.Config{
+		Dial: amqp.DefaultDial(time.Until(deadline)),
+	})
+}
+
+// dialKafka opens a TCP connection to a Kafka bootstrap server
+func dialKafka(ctx context.Context, address string) (io.Closer, error) {
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, "tcp", address)
+}
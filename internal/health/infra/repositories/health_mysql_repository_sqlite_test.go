@@ -0,0 +1,29 @@
+//go:build sqlite
+
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+)
+
+func TestCheckDatabaseLatency(t *testing.T) {
+	db := testhelpers.NewSQLiteForTest(t)
+	repo := NewHealthMySQLRepository(db, "", "")
+
+	latency, err := repo.CheckDatabaseLatency(context.Background())
+	if err != nil {
+		t.Fatalf("CheckDatabaseLatency: %v", err)
+	}
+	if latency <= 0 {
+		t.Errorf("latency = %v, want the query round trip", latency)
+	}
+
+	// A closed database fails the query, not just the measurement
+	db.Close()
+	if _, err := repo.CheckDatabaseLatency(context.Background()); err == nil {
+		t.Error("CheckDatabaseLatency succeeded on a closed database")
+	}
+}
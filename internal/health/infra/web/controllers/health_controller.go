@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/refortunato/go_app_base/internal/health/core/application/usecases"
 	"github.com/refortunato/go_app_base/internal/shared/web/advisor"
@@ -10,16 +11,48 @@ import (
 
 type HealthController struct {
 	HealthCheckUseCase usecases.HealthCheckUseCase
+	GetDBStatsUseCase  usecases.GetDBStatsUseCase
 }
 
-func NewHealthController(healthCheckUseCase usecases.HealthCheckUseCase) *HealthController {
+func NewHealthController(healthCheckUseCase usecases.HealthCheckUseCase, getDBStatsUseCase usecases.GetDBStatsUseCase) *HealthController {
 	return &HealthController{
 		HealthCheckUseCase: healthCheckUseCase,
+		GetDBStatsUseCase:  getDBStatsUseCase,
 	}
 }
 
 func (controller *HealthController) HealthCheck(c webcontext.WebContext) {
-	output, err := controller.HealthCheckUseCase.Execute()
+	output, err := controller.HealthCheckUseCase.Execute(c.GetContext())
+	if err != nil {
+		advisor.ReturnApplicationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, output)
+}
+
+// GetDBStats godoc
+// @Summary      Get database connection pool stats
+// @Description  Returns the current database connection pool statistics. Counters are cumulative since startup
+// @Tags         admin
+// @Produce      json
+// @Param        resetPoolStats  query     bool  false  "Request a stats reset (not supported, only logged)"
+// @Success      200             {object}  usecases.GetDBStatsOutputDTO
+// @Failure      400             {object}  map[string]string  "Invalid query parameter"
+// @Failure      401             {object}  map[string]string  "Authentication required"
+// @Router       /admin/db-stats [get]
+func (controller *HealthController) GetDBStats(c webcontext.WebContext) {
+	input := usecases.GetDBStatsInputDTO{}
+
+	if reset := c.Query("resetPoolStats"); reset != "" {
+		value, err := strconv.ParseBool(reset)
+		if err != nil {
+			advisor.ReturnBadRequestError(c, err)
+			return
+		}
+		input.ResetPoolStats = value
+	}
+
+	output, err := controller.GetDBStatsUseCase.Execute(c.GetContext(), input)
 	if err != nil {
 		advisor.ReturnApplicationError(c, err)
 		return
@@ -1,14 +1,28 @@
 package web
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/refortunato/go_app_base/internal/health/infra"
 	"github.com/refortunato/go_app_base/internal/shared/web/context"
+	"github.com/refortunato/go_app_base/internal/shared/web/routes"
 )
 
 // RegisterRoutes registers all routes for the health module
-func RegisterRoutes(router *gin.Engine, module *infra.HealthModule) {
+func RegisterRoutes(router gin.IRoutes, module *infra.HealthModule) {
+	// Tags listed by GET /routes
+	tag := routes.Tagger(router, "health")
+
 	router.GET("/health", func(ctx *gin.Context) {
 		module.HealthController.HealthCheck(context.NewGinContextAdapter(ctx))
 	})
+	tag(http.MethodGet, "/health")
+}
+
+// RegisterAdminRoutes registers the health module routes under the protected admin group
+func RegisterAdminRoutes(adminGroup *gin.RouterGroup, module *infra.HealthModule) {
+	adminGroup.GET("/db-stats", func(ctx *gin.Context) {
+		module.HealthController.GetDBStats(context.NewGinContextAdapter(ctx))
+	})
 }
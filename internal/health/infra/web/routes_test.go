@@ -0,0 +1,86 @@
+//go:build sqlite
+
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/configs"
+	"github.com/refortunato/go_app_base/internal/health/core/application/usecases"
+	"github.com/refortunato/go_app_base/internal/health/infra"
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+	"github.com/refortunato/go_app_base/internal/shared/web/middleware"
+)
+
+// newAdminRouter mounts the admin routes of a health module over db behind SwaggerBasicAuth,
+// as the route orchestrator does
+func newAdminRouter(db *sql.DB) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	adminGroup := router.Group("/admin")
+	adminGroup.Use(middleware.SwaggerBasicAuth())
+	RegisterAdminRoutes(adminGroup, infra.NewHealthModule(db, &configs.Conf{DBDriver: "sqlite"}))
+	return router
+}
+
+func TestGetDBStats(t *testing.T) {
+	t.Setenv("SERVER_APP_ENVIRONMENT", "production")
+	t.Setenv("SERVER_APP_SWAGGER_USER", "admin")
+	t.Setenv("SERVER_APP_SWAGGER_PASS", "secret")
+
+	db := testhelpers.NewSQLiteForTest(t)
+	router := newAdminRouter(db)
+
+	// Hold a connection so the pool reports it in use
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("Conn: %v", err)
+	}
+	defer conn.Close()
+
+	tests := []struct {
+		name       string
+		query      string
+		auth       bool
+		wantStatus int
+	}{
+		{name: "authenticated", auth: true, wantStatus: http.StatusOK},
+		{name: "reset requested", query: "?resetPoolStats=true", auth: true, wantStatus: http.StatusOK},
+		{name: "invalid reset flag", query: "?resetPoolStats=maybe", auth: true, wantStatus: http.StatusBadRequest},
+		{name: "no credentials", wantStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/db-stats"+tt.query, nil)
+			if tt.auth {
+				req.SetBasicAuth("admin", "secret")
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var stats usecases.GetDBStatsOutputDTO
+			if err := json.Unmarshal(rec.Body.Bytes(), &stats); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if stats.InUse < 1 {
+				t.Errorf("inUse = %d, want at least the held connection", stats.InUse)
+			}
+			if stats.OpenConnections < stats.InUse {
+				t.Errorf("openConnections = %d, want >= inUse %d", stats.OpenConnections, stats.InUse)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/refortunato/go_app_base/cmd/server/container"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"github.com/refortunato/go_app_base/internal/simple_module"
+)
+
+// RegisterServices is the main gRPC service orchestrator
+// It delegates service registration to each module
+func RegisterServices(c *container.Container) func(*grpc.Server) {
+	return func(server *grpc.Server) {
+		// Builds the module when the container was created with WithLazyInit
+		simpleModule, err := c.GetSimpleModule()
+		if err != nil {
+			ctx := context.Background()
+			logger.FromContext(ctx).WithError(err).Error(ctx, "Failed to initialize gRPC services", logger.CustomFields{})
+			return
+		}
+		simple_module.RegisterGRPCServices(server, simpleModule)
+	}
+}
@@ -0,0 +1,43 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/configs"
+	webcontext "github.com/refortunato/go_app_base/internal/shared/web/context"
+	"github.com/refortunato/go_app_base/internal/shared/web/middleware"
+)
+
+// DebugController exposes diagnostic endpoints, mounted only in debug mode
+type DebugController struct {
+	cfg *configs.Conf
+}
+
+// NewDebugController creates a new debug controller instance
+func NewDebugController(cfg *configs.Conf) *DebugController {
+	return &DebugController{cfg: cfg}
+}
+
+// GetConfig godoc
+// @Summary      Get current configuration
+// @Description  Returns the live configuration with passwords, tokens and keys replaced by "***". Only available when SERVER_APP_DEBUG_MODE=true
+// @Tags         debug
+// @Produce      json
+// @Success      200  {object}  configs.Conf
+// @Failure      401  {object}  map[string]string  "Authentication required"
+// @Router       /debug/config [get]
+func (controller *DebugController) GetConfig(c webcontext.WebContext) {
+	c.JSON(http.StatusOK, configs.MaskSensitive(controller.cfg))
+}
+
+// registerDebugRoutes mounts the debug endpoints, restricted to the admin networks like
+// /admin and protected by the Swagger credentials
+func registerDebugRoutes(router *gin.Engine, controller *DebugController) {
+	debugGroup := router.Group("/debug")
+	debugGroup.Use(middleware.IPFilterMiddleware(controller.cfg.GetAdminAllowCIDRs(), controller.cfg.GetAdminBlockCIDRs()))
+	debugGroup.Use(middleware.SwaggerBasicAuth())
+	debugGroup.GET("/config", func(ctx *gin.Context) {
+		controller.GetConfig(webcontext.NewGinContextAdapter(ctx))
+	})
+}
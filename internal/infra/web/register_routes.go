@@ -1,19 +1,23 @@
 package web
 
 import (
+	"context"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	"github.com/refortunato/go_app_base/cmd/server/container"
-	exampleWeb "github.com/refortunato/go_app_base/internal/example/infra/web"
 	healthWeb "github.com/refortunato/go_app_base/internal/health/infra/web"
+	"github.com/refortunato/go_app_base/internal/shared/auth"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"github.com/refortunato/go_app_base/internal/shared/module"
 	"github.com/refortunato/go_app_base/internal/shared/web/middleware"
-	"github.com/refortunato/go_app_base/internal/simple_module"
 )
 
 // RegisterRoutes is the main route orchestrator
-// It delegates route registration to each module
+// It delegates route registration to each module registered in the container
 func RegisterRoutes(c *container.Container) func(*gin.Engine) {
 	return func(router *gin.Engine) {
 		// Swagger documentation with authentication middleware
@@ -21,9 +25,124 @@ func RegisterRoutes(c *container.Container) func(*gin.Engine) {
 		swaggerGroup.Use(middleware.SwaggerBasicAuth())
 		swaggerGroup.GET("/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+		// Reject clients that accept none of the formats the API produces (406)
+		router.Use(middleware.AcceptMiddleware("application/json", "application/xml"))
+
+		// Identify the caller so routes can enforce scopes with middleware.RequireScope
+		if c.Config.AuthEnabled {
+			router.Use(middleware.APIKeyAuth(auth.ParseAPIKeys(c.Config.APIKeys)))
+		} else {
+			router.Use(middleware.AnonymousAuth())
+		}
+
+		// Rate limit per client; runs after authentication so unknown API keys never get a bucket
+		if c.Config.RateLimitRPS > 0 {
+			keyFn := middleware.IPKeyFunc
+			if c.Config.AuthEnabled {
+				keyFn = middleware.FallbackKeyFunc(middleware.APIKeyKeyFunc, middleware.IPKeyFunc)
+			}
+			limit := middleware.RateLimit{RequestsPerSecond: c.Config.RateLimitRPS, Burst: c.Config.RateLimitBurst}
+			router.Use(middleware.RateLimitMiddleware(limit, keyFn, middleware.ParseRateLimitOverrides(c.Config.RateLimitKeyOverrides)))
+		}
+
+		// Wrap successful JSON responses in a {"data", "meta"} envelope
+		if c.Config.ResponseEnvelopeEnabled {
+			router.Use(middleware.ResponseEnvelopeMiddleware())
+		}
+
+		// Admin routes: restricted to internal networks and protected by the Swagger credentials
+		adminGroup := router.Group("/admin")
+		adminGroup.Use(middleware.IPFilterMiddleware(c.Config.GetAdminAllowCIDRs(), c.Config.GetAdminBlockCIDRs()))
+		adminGroup.Use(middleware.SwaggerBasicAuth())
+
+		// Signed webhooks from external services, only mounted when a secret is configured
+		if c.Config.WebhookSecret != "" {
+			registerWebhookRoutes(router, c.Config.WebhookSecret, NewWebhookController())
+		}
+
+		// Machine-readable list of the public routes
+		registerRoutesListing(router, c.Config.Environment, NewRoutesController(router))
+
+		// Debug endpoints expose internals, so they only exist in debug mode
+		if c.Config.DebugMode {
+			registerDebugRoutes(router, NewDebugController(c.Config))
+		}
+
+		// Builds the module (and registers its routes) when the container was created with WithLazyInit
+		healthModule, err := c.GetHealthModule()
+		if err != nil {
+			c.Logger.Error(context.Background(), "Failed to initialize the health module, admin health routes disabled", logger.CustomFields{
+				"error": err.Error(),
+			})
+		}
+
+		// Modules mounted under /v1 are deprecated once a sunset date is configured
+		v1Deprecation := newV1DeprecationMiddleware(c)
+		var deprecatedPrefixes []string
+
 		// Register routes for each module
-		healthWeb.RegisterRoutes(router, c.HealthModule)
-		exampleWeb.RegisterRoutes(router, c.ExampleModule)
-		simple_module.RegisterRoutes(router, c.SimpleModule)
+		for _, m := range c.Modules() {
+			prefix := m.RoutePrefix()
+			if override, ok := c.Config.ModulePrefixes[m.Name()]; ok {
+				prefix = override
+			}
+			group := module.NewRouteGroup(router, prefix)
+			if v1Deprecation != nil && isV1Prefix(prefix) {
+				group.Use(v1Deprecation)
+				deprecatedPrefixes = append(deprecatedPrefixes, group.BasePath())
+			}
+			m.RegisterRoutes(group)
+		}
+		logDeprecatedRoutes(c, router, deprecatedPrefixes)
+		if healthModule != nil {
+			healthWeb.RegisterAdminRoutes(adminGroup, healthModule)
+		}
+	}
+}
+
+// newV1DeprecationMiddleware returns nil when no (valid) v1 sunset date is configured
+func newV1DeprecationMiddleware(c *container.Container) gin.HandlerFunc {
+	if c.Config.V1SunsetDate == "" {
+		return nil
+	}
+	sunsetDate, err := middleware.ParseSunsetDate(c.Config.V1SunsetDate)
+	if err != nil {
+		c.Logger.Error(context.Background(), "Invalid v1 sunset date, deprecation headers disabled", logger.CustomFields{
+			"error": err.Error(),
+		})
+		return nil
+	}
+	return middleware.DeprecationMiddleware(sunsetDate, c.Config.V1DeprecationLink)
+}
+
+// isV1Prefix reports whether prefix has a "v1" path segment (e.g. /v1, /api/v1)
+func isV1Prefix(prefix string) bool {
+	for _, segment := range strings.Split(prefix, "/") {
+		if segment == "v1" {
+			return true
+		}
 	}
+	return false
+}
+
+// logDeprecatedRoutes warns once at startup with every route served under a deprecated prefix
+func logDeprecatedRoutes(c *container.Container, router *gin.Engine, prefixes []string) {
+	if len(prefixes) == 0 {
+		return
+	}
+
+	var routes []string
+	for _, route := range router.Routes() {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(route.Path, prefix) {
+				routes = append(routes, route.Method+" "+route.Path)
+				break
+			}
+		}
+	}
+
+	c.Logger.Warn(context.Background(), "Deprecated v1 routes registered", logger.CustomFields{
+		"sunsetDate": c.Config.V1SunsetDate,
+		"routes":     routes,
+	})
 }
@@ -0,0 +1,218 @@
+//go:build sqlite && test
+
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/cmd/server/container"
+	"github.com/refortunato/go_app_base/configs"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"github.com/refortunato/go_app_base/internal/shared/observability"
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+)
+
+// newTestContainer wires the application on an in-memory SQLite database
+func newTestContainer(t *testing.T, cfg *configs.Conf) *container.Container {
+	t.Helper()
+	// The JSON schemas are resolved from the repository root
+	t.Chdir("../../..")
+	cfg.UploadDirectory = t.TempDir()
+
+	tracerProvider, err := observability.NewTracerProvider(cfg)
+	if err != nil {
+		t.Fatalf("NewTracerProvider: %v", err)
+	}
+	meterProvider, err := observability.NewMeterProvider(cfg)
+	if err != nil {
+		t.Fatalf("NewMeterProvider: %v", err)
+	}
+
+	c, err := container.New(testhelpers.NewSQLiteForTest(t), cfg, tracerProvider, meterProvider)
+	if err != nil {
+		t.Fatalf("container.New: %v", err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := c.Shutdown(ctx); err != nil {
+			t.Errorf("container Shutdown: %v", err)
+		}
+	})
+	return c
+}
+
+// newRoutedEngine runs RegisterRoutes on a new engine
+func newRoutedEngine(c *container.Container) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	RegisterRoutes(c)(router)
+	return router
+}
+
+// dummyModule serves GET /ping under its prefix
+type dummyModule struct{}
+
+func (dummyModule) Name() string        { return "dummy" }
+func (dummyModule) RoutePrefix() string { return "/dummy" }
+
+func (dummyModule) RegisterRoutes(group *gin.RouterGroup) {
+	group.GET("/ping", func(c *gin.Context) {
+		c.String(http.StatusOK, "pong")
+	})
+}
+
+func (dummyModule) HealthCheck(context.Context) error { return nil }
+
+func TestRegisterRoutes_MountsRegisteredModules(t *testing.T) {
+	c := newTestContainer(t, configs.NewTestConfig())
+	c.RegisterModule(dummyModule{})
+	router := newRoutedEngine(c)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/dummy/ping", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "pong" {
+		t.Errorf("GET /dummy/ping = %d %q, want 200 pong", w.Code, w.Body.String())
+	}
+
+	// The built-in modules are mounted the same way
+	routes := map[string]bool{}
+	for _, route := range router.Routes() {
+		routes[route.Method+" "+route.Path] = true
+	}
+	for _, want := range []string{"GET /dummy/ping", "GET /health", "GET /products/:id"} {
+		if !routes[want] {
+			t.Errorf("route %s not registered", want)
+		}
+	}
+}
+
+func TestRegisterRoutes_ModulePrefixes(t *testing.T) {
+	cfg := configs.NewTestConfig()
+	cfg.ModulePrefixes = map[string]string{"simple": "/api/v1", "dummy": "/internal"}
+	c := newTestContainer(t, cfg)
+	c.RegisterModule(dummyModule{})
+	router := newRoutedEngine(c)
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{path: "/api/v1/products", want: http.StatusOK},
+		{path: "/products", want: http.StatusNotFound},
+		{path: "/internal/ping", want: http.StatusOK},
+		{path: "/dummy/ping", want: http.StatusNotFound},
+		// Modules without an override keep their default prefix
+		{path: "/health", want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if w.Code != tt.want {
+			t.Errorf("GET %s = %d, want %d", tt.path, w.Code, tt.want)
+		}
+	}
+}
+
+// warnRecorder records the warnings logged through it and discards everything else
+type warnRecorder struct {
+	logger.Logger
+	warnings []logger.CustomFields
+	messages []string
+}
+
+func (l *warnRecorder) Warn(_ context.Context, message string, customFields ...logger.CustomFields) {
+	l.messages = append(l.messages, message)
+	l.warnings = append(l.warnings, customFields...)
+}
+
+func TestRegisterRoutes_V1Deprecation(t *testing.T) {
+	cfg := configs.NewTestConfig()
+	cfg.ModulePrefixes = map[string]string{"simple": "/api/v1"}
+	cfg.V1SunsetDate = "2025-06-30"
+	cfg.V1DeprecationLink = "https://api.example.com/docs/v2-migration"
+	c := newTestContainer(t, cfg)
+	logs := &warnRecorder{Logger: logger.NewMultiLogger()}
+	c.Logger = logs
+	router := newRoutedEngine(c)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/products", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /api/v1/products = %d, want 200", w.Code)
+	}
+	want := map[string]string{
+		"Deprecation": "true",
+		"Sunset":      "Mon, 30 Jun 2025 00:00:00 GMT",
+		"Link":        `<https://api.example.com/docs/v2-migration>; rel="deprecation"`,
+	}
+	for header, value := range want {
+		if got := w.Header().Get(header); got != value {
+			t.Errorf("%s = %q, want %q", header, got, value)
+		}
+	}
+
+	// Routes outside a v1 prefix are not deprecated
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if w.Header().Get("Deprecation") != "" {
+		t.Errorf("GET /health has Deprecation = %q, want none", w.Header().Get("Deprecation"))
+	}
+
+	if len(logs.messages) != 1 || logs.messages[0] != "Deprecated v1 routes registered" {
+		t.Fatalf("warnings = %v, want one listing the deprecated routes", logs.messages)
+	}
+	routes, _ := logs.warnings[0]["routes"].([]string)
+	if len(routes) == 0 || !strings.Contains(strings.Join(routes, ","), "GET /api/v1/products") {
+		t.Errorf("logged routes = %v, want the v1 product routes", routes)
+	}
+}
+
+func TestIsV1Prefix(t *testing.T) {
+	tests := map[string]bool{
+		"/v1":       true,
+		"/api/v1":   true,
+		"/v1/admin": true,
+		"/v10":      false,
+		"/api/v2":   false,
+		"/products": false,
+		"/apiv1":    false,
+	}
+	for prefix, want := range tests {
+		if got := isV1Prefix(prefix); got != want {
+			t.Errorf("isV1Prefix(%q) = %v, want %v", prefix, got, want)
+		}
+	}
+}
+
+func TestRegisterRoutes_NotAcceptable(t *testing.T) {
+	c := newTestContainer(t, configs.NewTestConfig())
+	c.RegisterModule(dummyModule{})
+	router := newRoutedEngine(c)
+
+	req := httptest.NewRequest(http.MethodGet, "/dummy/ping", nil)
+	req.Header.Set("Accept", "text/html")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotAcceptable {
+		t.Fatalf("status = %d, want 406", w.Code)
+	}
+	var problem struct {
+		SupportedMediaTypes []string `json:"supported_media_types"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("body is not a problem: %v: %s", err, w.Body.String())
+	}
+	if want := []string{"application/json", "application/xml"}; !reflect.DeepEqual(problem.SupportedMediaTypes, want) {
+		t.Errorf("supported_media_types = %v, want %v", problem.SupportedMediaTypes, want)
+	}
+}
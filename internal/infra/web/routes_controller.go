@@ -0,0 +1,49 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	webcontext "github.com/refortunato/go_app_base/internal/shared/web/context"
+	"github.com/refortunato/go_app_base/internal/shared/web/middleware"
+	"github.com/refortunato/go_app_base/internal/shared/web/routes"
+)
+
+// internalRoutePrefixes are left out of GET /routes
+var internalRoutePrefixes = []string{"/admin", "/debug", "/swagger"}
+
+// RoutesController lists the public API routes for discoverability
+type RoutesController struct {
+	router *gin.Engine
+}
+
+// NewRoutesController creates a controller listing the routes registered on router
+func NewRoutesController(router *gin.Engine) *RoutesController {
+	return &RoutesController{router: router}
+}
+
+// ListRoutes godoc
+// @Summary      List API routes
+// @Description  Returns every public route (method, path and tags) sorted by path. Admin, debug and Swagger routes are not listed
+// @Tags         routes
+// @Produce      json
+// @Success      200  {array}   routes.RouteInfo
+// @Failure      401  {object}  map[string]string  "Authentication required"
+// @Router       /routes [get]
+func (controller *RoutesController) ListRoutes(c webcontext.WebContext) {
+	// Read at request time so routes registered after this one are included
+	c.JSON(http.StatusOK, routes.List(controller.router.Routes(), internalRoutePrefixes...))
+}
+
+// registerRoutesListing mounts GET /routes, protected by the Swagger credentials outside development
+func registerRoutesListing(router *gin.Engine, environment string, controller *RoutesController) {
+	handlers := []gin.HandlerFunc{}
+	if environment != "development" {
+		handlers = append(handlers, middleware.SwaggerBasicAuth())
+	}
+	handlers = append(handlers, func(ctx *gin.Context) {
+		controller.ListRoutes(webcontext.NewGinContextAdapter(ctx))
+	})
+	router.GET("/routes", handlers...)
+	routes.TagRoute(http.MethodGet, "/routes", "routes")
+}
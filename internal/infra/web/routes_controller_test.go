@@ -0,0 +1,89 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/shared/web/routes"
+)
+
+// newRoutesRouter registers GET /routes and a few module routes for environment
+func newRoutesRouter(t *testing.T, environment string) *gin.Engine {
+	t.Helper()
+	t.Setenv("SERVER_APP_ENVIRONMENT", environment)
+	t.Setenv("SERVER_APP_SWAGGER_ENABLED", "")
+	t.Setenv("SERVER_APP_SWAGGER_USER", "docs")
+	t.Setenv("SERVER_APP_SWAGGER_PASS", "secret")
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	registerRoutesListing(router, environment, NewRoutesController(router))
+
+	handler := func(c *gin.Context) { c.Status(http.StatusOK) }
+	products := router.Group("/v1/products")
+	tag := routes.Tagger(products, "products")
+	for _, route := range []struct{ method, path string }{
+		{http.MethodGet, ""}, {http.MethodPost, ""}, {http.MethodGet, "/:id"}, {http.MethodPut, "/:id"}, {http.MethodDelete, "/:id"},
+	} {
+		products.Handle(route.method, route.path, handler)
+		tag(route.method, route.path)
+	}
+	router.GET("/debug/config", handler)
+	return router
+}
+
+func TestListRoutes(t *testing.T) {
+	router := newRoutesRouter(t, "development")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/routes", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	var listed []routes.RouteInfo
+	if err := json.Unmarshal(w.Body.Bytes(), &listed); err != nil {
+		t.Fatalf("decode: %v: %s", err, w.Body.String())
+	}
+
+	got := map[string][]string{}
+	for _, route := range listed {
+		got[route.Method+" "+route.Path] = route.Tags
+	}
+	for _, key := range []string{"GET /v1/products", "POST /v1/products", "GET /v1/products/:id", "PUT /v1/products/:id", "DELETE /v1/products/:id"} {
+		if tags, ok := got[key]; !ok || len(tags) != 1 || tags[0] != "products" {
+			t.Errorf("%s listed = %v with tags %v, want it tagged products", key, ok, tags)
+		}
+	}
+	if tags := got["GET /routes"]; len(tags) != 1 || tags[0] != "routes" {
+		t.Errorf("GET /routes tags = %v, want routes", tags)
+	}
+	if _, ok := got["GET /debug/config"]; ok {
+		t.Error("debug route listed")
+	}
+	for i := 1; i < len(listed); i++ {
+		if listed[i-1].Path > listed[i].Path {
+			t.Errorf("routes not sorted by path: %s before %s", listed[i-1].Path, listed[i].Path)
+		}
+	}
+}
+
+func TestListRoutes_ProtectedOutsideDevelopment(t *testing.T) {
+	router := newRoutesRouter(t, "production")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/routes", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("without credentials: status = %d, want 401", w.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/routes", nil)
+	req.SetBasicAuth("docs", "secret")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("with the Swagger credentials: status = %d, want 200", w.Code)
+	}
+}
@@ -0,0 +1,57 @@
+package web
+
+import (
+	"crypto"
+	_ "crypto/sha256" // registers crypto.SHA256 for HMACSignatureMiddleware
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	webcontext "github.com/refortunato/go_app_base/internal/shared/web/context"
+	"github.com/refortunato/go_app_base/internal/shared/web/middleware"
+	"github.com/refortunato/go_app_base/internal/shared/web/routes"
+)
+
+// WebhookSignatureHeader carries the hex-encoded HMAC-SHA256 of the webhook body
+const WebhookSignatureHeader = "X-Signature"
+
+// WebhookController receives signed webhooks from external services (e.g. payment processors)
+type WebhookController struct{}
+
+// NewWebhookController creates a new webhook controller instance
+func NewWebhookController() *WebhookController {
+	return &WebhookController{}
+}
+
+// ReceiveWebhook godoc
+// @Summary      Receive a webhook
+// @Description  Accepts a webhook from an external service. The X-Signature header must hold the hex-encoded HMAC-SHA256 of the raw body computed with SERVER_APP_WEBHOOK_SECRET. Only mounted when the secret is configured
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        source       path      string  true  "Webhook source (e.g. payments)"
+// @Param        X-Signature  header    string  true  "Hex-encoded HMAC-SHA256 of the body"
+// @Success      202          {object}  map[string]string
+// @Failure      401          {object}  errors.ProblemDetails  "Missing or invalid signature"
+// @Router       /webhooks/{source} [post]
+func (controller *WebhookController) ReceiveWebhook(c webcontext.WebContext) {
+	ctx := c.GetContext()
+	body, _ := c.GetBody()
+
+	// Handlers for specific sources are registered on the /webhooks group; this one only acknowledges
+	logger.FromContext(ctx).Info(ctx, "Webhook received", logger.CustomFields{
+		"source":    c.Param("source"),
+		"bodyBytes": len(body),
+	})
+	c.JSON(http.StatusAccepted, map[string]string{"status": "accepted"})
+}
+
+// registerWebhookRoutes mounts POST /webhooks/* behind HMAC signature verification
+func registerWebhookRoutes(router *gin.Engine, secret string, controller *WebhookController) {
+	webhookGroup := router.Group("/webhooks")
+	webhookGroup.Use(middleware.HMACSignatureMiddleware(secret, WebhookSignatureHeader, crypto.SHA256))
+	webhookGroup.POST("/:source", func(ctx *gin.Context) {
+		controller.ReceiveWebhook(webcontext.NewGinContextAdapter(ctx))
+	})
+	routes.TagRoute(http.MethodPost, "/webhooks/:source", "webhooks")
+}
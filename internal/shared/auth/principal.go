@@ -0,0 +1,36 @@
+package auth
+
+import "strings"
+
+// APIKeyInfo describes the caller authenticated by an API key
+type APIKeyInfo struct {
+	Key    string
+	Scopes []string
+}
+
+// JWTClaims holds the claims of a validated JWT relevant for authorization
+type JWTClaims struct {
+	Subject string
+	Scopes  []string
+}
+
+// ParseAPIKeys parses keys in the format "key1=scope1|scope2,key2=scope3"
+// Entries without a key are ignored
+func ParseAPIKeys(raw string) map[string]APIKeyInfo {
+	keys := make(map[string]APIKeyInfo)
+	for _, entry := range strings.Split(raw, ",") {
+		key, scopes, _ := strings.Cut(strings.TrimSpace(entry), "=")
+		if key == "" {
+			continue
+		}
+
+		info := APIKeyInfo{Key: key}
+		for _, scope := range strings.Split(scopes, "|") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				info.Scopes = append(info.Scopes, scope)
+			}
+		}
+		keys[key] = info
+	}
+	return keys
+}
@@ -0,0 +1,21 @@
+package auth
+
+// Scopes checked by RBAC middleware
+const (
+	// ScopeAll grants every scope; used by the anonymous principal when authentication is disabled
+	ScopeAll = "*"
+
+	ScopeProductRead  = "product:read"
+	ScopeProductWrite = "product:write"
+	ScopeAdminRead    = "admin:read"
+)
+
+// HasScope reports whether scopes grants the requested scope
+func HasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope || s == ScopeAll {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Execer is the subset of *sql.DB and *sql.Tx used by BatchInsert
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// BatchProcess calls processor for consecutive chunks of at most batchSize items
+// (a non-positive batchSize processes all items at once). Every chunk is processed even
+// when an earlier one fails; the failures are returned together with errors.Join
+func BatchProcess[T any](ctx context.Context, items []T, batchSize int, processor func(ctx context.Context, batch []T) error) error {
+	if batchSize <= 0 {
+		batchSize = len(items)
+	}
+
+	var errs []error
+	for start := 0; start < len(items); start += batchSize {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		end := min(start+batchSize, len(items))
+		if err := processor(ctx, items[start:end]); err != nil {
+			errs = append(errs, fmt.Errorf("batch of items %d-%d: %w", start, end-1, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BatchInsert inserts rows with a single multi-row "INSERT INTO table (columns) VALUES (...), (...)"
+// table and columns are written into the SQL text, so they must never come from user input
+// Keep len(rows)*len(columns) below the driver placeholder limit (65535 for MySQL) by batching
+func BatchInsert(ctx context.Context, db Execer, table string, columns []string, rows [][]any) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	if len(columns) == 0 {
+		return errors.New("batch insert: no columns")
+	}
+
+	placeholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+	values := make([]string, 0, len(rows))
+	args := make([]any, 0, len(rows)*len(columns))
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return fmt.Errorf("batch insert: row %d has %d values, expected %d", i, len(row), len(columns))
+		}
+		values = append(values, placeholders)
+		args = append(args, row...)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", table, strings.Join(columns, ", "), strings.Join(values, ", "))
+	_, err := db.ExecContext(ctx, query, args...)
+	return err
+}
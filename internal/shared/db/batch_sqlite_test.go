@@ -0,0 +1,49 @@
+//go:build sqlite
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+)
+
+func TestBatchInsert_250RowsInBatchesOf100(t *testing.T) {
+	ctx := context.Background()
+	db := testhelpers.NewSQLiteForTest(t)
+	now := time.Now().UTC()
+	rows := make([][]any, 250)
+	for i := range rows {
+		rows[i] = []any{fmt.Sprintf("product-%03d", i), fmt.Sprintf("Product %d", i), "", 10.0, i, now, now}
+	}
+	columns := []string{"id", "name", "description", "price", "stock", "created_at", "updated_at"}
+
+	batches := 0
+	err := BatchProcess(ctx, rows, 100, func(ctx context.Context, batch [][]any) error {
+		batches++
+		return BatchInsert(ctx, db, "products", columns, batch)
+	})
+	if err != nil {
+		t.Fatalf("BatchProcess: %v", err)
+	}
+
+	if batches != 3 {
+		t.Errorf("batches = %d, want 3", batches)
+	}
+	var count int
+	if err := db.QueryRow(countQuery).Scan(&count); err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if count != 250 {
+		t.Errorf("rows = %d, want 250", count)
+	}
+	for _, id := range []string{"product-000", "product-099", "product-100", "product-249"} {
+		var name string
+		if err := db.QueryRow(findQuery, id).Scan(&name); err != nil {
+			t.Errorf("%s not inserted: %v", id, err)
+		}
+	}
+}
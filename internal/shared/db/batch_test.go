@@ -0,0 +1,141 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBatchProcess_Chunks(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     int
+		batchSize int
+		want      []int
+	}{
+		{name: "exact multiple", items: 200, batchSize: 100, want: []int{100, 100}},
+		{name: "last chunk shorter", items: 250, batchSize: 100, want: []int{100, 100, 50}},
+		{name: "smaller than a batch", items: 3, batchSize: 100, want: []int{3}},
+		{name: "non-positive batch size", items: 7, batchSize: 0, want: []int{7}},
+		{name: "no items", items: 0, batchSize: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := make([]int, tt.items)
+			for i := range items {
+				items[i] = i
+			}
+			var sizes []int
+			next := 0
+			err := BatchProcess(context.Background(), items, tt.batchSize, func(_ context.Context, batch []int) error {
+				sizes = append(sizes, len(batch))
+				for _, item := range batch {
+					if item != next {
+						t.Fatalf("item %d processed out of order, want %d", item, next)
+					}
+					next++
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("BatchProcess: %v", err)
+			}
+			if !reflect.DeepEqual(sizes, tt.want) {
+				t.Errorf("batch sizes = %v, want %v", sizes, tt.want)
+			}
+		})
+	}
+}
+
+func TestBatchProcess_JoinsErrors(t *testing.T) {
+	first, third := errors.New("first failed"), errors.New("third failed")
+	calls := 0
+	err := BatchProcess(context.Background(), make([]int, 25), 10, func(context.Context, []int) error {
+		calls++
+		switch calls {
+		case 1:
+			return first
+		case 3:
+			return third
+		}
+		return nil
+	})
+
+	if calls != 3 {
+		t.Errorf("processor calls = %d, want every batch processed", calls)
+	}
+	if !errors.Is(err, first) || !errors.Is(err, third) {
+		t.Fatalf("err = %v, want both batch errors", err)
+	}
+	if !strings.Contains(err.Error(), "items 0-9") || !strings.Contains(err.Error(), "items 20-24") {
+		t.Errorf("err = %q, want the failing item ranges", err)
+	}
+}
+
+func TestBatchProcess_StopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := BatchProcess(ctx, make([]int, 30), 10, func(context.Context, []int) error {
+		calls++
+		cancel()
+		return nil
+	})
+
+	if calls != 1 || !errors.Is(err, context.Canceled) {
+		t.Errorf("calls = %d, err = %v, want 1 call and context.Canceled", calls, err)
+	}
+}
+
+// recordingExecer records the statements it is given
+type recordingExecer struct {
+	query string
+	args  []any
+}
+
+func (e *recordingExecer) ExecContext(_ context.Context, query string, args ...any) (sql.Result, error) {
+	e.query, e.args = query, args
+	return nil, nil
+}
+
+func TestBatchInsert_BuildsMultiRowInsert(t *testing.T) {
+	exec := &recordingExecer{}
+	rows := [][]any{{"1", "Keyboard"}, {"2", "Mouse"}}
+
+	if err := BatchInsert(context.Background(), exec, "products", []string{"id", "name"}, rows); err != nil {
+		t.Fatalf("BatchInsert: %v", err)
+	}
+	if want := "INSERT INTO products (id, name) VALUES (?, ?), (?, ?)"; exec.query != want {
+		t.Errorf("query = %q, want %q", exec.query, want)
+	}
+	if want := []any{"1", "Keyboard", "2", "Mouse"}; !reflect.DeepEqual(exec.args, want) {
+		t.Errorf("args = %v, want %v", exec.args, want)
+	}
+}
+
+func TestBatchInsert_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []string
+		rows    [][]any
+		wantErr bool
+	}{
+		{name: "no rows is a no-op", columns: []string{"id"}},
+		{name: "no columns", rows: [][]any{{"1"}}, wantErr: true},
+		{name: "short row", columns: []string{"id", "name"}, rows: [][]any{{"1", "Keyboard"}, {"2"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exec := &recordingExecer{}
+			err := BatchInsert(context.Background(), exec, "products", tt.columns, tt.rows)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if exec.query != "" {
+				t.Errorf("ran %q, want nothing executed", exec.query)
+			}
+		})
+	}
+}
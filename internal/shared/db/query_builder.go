@@ -0,0 +1,55 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ErrColumnNotAllowed is returned when a condition references a column outside the allowlist
+var ErrColumnNotAllowed = errors.New("column not allowed")
+
+// BuildWhereClause builds a "WHERE col1 = ? AND col2 = ?" clause from conditions
+// Only columns in allowedColumns are accepted, so user input never reaches the SQL text
+// A nil value produces "col IS NULL". Conditions are sorted by column for a stable query
+// Returns an empty clause and no args when conditions is empty
+func BuildWhereClause(allowedColumns []string, conditions map[string]any) (string, []any, error) {
+	if len(conditions) == 0 {
+		return "", nil, nil
+	}
+
+	columns := make([]string, 0, len(conditions))
+	for column := range conditions {
+		if !slices.Contains(allowedColumns, column) {
+			return "", nil, fmt.Errorf("%w: %q", ErrColumnNotAllowed, column)
+		}
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	predicates := make([]string, 0, len(columns))
+	args := make([]any, 0, len(columns))
+	for _, column := range columns {
+		value := conditions[column]
+		if value == nil {
+			predicates = append(predicates, column+" IS NULL")
+			continue
+		}
+		predicates = append(predicates, column+" = ?")
+		args = append(args, value)
+	}
+
+	return "WHERE " + strings.Join(predicates, " AND "), args, nil
+}
+
+// LikeEscapeChar is the escape character used by EscapeLike; queries must declare it
+// with "LIKE ? ESCAPE '!'" (SQLite has no default escape character and MySQL's
+// backslash would need escaping inside the SQL string literal)
+const LikeEscapeChar = "!"
+
+// EscapeLike escapes the LIKE wildcards (%, _) and the escape character in value
+func EscapeLike(value string) string {
+	return strings.NewReplacer(LikeEscapeChar, LikeEscapeChar+LikeEscapeChar, `%`, LikeEscapeChar+`%`, `_`, LikeEscapeChar+`_`).Replace(value)
+}
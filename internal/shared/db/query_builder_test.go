@@ -0,0 +1,63 @@
+package db
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBuildWhereClause(t *testing.T) {
+	allowed := []string{"name", "price", "stock", "deleted_at"}
+
+	tests := []struct {
+		name       string
+		conditions map[string]any
+		wantWhere  string
+		wantArgs   []any
+	}{
+		{name: "empty conditions", conditions: nil, wantWhere: "", wantArgs: nil},
+		{name: "single column", conditions: map[string]any{"name": "Keyboard"}, wantWhere: "WHERE name = ?", wantArgs: []any{"Keyboard"}},
+		{name: "sorted by column", conditions: map[string]any{"stock": 0, "name": "Keyboard", "price": 99.9},
+			wantWhere: "WHERE name = ? AND price = ? AND stock = ?", wantArgs: []any{"Keyboard", 99.9, 0}},
+		{name: "nil is IS NULL", conditions: map[string]any{"deleted_at": nil, "stock": 5},
+			wantWhere: "WHERE deleted_at IS NULL AND stock = ?", wantArgs: []any{5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args, err := BuildWhereClause(allowed, tt.conditions)
+			if err != nil {
+				t.Fatalf("BuildWhereClause: %v", err)
+			}
+			if where != tt.wantWhere || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("got %q %v, want %q %v", where, args, tt.wantWhere, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestBuildWhereClause_RejectsColumnsOutsideAllowlist(t *testing.T) {
+	for _, column := range []string{"password", "name; DROP TABLE products", "1 = 1 OR name", "Name"} {
+		where, args, err := BuildWhereClause([]string{"name", "price"}, map[string]any{"price": 10, column: "x"})
+		if !errors.Is(err, ErrColumnNotAllowed) {
+			t.Errorf("%q: err = %v, want ErrColumnNotAllowed", column, err)
+		}
+		if where != "" || args != nil {
+			t.Errorf("%q: got %q %v, want no clause", column, where, args)
+		}
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	tests := map[string]string{
+		"keyboard":   "keyboard",
+		"100%":       "100!%",
+		"snake_case": "snake!_case",
+		"Hi!":        "Hi!!",
+		`C:\temp`:    `C:\temp`,
+	}
+	for value, want := range tests {
+		if got := EscapeLike(value); got != want {
+			t.Errorf("EscapeLike(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
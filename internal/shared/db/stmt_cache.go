@@ -0,0 +1,108 @@
+package db
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+)
+
+// DefaultStmtCacheSize is used when NewStmtCache receives a size <= 0
+const DefaultStmtCacheSize = 50
+
+// StmtCache keeps prepared statements by query text so the database parses each query once
+// The cache holds at most size statements; the least recently used one is closed when
+// a new query does not fit. Size it above the number of distinct queries, since a
+// statement evicted while another goroutine is about to use it fails with "statement is closed"
+type StmtCache struct {
+	db   *sql.DB
+	size int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// order holds the queries from most (front) to least (back) recently used
+	order *list.List
+}
+
+type stmtCacheEntry struct {
+	query string
+	stmt  *sql.Stmt
+}
+
+// NewStmtCache creates a statement cache for db holding up to size statements
+func NewStmtCache(db *sql.DB, size int) *StmtCache {
+	if size <= 0 {
+		size = DefaultStmtCacheSize
+	}
+	return &StmtCache{
+		db:      db,
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Get returns the cached statement for query, preparing it on first use
+// Callers must not close the returned statement
+func (c *StmtCache) Get(ctx context.Context, query string) (*sql.Stmt, error) {
+	c.mu.Lock()
+	if elem, ok := c.entries[query]; ok {
+		c.order.MoveToFront(elem)
+		stmt := elem.Value.(*stmtCacheEntry).stmt
+		c.mu.Unlock()
+		return stmt, nil
+	}
+	c.mu.Unlock()
+
+	// Prepare outside the lock so slow round trips don't block other queries
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Another goroutine prepared the same query meanwhile: keep the cached one
+	if elem, ok := c.entries[query]; ok {
+		stmt.Close()
+		c.order.MoveToFront(elem)
+		return elem.Value.(*stmtCacheEntry).stmt, nil
+	}
+
+	c.entries[query] = c.order.PushFront(&stmtCacheEntry{query: query, stmt: stmt})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		entry := oldest.Value.(*stmtCacheEntry)
+		c.order.Remove(oldest)
+		delete(c.entries, entry.query)
+		entry.stmt.Close()
+	}
+
+	return stmt, nil
+}
+
+// Len returns the number of cached statements
+func (c *StmtCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Close closes every cached statement and empties the cache
+func (c *StmtCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var errs []error
+	for _, elem := range c.entries {
+		if err := elem.Value.(*stmtCacheEntry).stmt.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+
+	return errors.Join(errs...)
+}
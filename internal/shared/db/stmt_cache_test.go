@@ -0,0 +1,90 @@
+//go:build sqlite
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+)
+
+const (
+	countQuery  = "SELECT COUNT(*) FROM products"
+	findQuery   = "SELECT name FROM products WHERE id = ?"
+	deleteQuery = "DELETE FROM products WHERE id = ?"
+)
+
+func TestStmtCache_ReusesStatements(t *testing.T) {
+	ctx := context.Background()
+	cache := NewStmtCache(testhelpers.NewSQLiteForTest(t), 10)
+	t.Cleanup(func() { cache.Close() })
+
+	first, err := cache.Get(ctx, countQuery)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	second, err := cache.Get(ctx, countQuery)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if first != second {
+		t.Error("the same query was prepared twice")
+	}
+	if other, _ := cache.Get(ctx, findQuery); other == first {
+		t.Error("different queries share a statement")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len = %d, want 2", cache.Len())
+	}
+
+	if _, err := cache.Get(ctx, "SELECT * FROM missing_table"); err == nil {
+		t.Error("Get of an invalid query succeeded, want the prepare error")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len after a failed prepare = %d, want 2", cache.Len())
+	}
+}
+
+func TestStmtCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	cache := NewStmtCache(testhelpers.NewSQLiteForTest(t), 2)
+	t.Cleanup(func() { cache.Close() })
+
+	count, _ := cache.Get(ctx, countQuery)
+	find, _ := cache.Get(ctx, findQuery)
+	// Using count makes find the least recently used statement
+	cache.Get(ctx, countQuery)
+	if _, err := cache.Get(ctx, deleteQuery); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if cache.Len() != 2 {
+		t.Errorf("Len = %d, want the size of 2", cache.Len())
+	}
+	if _, err := find.QueryContext(ctx, "product-1"); err == nil {
+		t.Error("evicted statement is still open")
+	}
+	if again, _ := cache.Get(ctx, countQuery); again != count {
+		t.Error("recently used statement was evicted")
+	}
+	if _, err := count.ExecContext(ctx); err != nil {
+		t.Errorf("cached statement: %v", err)
+	}
+}
+
+func TestStmtCache_Close(t *testing.T) {
+	ctx := context.Background()
+	cache := NewStmtCache(testhelpers.NewSQLiteForTest(t), 0)
+	stmt, _ := cache.Get(ctx, countQuery)
+
+	if err := cache.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Len after Close = %d, want 0", cache.Len())
+	}
+	if _, err := stmt.ExecContext(ctx); err == nil {
+		t.Error("statement still open after Close")
+	}
+}
@@ -2,19 +2,36 @@ package dto
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 )
 
+// DefaultPaginationMaxLimit caps the page size accepted by NewPaginationRequestDTO
+const DefaultPaginationMaxLimit = 100
+
 // PaginationRequestDTO represents pagination parameters for list queries
 type PaginationRequestDTO struct {
 	Page   int
 	Limit  int
 	Offset int
+	// MaxLimit is the largest Limit that was accepted
+	MaxLimit int
 }
 
 // NewPaginationRequestDTO creates a pagination DTO from query string parameters
-// Default values: page=1, limit=10
+// Default values: page=1, limit=10 (also for limit=0), max limit=100
 func NewPaginationRequestDTO(pageStr, limitStr string) (*PaginationRequestDTO, error) {
+	return NewPaginationRequestDTOWithMax(pageStr, limitStr, DefaultPaginationMaxLimit)
+}
+
+// NewPaginationRequestDTOWithMax is like NewPaginationRequestDTO but rejects limits above maxLimit
+// A maxLimit <= 0 uses DefaultPaginationMaxLimit
+func NewPaginationRequestDTOWithMax(pageStr, limitStr string, maxLimit int) (*PaginationRequestDTO, error) {
+	if maxLimit <= 0 {
+		maxLimit = DefaultPaginationMaxLimit
+	}
+
 	page := 1
 	limit := 10
 
@@ -27,22 +44,28 @@ func NewPaginationRequestDTO(pageStr, limitStr string) (*PaginationRequestDTO, e
 		}
 	}
 
-	// Parse limit
+	// Parse limit (0 keeps the default)
 	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
+		if l, err := strconv.Atoi(limitStr); err == nil && l >= 0 {
+			if l > 0 {
+				limit = l
+			}
 		} else {
 			return nil, errors.New("invalid limit parameter")
 		}
 	}
+	if limit > maxLimit {
+		return nil, fmt.Errorf("limit exceeds maximum of %d", maxLimit)
+	}
 
 	// Calculate offset
 	offset := (page - 1) * limit
 
 	return &PaginationRequestDTO{
-		Page:   page,
-		Limit:  limit,
-		Offset: offset,
+		Page:     page,
+		Limit:    limit,
+		Offset:   offset,
+		MaxLimit: maxLimit,
 	}, nil
 }
 
@@ -52,9 +75,15 @@ type PaginationResponseDTO struct {
 	Limit      int `json:"limit"`
 	TotalItems int `json:"total_items,omitempty"`
 	TotalPages int `json:"total_pages,omitempty"`
+	// Navigation flags so clients don't have to compare page and total_pages
+	HasNextPage bool `json:"has_next_page"`
+	HasPrevPage bool `json:"has_prev_page"`
+	IsFirstPage bool `json:"is_first_page"`
+	IsLastPage  bool `json:"is_last_page"`
 }
 
 // NewPaginationResponseDTO creates pagination metadata for responses
+// An empty result or a page beyond the last one is reported as the last page (no next page)
 func NewPaginationResponseDTO(page, limit, totalItems int) *PaginationResponseDTO {
 	totalPages := 0
 	if totalItems > 0 {
@@ -62,9 +91,37 @@ func NewPaginationResponseDTO(page, limit, totalItems int) *PaginationResponseDT
 	}
 
 	return &PaginationResponseDTO{
-		Page:       page,
-		Limit:      limit,
-		TotalItems: totalItems,
-		TotalPages: totalPages,
+		Page:        page,
+		Limit:       limit,
+		TotalItems:  totalItems,
+		TotalPages:  totalPages,
+		HasNextPage: page < totalPages,
+		HasPrevPage: page > 1,
+		IsFirstPage: page == 1,
+		IsLastPage:  page >= totalPages,
 	}
 }
+
+// PaginationLinks returns the first, prev, next and last page URLs (RFC 5988 relations)
+// prev and next are omitted on the first and last page; baseURL may be empty (relative links)
+func PaginationLinks(baseURL, path string, page, limit, totalPages int) map[string]string {
+	links := make(map[string]string)
+	if totalPages <= 0 {
+		return links
+	}
+
+	pageURL := func(p int) string {
+		return fmt.Sprintf("%s%s?page=%d&limit=%d", strings.TrimSuffix(baseURL, "/"), path, p, limit)
+	}
+
+	links["first"] = pageURL(1)
+	if page > 1 {
+		links["prev"] = pageURL(min(page-1, totalPages))
+	}
+	if page < totalPages {
+		links["next"] = pageURL(page + 1)
+	}
+	links["last"] = pageURL(totalPages)
+
+	return links
+}
@@ -0,0 +1,107 @@
+package dto
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewPaginationResponseDTO_NavigationFlags(t *testing.T) {
+	tests := []struct {
+		name       string
+		page       int
+		limit      int
+		totalItems int
+		wantNext   bool
+		wantPrev   bool
+		wantFirst  bool
+		wantLast   bool
+	}{
+		{name: "page 1 of 1", page: 1, limit: 10, totalItems: 5, wantFirst: true, wantLast: true},
+		{name: "single full page", page: 1, limit: 10, totalItems: 10, wantFirst: true, wantLast: true},
+		{name: "first of many", page: 1, limit: 10, totalItems: 25, wantNext: true, wantFirst: true},
+		{name: "middle page", page: 2, limit: 10, totalItems: 25, wantNext: true, wantPrev: true},
+		{name: "last page", page: 3, limit: 10, totalItems: 25, wantPrev: true, wantLast: true},
+		{name: "page beyond last", page: 5, limit: 10, totalItems: 25, wantPrev: true, wantLast: true},
+		{name: "empty result", page: 1, limit: 10, totalItems: 0, wantFirst: true, wantLast: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPaginationResponseDTO(tt.page, tt.limit, tt.totalItems)
+			if got.HasNextPage != tt.wantNext {
+				t.Errorf("HasNextPage = %v, want %v", got.HasNextPage, tt.wantNext)
+			}
+			if got.HasPrevPage != tt.wantPrev {
+				t.Errorf("HasPrevPage = %v, want %v", got.HasPrevPage, tt.wantPrev)
+			}
+			if got.IsFirstPage != tt.wantFirst {
+				t.Errorf("IsFirstPage = %v, want %v", got.IsFirstPage, tt.wantFirst)
+			}
+			if got.IsLastPage != tt.wantLast {
+				t.Errorf("IsLastPage = %v, want %v", got.IsLastPage, tt.wantLast)
+			}
+			if got.IsLastPage && got.HasNextPage {
+				t.Error("IsLastPage and HasNextPage are both true")
+			}
+		})
+	}
+}
+
+func TestNewPaginationRequestDTOWithMax(t *testing.T) {
+	if _, err := NewPaginationRequestDTOWithMax("1", "101", 100); err == nil || err.Error() != "limit exceeds maximum of 100" {
+		t.Errorf("limit=101 with max 100: err = %v, want limit exceeds maximum of 100", err)
+	}
+
+	got, err := NewPaginationRequestDTOWithMax("1", "100", 100)
+	if err != nil || got.Limit != 100 {
+		t.Errorf("limit=100 with max 100: got %+v, %v", got, err)
+	}
+
+	got, err = NewPaginationRequestDTO("2", "0")
+	if err != nil {
+		t.Fatalf("limit=0: unexpected error %v", err)
+	}
+	if got.Limit != 10 || got.Offset != 10 {
+		t.Errorf("limit=0: Limit = %d, Offset = %d, want 10, 10", got.Limit, got.Offset)
+	}
+}
+
+func TestPaginationLinks(t *testing.T) {
+	const base = "https://api.example.com/"
+	tests := []struct {
+		name       string
+		page       int
+		totalPages int
+		want       map[string]string
+	}{
+		{name: "page 1 of 3", page: 1, totalPages: 3, want: map[string]string{
+			"first": "https://api.example.com/products?page=1&limit=10",
+			"next":  "https://api.example.com/products?page=2&limit=10",
+			"last":  "https://api.example.com/products?page=3&limit=10",
+		}},
+		{name: "page 2 of 3", page: 2, totalPages: 3, want: map[string]string{
+			"first": "https://api.example.com/products?page=1&limit=10",
+			"prev":  "https://api.example.com/products?page=1&limit=10",
+			"next":  "https://api.example.com/products?page=3&limit=10",
+			"last":  "https://api.example.com/products?page=3&limit=10",
+		}},
+		{name: "page beyond last", page: 7, totalPages: 3, want: map[string]string{
+			"first": "https://api.example.com/products?page=1&limit=10",
+			"prev":  "https://api.example.com/products?page=3&limit=10",
+			"last":  "https://api.example.com/products?page=3&limit=10",
+		}},
+		{name: "no pages", page: 1, totalPages: 0, want: map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PaginationLinks(base, "/products", tt.page, 10, tt.totalPages); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PaginationLinks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Without a base URL the links are relative
+	if got := PaginationLinks("", "/products", 1, 5, 1)["first"]; got != "/products?page=1&limit=5" {
+		t.Errorf("relative first = %q", got)
+	}
+}
@@ -0,0 +1,51 @@
+package dto
+
+import "context"
+
+// Transformer maps values of type In to response values of type Out
+// Implementations must not modify their input, so the same value can be transformed more than once
+type Transformer[In, Out any] interface {
+	Transform(ctx context.Context, in In) (Out, error)
+	TransformMany(ctx context.Context, in []In) ([]Out, error)
+}
+
+// TransformerFunc adapts a mapping function to the Transformer interface
+type TransformerFunc[In, Out any] func(ctx context.Context, in In) (Out, error)
+
+// Transform calls f
+func (f TransformerFunc[In, Out]) Transform(ctx context.Context, in In) (Out, error) {
+	return f(ctx, in)
+}
+
+// TransformMany calls f for each element, stopping at the first error
+func (f TransformerFunc[In, Out]) TransformMany(ctx context.Context, in []In) ([]Out, error) {
+	return TransformEach(ctx, f, in)
+}
+
+// TransformEach transforms every element with t.Transform, stopping at the first error
+// It is a ready-made TransformMany for Transformer implementations
+func TransformEach[In, Out any](ctx context.Context, t interface {
+	Transform(ctx context.Context, in In) (Out, error)
+}, in []In) ([]Out, error) {
+	out := make([]Out, 0, len(in))
+	for _, item := range in {
+		transformed, err := t.Transform(ctx, item)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, transformed)
+	}
+	return out, nil
+}
+
+// Chain returns a Transformer applying first and then second
+func Chain[A, B, C any](first Transformer[A, B], second Transformer[B, C]) Transformer[A, C] {
+	return TransformerFunc[A, C](func(ctx context.Context, in A) (C, error) {
+		mid, err := first.Transform(ctx, in)
+		if err != nil {
+			var zero C
+			return zero, err
+		}
+		return second.Transform(ctx, mid)
+	})
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+var errNegative = errors.New("negative value")
+
+// itoa formats non-negative ints, failing on negative ones
+var itoa = TransformerFunc[int, string](func(_ context.Context, in int) (string, error) {
+	if in < 0 {
+		return "", errNegative
+	}
+	return strconv.Itoa(in), nil
+})
+
+// length returns the length of a string
+var length = TransformerFunc[string, int](func(_ context.Context, in string) (int, error) {
+	return len(in), nil
+})
+
+func TestTransformerFunc_TransformMany(t *testing.T) {
+	got, err := itoa.TransformMany(context.Background(), []int{1, 22, 333})
+	if err != nil || !slices.Equal(got, []string{"1", "22", "333"}) {
+		t.Errorf("TransformMany = %v, %v, want [1 22 333]", got, err)
+	}
+
+	if got, err := itoa.TransformMany(context.Background(), []int{1, -2, 3}); !errors.Is(err, errNegative) || got != nil {
+		t.Errorf("TransformMany with an invalid element = %v, %v, want nil and the error", got, err)
+	}
+
+	if got, err := itoa.TransformMany(context.Background(), nil); err != nil || got == nil || len(got) != 0 {
+		t.Errorf("TransformMany(nil) = %#v, %v, want an empty slice (serialized as [])", got, err)
+	}
+}
+
+func TestChain(t *testing.T) {
+	digits := Chain[int, string, int](itoa, length)
+
+	got, err := digits.TransformMany(context.Background(), []int{7, 42, 1000})
+	if err != nil || !slices.Equal(got, []int{1, 2, 4}) {
+		t.Errorf("Chain.TransformMany = %v, %v, want [1 2 4]", got, err)
+	}
+	if _, err := digits.Transform(context.Background(), -1); !errors.Is(err, errNegative) {
+		t.Errorf("Chain.Transform error = %v, want the first transformer error", err)
+	}
+}
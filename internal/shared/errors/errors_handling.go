@@ -2,17 +2,31 @@ package errors
 
 import (
 	"encoding/json"
+	"encoding/xml"
 )
 
 // ProblemDetails segue RFC7807 e inclui campos extras.
 type ProblemDetails struct {
-	Type         string `json:"type,omitempty"`     // URI identificando o tipo do erro
-	Title        string `json:"title"`              // Título curto do erro
-	Status       int    `json:"status"`             // Código HTTP
-	Detail       string `json:"detail,omitempty"`   // Descrição detalhada
-	Instance     string `json:"instance,omitempty"` // URI da ocorrência do erro
-	Code         string `json:"code"`               // Código específico do erro
-	ErrorContext string `json:"error_context"`      // business ou infra
+	XMLName      xml.Name `json:"-" xml:"problem" swaggerignore:"true"`
+	Type         string   `json:"type,omitempty" xml:"type,omitempty"`           // URI identificando o tipo do erro
+	Title        string   `json:"title" xml:"title"`                             // Título curto do erro
+	Status       int      `json:"status" xml:"status"`                           // Código HTTP
+	Detail       string   `json:"detail,omitempty" xml:"detail,omitempty"`       // Descrição detalhada
+	Instance     string   `json:"instance,omitempty" xml:"instance,omitempty"`   // URI da ocorrência do erro
+	Code         string   `json:"code" xml:"code"`                               // Código específico do erro
+	ErrorContext string   `json:"error_context" xml:"error_context"`             // business ou infra
+	Module       string   `json:"module,omitempty" xml:"module,omitempty"`       // Módulo que gerou o erro
+	Operation    string   `json:"operation,omitempty" xml:"operation,omitempty"` // Operação que gerou o erro
+	// Erros de validação por campo (ex.: JSON Schema)
+	ValidationErrors []ValidationError `json:"validation_errors,omitempty" xml:"validation_errors>error,omitempty"`
+	// Media types aceitos pelo endpoint (respostas 406)
+	SupportedMediaTypes []string `json:"supported_media_types,omitempty" xml:"supported_media_types>media_type,omitempty"`
+}
+
+// ValidationError descreve uma falha de validação de um campo da requisição
+type ValidationError struct {
+	Field   string `json:"field" xml:"field"`     // JSON Pointer do campo (vazio para o documento inteiro)
+	Message string `json:"message" xml:"message"` // Motivo da falha
 }
 
 // Função para criar um novo erro RFC7807
@@ -27,6 +41,41 @@ func NewProblemDetails(status int, title, detail, code, errorContext string) *Pr
 	}
 }
 
+// NewProblemDetailsWithContext cria um erro RFC7807 identificando o módulo e a operação de origem
+func NewProblemDetailsWithContext(status int, title, detail, code, errorContext, module, operation string) *ProblemDetails {
+	pd := NewProblemDetails(status, title, detail, code, errorContext)
+	pd.Module = module
+	pd.Operation = operation
+	return pd
+}
+
+// WithOperation retorna uma cópia do erro com a operação informada (o original não é alterado)
+func (pd *ProblemDetails) WithOperation(operation string) *ProblemDetails {
+	clone := *pd
+	clone.Operation = operation
+	return &clone
+}
+
+// WithValidationErrors retorna uma cópia do erro com os erros de validação informados
+func (pd *ProblemDetails) WithValidationErrors(validationErrors []ValidationError) *ProblemDetails {
+	clone := *pd
+	clone.ValidationErrors = validationErrors
+	return &clone
+}
+
+// WithSupportedMediaTypes retorna uma cópia do erro com os media types suportados informados
+func (pd *ProblemDetails) WithSupportedMediaTypes(mediaTypes []string) *ProblemDetails {
+	clone := *pd
+	clone.SupportedMediaTypes = mediaTypes
+	return &clone
+}
+
+// Is permite que errors.Is reconheça cópias (ex.: WithOperation) pelo código do erro
+func (pd *ProblemDetails) Is(target error) bool {
+	t, ok := target.(*ProblemDetails)
+	return ok && t.Code == pd.Code
+}
+
 // Implementa a interface error
 func (pd *ProblemDetails) Error() string {
 	b, err := json.Marshal(pd)
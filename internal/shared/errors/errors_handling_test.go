@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestProblemDetails_ModuleAndOperationJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		pd   *ProblemDetails
+		want map[string]any
+	}{
+		{name: "with context",
+			pd:   NewProblemDetailsWithContext(404, "Product not found", "No product", "SIP1002", ErrorContextBusiness, "simple_module", "find_product"),
+			want: map[string]any{"module": "simple_module", "operation": "find_product"}},
+		{name: "with operation",
+			pd:   NewProblemDetailsWithContext(404, "Product not found", "No product", "SIP1002", ErrorContextBusiness, "simple_module", "find_product").WithOperation("delete_product"),
+			want: map[string]any{"module": "simple_module", "operation": "delete_product"}},
+		{name: "without context",
+			pd:   NewProblemDetails(500, "Internal error", "", "SHARED0001", ErrorContextInfra),
+			want: map[string]any{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.pd)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			var body map[string]any
+			if err := json.Unmarshal(b, &body); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			for _, key := range []string{"module", "operation"} {
+				if got, want := body[key], tt.want[key]; got != want {
+					t.Errorf("%s = %v, want %v in %s", key, got, want, b)
+				}
+			}
+			if body["code"] != tt.pd.Code || body["error_context"] != tt.pd.ErrorContext {
+				t.Errorf("body = %s, want the code and error context kept", b)
+			}
+		})
+	}
+}
+
+func TestProblemDetails_WithOperationClones(t *testing.T) {
+	original := NewProblemDetailsWithContext(404, "Product not found", "No product", "SIP1002", ErrorContextBusiness, "simple_module", "find_product")
+
+	clone := original.WithOperation("update_product")
+
+	if original.Operation != "find_product" {
+		t.Errorf("original operation = %q, want it unchanged", original.Operation)
+	}
+	if clone.Operation != "update_product" || clone.Module != "simple_module" || clone.Status != 404 {
+		t.Errorf("clone = %+v, want the original with the new operation", clone)
+	}
+	if !errors.Is(fmt.Errorf("update: %w", clone), original) {
+		t.Error("errors.Is does not match the clone to the original error")
+	}
+}
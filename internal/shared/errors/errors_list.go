@@ -9,4 +9,169 @@ var (
 		"DB1001",
 		ErrorContextInfra,
 	)
+	ErrPoolFull = NewProblemDetails(
+		503,
+		"Service unavailable",
+		"Too many background jobs are pending, retry later",
+		"WPOOL001",
+		ErrorContextInfra,
+	)
+	ErrPoolClosed = NewProblemDetails(
+		503,
+		"Service unavailable",
+		"The server is shutting down and no longer accepts background jobs",
+		"WPOOL002",
+		ErrorContextInfra,
+	)
+	ErrCircuitOpen = NewProblemDetails(
+		503,
+		"Service unavailable",
+		"The database is not responding, retry later",
+		"CB0002",
+		ErrorContextInfra,
+	)
+)
+
+// Generic HTTP errors
+var (
+	ErrInternalServer = NewProblemDetails(
+		500,
+		"Internal server error",
+		"An unexpected error occurred",
+		"HTTP9999",
+		ErrorContextGeneric,
+	)
+	ErrInsufficientScope = NewProblemDetails(
+		403,
+		"Forbidden",
+		"The authenticated principal lacks the scope required for this operation",
+		"RBAC0001",
+		ErrorContextGeneric,
+	)
+	ErrIPNotAllowed = NewProblemDetails(
+		403,
+		"Forbidden",
+		"Access from this IP address is not allowed",
+		"IPF0001",
+		ErrorContextGeneric,
+	)
+	ErrDuplicateRequest = NewProblemDetails(
+		409,
+		"Duplicate request",
+		"An identical request was already received recently",
+		"DUP0001",
+		ErrorContextGeneric,
+	)
+	ErrInvalidSignature = NewProblemDetails(
+		401,
+		"Invalid signature",
+		"The request signature is missing or does not match the request body",
+		"HMAC001",
+		ErrorContextGeneric,
+	)
+	ErrRateLimited = NewProblemDetails(
+		429,
+		"Too many requests",
+		"The rate limit for this client was exceeded, retry after the time in the Retry-After header",
+		"RATE0001",
+		ErrorContextGeneric,
+	)
+	ErrInvalidID = NewProblemDetails(
+		400,
+		"Invalid ID",
+		"Invalid ID format, expected UUID",
+		"VAL0002",
+		ErrorContextGeneric,
+	)
+	ErrNotFound = NewProblemDetails(
+		404,
+		"Not found",
+		"The requested resource was not found",
+		"HTTP0404",
+		ErrorContextGeneric,
+	)
+	ErrMethodNotAllowed = NewProblemDetails(
+		405,
+		"Method not allowed",
+		"The request method is not supported by this resource",
+		"HTTP0405",
+		ErrorContextGeneric,
+	)
+	ErrNotAcceptable = NewProblemDetails(
+		406,
+		"Not acceptable",
+		"None of the media types in the Accept header can be produced by this endpoint",
+		"HTTP0406",
+		ErrorContextGeneric,
+	)
+	ErrUnsupportedContentType = NewProblemDetails(
+		415,
+		"Unsupported media type",
+		"The request body must be sent with a supported Content-Type",
+		"HTTP0415",
+		ErrorContextGeneric,
+	)
+	ErrPanic = NewProblemDetails(
+		500,
+		"Internal server error",
+		"An unexpected error occurred while processing the request",
+		"PANIC001",
+		ErrorContextGeneric,
+	)
+	ErrUnauthorized = NewProblemDetails(
+		401,
+		"Unauthorized",
+		"Valid authentication credentials are required",
+		"HTTP1002",
+		ErrorContextGeneric,
+	)
+	ErrUnsupportedMediaType = NewProblemDetails(
+		415,
+		"Unsupported media type",
+		"The request content type is not supported by this endpoint",
+		"HTTP1001",
+		ErrorContextGeneric,
+	)
 )
+
+// Generic business errors (use the constructors below for per-resource variants)
+var (
+	ErrConflict = NewProblemDetails(
+		409,
+		"Conflict",
+		"Resource already exists",
+		"SHARED0003",
+		ErrorContextBusiness,
+	)
+	ErrUnprocessableEntity = NewProblemDetails(
+		422,
+		"Unprocessable Entity",
+		"The request was well-formed but the entity is invalid",
+		"SHARED0004",
+		ErrorContextBusiness,
+	)
+	ErrRequestSchemaInvalid = NewProblemDetails(
+		422,
+		"Invalid request body",
+		"The request body does not match the expected schema",
+		"SHARED0005",
+		ErrorContextBusiness,
+	)
+	ErrRequestBodyMalformed = NewProblemDetails(
+		400,
+		"Malformed request body",
+		"The request body is not valid JSON",
+		"SHARED0006",
+		ErrorContextBusiness,
+	)
+)
+
+// NewConflictError creates a 409 error for a specific resource
+func NewConflictError(title, detail, code string) *ProblemDetails {
+	return NewProblemDetails(409, title, detail, code, ErrorContextBusiness)
+}
+
+// NewUnprocessableEntityError creates a 422 error for a specific resource
+func NewUnprocessableEntityError(title, detail, code string) *ProblemDetails {
+	return NewProblemDetails(422, title, detail, code, ErrorContextBusiness)
+}
@@ -2,7 +2,47 @@ package shared
 
 import "github.com/google/uuid"
 
+// ID versions accepted by GenerateIdWithVersion (SERVER_APP_ID_VERSION)
+const (
+	IdVersionV4 = "v4"
+	IdVersionV7 = "v7"
+)
+
+// GenerateId returns a new time-ordered UUID (version 7)
 func GenerateId() string {
-	newId, _ := uuid.NewV7()
+	return GenerateIdV7()
+}
+
+// GenerateIdV4 returns a new random UUID (version 4)
+func GenerateIdV4() string {
+	return uuid.NewString()
+}
+
+// GenerateIdV7 returns a new UUID version 7, which sorts lexicographically by creation
+// time and can be used as a pagination cursor
+func GenerateIdV7() string {
+	newId, err := uuid.NewV7()
+	if err != nil {
+		return GenerateIdV4()
+	}
 	return newId.String()
 }
+
+// GenerateIdWithVersion returns a new UUID of the given version ("v4" or "v7"),
+// falling back to GenerateId for any other value
+func GenerateIdWithVersion(version string) string {
+	switch version {
+	case IdVersionV4:
+		return GenerateIdV4()
+	case IdVersionV7:
+		return GenerateIdV7()
+	default:
+		return GenerateId()
+	}
+}
+
+// ParseId parses a UUID in any of the forms accepted by uuid.Parse
+// Use validation.ValidateUUID to accept only the canonical form
+func ParseId(s string) (uuid.UUID, error) {
+	return uuid.Parse(s)
+}
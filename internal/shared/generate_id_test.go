@@ -0,0 +1,51 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestGenerateIdV7_SortsByCreationTime(t *testing.T) {
+	previous := GenerateIdV7()
+	for i := 0; i < 1000; i++ {
+		id := GenerateIdV7()
+		if id <= previous {
+			t.Fatalf("id %d = %s, want it after %s", i, id, previous)
+		}
+		previous = id
+	}
+}
+
+func TestGenerateIdWithVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    uuid.Version
+	}{
+		{version: IdVersionV4, want: 4},
+		{version: IdVersionV7, want: 7},
+		// Unknown versions fall back to GenerateId
+		{version: "", want: 7},
+		{version: "v1", want: 7},
+	}
+	for _, tt := range tests {
+		id, err := ParseId(GenerateIdWithVersion(tt.version))
+		if err != nil {
+			t.Fatalf("%q: ParseId: %v", tt.version, err)
+		}
+		if id.Version() != tt.want {
+			t.Errorf("%q: version = %d, want %d", tt.version, id.Version(), tt.want)
+		}
+	}
+}
+
+func TestParseId(t *testing.T) {
+	id := GenerateId()
+	parsed, err := ParseId(id)
+	if err != nil || parsed.String() != id {
+		t.Errorf("ParseId(%s) = %s, %v, want the same UUID", id, parsed, err)
+	}
+	if _, err := ParseId("abc"); err == nil {
+		t.Error("ParseId(abc): expected an error")
+	}
+}
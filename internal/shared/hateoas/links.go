@@ -0,0 +1,48 @@
+package hateoas
+
+import (
+	"encoding/xml"
+	"sort"
+	"strings"
+)
+
+// Links maps a relation name (self, update, ...) to the URL of the related resource
+type Links map[string]string
+
+// MarshalXML renders links as <link rel="..." href="..."/> elements, sorted by relation
+// encoding/xml cannot marshal maps on its own
+func (l Links) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	rels := make([]string, 0, len(l))
+	for rel := range l {
+		rels = append(rels, rel)
+	}
+	sort.Strings(rels)
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, rel := range rels {
+		link := struct {
+			Rel  string `xml:"rel,attr"`
+			Href string `xml:"href,attr"`
+		}{Rel: rel, Href: l[rel]}
+		if err := e.EncodeElement(link, xml.StartElement{Name: xml.Name{Local: "link"}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// ProductLinks returns the links related to a product
+// baseURL may be empty (relative links) and must include any route prefix
+func ProductLinks(baseURL, productID string) map[string]string {
+	collection := strings.TrimSuffix(baseURL, "/") + "/products"
+	item := collection + "/" + productID
+
+	return map[string]string{
+		"self":   item,
+		"update": item,
+		"delete": item,
+		"list":   collection,
+	}
+}
@@ -0,0 +1,51 @@
+package hateoas
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestProductLinks(t *testing.T) {
+	const id = "0190a5e8-0000-7000-8000-000000000001"
+	tests := []struct {
+		name    string
+		baseURL string
+		want    string
+	}{
+		{name: "absolute with prefix", baseURL: "https://api.example.com/v1", want: "https://api.example.com/v1/products"},
+		{name: "trailing slash", baseURL: "https://api.example.com/v1/", want: "https://api.example.com/v1/products"},
+		{name: "relative", baseURL: "", want: "/products"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := map[string]string{
+				"self":   tt.want + "/" + id,
+				"update": tt.want + "/" + id,
+				"delete": tt.want + "/" + id,
+				"list":   tt.want,
+			}
+			if got := ProductLinks(tt.baseURL, id); !reflect.DeepEqual(got, want) {
+				t.Errorf("ProductLinks = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestLinks_MarshalXML(t *testing.T) {
+	links := Links{"self": "/products/1", "list": "/products"}
+
+	out, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"product"`
+		Links   Links    `xml:"links"`
+	}{Links: links})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	// Relations are sorted, so the output is stable
+	want := `<product><links><link rel="list" href="/products"></link><link rel="self" href="/products/1"></link></links></product>`
+	if string(out) != want {
+		t.Errorf("xml = %s, want %s", out, want)
+	}
+}
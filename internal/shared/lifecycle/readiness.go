@@ -0,0 +1,52 @@
+package lifecycle
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// StartupProbePath is the path answered by StartupProbeHandler
+const StartupProbePath = "/startupz"
+
+// ReadinessGate records whether the application finished starting up
+// It is safe for concurrent use
+type ReadinessGate struct {
+	ready atomic.Bool
+}
+
+// NewReadinessGate creates a gate in the "starting" state
+func NewReadinessGate() *ReadinessGate {
+	return &ReadinessGate{}
+}
+
+// SetReady marks the application as fully initialized
+func (g *ReadinessGate) SetReady() {
+	g.ready.Store(true)
+}
+
+// IsReady reports whether SetReady has been called
+func (g *ReadinessGate) IsReady() bool {
+	return g.ready.Load()
+}
+
+// StartupProbeHandler answers GET /startupz from gate and passes every other request to next
+// The probe is served before next so it bypasses all of its middleware
+// Returns 503 {"status":"starting"} until the gate is ready, then 200 {"status":"ready"}
+func StartupProbeHandler(gate *ReadinessGate, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != StartupProbePath || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		if !gate.IsReady() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"status":"starting"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"ready"}`))
+	})
+}
@@ -0,0 +1,49 @@
+package lifecycle
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStartupProbeHandler(t *testing.T) {
+	gate := NewReadinessGate()
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	handler := StartupProbeHandler(gate, next)
+
+	probe := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+
+	if w := probe(http.MethodGet, StartupProbePath); w.Code != http.StatusServiceUnavailable || w.Body.String() != `{"status":"starting"}` {
+		t.Errorf("before SetReady: %d %s, want 503 starting", w.Code, w.Body.String())
+	}
+	gate.SetReady()
+	if !gate.IsReady() {
+		t.Fatal("IsReady = false after SetReady")
+	}
+	w := probe(http.MethodGet, StartupProbePath)
+	if w.Code != http.StatusOK || w.Body.String() != `{"status":"ready"}` {
+		t.Errorf("after SetReady: %d %s, want 200 ready", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("Cache-Control = %q, want no-store", w.Header().Get("Cache-Control"))
+	}
+	if w := probe(http.MethodHead, StartupProbePath); w.Code != http.StatusOK {
+		t.Errorf("HEAD: status = %d, want 200", w.Code)
+	}
+
+	// Every other request reaches next
+	for _, req := range []struct{ method, path string }{
+		{http.MethodGet, "/products"},
+		{http.MethodPost, StartupProbePath},
+	} {
+		if w := probe(req.method, req.path); w.Code != http.StatusTeapot {
+			t.Errorf("%s %s: status = %d, want it passed to next", req.method, req.path, w.Code)
+		}
+	}
+}
@@ -6,6 +6,24 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+// loggerContextKey is the context key holding the request-scoped logger
+type loggerContextKey struct{}
+
+// WithLogger returns a copy of ctx carrying l (e.g. a logger with the request ID attached)
+func WithLogger(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, l)
+}
+
+// FromContext returns the logger stored by WithLogger, falling back to the global logger
+func FromContext(ctx context.Context) Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(loggerContextKey{}).(Logger); ok && l != nil {
+			return l
+		}
+	}
+	return getLogger()
+}
+
 // ExtractTraceContext extracts trace and span IDs from context using OpenTelemetry.
 // Returns empty strings if context is nil or span context is not valid.
 func ExtractTraceContext(ctx context.Context) (traceID, spanID string) {
@@ -45,9 +63,7 @@ func ExtractCustomContextFields(ctx context.Context) CustomFields {
 	//     fields["userId"] = userId
 	// }
 	//
-	// if requestId := ctx.Value(requestIDKey); requestId != nil {
-	//     fields["requestId"] = requestId
-	// }
+	// requestId is attached by the request-scoped logger (see FromContext)
 
 	return fields
 }
@@ -0,0 +1,30 @@
+package logger
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	previous := globalLogger
+	t.Cleanup(func() { SetGlobalLogger(previous) })
+	global := NewSlogLogger("go_app_base", "1.2.3")
+	SetGlobalLogger(global)
+
+	if got := FromContext(context.Background()); got != global {
+		t.Errorf("FromContext(plain context) = %v, want the global logger", got)
+	}
+	// A nil context also falls back to the global logger
+	if got := FromContext(nil); got != global {
+		t.Errorf("FromContext(nil) = %v, want the global logger", got)
+	}
+
+	requestLogger := global.With(CustomFields{"requestId": "req-1"})
+	ctx := WithLogger(context.Background(), requestLogger)
+	if got := FromContext(ctx); got != requestLogger {
+		t.Errorf("FromContext = %v, want the logger stored by WithLogger", got)
+	}
+	if fields := FromContext(ctx).(*SlogLogger).contextData; fields["requestId"] != "req-1" {
+		t.Errorf("request logger fields = %v, want requestId=req-1", fields)
+	}
+}
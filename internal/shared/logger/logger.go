@@ -24,6 +24,10 @@ type Logger interface {
 	// With creates a new logger instance with additional context fields
 	// that will be included in all subsequent log entries
 	With(fields CustomFields) Logger
+
+	// WithError creates a new logger instance with the "error" field set
+	// (plus "errorCode" and "errorStatus" for ProblemDetails errors)
+	WithError(err error) Logger
 }
 
 // CustomFields represents additional structured data to be included in log entries.
@@ -79,3 +83,8 @@ func Error(ctx context.Context, message string, customFields ...CustomFields) {
 func With(fields CustomFields) Logger {
 	return getLogger().With(fields)
 }
+
+// WithError creates a new logger instance with the error fields set.
+func WithError(err error) Logger {
+	return getLogger().WithError(err)
+}
@@ -0,0 +1,334 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// lokiPushPath is appended to the push URL given to NewLokiLogger
+	lokiPushPath = "/loki/api/v1/push"
+	// lokiQueueBatches is how many full batches the entry queue holds before entries are dropped
+	lokiQueueBatches = 10
+	// lokiPushTimeout bounds a single push request
+	lokiPushTimeout = 10 * time.Second
+)
+
+// LokiLogger implements Logger by pushing entries to the Grafana Loki push API
+// Entries are queued without blocking the caller and pushed asynchronously in batches,
+// one stream per level, with the labels set by WithLokiLabels
+// When the queue is full (Loki slow or down) new entries are dropped
+type LokiLogger struct {
+	sink        *lokiSink
+	imageName   string
+	imageVer    string
+	contextData CustomFields
+}
+
+// LokiLoggerOption customizes NewLokiLogger
+type LokiLoggerOption func(*lokiLoggerOptions)
+
+type lokiLoggerOptions struct {
+	labels       map[string]string
+	imageName    string
+	imageVersion string
+	client       *http.Client
+}
+
+// WithLokiLabels sets the stream labels (e.g. app, environment, imageName)
+// Keep them low-cardinality: every distinct label set is a separate stream in Loki
+func WithLokiLabels(labels map[string]string) LokiLoggerOption {
+	return func(o *lokiLoggerOptions) {
+		o.labels = labels
+	}
+}
+
+// WithLokiImage adds imageName and imageVersion to every entry, like NewSlogLogger
+func WithLokiImage(imageName, imageVersion string) LokiLoggerOption {
+	return func(o *lokiLoggerOptions) {
+		o.imageName = imageName
+		o.imageVersion = imageVersion
+	}
+}
+
+// WithLokiHTTPClient replaces the client used to push entries (default: 10s timeout)
+func WithLokiHTTPClient(client *http.Client) LokiLoggerOption {
+	return func(o *lokiLoggerOptions) {
+		o.client = client
+	}
+}
+
+// NewLokiLogger starts a background goroutine that pushes entries to <pushURL>/loki/api/v1/push
+// whenever batchSize entries are queued or flushInterval elapses
+// tenantID is sent as X-Scope-OrgID when not empty (multi-tenant Loki)
+func NewLokiLogger(pushURL, tenantID string, batchSize int, flushInterval time.Duration, opts ...LokiLoggerOption) *LokiLogger {
+	options := lokiLoggerOptions{client: &http.Client{Timeout: lokiPushTimeout}}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	if flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+
+	sink := &lokiSink{
+		url:       strings.TrimSuffix(pushURL, "/") + lokiPushPath,
+		tenantID:  tenantID,
+		labels:    options.labels,
+		client:    options.client,
+		batchSize: batchSize,
+		entries:   make(chan lokiEntry, batchSize*lokiQueueBatches),
+		flushes:   make(chan chan error),
+	}
+	go sink.run(flushInterval)
+
+	return &LokiLogger{
+		sink:        sink,
+		imageName:   options.imageName,
+		imageVer:    options.imageVersion,
+		contextData: make(CustomFields),
+	}
+}
+
+// Debug logs a debug-level message
+func (l *LokiLogger) Debug(ctx context.Context, message string, customFields ...CustomFields) {
+	l.log(ctx, "debug", message, customFields...)
+}
+
+// Info logs an info-level message
+func (l *LokiLogger) Info(ctx context.Context, message string, customFields ...CustomFields) {
+	l.log(ctx, "info", message, customFields...)
+}
+
+// Warn logs a warning-level message
+func (l *LokiLogger) Warn(ctx context.Context, message string, customFields ...CustomFields) {
+	l.log(ctx, "warn", message, customFields...)
+}
+
+// Error logs an error-level message
+func (l *LokiLogger) Error(ctx context.Context, message string, customFields ...CustomFields) {
+	l.log(ctx, "error", message, customFields...)
+}
+
+// With creates a new logger instance with additional context fields (sharing the same queue)
+func (l *LokiLogger) With(fields CustomFields) Logger {
+	newContextData := make(CustomFields, len(l.contextData)+len(fields))
+	for k, v := range l.contextData {
+		newContextData[k] = v
+	}
+	for k, v := range fields {
+		newContextData[k] = v
+	}
+
+	return &LokiLogger{
+		sink:        l.sink,
+		imageName:   l.imageName,
+		imageVer:    l.imageVer,
+		contextData: newContextData,
+	}
+}
+
+// WithError creates a new logger instance with the error fields set
+func (l *LokiLogger) WithError(err error) Logger {
+	return l.With(errorFields(err))
+}
+
+// Flush pushes every queued entry and waits for the push to finish (call it on shutdown)
+func (l *LokiLogger) Flush(ctx context.Context) error {
+	done := make(chan error, 1)
+	select {
+	case l.sink.flushes <- done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// log builds the entry line with the same fields as SlogLogger and queues it
+func (l *LokiLogger) log(ctx context.Context, level, message string, customFields ...CustomFields) {
+	// Merge: contextData (from With) + context fields (traceId/spanId) + customFields
+	mergedCustom := make(CustomFields)
+	for k, v := range l.contextData {
+		mergedCustom[k] = v
+	}
+	for k, v := range ExtractCustomContextFields(ctx) {
+		mergedCustom[k] = v
+	}
+	for _, cf := range customFields {
+		for k, v := range cf {
+			mergedCustom[k] = v
+		}
+	}
+
+	now := time.Now()
+	line := map[string]any{
+		"timestamp":    now.Format("2006-01-02T15:04:05.000000Z07:00"),
+		"level":        strings.ToUpper(level),
+		"msg":          message,
+		"imageName":    l.imageName,
+		"imageVersion": l.imageVer,
+	}
+	if len(mergedCustom) > 0 {
+		line["custom"] = mergedCustom
+	}
+	encoded, err := json.Marshal(line)
+	if err != nil {
+		encoded, _ = json.Marshal(map[string]any{"level": strings.ToUpper(level), "msg": message, "error": err.Error()})
+	}
+
+	select {
+	case l.sink.entries <- lokiEntry{level: level, timestamp: now, line: string(encoded)}:
+	default:
+		// Queue full: drop rather than block the caller
+	}
+}
+
+// lokiEntry is a queued log line
+type lokiEntry struct {
+	level     string
+	timestamp time.Time
+	line      string
+}
+
+// lokiSink owns the queue and the push goroutine shared by a LokiLogger and its derived loggers
+type lokiSink struct {
+	url       string
+	tenantID  string
+	labels    map[string]string
+	client    *http.Client
+	batchSize int
+	entries   chan lokiEntry
+	flushes   chan chan error
+}
+
+// run batches queued entries, pushing when the batch is full, on every tick and on Flush
+func (s *lokiSink) run(flushInterval time.Duration) {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]lokiEntry, 0, s.batchSize)
+	push := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := s.push(batch)
+		batch = batch[:0]
+		return err
+	}
+
+	for {
+		select {
+		case entry := <-s.entries:
+			batch = append(batch, entry)
+			if len(batch) >= s.batchSize {
+				s.logPushError(push())
+			}
+		case <-ticker.C:
+			s.logPushError(push())
+		case done := <-s.flushes:
+			// Drain what was queued before the flush request
+			var errs []error
+			for drained := false; !drained; {
+				select {
+				case entry := <-s.entries:
+					batch = append(batch, entry)
+					if len(batch) >= s.batchSize {
+						if err := push(); err != nil {
+							errs = append(errs, err)
+						}
+					}
+				default:
+					drained = true
+				}
+			}
+			if err := push(); err != nil {
+				errs = append(errs, err)
+			}
+			done <- errors.Join(errs...)
+		}
+	}
+}
+
+// logPushError reports a failed push on the standard logger (the entries are dropped);
+// logging through Logger could feed the failure back into the queue
+func (s *lokiSink) logPushError(err error) {
+	if err != nil {
+		log.Printf("loki: %v", err)
+	}
+}
+
+// lokiPushRequest is the JSON body of the Loki push API
+type lokiPushRequest struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+// lokiStream holds the entries of one label set as [timestamp in ns, line] pairs
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// push sends the batch as one request, with one stream per level
+func (s *lokiSink) push(batch []lokiEntry) error {
+	streams := make(map[string]*lokiStream)
+	var order []string
+	for _, entry := range batch {
+		stream, ok := streams[entry.level]
+		if !ok {
+			labels := make(map[string]string, len(s.labels)+1)
+			for k, v := range s.labels {
+				labels[k] = v
+			}
+			labels["level"] = entry.level
+			stream = &lokiStream{Stream: labels}
+			streams[entry.level] = stream
+			order = append(order, entry.level)
+		}
+		stream.Values = append(stream.Values, [2]string{strconv.FormatInt(entry.timestamp.UnixNano(), 10), entry.line})
+	}
+
+	request := lokiPushRequest{Streams: make([]lokiStream, 0, len(order))}
+	for _, level := range order {
+		request.Streams = append(request.Streams, *streams[level])
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.tenantID != "" {
+		req.Header.Set("X-Scope-OrgID", s.tenantID)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("push of %d entries failed: %w", len(batch), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("push of %d entries failed with status %d", len(batch), resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// lokiPush is a push request received by startLokiServer
+type lokiPush struct {
+	path     string
+	tenantID string
+	body     lokiPushRequest
+}
+
+// startLokiServer answers pushes with status, sending each one on the returned channel
+func startLokiServer(t *testing.T, status int) (string, <-chan lokiPush) {
+	t.Helper()
+	pushes := make(chan lokiPush, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		body, _ := io.ReadAll(r.Body)
+		push := lokiPush{path: r.URL.Path, tenantID: r.Header.Get("X-Scope-OrgID")}
+		if err := json.Unmarshal(body, &push.body); err != nil {
+			t.Errorf("push body is not JSON: %v: %s", err, body)
+		}
+		pushes <- push
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, pushes
+}
+
+// receivePush waits for the next push
+func receivePush(t *testing.T, pushes <-chan lokiPush) lokiPush {
+	t.Helper()
+	select {
+	case push := <-pushes:
+		return push
+	case <-time.After(5 * time.Second):
+		t.Fatal("no push received")
+		return lokiPush{}
+	}
+}
+
+func TestLokiLogger_PushPayload(t *testing.T) {
+	url, pushes := startLokiServer(t, http.StatusNoContent)
+	labels := map[string]string{"app": "go_app_base", "environment": "test", "imageName": "go-app"}
+	log := NewLokiLogger(url+"/", "tenant-1", 100, time.Hour, WithLokiLabels(labels), WithLokiImage("go-app", "1.2.3"))
+	ctx := context.Background()
+
+	before := time.Now()
+	log.Info(ctx, "Product created", CustomFields{"productId": "p-1"})
+	log.With(CustomFields{"module": "simple_module"}).Error(ctx, "Product update failed")
+	log.Info(ctx, "Product deleted")
+	if err := log.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	push := receivePush(t, pushes)
+	if push.path != "/loki/api/v1/push" || push.tenantID != "tenant-1" {
+		t.Errorf("path, tenant = %q, %q, want the push API with X-Scope-OrgID", push.path, push.tenantID)
+	}
+	// One stream per level, in the order the levels were first logged
+	streams := push.body.Streams
+	if len(streams) != 2 {
+		t.Fatalf("streams = %+v, want info and error", streams)
+	}
+	for i, level := range []string{"info", "error"} {
+		if streams[i].Stream["level"] != level {
+			t.Errorf("stream %d level = %q, want %q", i, streams[i].Stream["level"], level)
+		}
+		for key, value := range labels {
+			if streams[i].Stream[key] != value {
+				t.Errorf("stream %d label %s = %q, want %q", i, key, streams[i].Stream[key], value)
+			}
+		}
+	}
+	if len(streams[0].Values) != 2 || len(streams[1].Values) != 1 {
+		t.Fatalf("values = %d info, %d error, want 2 and 1", len(streams[0].Values), len(streams[1].Values))
+	}
+
+	// Each value is a [nanosecond timestamp, JSON line] pair
+	timestamp, err := strconv.ParseInt(streams[0].Values[0][0], 10, 64)
+	if err != nil || timestamp < before.UnixNano() || timestamp > time.Now().UnixNano() {
+		t.Errorf("timestamp = %q, want the entry time in nanoseconds", streams[0].Values[0][0])
+	}
+	var line struct {
+		Level        string         `json:"level"`
+		Msg          string         `json:"msg"`
+		ImageName    string         `json:"imageName"`
+		ImageVersion string         `json:"imageVersion"`
+		Custom       map[string]any `json:"custom"`
+	}
+	if err := json.Unmarshal([]byte(streams[0].Values[0][1]), &line); err != nil {
+		t.Fatalf("line is not JSON: %v", err)
+	}
+	if line.Level != "INFO" || line.Msg != "Product created" || line.ImageName != "go-app" || line.ImageVersion != "1.2.3" || line.Custom["productId"] != "p-1" {
+		t.Errorf("line = %+v, want the slog fields", line)
+	}
+	if !strings.Contains(streams[1].Values[0][1], `"module":"simple_module"`) {
+		t.Errorf("error line = %s, want the fields set by With", streams[1].Values[0][1])
+	}
+}
+
+func TestLokiLogger_PushesFullBatches(t *testing.T) {
+	url, pushes := startLokiServer(t, http.StatusNoContent)
+	log := NewLokiLogger(url, "", 2, time.Hour)
+
+	log.Warn(context.Background(), "first")
+	log.Warn(context.Background(), "second")
+
+	push := receivePush(t, pushes)
+	if len(push.body.Streams) != 1 || len(push.body.Streams[0].Values) != 2 {
+		t.Errorf("streams = %+v, want one batch of 2 entries", push.body.Streams)
+	}
+	if push.tenantID != "" {
+		t.Errorf("X-Scope-OrgID = %q, want none without a tenant", push.tenantID)
+	}
+}
+
+func TestLokiLogger_PushesOnFlushInterval(t *testing.T) {
+	url, pushes := startLokiServer(t, http.StatusNoContent)
+	log := NewLokiLogger(url, "", 100, 10*time.Millisecond)
+
+	log.Debug(context.Background(), "tick")
+
+	push := receivePush(t, pushes)
+	if len(push.body.Streams) != 1 || push.body.Streams[0].Stream["level"] != "debug" {
+		t.Errorf("streams = %+v, want the debug entry", push.body.Streams)
+	}
+}
+
+func TestLokiLogger_FlushErrors(t *testing.T) {
+	url, _ := startLokiServer(t, http.StatusInternalServerError)
+	log := NewLokiLogger(url, "", 100, time.Hour)
+
+	// Nothing queued: nothing to push
+	if err := log.Flush(context.Background()); err != nil {
+		t.Errorf("Flush of an empty queue: %v", err)
+	}
+
+	log.Info(context.Background(), "lost")
+	if err := log.Flush(context.Background()); err == nil || !strings.Contains(err.Error(), "status 500") {
+		t.Errorf("Flush = %v, want the push status", err)
+	}
+
+	unreachable := NewLokiLogger("http://127.0.0.1:1", "", 100, time.Hour)
+	unreachable.Info(context.Background(), "lost")
+	if err := unreachable.Flush(context.Background()); err == nil {
+		t.Error("Flush to an unreachable Loki: expected an error")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	blocked := &LokiLogger{sink: &lokiSink{flushes: make(chan chan error)}}
+	if err := blocked.Flush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Flush past the deadline = %v, want DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// MultiHandler is a slog.Handler that fans out every record to all its handlers
+// (e.g. JSON to STDOUT and OpenTelemetry export)
+type MultiHandler []slog.Handler
+
+// Enabled reports whether any of the handlers accepts records at the given level
+func (m MultiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range m {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle passes a copy of the record to every handler enabled for its level
+func (m MultiHandler) Handle(ctx context.Context, record slog.Record) error {
+	var errs []error
+	for _, h := range m {
+		if !h.Enabled(ctx, record.Level) {
+			continue
+		}
+		if err := h.Handle(ctx, record.Clone()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs returns a MultiHandler whose handlers all include the given attributes
+func (m MultiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(MultiHandler, len(m))
+	for i, h := range m {
+		handlers[i] = h.WithAttrs(attrs)
+	}
+	return handlers
+}
+
+// WithGroup returns a MultiHandler whose handlers all use the given group
+func (m MultiHandler) WithGroup(name string) slog.Handler {
+	handlers := make(MultiHandler, len(m))
+	for i, h := range m {
+		handlers[i] = h.WithGroup(name)
+	}
+	return handlers
+}
@@ -0,0 +1,57 @@
+package logger
+
+import "context"
+
+// MultiLogger sends every entry to all of its loggers (e.g. STDOUT JSON and Loki)
+type MultiLogger []Logger
+
+// NewMultiLogger combines loggers into a single Logger
+func NewMultiLogger(loggers ...Logger) Logger {
+	return MultiLogger(loggers)
+}
+
+// Debug logs a debug-level message on every logger
+func (m MultiLogger) Debug(ctx context.Context, message string, customFields ...CustomFields) {
+	for _, l := range m {
+		l.Debug(ctx, message, customFields...)
+	}
+}
+
+// Info logs an info-level message on every logger
+func (m MultiLogger) Info(ctx context.Context, message string, customFields ...CustomFields) {
+	for _, l := range m {
+		l.Info(ctx, message, customFields...)
+	}
+}
+
+// Warn logs a warning-level message on every logger
+func (m MultiLogger) Warn(ctx context.Context, message string, customFields ...CustomFields) {
+	for _, l := range m {
+		l.Warn(ctx, message, customFields...)
+	}
+}
+
+// Error logs an error-level message on every logger
+func (m MultiLogger) Error(ctx context.Context, message string, customFields ...CustomFields) {
+	for _, l := range m {
+		l.Error(ctx, message, customFields...)
+	}
+}
+
+// With returns a MultiLogger whose loggers all include the given fields
+func (m MultiLogger) With(fields CustomFields) Logger {
+	loggers := make(MultiLogger, len(m))
+	for i, l := range m {
+		loggers[i] = l.With(fields)
+	}
+	return loggers
+}
+
+// WithError returns a MultiLogger whose loggers all include the error fields
+func (m MultiLogger) WithError(err error) Logger {
+	loggers := make(MultiLogger, len(m))
+	for i, l := range m {
+		loggers[i] = l.WithError(err)
+	}
+	return loggers
+}
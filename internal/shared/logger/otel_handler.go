@@ -0,0 +1,143 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+// otelScopeName identifies the application logger in the OpenTelemetry log backend
+const otelScopeName = "github.com/refortunato/go_app_base/internal/shared/logger"
+
+// OTelHandler is a slog.Handler that emits log records to the OpenTelemetry log SDK.
+// The trace and span IDs are taken from the context passed to the log call,
+// so records are correlated with the active trace in the backend.
+type OTelHandler struct {
+	logger otellog.Logger
+	attrs  []otellog.KeyValue
+	group  string
+}
+
+// NewOTelHandler creates a handler that emits records through the given provider
+func NewOTelHandler(provider otellog.LoggerProvider) *OTelHandler {
+	return &OTelHandler{
+		logger: provider.Logger(otelScopeName),
+	}
+}
+
+// Enabled reports whether the OpenTelemetry logger accepts records at the given level
+func (h *OTelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.logger.Enabled(ctx, otellog.EnabledParameters{Severity: convertLevel(level)})
+}
+
+// Handle converts the slog record and emits it to the OpenTelemetry log SDK
+func (h *OTelHandler) Handle(ctx context.Context, record slog.Record) error {
+	var rec otellog.Record
+	rec.SetTimestamp(record.Time)
+	rec.SetObservedTimestamp(time.Now())
+	rec.SetBody(otellog.StringValue(record.Message))
+	rec.SetSeverity(convertLevel(record.Level))
+	rec.SetSeverityText(record.Level.String())
+
+	rec.AddAttributes(h.attrs...)
+	record.Attrs(func(a slog.Attr) bool {
+		rec.AddAttributes(convertAttr(h.group, a)...)
+		return true
+	})
+
+	h.logger.Emit(ctx, rec)
+	return nil
+}
+
+// WithAttrs returns a handler that includes the given attributes in every record
+func (h *OTelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	newAttrs := make([]otellog.KeyValue, 0, len(h.attrs)+len(attrs))
+	newAttrs = append(newAttrs, h.attrs...)
+	for _, a := range attrs {
+		newAttrs = append(newAttrs, convertAttr(h.group, a)...)
+	}
+	return &OTelHandler{logger: h.logger, attrs: newAttrs, group: h.group}
+}
+
+// WithGroup returns a handler that prefixes subsequent attribute keys with the group name
+func (h *OTelHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &OTelHandler{logger: h.logger, attrs: h.attrs, group: joinKey(h.group, name)}
+}
+
+// convertLevel maps slog levels to OpenTelemetry severities
+func convertLevel(level slog.Level) otellog.Severity {
+	switch {
+	case level >= slog.LevelError:
+		return otellog.SeverityError
+	case level >= slog.LevelWarn:
+		return otellog.SeverityWarn
+	case level >= slog.LevelInfo:
+		return otellog.SeverityInfo
+	default:
+		return otellog.SeverityDebug
+	}
+}
+
+// convertAttr converts a slog attribute to OpenTelemetry key-values
+// Inline groups (empty key) are flattened into the parent
+func convertAttr(group string, a slog.Attr) []otellog.KeyValue {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return nil
+	}
+
+	if a.Value.Kind() == slog.KindGroup && a.Key == "" {
+		var kvs []otellog.KeyValue
+		for _, ga := range a.Value.Group() {
+			kvs = append(kvs, convertAttr(group, ga)...)
+		}
+		return kvs
+	}
+
+	return []otellog.KeyValue{{Key: joinKey(group, a.Key), Value: convertValue(a.Value)}}
+}
+
+// convertValue converts a slog value to an OpenTelemetry log value
+func convertValue(v slog.Value) otellog.Value {
+	switch v.Kind() {
+	case slog.KindString:
+		return otellog.StringValue(v.String())
+	case slog.KindInt64:
+		return otellog.Int64Value(v.Int64())
+	case slog.KindUint64:
+		return otellog.Int64Value(int64(v.Uint64()))
+	case slog.KindFloat64:
+		return otellog.Float64Value(v.Float64())
+	case slog.KindBool:
+		return otellog.BoolValue(v.Bool())
+	case slog.KindDuration:
+		return otellog.Int64Value(v.Duration().Nanoseconds())
+	case slog.KindTime:
+		return otellog.StringValue(v.Time().Format(time.RFC3339Nano))
+	case slog.KindGroup:
+		group := v.Group()
+		kvs := make([]otellog.KeyValue, 0, len(group))
+		for _, a := range group {
+			kvs = append(kvs, convertAttr("", a)...)
+		}
+		return otellog.MapValue(kvs...)
+	default:
+		if err, ok := v.Any().(error); ok {
+			return otellog.StringValue(err.Error())
+		}
+		return otellog.StringValue(fmt.Sprint(v.Any()))
+	}
+}
+
+func joinKey(group, key string) string {
+	if group == "" {
+		return key
+	}
+	return group + "." + key
+}
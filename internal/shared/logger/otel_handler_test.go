@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordingExporter keeps the records exported by the OpenTelemetry log SDK
+type recordingExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(context.Context) error   { return nil }
+func (e *recordingExporter) ForceFlush(context.Context) error { return nil }
+
+// newRecordingProvider returns a provider exporting every record synchronously to the returned exporter
+func newRecordingProvider(t *testing.T) (*sdklog.LoggerProvider, *recordingExporter) {
+	t.Helper()
+	exporter := &recordingExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	return provider, exporter
+}
+
+// attributes collects the attributes of a record by key
+func attributes(r sdklog.Record) map[string]otellog.Value {
+	attrs := map[string]otellog.Value{}
+	r.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	return attrs
+}
+
+// sampledContext carries a fixed sampled span context, as if a request span were active
+func sampledContext(t *testing.T) (context.Context, trace.SpanContext) {
+	t.Helper()
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled})
+	return trace.ContextWithSpanContext(context.Background(), sc), sc
+}
+
+func TestSlogLogger_ExportsToOTel(t *testing.T) {
+	provider, exporter := newRecordingProvider(t)
+	log := NewSlogLogger("go_app_base", "1.2.3", WithOTelLoggerProvider(provider))
+	ctx, sc := sampledContext(t)
+
+	log.Info(ctx, "Product created", CustomFields{"productId": "42"})
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported records = %d, want 1", len(exporter.records))
+	}
+	record := exporter.records[0]
+	if record.Body().AsString() != "Product created" {
+		t.Errorf("body = %q, want the log message", record.Body().AsString())
+	}
+	if record.Severity() != otellog.SeverityInfo || record.SeverityText() != "INFO" {
+		t.Errorf("severity = %v %q, want INFO", record.Severity(), record.SeverityText())
+	}
+	if record.TraceID() != sc.TraceID() || record.SpanID() != sc.SpanID() {
+		t.Errorf("trace, span = %s, %s, want %s, %s", record.TraceID(), record.SpanID(), sc.TraceID(), sc.SpanID())
+	}
+	attrs := attributes(record)
+	if attrs["imageName"].AsString() != "go_app_base" || attrs["imageVersion"].AsString() != "1.2.3" {
+		t.Errorf("attributes = %v, want the image name and version", attrs)
+	}
+	custom := map[string]string{}
+	for _, kv := range attrs["custom"].AsMap() {
+		custom[kv.Key] = kv.Value.AsString()
+	}
+	if custom["productId"] != "42" {
+		t.Errorf("custom = %v, want productId=42", custom)
+	}
+}
+
+func TestOTelHandler_ConvertsAttributes(t *testing.T) {
+	provider, exporter := newRecordingProvider(t)
+	handler := NewOTelHandler(provider).WithAttrs([]slog.Attr{slog.String("service", "api")}).WithGroup("request")
+
+	slog.New(handler).Warn("Slow request", slog.Int("status", 200), slog.Group("client", slog.String("ip", "10.0.0.1")))
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported records = %d, want 1", len(exporter.records))
+	}
+	record := exporter.records[0]
+	if record.Severity() != otellog.SeverityWarn {
+		t.Errorf("severity = %v, want WARN", record.Severity())
+	}
+	attrs := attributes(record)
+	if attrs["service"].AsString() != "api" || attrs["request.status"].AsInt64() != 200 {
+		t.Errorf("attributes = %v, want service=api and request.status=200", attrs)
+	}
+	client := attrs["request.client"].AsMap()
+	if len(client) != 1 || client[0].Key != "ip" || client[0].Value.AsString() != "10.0.0.1" {
+		t.Errorf("request.client = %v, want a map with ip=10.0.0.1", client)
+	}
+}
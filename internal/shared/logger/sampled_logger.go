@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// SampledLogger forwards only a fraction of DEBUG and INFO entries to the underlying logger
+// WARN and ERROR entries are always logged
+type SampledLogger struct {
+	underlying Logger
+	debugRate  float64
+	infoRate   float64
+	sampler    *sampler
+}
+
+// sampler is shared by a SampledLogger and the loggers derived from it (With, WithError)
+type sampler struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// NewSampledLogger wraps underlying, keeping each DEBUG entry with probability debugRate
+// and each INFO entry with probability infoRate (0.0 drops all, 1.0 keeps all)
+func NewSampledLogger(underlying Logger, debugRate, infoRate float64) Logger {
+	return &SampledLogger{
+		underlying: underlying,
+		debugRate:  debugRate,
+		infoRate:   infoRate,
+		sampler: &sampler{
+			rnd: rand.New(rand.NewSource(time.Now().UnixNano())),
+		},
+	}
+}
+
+// Debug logs a debug-level message if it is sampled
+func (l *SampledLogger) Debug(ctx context.Context, message string, customFields ...CustomFields) {
+	if l.sampler.keep(l.debugRate) {
+		l.underlying.Debug(ctx, message, customFields...)
+	}
+}
+
+// Info logs an info-level message if it is sampled
+func (l *SampledLogger) Info(ctx context.Context, message string, customFields ...CustomFields) {
+	if l.sampler.keep(l.infoRate) {
+		l.underlying.Info(ctx, message, customFields...)
+	}
+}
+
+// Warn logs a warning-level message (never sampled)
+func (l *SampledLogger) Warn(ctx context.Context, message string, customFields ...CustomFields) {
+	l.underlying.Warn(ctx, message, customFields...)
+}
+
+// Error logs an error-level message (never sampled)
+func (l *SampledLogger) Error(ctx context.Context, message string, customFields ...CustomFields) {
+	l.underlying.Error(ctx, message, customFields...)
+}
+
+// With creates a new sampled logger with additional context fields
+func (l *SampledLogger) With(fields CustomFields) Logger {
+	return l.derive(l.underlying.With(fields))
+}
+
+// WithError creates a new sampled logger with the error fields set
+func (l *SampledLogger) WithError(err error) Logger {
+	return l.derive(l.underlying.WithError(err))
+}
+
+func (l *SampledLogger) derive(underlying Logger) Logger {
+	return &SampledLogger{
+		underlying: underlying,
+		debugRate:  l.debugRate,
+		infoRate:   l.infoRate,
+		sampler:    l.sampler,
+	}
+}
+
+// keep reports whether an entry is logged for the given sampling rate
+func (s *sampler) keep(rate float64) bool {
+	if rate >= 1 {
+		return true
+	}
+	if rate <= 0 {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rnd.Float64() < rate
+}
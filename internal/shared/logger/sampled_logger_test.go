@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+// countingLogger counts the entries logged at each level
+type countingLogger struct {
+	counts map[string]int
+}
+
+func newCountingLogger() *countingLogger {
+	return &countingLogger{counts: map[string]int{}}
+}
+
+func (l *countingLogger) Debug(context.Context, string, ...CustomFields) { l.counts["debug"]++ }
+func (l *countingLogger) Info(context.Context, string, ...CustomFields)  { l.counts["info"]++ }
+func (l *countingLogger) Warn(context.Context, string, ...CustomFields)  { l.counts["warn"]++ }
+func (l *countingLogger) Error(context.Context, string, ...CustomFields) { l.counts["error"]++ }
+func (l *countingLogger) With(CustomFields) Logger                       { return l }
+func (l *countingLogger) WithError(error) Logger                         { return l }
+
+func TestSampledLogger_SamplesDebugAndInfo(t *testing.T) {
+	const calls = 1000
+	tests := []struct {
+		name      string
+		debugRate float64
+		infoRate  float64
+	}{
+		{name: "quarter debug, half info", debugRate: 0.25, infoRate: 0.5},
+		{name: "drop debug, keep info", debugRate: 0, infoRate: 1},
+		{name: "keep all", debugRate: 1, infoRate: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			underlying := newCountingLogger()
+			log := NewSampledLogger(underlying, tt.debugRate, tt.infoRate)
+			ctx := context.Background()
+			for i := 0; i < calls; i++ {
+				log.Debug(ctx, "cache lookup")
+				log.Info(ctx, "request served")
+				log.Warn(ctx, "slow query")
+				log.Error(ctx, "request failed")
+			}
+
+			// Observed rates within 10 percentage points of the configured ones
+			for level, rate := range map[string]float64{"debug": tt.debugRate, "info": tt.infoRate} {
+				if observed := float64(underlying.counts[level]) / calls; math.Abs(observed-rate) > 0.1 {
+					t.Errorf("%s: logged %d of %d, want a rate of %.2f", level, underlying.counts[level], calls, rate)
+				}
+			}
+			if underlying.counts["warn"] != calls || underlying.counts["error"] != calls {
+				t.Errorf("warn, error = %d, %d, want every entry logged", underlying.counts["warn"], underlying.counts["error"])
+			}
+		})
+	}
+}
+
+func TestSampledLogger_DerivedLoggersKeepSampling(t *testing.T) {
+	underlying := newCountingLogger()
+	log := NewSampledLogger(underlying, 0, 0).With(CustomFields{"requestId": "req-1"}).WithError(nil)
+
+	log.Debug(context.Background(), "cache lookup")
+	log.Info(context.Background(), "request served")
+	log.Error(context.Background(), "request failed")
+
+	if underlying.counts["debug"] != 0 || underlying.counts["info"] != 0 || underlying.counts["error"] != 1 {
+		t.Errorf("counts = %v, want only the error logged", underlying.counts)
+	}
+}
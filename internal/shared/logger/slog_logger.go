@@ -2,9 +2,13 @@ package logger
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os"
 	"time"
+
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+	otellog "go.opentelemetry.io/otel/log"
 )
 
 // SlogLogger is a concrete implementation of Logger interface using Go's log/slog package.
@@ -16,9 +20,29 @@ type SlogLogger struct {
 	contextData CustomFields
 }
 
+// SlogLoggerOption customizes NewSlogLogger
+type SlogLoggerOption func(*slogLoggerOptions)
+
+type slogLoggerOptions struct {
+	otelProvider otellog.LoggerProvider
+}
+
+// WithOTelLoggerProvider also emits every log entry to the OpenTelemetry log SDK
+// (used when OpenTelemetry is enabled, so logs appear next to their traces)
+func WithOTelLoggerProvider(provider otellog.LoggerProvider) SlogLoggerOption {
+	return func(o *slogLoggerOptions) {
+		o.otelProvider = provider
+	}
+}
+
 // NewSlogLogger creates a new logger instance configured to output JSON to STDOUT.
 // It includes imageName and imageVersion in all log entries.
-func NewSlogLogger(imageName, imageVersion string) Logger {
+func NewSlogLogger(imageName, imageVersion string, loggerOpts ...SlogLoggerOption) Logger {
+	options := slogLoggerOptions{}
+	for _, opt := range loggerOpts {
+		opt(&options)
+	}
+
 	// Create a custom JSON handler that writes to STDOUT
 	opts := &slog.HandlerOptions{
 		Level: slog.LevelDebug,
@@ -34,7 +58,11 @@ func NewSlogLogger(imageName, imageVersion string) Logger {
 		},
 	}
 
-	handler := slog.NewJSONHandler(os.Stdout, opts)
+	var handler slog.Handler = slog.NewJSONHandler(os.Stdout, opts)
+	if options.otelProvider != nil {
+		// Compose STDOUT JSON with OpenTelemetry export
+		handler = MultiHandler{handler, NewOTelHandler(options.otelProvider)}
+	}
 	logger := slog.New(handler)
 
 	return &SlogLogger{
@@ -86,6 +114,26 @@ func (l *SlogLogger) With(fields CustomFields) Logger {
 	}
 }
 
+// WithError creates a new logger instance with the error fields set
+func (l *SlogLogger) WithError(err error) Logger {
+	return l.With(errorFields(err))
+}
+
+// errorFields returns the fields describing err (nil err logs as an empty "error")
+func errorFields(err error) CustomFields {
+	if err == nil {
+		return CustomFields{"error": ""}
+	}
+
+	fields := CustomFields{"error": err.Error()}
+	var pd *app_errors.ProblemDetails
+	if errors.As(err, &pd) {
+		fields["errorCode"] = pd.Code
+		fields["errorStatus"] = pd.Status
+	}
+	return fields
+}
+
 // log is the internal method that performs the actual logging
 func (l *SlogLogger) log(ctx context.Context, level slog.Level, message string, customFields ...CustomFields) {
 	// Extract trace information from context
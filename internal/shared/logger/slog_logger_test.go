@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+// exportedCustomFields runs logFn and returns the "custom" fields of the exported record
+func exportedCustomFields(t *testing.T, exporter *recordingExporter, logFn func()) map[string]otellog.Value {
+	t.Helper()
+	exporter.records = nil
+	logFn()
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported records = %d, want 1", len(exporter.records))
+	}
+	custom := map[string]otellog.Value{}
+	for _, kv := range attributes(exporter.records[0])["custom"].AsMap() {
+		custom[kv.Key] = kv.Value
+	}
+	return custom
+}
+
+func TestSlogLogger_WithError(t *testing.T) {
+	provider, exporter := newRecordingProvider(t)
+	log := NewSlogLogger("go_app_base", "1.2.3", WithOTelLoggerProvider(provider))
+	notFound := app_errors.NewProblemDetails(http.StatusNotFound, "Not Found", "product 42 not found", "PRD404", "")
+
+	t.Run("problem details", func(t *testing.T) {
+		custom := exportedCustomFields(t, exporter, func() {
+			log.WithError(notFound).Error(context.Background(), "failed to process request")
+		})
+		if custom["error"].AsString() != notFound.Error() {
+			t.Errorf("error = %v, want %q", custom["error"], notFound.Error())
+		}
+		if custom["errorCode"].AsString() != "PRD404" || custom["errorStatus"].AsInt64() != http.StatusNotFound {
+			t.Errorf("errorCode, errorStatus = %v, %v, want PRD404, 404", custom["errorCode"], custom["errorStatus"])
+		}
+	})
+
+	t.Run("wrapped problem details", func(t *testing.T) {
+		custom := exportedCustomFields(t, exporter, func() {
+			log.WithError(fmt.Errorf("get product: %w", notFound)).Error(context.Background(), "failed to process request")
+		})
+		if custom["errorCode"].AsString() != "PRD404" {
+			t.Errorf("errorCode = %v, want PRD404", custom["errorCode"])
+		}
+	})
+
+	t.Run("plain error", func(t *testing.T) {
+		custom := exportedCustomFields(t, exporter, func() {
+			log.WithError(errors.New("connection refused")).Error(context.Background(), "failed to process request")
+		})
+		if custom["error"].AsString() != "connection refused" {
+			t.Errorf("error = %v, want connection refused", custom["error"])
+		}
+		if _, ok := custom["errorCode"]; ok {
+			t.Errorf("errorCode = %v, want none for a plain error", custom["errorCode"])
+		}
+	})
+
+	t.Run("through MultiLogger", func(t *testing.T) {
+		custom := exportedCustomFields(t, exporter, func() {
+			NewMultiLogger(log).WithError(notFound).Error(context.Background(), "failed to process request")
+		})
+		if custom["errorCode"].AsString() != "PRD404" {
+			t.Errorf("errorCode = %v, want PRD404", custom["errorCode"])
+		}
+	})
+}
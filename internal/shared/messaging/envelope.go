@@ -0,0 +1,78 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/shared"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// EnvelopeVersion is the version of the envelope format produced by NewEnvelope
+const EnvelopeVersion = "1.0"
+
+// Envelope wraps a message payload with standard metadata shared by Kafka and RabbitMQ
+type Envelope struct {
+	MessageID     string            `json:"messageId"`
+	CorrelationID string            `json:"correlationId"`
+	Type          string            `json:"type"`
+	Version       string            `json:"version"`
+	Source        string            `json:"source"`
+	Timestamp     time.Time         `json:"timestamp"`
+	TraceContext  map[string]string `json:"traceContext,omitempty"`
+	Payload       []byte            `json:"payload"`
+}
+
+// NewEnvelope creates an envelope with a new MessageID, capturing the trace context from ctx
+// CorrelationID defaults to the MessageID and can be overridden to continue an existing flow
+func NewEnvelope(ctx context.Context, msgType, source string, payload []byte) *Envelope {
+	messageID := shared.GenerateId()
+
+	return &Envelope{
+		MessageID:     messageID,
+		CorrelationID: messageID,
+		Type:          msgType,
+		Version:       EnvelopeVersion,
+		Source:        source,
+		Timestamp:     time.Now().UTC(),
+		TraceContext:  InjectMessageContext(ctx),
+		Payload:       payload,
+	}
+}
+
+// Marshal encodes the envelope as JSON
+func (e *Envelope) Marshal() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// Unmarshal decodes a JSON encoded envelope
+func Unmarshal(data []byte) (*Envelope, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, err
+	}
+	return &envelope, nil
+}
+
+// Context returns ctx carrying the trace context recorded in the envelope
+func (e *Envelope) Context(ctx context.Context) context.Context {
+	return ExtractMessageContext(ctx, e.TraceContext)
+}
+
+// InjectMessageContext serializes the trace context of ctx using the global propagator (W3C Trace Context)
+func InjectMessageContext(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	return carrier
+}
+
+// ExtractMessageContext restores a trace context serialized by InjectMessageContext into ctx
+// Consumers should use the returned context so their spans join the producer's trace
+func ExtractMessageContext(ctx context.Context, traceContext map[string]string) context.Context {
+	if len(traceContext) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(traceContext))
+}
@@ -0,0 +1,107 @@
+package messaging
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// useTraceContextPropagator installs the W3C propagator the application configures at startup
+func useTraceContextPropagator(t *testing.T) {
+	t.Helper()
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+}
+
+// sampledContext returns a context carrying a fixed, sampled span context
+func sampledContext(t *testing.T) (context.Context, trace.SpanContext) {
+	t.Helper()
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	return trace.ContextWithSpanContext(context.Background(), sc), sc
+}
+
+func TestEnvelope_RoundTrip(t *testing.T) {
+	useTraceContextPropagator(t)
+	ctx, _ := sampledContext(t)
+
+	original := NewEnvelope(ctx, "product.created", "go_app_base", []byte(`{"id":"42","name":"Keyboard"}`))
+	original.CorrelationID = "order-flow-7"
+
+	data, err := original.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	decoded, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if !decoded.Timestamp.Equal(original.Timestamp) {
+		t.Errorf("Timestamp = %s, want %s", decoded.Timestamp, original.Timestamp)
+	}
+	decoded.Timestamp = original.Timestamp
+	if !reflect.DeepEqual(decoded, original) {
+		t.Errorf("decoded = %+v, want %+v", decoded, original)
+	}
+}
+
+func TestNewEnvelope_Metadata(t *testing.T) {
+	before := time.Now().UTC()
+	first := NewEnvelope(context.Background(), "product.created", "go_app_base", []byte("{}"))
+	second := NewEnvelope(context.Background(), "product.created", "go_app_base", []byte("{}"))
+
+	if first.MessageID == "" || first.MessageID == second.MessageID {
+		t.Errorf("MessageIDs %q and %q, want unique IDs", first.MessageID, second.MessageID)
+	}
+	if first.CorrelationID != first.MessageID {
+		t.Errorf("CorrelationID = %q, want the MessageID by default", first.CorrelationID)
+	}
+	if first.Version != EnvelopeVersion || first.Type != "product.created" || first.Source != "go_app_base" {
+		t.Errorf("envelope = %+v, want the given type and source with the current version", first)
+	}
+	if first.Timestamp.Before(before) || first.Timestamp.Location() != time.UTC {
+		t.Errorf("Timestamp = %s, want the current UTC time", first.Timestamp)
+	}
+	// Without an active span there is no trace context to propagate
+	if len(first.TraceContext) != 0 {
+		t.Errorf("TraceContext = %v, want it empty without a span", first.TraceContext)
+	}
+}
+
+func TestEnvelope_PropagatesTraceContext(t *testing.T) {
+	useTraceContextPropagator(t)
+	ctx, sc := sampledContext(t)
+
+	data, err := NewEnvelope(ctx, "product.created", "go_app_base", nil).Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	decoded, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	consumed := trace.SpanContextFromContext(decoded.Context(context.Background()))
+	if consumed.TraceID() != sc.TraceID() || consumed.SpanID() != sc.SpanID() || !consumed.IsSampled() {
+		t.Errorf("consumer span context = %+v, want the producer's %+v", consumed, sc)
+	}
+}
+
+func TestUnmarshal_InvalidData(t *testing.T) {
+	if _, err := Unmarshal([]byte("not json")); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
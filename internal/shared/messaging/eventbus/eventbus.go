@@ -0,0 +1,71 @@
+package eventbus
+
+import "sync"
+
+// defaultSubscriberBuffer is used when Subscribe is called with a non-positive buffer
+const defaultSubscriberBuffer = 16
+
+// Event is a message published on a topic
+type Event struct {
+	Topic   string
+	Payload any
+}
+
+// EventBus is an in-process publish/subscribe hub for events that only matter to this instance
+// (e.g. pushing changes to connected WebSocket clients). Durable events go through the outbox
+type EventBus struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[*subscription]struct{}
+}
+
+type subscription struct {
+	ch   chan Event
+	once sync.Once
+}
+
+// New creates an empty event bus
+func New() *EventBus {
+	return &EventBus{subscribers: make(map[string]map[*subscription]struct{})}
+}
+
+// Subscribe returns a channel receiving the events published on topic and a function that
+// unsubscribes and closes the channel. Call it when done, or the subscription leaks
+func (b *EventBus) Subscribe(topic string, buffer int) (<-chan Event, func()) {
+	if buffer <= 0 {
+		buffer = defaultSubscriberBuffer
+	}
+	sub := &subscription{ch: make(chan Event, buffer)}
+
+	b.mu.Lock()
+	if b.subscribers[topic] == nil {
+		b.subscribers[topic] = make(map[*subscription]struct{})
+	}
+	b.subscribers[topic][sub] = struct{}{}
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		delete(b.subscribers[topic], sub)
+		if len(b.subscribers[topic]) == 0 {
+			delete(b.subscribers, topic)
+		}
+		b.mu.Unlock()
+		sub.once.Do(func() { close(sub.ch) })
+	}
+	return sub.ch, unsubscribe
+}
+
+// Publish delivers payload to every subscriber of topic without blocking
+// Subscribers whose buffer is full miss the event, so a slow client cannot stall the publisher
+func (b *EventBus) Publish(topic string, payload any) {
+	event := Event{Topic: topic, Payload: payload}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for sub := range b.subscribers[topic] {
+		select {
+		case sub.ch <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package eventbus
+
+import "testing"
+
+func TestEventBus_PublishReachesTopicSubscribers(t *testing.T) {
+	bus := New()
+	first, unsubscribeFirst := bus.Subscribe("stock:42", 1)
+	defer unsubscribeFirst()
+	second, unsubscribeSecond := bus.Subscribe("stock:42", 1)
+	defer unsubscribeSecond()
+	other, unsubscribeOther := bus.Subscribe("stock:7", 1)
+	defer unsubscribeOther()
+
+	bus.Publish("stock:42", 8)
+
+	for i, ch := range []<-chan Event{first, second} {
+		select {
+		case event := <-ch:
+			if event.Topic != "stock:42" || event.Payload != 8 {
+				t.Errorf("subscriber %d got %+v, want stock:42 with payload 8", i, event)
+			}
+		default:
+			t.Errorf("subscriber %d got nothing", i)
+		}
+	}
+	select {
+	case event := <-other:
+		t.Errorf("subscriber of another topic got %+v", event)
+	default:
+	}
+}
+
+func TestEventBus_FullSubscriberMissesEvents(t *testing.T) {
+	bus := New()
+	ch, unsubscribe := bus.Subscribe("stock:42", 1)
+	defer unsubscribe()
+
+	// The second event does not fit the buffer; Publish must return anyway
+	bus.Publish("stock:42", 1)
+	bus.Publish("stock:42", 2)
+
+	if event := <-ch; event.Payload != 1 {
+		t.Errorf("payload = %v, want the first event", event.Payload)
+	}
+	select {
+	case event := <-ch:
+		t.Errorf("got %+v, want the second event dropped", event)
+	default:
+	}
+}
+
+func TestEventBus_Unsubscribe(t *testing.T) {
+	bus := New()
+	ch, unsubscribe := bus.Subscribe("stock:42", 0)
+
+	unsubscribe()
+	unsubscribe()
+
+	if _, ok := <-ch; ok {
+		t.Error("channel still open after unsubscribe")
+	}
+	// Publishing to a topic without subscribers is a no-op
+	bus.Publish("stock:42", 1)
+	if len(bus.subscribers) != 0 {
+		t.Errorf("subscribers = %v, want the topic removed", bus.subscribers)
+	}
+}
@@ -0,0 +1,109 @@
+package kafka
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Headers added to messages published to the DLQ
+const (
+	DLQErrorHeader         = "x-dlq-error"
+	DLQOriginalTopicHeader = "x-dlq-original-topic"
+	DLQAttemptsHeader      = "x-dlq-attempts"
+)
+
+// DLQConfig configures DLQHandler
+type DLQConfig struct {
+	// TopicSuffix is appended to the original topic to build the DLQ topic (default ".dlq")
+	TopicSuffix string
+	// MaxRetries is the number of retries before the message is sent to the DLQ
+	MaxRetries  int
+	ServiceName string
+}
+
+// DLQHandler wraps a MessageHandler, retrying failures and publishing messages that still
+// fail (or fail with a NonRetryable error) to "<topic><TopicSuffix>" instead of discarding them
+type DLQHandler struct {
+	next        MessageHandler
+	producer    Producer
+	topicSuffix string
+	maxRetries  int
+	dlqCounter  metric.Int64Counter
+}
+
+// NewDLQHandler creates a DLQHandler around next, publishing failed messages with producer
+func NewDLQHandler(next MessageHandler, producer Producer, cfg DLQConfig) *DLQHandler {
+	if cfg.TopicSuffix == "" {
+		cfg.TopicSuffix = ".dlq"
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+
+	dlqCounter, _ := otel.Meter(cfg.ServiceName).Int64Counter(
+		"kafka.consumer.dlq.messages",
+		metric.WithDescription("Number of messages published to a dead-letter topic"),
+		metric.WithUnit("{message}"),
+	)
+
+	return &DLQHandler{
+		next:        next,
+		producer:    producer,
+		topicSuffix: cfg.TopicSuffix,
+		maxRetries:  cfg.MaxRetries,
+		dlqCounter:  dlqCounter,
+	}
+}
+
+// Handle runs the wrapped handler, sending the message to the DLQ once retries are exhausted
+// It only returns an error when the DLQ publication itself fails
+func (h *DLQHandler) Handle(ctx context.Context, msg *Message) error {
+	var err error
+	attempts := 0
+	for attempts <= h.maxRetries {
+		attempts++
+		if err = h.next.Handle(ctx, msg); err == nil {
+			return nil
+		}
+		if IsNonRetryable(err) {
+			break
+		}
+	}
+
+	return h.publishToDLQ(ctx, msg, err, attempts)
+}
+
+func (h *DLQHandler) publishToDLQ(ctx context.Context, msg *Message, handlerErr error, attempts int) error {
+	dlqTopic := msg.Topic + h.topicSuffix
+
+	headers := make(map[string]string, len(msg.Headers)+3)
+	for key, value := range msg.Headers {
+		headers[key] = value
+	}
+	headers[DLQErrorHeader] = handlerErr.Error()
+	headers[DLQOriginalTopicHeader] = msg.Topic
+	headers[DLQAttemptsHeader] = strconv.Itoa(attempts)
+
+	if err := h.producer.Publish(ctx, dlqTopic, msg.Key, msg.Value, headers); err != nil {
+		logger.Error(ctx, "Failed to publish message to DLQ", logger.CustomFields{
+			"topic":    msg.Topic,
+			"dlqTopic": dlqTopic,
+			"error":    err.Error(),
+		})
+		return err
+	}
+
+	h.dlqCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("topic", msg.Topic)))
+	logger.Warn(ctx, "Message sent to DLQ", logger.CustomFields{
+		"topic":    msg.Topic,
+		"dlqTopic": dlqTopic,
+		"attempts": attempts,
+		"error":    handlerErr.Error(),
+	})
+	return nil
+}
@@ -0,0 +1,141 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+)
+
+func TestMain(m *testing.M) {
+	// DLQ publications are logged through the global logger
+	logger.SetGlobalLogger(logger.NewMultiLogger())
+	os.Exit(m.Run())
+}
+
+// publishedMessage is a message recorded by fakeProducer
+type publishedMessage struct {
+	topic      string
+	key, value []byte
+	headers    map[string]string
+}
+
+// fakeProducer records publications, failing with err when set
+type fakeProducer struct {
+	published []publishedMessage
+	err       error
+}
+
+func (p *fakeProducer) Publish(_ context.Context, topic string, key, value []byte, headers map[string]string) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, publishedMessage{topic: topic, key: key, value: value, headers: headers})
+	return nil
+}
+
+// failingHandler fails its first failures calls with err, counting every call
+type failingHandler struct {
+	failures int
+	err      error
+	calls    int
+}
+
+func (h *failingHandler) Handle(context.Context, *Message) error {
+	h.calls++
+	if h.calls <= h.failures {
+		return h.err
+	}
+	return nil
+}
+
+func testMessage() *Message {
+	return &Message{
+		Topic:   "products",
+		Key:     []byte("42"),
+		Value:   []byte(`{"id":"42"}`),
+		Headers: map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+	}
+}
+
+func TestDLQHandler_RepeatedFailuresGoToDLQ(t *testing.T) {
+	producer := &fakeProducer{}
+	next := &failingHandler{failures: 100, err: errors.New("database unavailable")}
+	handler := NewDLQHandler(next, producer, DLQConfig{MaxRetries: 2})
+
+	if err := handler.Handle(context.Background(), testMessage()); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+
+	if next.calls != 3 {
+		t.Errorf("handler calls = %d, want 1 attempt plus 2 retries", next.calls)
+	}
+	if len(producer.published) != 1 {
+		t.Fatalf("DLQ publications = %d, want 1", len(producer.published))
+	}
+	msg := producer.published[0]
+	if msg.topic != "products.dlq" || string(msg.key) != "42" || string(msg.value) != `{"id":"42"}` {
+		t.Errorf("published %s %q %q, want the original message on products.dlq", msg.topic, msg.key, msg.value)
+	}
+	wantHeaders := map[string]string{
+		DLQErrorHeader:         "database unavailable",
+		DLQOriginalTopicHeader: "products",
+		DLQAttemptsHeader:      "3",
+		"traceparent":          "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	}
+	for key, value := range wantHeaders {
+		if msg.headers[key] != value {
+			t.Errorf("header %s = %q, want %q", key, msg.headers[key], value)
+		}
+	}
+}
+
+func TestDLQHandler_Outcomes(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           DLQConfig
+		failures      int
+		err           error
+		wantCalls     int
+		wantDLQTopic  string
+		wantPublished bool
+	}{
+		{name: "success", cfg: DLQConfig{MaxRetries: 3}, wantCalls: 1},
+		{name: "succeeds on retry", cfg: DLQConfig{MaxRetries: 3}, failures: 2, err: errors.New("timeout"), wantCalls: 3},
+		{name: "non-retryable skips retries", cfg: DLQConfig{MaxRetries: 3}, failures: 1, err: NonRetryable(errors.New("malformed payload")), wantCalls: 1, wantDLQTopic: "products.dlq", wantPublished: true},
+		{name: "no retries", cfg: DLQConfig{}, failures: 1, err: errors.New("timeout"), wantCalls: 1, wantDLQTopic: "products.dlq", wantPublished: true},
+		{name: "custom suffix", cfg: DLQConfig{TopicSuffix: ".dead", MaxRetries: 1}, failures: 5, err: errors.New("timeout"), wantCalls: 2, wantDLQTopic: "products.dead", wantPublished: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			producer := &fakeProducer{}
+			next := &failingHandler{failures: tt.failures, err: tt.err}
+
+			if err := NewDLQHandler(next, producer, tt.cfg).Handle(context.Background(), testMessage()); err != nil {
+				t.Fatalf("Handle: %v", err)
+			}
+			if next.calls != tt.wantCalls {
+				t.Errorf("handler calls = %d, want %d", next.calls, tt.wantCalls)
+			}
+			if published := len(producer.published) == 1; published != tt.wantPublished {
+				t.Fatalf("published to DLQ = %v, want %v", published, tt.wantPublished)
+			}
+			if tt.wantPublished && producer.published[0].topic != tt.wantDLQTopic {
+				t.Errorf("DLQ topic = %q, want %q", producer.published[0].topic, tt.wantDLQTopic)
+			}
+		})
+	}
+}
+
+func TestDLQHandler_PublishFailureIsReturned(t *testing.T) {
+	brokerDown := errors.New("broker unavailable")
+	producer := &fakeProducer{err: brokerDown}
+	next := &failingHandler{failures: 1, err: NonRetryable(errors.New("malformed payload"))}
+
+	// The consumer must not commit the offset of a message that reached neither the handler nor the DLQ
+	if err := NewDLQHandler(next, producer, DLQConfig{}).Handle(context.Background(), testMessage()); !errors.Is(err, brokerDown) {
+		t.Errorf("err = %v, want the producer error", err)
+	}
+}
@@ -0,0 +1,60 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+)
+
+// Message is a record consumed from a Kafka topic
+type Message struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+}
+
+// MessageHandler processes messages consumed from a topic
+type MessageHandler interface {
+	Handle(ctx context.Context, msg *Message) error
+}
+
+// MessageHandlerFunc adapts a function to the MessageHandler interface
+type MessageHandlerFunc func(ctx context.Context, msg *Message) error
+
+func (f MessageHandlerFunc) Handle(ctx context.Context, msg *Message) error {
+	return f(ctx, msg)
+}
+
+// Producer publishes messages to Kafka topics
+type Producer interface {
+	Publish(ctx context.Context, topic string, key, value []byte, headers map[string]string) error
+}
+
+// nonRetryableError marks failures that retrying cannot fix (e.g. malformed payloads)
+type nonRetryableError struct {
+	err error
+}
+
+func (e *nonRetryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *nonRetryableError) Unwrap() error {
+	return e.err
+}
+
+// NonRetryable wraps err so handlers skip retries and send the message straight to the DLQ
+func NonRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &nonRetryableError{err: err}
+}
+
+// IsNonRetryable reports whether err was wrapped with NonRetryable
+func IsNonRetryable(err error) bool {
+	var target *nonRetryableError
+	return errors.As(err, &target)
+}
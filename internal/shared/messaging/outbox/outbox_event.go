@@ -0,0 +1,47 @@
+// Package outbox implements the transactional outbox pattern: events are stored in the
+// outbox_events table in the same transaction as the business change, and OutboxPoller
+// publishes them to the broker afterwards, so no event is lost while the broker is down
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/shared"
+)
+
+// Outbox event statuses
+const (
+	StatusPending = "pending"
+	StatusSent    = "sent"
+)
+
+// OutboxEvent is an event waiting in (or already delivered from) the outbox
+type OutboxEvent struct {
+	ID            string
+	AggregateType string
+	AggregateID   string
+	EventType     string
+	Payload       []byte
+	Status        string
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
+
+// NewOutboxEvent creates a pending event with payload encoded as JSON
+func NewOutboxEvent(aggregateType, aggregateID, eventType string, payload any) (*OutboxEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxEvent{
+		ID:            shared.GenerateId(),
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Payload:       data,
+		Status:        StatusPending,
+		CreatedAt:     time.Now().UTC(),
+	}, nil
+}
@@ -0,0 +1,94 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// OutboxRepository handles database operations for the outbox_events table
+type OutboxRepository struct {
+	db *sql.DB
+}
+
+// ExpectedSchema lists the outbox_events columns (see configs.ValidateSchema)
+func ExpectedSchema() map[string][]string {
+	return map[string][]string{
+		"outbox_events": {"id", "aggregate_type", "aggregate_id", "event_type", "payload", "status", "created_at", "sent_at"},
+	}
+}
+
+// NewOutboxRepository creates a new outbox repository instance
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Save stores the event inside tx, so it is only kept if the business change commits
+// A nil tx stores the event on its own
+func (r *OutboxRepository) Save(ctx context.Context, event *OutboxEvent, tx *sql.Tx) error {
+	query := `
+		INSERT INTO outbox_events (id, aggregate_type, aggregate_id, event_type, payload, status, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	args := []any{
+		event.ID,
+		event.AggregateType,
+		event.AggregateID,
+		event.EventType,
+		event.Payload,
+		event.Status,
+		event.CreatedAt,
+	}
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, args...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, args...)
+	}
+	return err
+}
+
+// FindPending retrieves up to limit pending events, oldest first
+func (r *OutboxRepository) FindPending(ctx context.Context, limit int) ([]*OutboxEvent, error) {
+	query := `
+		SELECT id, aggregate_type, aggregate_id, event_type, payload, status, created_at, sent_at
+		FROM outbox_events
+		WHERE status = ?
+		ORDER BY created_at, id
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, StatusPending, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*OutboxEvent
+	for rows.Next() {
+		var event OutboxEvent
+		err := rows.Scan(
+			&event.ID,
+			&event.AggregateType,
+			&event.AggregateID,
+			&event.EventType,
+			&event.Payload,
+			&event.Status,
+			&event.CreatedAt,
+			&event.SentAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+	return events, rows.Err()
+}
+
+// MarkSent flags the event as delivered at sentAt
+func (r *OutboxRepository) MarkSent(ctx context.Context, id string, sentAt time.Time) error {
+	query := `UPDATE outbox_events SET status = ?, sent_at = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, StatusSent, sentAt, id)
+	return err
+}
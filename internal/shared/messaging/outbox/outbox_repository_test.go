@@ -0,0 +1,130 @@
+//go:build sqlite
+
+package outbox
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+)
+
+func TestMain(m *testing.M) {
+	// The poller and the log publisher write through the global logger
+	logger.SetGlobalLogger(logger.NewMultiLogger())
+	os.Exit(m.Run())
+}
+
+// newTestEvent creates a pending event created offset after a fixed time, so tests control the order
+func newTestEvent(t *testing.T, aggregateID string, offset time.Duration) *OutboxEvent {
+	t.Helper()
+	event, err := NewOutboxEvent("product", aggregateID, "product.created", map[string]string{"id": aggregateID})
+	if err != nil {
+		t.Fatalf("NewOutboxEvent: %v", err)
+	}
+	event.CreatedAt = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC).Add(offset)
+	return event
+}
+
+func pendingIDs(t *testing.T, repo *OutboxRepository, limit int) []string {
+	t.Helper()
+	events, err := repo.FindPending(context.Background(), limit)
+	if err != nil {
+		t.Fatalf("FindPending: %v", err)
+	}
+	ids := make([]string, len(events))
+	for i, event := range events {
+		ids[i] = event.AggregateID
+	}
+	return ids
+}
+
+func TestOutboxRepository_SaveKeepsEventOnlyIfTransactionCommits(t *testing.T) {
+	ctx := context.Background()
+	db := testhelpers.NewSQLiteForTest(t)
+	repo := NewOutboxRepository(db)
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("BeginTx: %v", err)
+	}
+	if err := repo.Save(ctx, newTestEvent(t, "rolled-back", 0), tx); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+
+	tx, err = db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("BeginTx: %v", err)
+	}
+	if err := repo.Save(ctx, newTestEvent(t, "committed", time.Second), tx); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	if err := repo.Save(ctx, newTestEvent(t, "no-transaction", 2*time.Second), nil); err != nil {
+		t.Fatalf("Save without transaction: %v", err)
+	}
+
+	got := pendingIDs(t, repo, 10)
+	if len(got) != 2 || got[0] != "committed" || got[1] != "no-transaction" {
+		t.Fatalf("pending events = %v, want [committed no-transaction]", got)
+	}
+}
+
+func TestOutboxRepository_FindPendingIsOrderedAndLimited(t *testing.T) {
+	ctx := context.Background()
+	repo := NewOutboxRepository(testhelpers.NewSQLiteForTest(t))
+
+	// Saved out of order: FindPending must return the oldest first
+	for _, e := range []struct {
+		id     string
+		offset time.Duration
+	}{{"third", 3 * time.Second}, {"first", time.Second}, {"second", 2 * time.Second}} {
+		if err := repo.Save(ctx, newTestEvent(t, e.id, e.offset), nil); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	got := pendingIDs(t, repo, 2)
+	if len(got) != 2 || got[0] != "first" || got[1] != "second" {
+		t.Fatalf("FindPending(2) = %v, want [first second]", got)
+	}
+}
+
+func TestOutboxRepository_MarkSentRemovesEventFromPending(t *testing.T) {
+	ctx := context.Background()
+	repo := NewOutboxRepository(testhelpers.NewSQLiteForTest(t))
+
+	sent := newTestEvent(t, "sent", 0)
+	for _, event := range []*OutboxEvent{sent, newTestEvent(t, "pending", time.Second)} {
+		if err := repo.Save(ctx, event, nil); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	if err := repo.MarkSent(ctx, sent.ID, time.Now().UTC()); err != nil {
+		t.Fatalf("MarkSent: %v", err)
+	}
+
+	events, err := repo.FindPending(ctx, 10)
+	if err != nil {
+		t.Fatalf("FindPending: %v", err)
+	}
+	if len(events) != 1 || events[0].AggregateID != "pending" {
+		t.Fatalf("pending events = %v, want only the unsent one", events)
+	}
+	if events[0].Status != StatusPending || events[0].SentAt != nil {
+		t.Errorf("pending event status = %q, sent_at = %v", events[0].Status, events[0].SentAt)
+	}
+	if string(events[0].Payload) != `{"id":"pending"}` {
+		t.Errorf("payload = %s", events[0].Payload)
+	}
+}
@@ -0,0 +1,91 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+)
+
+// pollBatchSize is the maximum number of pending events published per poll
+const pollBatchSize = 100
+
+// OutboxPoller periodically publishes the pending outbox events and marks them sent
+// Delivery is at-least-once: an event published right before a failed MarkSent (or by
+// two replicas polling at once) is published again, so consumers must be idempotent
+type OutboxPoller struct {
+	repository *OutboxRepository
+	publisher  Publisher
+	interval   time.Duration
+
+	cancel   context.CancelFunc
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewOutboxPoller creates a poller running every interval (defaults to 5 seconds)
+func NewOutboxPoller(repository *OutboxRepository, publisher Publisher, interval time.Duration) *OutboxPoller {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	return &OutboxPoller{repository: repository, publisher: publisher, interval: interval}
+}
+
+// Start runs the poll loop in a background goroutine until ctx is done or Stop is called
+func (p *OutboxPoller) Start(ctx context.Context) {
+	ctx, p.cancel = context.WithCancel(ctx)
+	p.done = make(chan struct{})
+
+	go func() {
+		defer close(p.done)
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := p.Poll(ctx); err != nil {
+					logger.WithError(err).Error(ctx, "Outbox poll failed")
+				}
+			}
+		}
+	}()
+
+	logger.Info(ctx, "Outbox poller started", logger.CustomFields{"interval": p.interval.String()})
+}
+
+// Stop stops the poll loop and waits for a running poll to finish
+func (p *OutboxPoller) Stop() {
+	p.stopOnce.Do(func() {
+		if p.cancel != nil {
+			p.cancel()
+			<-p.done
+		}
+	})
+}
+
+// Poll publishes one batch of pending events in order and returns how many were sent
+// It stops at the first failure so later events are not delivered before earlier ones;
+// the remaining events are retried by the next poll
+func (p *OutboxPoller) Poll(ctx context.Context) (int, error) {
+	events, err := p.repository.FindPending(ctx, pollBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, event := range events {
+		if err := p.publisher.Publish(ctx, event); err != nil {
+			return sent, err
+		}
+		if err := p.repository.MarkSent(ctx, event.ID, time.Now().UTC()); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, nil
+}
@@ -0,0 +1,121 @@
+//go:build sqlite
+
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+)
+
+// recordingPublisher records the aggregate IDs it publishes and fails on failOn
+type recordingPublisher struct {
+	mu        sync.Mutex
+	published []string
+	failOn    string
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event *OutboxEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if event.AggregateID == p.failOn {
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, event.AggregateID)
+	return nil
+}
+
+func (p *recordingPublisher) Published() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.published...)
+}
+
+func saveTestEvents(t *testing.T, repo *OutboxRepository, ids ...string) {
+	t.Helper()
+	for i, id := range ids {
+		if err := repo.Save(context.Background(), newTestEvent(t, id, time.Duration(i)*time.Second), nil); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+}
+
+func TestOutboxPoller_PollPublishesInOrderAndMarksSent(t *testing.T) {
+	repo := NewOutboxRepository(testhelpers.NewSQLiteForTest(t))
+	saveTestEvents(t, repo, "a", "b", "c")
+	publisher := &recordingPublisher{}
+	poller := NewOutboxPoller(repo, publisher, time.Minute)
+
+	sent, err := poller.Poll(context.Background())
+	if err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+	if sent != 3 {
+		t.Errorf("sent = %d, want 3", sent)
+	}
+	if got := publisher.Published(); len(got) != 3 || got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Errorf("published = %v, want [a b c]", got)
+	}
+	if pending := pendingIDs(t, repo, 10); len(pending) != 0 {
+		t.Errorf("pending after poll = %v, want none", pending)
+	}
+
+	// Sent events are not published again
+	if sent, err := poller.Poll(context.Background()); err != nil || sent != 0 {
+		t.Errorf("second Poll = %d, %v, want 0, nil", sent, err)
+	}
+}
+
+func TestOutboxPoller_PollStopsAtFirstFailure(t *testing.T) {
+	repo := NewOutboxRepository(testhelpers.NewSQLiteForTest(t))
+	saveTestEvents(t, repo, "a", "b", "c")
+	publisher := &recordingPublisher{failOn: "b"}
+	poller := NewOutboxPoller(repo, publisher, time.Minute)
+
+	sent, err := poller.Poll(context.Background())
+	if err == nil {
+		t.Fatal("expected the publisher error")
+	}
+	if sent != 1 {
+		t.Errorf("sent = %d, want 1", sent)
+	}
+	// c is not delivered before b; both are retried by the next poll
+	if got := pendingIDs(t, repo, 10); len(got) != 2 || got[0] != "b" || got[1] != "c" {
+		t.Fatalf("pending after failure = %v, want [b c]", got)
+	}
+
+	publisher.failOn = ""
+	if sent, err := poller.Poll(context.Background()); err != nil || sent != 2 {
+		t.Fatalf("retry Poll = %d, %v, want 2, nil", sent, err)
+	}
+}
+
+func TestOutboxPoller_StartPublishesUntilStopped(t *testing.T) {
+	repo := NewOutboxRepository(testhelpers.NewSQLiteForTest(t))
+	saveTestEvents(t, repo, "a")
+	publisher := &recordingPublisher{}
+	poller := NewOutboxPoller(repo, publisher, 10*time.Millisecond)
+
+	poller.Start(context.Background())
+	deadline := time.Now().Add(2 * time.Second)
+	for len(publisher.Published()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	poller.Stop()
+	poller.Stop() // idempotent
+
+	if got := publisher.Published(); len(got) != 1 || got[0] != "a" {
+		t.Fatalf("published = %v, want [a]", got)
+	}
+
+	// Nothing is polled after Stop
+	saveTestEvents(t, repo, "late")
+	time.Sleep(30 * time.Millisecond)
+	if got := publisher.Published(); len(got) != 1 {
+		t.Errorf("published after Stop = %v", got)
+	}
+}
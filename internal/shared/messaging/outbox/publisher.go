@@ -0,0 +1,52 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"github.com/refortunato/go_app_base/internal/shared/messaging"
+	"github.com/refortunato/go_app_base/internal/shared/messaging/kafka"
+)
+
+// Publisher delivers outbox events to a message broker
+type Publisher interface {
+	Publish(ctx context.Context, event *OutboxEvent) error
+}
+
+// PublisherFunc adapts a function to the Publisher interface
+type PublisherFunc func(ctx context.Context, event *OutboxEvent) error
+
+func (f PublisherFunc) Publish(ctx context.Context, event *OutboxEvent) error {
+	return f(ctx, event)
+}
+
+// NewKafkaPublisher publishes each event to the topic named after its aggregate type,
+// keyed by the aggregate ID so the events of an aggregate keep their order
+// The payload is wrapped in a messaging.Envelope whose MessageID is the outbox event ID,
+// letting consumers discard the duplicates caused by redeliveries
+func NewKafkaPublisher(producer kafka.Producer, source string) Publisher {
+	return PublisherFunc(func(ctx context.Context, event *OutboxEvent) error {
+		envelope := messaging.NewEnvelope(ctx, event.EventType, source, event.Payload)
+		envelope.MessageID = event.ID
+		envelope.CorrelationID = event.ID
+
+		value, err := envelope.Marshal()
+		if err != nil {
+			return err
+		}
+		return producer.Publish(ctx, event.AggregateType, []byte(event.AggregateID), value, nil)
+	})
+}
+
+// NewLogPublisher only logs the events; used while no broker producer is configured
+func NewLogPublisher() Publisher {
+	return PublisherFunc(func(ctx context.Context, event *OutboxEvent) error {
+		logger.Info(ctx, "Outbox event published", logger.CustomFields{
+			"outbox.event_id":   event.ID,
+			"outbox.event_type": event.EventType,
+			"aggregate.type":    event.AggregateType,
+			"aggregate.id":      event.AggregateID,
+		})
+		return nil
+	})
+}
@@ -0,0 +1,50 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/refortunato/go_app_base/internal/shared/messaging"
+)
+
+// producerFunc adapts a function to kafka.Producer
+type producerFunc func(ctx context.Context, topic string, key, value []byte, headers map[string]string) error
+
+func (f producerFunc) Publish(ctx context.Context, topic string, key, value []byte, headers map[string]string) error {
+	return f(ctx, topic, key, value, headers)
+}
+
+func TestKafkaPublisher_PublishesEnvelopeKeyedByAggregate(t *testing.T) {
+	event, err := NewOutboxEvent("product", "product-1", "product.updated", map[string]string{"id": "product-1"})
+	if err != nil {
+		t.Fatalf("NewOutboxEvent: %v", err)
+	}
+
+	var topic, key string
+	var value []byte
+	publisher := NewKafkaPublisher(producerFunc(func(_ context.Context, t string, k, v []byte, _ map[string]string) error {
+		topic, key, value = t, string(k), v
+		return nil
+	}), "go_app_base")
+
+	if err := publisher.Publish(context.Background(), event); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if topic != "product" || key != "product-1" {
+		t.Errorf("topic, key = %q, %q, want product, product-1", topic, key)
+	}
+
+	envelope, err := messaging.Unmarshal(value)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if envelope.MessageID != event.ID {
+		t.Errorf("MessageID = %q, want the outbox event ID %q", envelope.MessageID, event.ID)
+	}
+	if envelope.Type != "product.updated" || envelope.Source != "go_app_base" {
+		t.Errorf("envelope type, source = %q, %q", envelope.Type, envelope.Source)
+	}
+	if string(envelope.Payload) != string(event.Payload) {
+		t.Errorf("payload = %s, want %s", envelope.Payload, event.Payload)
+	}
+}
@@ -0,0 +1,28 @@
+package module
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Module is implemented by application modules so they can be plugged into the server
+// without editing the route orchestrator
+type Module interface {
+	// Name identifies the module in configuration (e.g. SERVER_APP_MODULE_PREFIXES)
+	Name() string
+	// RoutePrefix is the default path prefix for the module's routes ("/" for the root)
+	RoutePrefix() string
+	// RegisterRoutes registers the module's HTTP routes on group
+	RegisterRoutes(group *gin.RouterGroup)
+	// HealthCheck reports whether the module's dependencies are available
+	HealthCheck(ctx context.Context) error
+}
+
+// NewRouteGroup creates the route group for a module prefix, using the root for an empty prefix
+func NewRouteGroup(router *gin.Engine, prefix string) *gin.RouterGroup {
+	if prefix == "" {
+		prefix = "/"
+	}
+	return router.Group(prefix)
+}
@@ -0,0 +1,76 @@
+package observability
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DBSystem is reported as db.system by TraceQuery and TraceExec
+const DBSystem = "mysql"
+
+// Span names of TraceQuery and TraceExec
+const (
+	dbQuerySpanName = "db.query"
+	dbExecSpanName  = "db.exec"
+)
+
+var (
+	// sqlStringLiteral matches single-quoted literals, including '' escapes
+	sqlStringLiteral = regexp.MustCompile(`'(?:[^']|'')*'`)
+	// sqlNumberLiteral matches numbers that are not part of an identifier
+	sqlNumberLiteral = regexp.MustCompile(`\b\d+(?:\.\d+)?\b`)
+)
+
+// TraceQuery runs fn inside a "db.query" span for drivers that cannot be wrapped by otelsql
+// The span carries db.statement (see SanitizeQuery), db.system and db.operation (the first
+// word of the query, e.g. SELECT); fn must use the ctx it receives so the span is its parent
+func TraceQuery(ctx context.Context, tracer trace.Tracer, query string, fn func(ctx context.Context) error) error {
+	return traceDB(ctx, tracer, dbQuerySpanName, query, fn)
+}
+
+// TraceExec is like TraceQuery for statements that change data (INSERT, UPDATE, DELETE),
+// recorded as a "db.exec" span
+func TraceExec(ctx context.Context, tracer trace.Tracer, query string, fn func(ctx context.Context) error) error {
+	return traceDB(ctx, tracer, dbExecSpanName, query, fn)
+}
+
+func traceDB(ctx context.Context, tracer trace.Tracer, spanName, query string, fn func(ctx context.Context) error) error {
+	ctx, span := tracer.Start(ctx, spanName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.statement", SanitizeQuery(query)),
+			attribute.String("db.system", DBSystem),
+			attribute.String("db.operation", queryOperation(query)),
+		),
+	)
+	defer span.End()
+
+	if err := fn(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
+
+// SanitizeQuery replaces string and number literals with "?" and collapses whitespace,
+// so values inlined in a query never reach the traces; existing "?" placeholders are kept
+func SanitizeQuery(query string) string {
+	query = sqlStringLiteral.ReplaceAllString(query, "?")
+	query = sqlNumberLiteral.ReplaceAllString(query, "?")
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// queryOperation returns the upper-cased first word of the query (e.g. SELECT)
+func queryOperation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
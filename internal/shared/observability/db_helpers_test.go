@@ -0,0 +1,117 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newInMemoryTracer returns a tracer whose spans are exported synchronously to the returned exporter
+func newInMemoryTracer() (trace.Tracer, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	return provider.Tracer("test"), exporter
+}
+
+// spanAttributes indexes the attributes of span by key
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]string {
+	attrs := make(map[attribute.Key]string, len(span.Attributes))
+	for _, attr := range span.Attributes {
+		attrs[attr.Key] = attr.Value.Emit()
+	}
+	return attrs
+}
+
+func TestTraceQuery_Select(t *testing.T) {
+	tracer, exporter := newInMemoryTracer()
+	ctx, parent := tracer.Start(context.Background(), "request")
+
+	var fnSpan trace.SpanContext
+	err := TraceQuery(ctx, tracer, "SELECT id, name\n  FROM examples WHERE id = ? AND status = 'active' LIMIT 10", func(ctx context.Context) error {
+		fnSpan = trace.SpanContextFromContext(ctx)
+		return nil
+	})
+	parent.End()
+	if err != nil {
+		t.Fatalf("TraceQuery: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("spans = %d, want the query and its parent", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "db.query" || span.SpanKind != trace.SpanKindClient {
+		t.Errorf("span = %s (%s), want a db.query client span", span.Name, span.SpanKind)
+	}
+	want := map[attribute.Key]string{
+		"db.statement": "SELECT id, name FROM examples WHERE id = ? AND status = ? LIMIT ?",
+		"db.system":    "mysql",
+		"db.operation": "SELECT",
+	}
+	if got := spanAttributes(span); len(got) != len(want) || got["db.statement"] != want["db.statement"] || got["db.system"] != want["db.system"] || got["db.operation"] != want["db.operation"] {
+		t.Errorf("attributes = %v, want %v", got, want)
+	}
+	if span.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Error("db.query is not a child of the request span")
+	}
+	if fnSpan.SpanID() != span.SpanContext.SpanID() {
+		t.Error("fn did not receive the db.query span in its context")
+	}
+	if span.Status.Code != codes.Unset {
+		t.Errorf("status = %v, want unset", span.Status)
+	}
+}
+
+func TestTraceExec_InsertError(t *testing.T) {
+	tracer, exporter := newInMemoryTracer()
+	failure := errors.New("duplicate entry")
+
+	err := TraceExec(context.Background(), tracer, "insert into examples (id, name) values (?, 'x')", func(context.Context) error {
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("err = %v, want the error of fn", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("spans = %d, want 1", len(spans))
+	}
+	span := spans[0]
+	attrs := spanAttributes(span)
+	if span.Name != "db.exec" || attrs["db.operation"] != "INSERT" || attrs["db.statement"] != "insert into examples (id, name) values (?, ?)" {
+		t.Errorf("span = %s with %v, want db.exec for an INSERT", span.Name, attrs)
+	}
+	if span.Status.Code != codes.Error || span.Status.Description != "duplicate entry" {
+		t.Errorf("status = %+v, want Error", span.Status)
+	}
+	if len(span.Events) != 1 || span.Events[0].Name != "exception" {
+		t.Errorf("events = %+v, want the recorded error", span.Events)
+	}
+}
+
+func TestSanitizeQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "SELECT * FROM t WHERE a = ?", want: "SELECT * FROM t WHERE a = ?"},
+		{query: "SELECT * FROM t WHERE name = 'O''Brien'", want: "SELECT * FROM t WHERE name = ?"},
+		{query: "UPDATE t SET price = 19.90 WHERE id = 7", want: "UPDATE t SET price = ? WHERE id = ?"},
+		// Digits inside identifiers are kept
+		{query: "SELECT col1 FROM table2", want: "SELECT col1 FROM table2"},
+		{query: "  DELETE\n\tFROM t  ", want: "DELETE FROM t"},
+	}
+	for _, tt := range tests {
+		if got := SanitizeQuery(tt.query); got != tt.want {
+			t.Errorf("SanitizeQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package observability
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AddBusinessEvent adds a timestamped event to the active span in ctx
+// Use it to mark key steps of a business operation (e.g. "product.validated")
+// It is a no-op when ctx carries no recording span
+func AddBusinessEvent(ctx context.Context, name string, attrs ...attribute.KeyValue) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.AddEvent(name, trace.WithAttributes(attrs...))
+}
@@ -0,0 +1,30 @@
+package observability
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestAddBusinessEvent(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)).Tracer("test")
+
+	ctx, span := tracer.Start(context.Background(), "operation")
+	AddBusinessEvent(ctx, "order.paid", attribute.String("order.id", "42"))
+	span.End()
+	// After the span ended, and without a span, events are dropped silently
+	AddBusinessEvent(ctx, "order.shipped")
+	AddBusinessEvent(context.Background(), "order.shipped")
+
+	events := spans.Ended()[0].Events()
+	if len(events) != 1 || events[0].Name != "order.paid" {
+		t.Fatalf("events = %+v, want only order.paid", events)
+	}
+	if attrs := events[0].Attributes; len(attrs) != 1 || attrs[0] != attribute.String("order.id", "42") {
+		t.Errorf("attributes = %v, want order.id=42", attrs)
+	}
+}
@@ -0,0 +1,42 @@
+package observability
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attribute keys used to link a metric observation to its trace
+const (
+	ExemplarTraceIDKey = "trace_id"
+	ExemplarSpanIDKey  = "span_id"
+)
+
+// CurrentExemplarAttrs returns the trace_id and span_id of the active span in ctx
+// Returns nil if there is no valid span
+// These keys are removed from every metric stream by exemplarView, so they only
+// end up on exemplars (no extra cardinality)
+func CurrentExemplarAttrs(ctx context.Context) []attribute.KeyValue {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return nil
+	}
+
+	return []attribute.KeyValue{
+		attribute.String(ExemplarTraceIDKey, spanCtx.TraceID().String()),
+		attribute.String(ExemplarSpanIDKey, spanCtx.SpanID().String()),
+	}
+}
+
+// exemplarView drops the exemplar keys from the aggregated attributes of all instruments
+// Filtered attributes are kept on the exemplars recorded for each data point
+func exemplarView() sdkmetric.View {
+	return sdkmetric.NewView(
+		sdkmetric.Instrument{Name: "*"},
+		sdkmetric.Stream{
+			AttributeFilter: attribute.NewDenyKeysFilter(ExemplarTraceIDKey, ExemplarSpanIDKey),
+		},
+	)
+}
@@ -0,0 +1,126 @@
+package observability
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// usePrometheusMeterProvider installs a global meter provider configured like NewMeterProvider
+// with exemplars enabled, collecting into the returned Prometheus registry
+func usePrometheusMeterProvider(t *testing.T) *prometheus.Registry {
+	t.Helper()
+	registry := prometheus.NewRegistry()
+	exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+	if err != nil {
+		t.Fatalf("prometheus exporter: %v", err)
+	}
+	mp := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(exporter),
+		sdkmetric.WithView(metricsView(nil)),
+		sdkmetric.WithExemplarFilter(exemplar.AlwaysOnFilter),
+	)
+	previous := otel.GetMeterProvider()
+	otel.SetMeterProvider(mp)
+	t.Cleanup(func() {
+		otel.SetMeterProvider(previous)
+		mp.Shutdown(context.Background())
+	})
+	return registry
+}
+
+// findFamily returns the gathered metric family whose name starts with prefix
+func findFamily(t *testing.T, registry *prometheus.Registry, prefix string) *dto.MetricFamily {
+	t.Helper()
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	for _, family := range families {
+		if strings.HasPrefix(family.GetName(), prefix) {
+			return family
+		}
+	}
+	t.Fatalf("no metric family starting with %s", prefix)
+	return nil
+}
+
+func labelValue(labels []*dto.LabelPair, name string) (string, bool) {
+	for _, label := range labels {
+		if label.GetName() == name {
+			return label.GetValue(), true
+		}
+	}
+	return "", false
+}
+
+func TestMetricsMiddleware_RecordsExemplarsWithTraceID(t *testing.T) {
+	registry := usePrometheusMeterProvider(t)
+	tracer := sdktrace.NewTracerProvider().Tracer("test")
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	var spanCtx trace.SpanContext
+	router.Use(func(c *gin.Context) {
+		ctx, span := tracer.Start(c.Request.Context(), "request")
+		defer span.End()
+		spanCtx = span.SpanContext()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	})
+	router.Use(MetricsMiddleware("test", "shop-api"))
+	router.GET("/products", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/products", nil))
+
+	family := findFamily(t, registry, "shop_api_http_server_request_duration")
+	if len(family.GetMetric()) != 1 {
+		t.Fatalf("series = %d, want 1", len(family.GetMetric()))
+	}
+	series := family.GetMetric()[0]
+	// The trace IDs must not become series labels (one series per request)
+	for _, key := range []string{ExemplarTraceIDKey, ExemplarSpanIDKey} {
+		if _, ok := labelValue(series.GetLabel(), key); ok {
+			t.Errorf("series has a %s label", key)
+		}
+	}
+
+	var exemplars []*dto.Exemplar
+	for _, bucket := range series.GetHistogram().GetBucket() {
+		if bucket.GetExemplar() != nil {
+			exemplars = append(exemplars, bucket.GetExemplar())
+		}
+	}
+	if len(exemplars) != 1 {
+		t.Fatalf("exemplars = %d, want 1", len(exemplars))
+	}
+	if traceID, _ := labelValue(exemplars[0].GetLabel(), "trace_id"); traceID != spanCtx.TraceID().String() {
+		t.Errorf("exemplar trace_id = %q, want %s", traceID, spanCtx.TraceID())
+	}
+}
+
+func TestCurrentExemplarAttrs(t *testing.T) {
+	if attrs := CurrentExemplarAttrs(context.Background()); attrs != nil {
+		t.Errorf("attrs without a span = %v, want nil", attrs)
+	}
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "operation")
+	defer span.End()
+	attrs := CurrentExemplarAttrs(ctx)
+	if len(attrs) != 2 ||
+		attrs[0].Value.AsString() != span.SpanContext().TraceID().String() ||
+		attrs[1].Value.AsString() != span.SpanContext().SpanID().String() {
+		t.Errorf("attrs = %v, want the trace and span IDs", attrs)
+	}
+}
@@ -0,0 +1,52 @@
+package observability
+
+import (
+	"fmt"
+	"strings"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// bucketedHistogramSuffixes are the HTTP histograms (whatever the app name prefix)
+// whose buckets come from ConfigProvider.GetOtelHistogramBuckets
+var bucketedHistogramSuffixes = []string{
+	".http.server.request.duration",
+	".http.server.request.size",
+}
+
+// ValidateHistogramBuckets returns an error unless the boundaries are strictly increasing
+// An empty list is valid and keeps the SDK default buckets
+func ValidateHistogramBuckets(boundaries []float64) error {
+	for i := 1; i < len(boundaries); i++ {
+		if boundaries[i] <= boundaries[i-1] {
+			return fmt.Errorf("histogram bucket boundaries must be strictly increasing: %v <= %v at position %d", boundaries[i], boundaries[i-1], i)
+		}
+	}
+	return nil
+}
+
+// metricsView extends exemplarView with the configured bucket boundaries for the HTTP histograms
+// Both must live in one view: every matching view creates its own stream, so a separate
+// bucket view would export those histograms twice
+func metricsView(boundaries []float64) sdkmetric.View {
+	exemplars := exemplarView()
+	return func(i sdkmetric.Instrument) (sdkmetric.Stream, bool) {
+		stream, ok := exemplars(i)
+		if ok && len(boundaries) > 0 && usesConfiguredBuckets(i) {
+			stream.Aggregation = sdkmetric.AggregationExplicitBucketHistogram{Boundaries: boundaries}
+		}
+		return stream, ok
+	}
+}
+
+func usesConfiguredBuckets(i sdkmetric.Instrument) bool {
+	if i.Kind != sdkmetric.InstrumentKindHistogram {
+		return false
+	}
+	for _, suffix := range bucketedHistogramSuffixes {
+		if strings.HasSuffix(i.Name, suffix) {
+			return true
+		}
+	}
+	return false
+}
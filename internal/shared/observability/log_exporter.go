@@ -0,0 +1,111 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/noop"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// LogProvider wraps the OpenTelemetry log provider
+type LogProvider struct {
+	provider *sdklog.LoggerProvider
+}
+
+// NewLogExporter initializes an OpenTelemetry log provider exporting over OTLP HTTP
+// If observability or log export is disabled, returns a noop provider
+// Records are batched and exported in background to avoid blocking the caller
+func NewLogExporter(cfg ConfigProvider) (*LogProvider, error) {
+	if !cfg.GetOtelEnabled() || !cfg.GetOtelLogsEnabled() {
+		log.Println("OpenTelemetry logs export is disabled")
+		return &LogProvider{}, nil
+	}
+
+	exporter, err := otlploghttp.New(
+		context.Background(),
+		otlploghttp.WithEndpoint(cfg.GetJaegerEndpoint()),
+		otlploghttp.WithInsecure(), // Use insecure for local development
+		otlploghttp.WithCompression(otlploghttp.GzipCompression),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP log exporter: %w", err)
+	}
+
+	// Create resource with service information (plus auto-detected attributes)
+	res, err := newResource(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reuse the span batching settings for log records
+	batchTimeout := cfg.GetOtelBatchTimeout()
+	if batchTimeout == 0 {
+		batchTimeout = 5 // Default: 5 seconds
+	}
+	maxExportBatchSize := cfg.GetOtelMaxExportBatchSize()
+	if maxExportBatchSize == 0 {
+		maxExportBatchSize = 512 // Default: 512 records
+	}
+	maxQueueSize := cfg.GetOtelMaxQueueSize()
+	if maxQueueSize == 0 {
+		maxQueueSize = 2048 // Default: 2048 records
+	}
+	exportTimeout := cfg.GetOtelExportTimeout()
+	if exportTimeout == 0 {
+		exportTimeout = 30 // Default: 30 seconds
+	}
+
+	processor := sdklog.NewBatchProcessor(
+		exporter,
+		sdklog.WithExportInterval(time.Duration(batchTimeout)*time.Second),
+		sdklog.WithExportMaxBatchSize(maxExportBatchSize),
+		sdklog.WithMaxQueueSize(maxQueueSize),
+		sdklog.WithExportTimeout(time.Duration(exportTimeout)*time.Second),
+	)
+
+	lp := sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(processor),
+		sdklog.WithResource(res),
+	)
+
+	log.Printf("OpenTelemetry logs initialized: service=%s, endpoint=%s", cfg.GetOtelServiceName(), cfg.GetJaegerEndpoint())
+
+	return &LogProvider{
+		provider: lp,
+	}, nil
+}
+
+// Enabled reports whether log records are exported
+func (lp *LogProvider) Enabled() bool {
+	return lp.provider != nil
+}
+
+// GetProvider returns the log provider to bridge the application logger with
+// A noop provider is returned when log export is disabled
+func (lp *LogProvider) GetProvider() otellog.LoggerProvider {
+	if lp.provider == nil {
+		return noop.NewLoggerProvider()
+	}
+	return lp.provider
+}
+
+// Shutdown gracefully shuts down the log provider
+// This ensures all log records are flushed before application exit
+func (lp *LogProvider) Shutdown(ctx context.Context) error {
+	if lp.provider == nil {
+		return nil
+	}
+
+	log.Println("Shutting down OpenTelemetry log provider...")
+	if err := lp.provider.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shutdown log provider: %w", err)
+	}
+
+	log.Println("OpenTelemetry log provider shut down successfully")
+	return nil
+}
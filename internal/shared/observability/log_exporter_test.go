@@ -0,0 +1,76 @@
+//go:build test
+
+package observability_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/refortunato/go_app_base/configs"
+	"github.com/refortunato/go_app_base/internal/shared/observability"
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+func TestNewLogExporter_Disabled(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *configs.Conf
+	}{
+		{name: "otel disabled", cfg: configs.NewTestConfig()},
+		{name: "logs disabled", cfg: configs.NewTestConfig(configs.WithOtelEnabled())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lp, err := observability.NewLogExporter(tt.cfg)
+			if err != nil {
+				t.Fatalf("NewLogExporter: %v", err)
+			}
+			if lp.Enabled() {
+				t.Error("log export enabled")
+			}
+			// The noop provider can still be bridged to the logger
+			lp.GetProvider().Logger("test").Emit(context.Background(), otellog.Record{})
+			if err := lp.Shutdown(context.Background()); err != nil {
+				t.Errorf("Shutdown: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewLogExporter_ShutdownFlushesToCollector(t *testing.T) {
+	var exports atomic.Int32
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/logs" {
+			exports.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	cfg := configs.NewTestConfig(configs.WithOtelEnabled())
+	cfg.OtelLogsEnabled = true
+	cfg.JaegerEndpoint = strings.TrimPrefix(collector.URL, "http://")
+
+	lp, err := observability.NewLogExporter(cfg)
+	if err != nil {
+		t.Fatalf("NewLogExporter: %v", err)
+	}
+	if !lp.Enabled() {
+		t.Fatal("log export disabled")
+	}
+
+	var record otellog.Record
+	record.SetBody(otellog.StringValue("Product created"))
+	lp.GetProvider().Logger("test").Emit(context.Background(), record)
+
+	if err := lp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if exports.Load() != 1 {
+		t.Errorf("exports to /v1/logs = %d, want the pending record flushed on Shutdown", exports.Load())
+	}
+}
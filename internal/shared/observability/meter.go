@@ -6,23 +6,49 @@ import (
 	"log"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
-	"go.opentelemetry.io/otel/sdk/resource"
-	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
 )
 
 // MeterProvider wraps the OpenTelemetry meter provider
 type MeterProvider struct {
 	provider *sdkmetric.MeterProvider
+	// registry holds the metrics in Prometheus format (set by WithPrometheusRegistry)
+	registry *prometheus.Registry
+}
+
+// MeterProviderOption customizes NewMeterProvider
+type MeterProviderOption func(*meterProviderOptions)
+
+type meterProviderOptions struct {
+	grpcEndpoint       string
+	prometheusRegistry bool
+}
+
+// WithGRPCExporter exports metrics over OTLP gRPC to endpoint, whatever the configured protocol
+func WithGRPCExporter(endpoint string) MeterProviderOption {
+	return func(o *meterProviderOptions) {
+		o.grpcEndpoint = endpoint
+	}
+}
+
+// WithPrometheusRegistry also collects metrics into a Prometheus registry (used by PushGatewayReporter)
+func WithPrometheusRegistry() MeterProviderOption {
+	return func(o *meterProviderOptions) {
+		o.prometheusRegistry = true
+	}
 }
 
 // NewMeterProvider initializes a new OpenTelemetry meter provider
 // If observability is disabled, returns a noop provider
 // Uses non-blocking batch processing to avoid I/O overhead
-func NewMeterProvider(cfg ConfigProvider) (*MeterProvider, error) {
+func NewMeterProvider(cfg ConfigProvider, opts ...MeterProviderOption) (*MeterProvider, error) {
 	if !cfg.GetOtelEnabled() {
 		log.Println("OpenTelemetry metrics is disabled")
 		return &MeterProvider{
@@ -30,28 +56,24 @@ func NewMeterProvider(cfg ConfigProvider) (*MeterProvider, error) {
 		}, nil
 	}
 
-	// Create OTLP HTTP exporter for metrics with compression
-	exporter, err := otlpmetrichttp.New(
-		context.Background(),
-		otlpmetrichttp.WithEndpoint(cfg.GetJaegerEndpoint()),
-		otlpmetrichttp.WithInsecure(),
-		otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression),
-	)
+	options := meterProviderOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if err := ValidateHistogramBuckets(cfg.GetOtelHistogramBuckets()); err != nil {
+		return nil, err
+	}
+
+	exporter, endpoint, err := newMetricExporter(cfg, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create OTLP metric exporter: %w", err)
 	}
 
-	// Create resource with service information
-	res, err := resource.New(
-		context.Background(),
-		resource.WithAttributes(
-			semconv.ServiceName(cfg.GetOtelServiceName()),
-			semconv.ServiceVersion("1.0.0"),
-			semconv.DeploymentEnvironment(cfg.GetEnvironment()),
-		),
-	)
+	// Create resource with service information (plus auto-detected attributes)
+	res, err := newResource(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create resource: %w", err)
+		return nil, err
 	}
 
 	// Get metric export interval (default 10 seconds for lower overhead)
@@ -74,23 +96,75 @@ func NewMeterProvider(cfg ConfigProvider) (*MeterProvider, error) {
 		sdkmetric.WithTimeout(time.Duration(exportTimeout)*time.Second),
 	)
 
-	// Create meter provider with async reader
-	mp := sdkmetric.NewMeterProvider(
+	providerOptions := []sdkmetric.Option{
 		sdkmetric.WithResource(res),
 		sdkmetric.WithReader(reader),
-	)
+		sdkmetric.WithView(metricsView(cfg.GetOtelHistogramBuckets())),
+	}
+
+	// Record an exemplar for every observation (default: only for sampled traces)
+	if cfg.GetOtelExemplarsEnabled() {
+		providerOptions = append(providerOptions, sdkmetric.WithExemplarFilter(exemplar.AlwaysOnFilter))
+	}
+
+	// Optional Prometheus reader (metrics are gathered on demand, e.g. when pushed)
+	var registry *prometheus.Registry
+	if options.prometheusRegistry {
+		registry = prometheus.NewRegistry()
+		promExporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Prometheus exporter: %w", err)
+		}
+		providerOptions = append(providerOptions, sdkmetric.WithReader(promExporter))
+	}
+
+	// Create meter provider with async reader
+	mp := sdkmetric.NewMeterProvider(providerOptions...)
 
 	// Set global meter provider
 	otel.SetMeterProvider(mp)
 
 	log.Printf("OpenTelemetry metrics initialized: service=%s, endpoint=%s, interval=%ds",
-		cfg.GetOtelServiceName(), cfg.GetJaegerEndpoint(), exportInterval)
+		cfg.GetOtelServiceName(), endpoint, exportInterval)
 
 	return &MeterProvider{
 		provider: mp,
+		registry: registry,
 	}, nil
 }
 
+// newMetricExporter creates the OTLP exporter selected by options or the configured protocol
+// (HTTP to JaegerEndpoint by default) and returns the endpoint it sends to
+func newMetricExporter(cfg ConfigProvider, options meterProviderOptions) (sdkmetric.Exporter, string, error) {
+	grpcExporter, err := useGRPCExporter(cfg)
+	if err != nil {
+		return nil, "", err
+	}
+	if grpcExporter && options.grpcEndpoint == "" {
+		options.grpcEndpoint = cfg.GetOtelGRPCEndpoint()
+	}
+
+	if options.grpcEndpoint != "" {
+		// Create OTLP gRPC exporter for metrics with compression (connects lazily)
+		exporter, err := otlpmetricgrpc.New(
+			context.Background(),
+			otlpmetricgrpc.WithEndpoint(options.grpcEndpoint),
+			otlpmetricgrpc.WithInsecure(),
+			otlpmetricgrpc.WithCompressor("gzip"),
+		)
+		return exporter, options.grpcEndpoint, err
+	}
+
+	// Create OTLP HTTP exporter for metrics with compression
+	exporter, err := otlpmetrichttp.New(
+		context.Background(),
+		otlpmetrichttp.WithEndpoint(cfg.GetJaegerEndpoint()),
+		otlpmetrichttp.WithInsecure(),
+		otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression),
+	)
+	return exporter, cfg.GetJaegerEndpoint(), err
+}
+
 // Meter returns a named meter
 func (mp *MeterProvider) Meter(name string) metric.Meter {
 	return mp.provider.Meter(name)
@@ -0,0 +1,91 @@
+//go:build test
+
+package observability_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/refortunato/go_app_base/configs"
+	"github.com/refortunato/go_app_base/internal/shared/observability"
+)
+
+// listenCollector accepts connections on a local port, standing in for an OTLP collector,
+// and returns its address and a channel signalled when a connection is accepted
+func listenCollector(t *testing.T) (string, <-chan struct{}) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	dialed := make(chan struct{}, 1)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+			select {
+			case dialed <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return listener.Addr().String(), dialed
+}
+
+// recordAndShutdown records a measurement and shuts the provider down, which flushes it to the exporter
+func recordAndShutdown(t *testing.T, mp *observability.MeterProvider) {
+	t.Helper()
+	counter, err := mp.Meter("test").Int64Counter("test.counter")
+	if err != nil {
+		t.Fatalf("Int64Counter: %v", err)
+	}
+	counter.Add(context.Background(), 1)
+	// The collector drops every connection, so an enabled exporter fails to export
+	_ = mp.Shutdown(context.Background())
+}
+
+func TestNewMeterProvider_DisabledDialsNoExporter(t *testing.T) {
+	endpoint, dialed := listenCollector(t)
+	cfg := configs.NewTestConfig()
+	cfg.OtelExporterProtocol = "grpc"
+	cfg.OtelGRPCEndpoint = endpoint
+
+	mp, err := observability.NewMeterProvider(cfg, observability.WithGRPCExporter(endpoint))
+	if err != nil {
+		t.Fatalf("NewMeterProvider: %v", err)
+	}
+	recordAndShutdown(t, mp)
+
+	select {
+	case <-dialed:
+		t.Error("disabled meter provider dialed the exporter endpoint")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestNewMeterProvider_WithGRPCExporterDialsEndpoint(t *testing.T) {
+	endpoint, dialed := listenCollector(t)
+	// The configured protocol is HTTP: WithGRPCExporter must win over it
+	cfg := configs.NewTestConfig(configs.WithOtelEnabled())
+	cfg.JaegerEndpoint = "127.0.0.1:1"
+	cfg.OtelExportTimeout = 1
+
+	mp, err := observability.NewMeterProvider(cfg, observability.WithGRPCExporter(endpoint))
+	if err != nil {
+		t.Fatalf("NewMeterProvider: %v", err)
+	}
+	recordAndShutdown(t, mp)
+
+	select {
+	case <-dialed:
+	case <-time.After(5 * time.Second):
+		t.Error("meter provider did not dial the gRPC exporter endpoint")
+	}
+}
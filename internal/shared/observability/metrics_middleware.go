@@ -93,7 +93,11 @@ func MetricsMiddleware(serviceName, appName string) gin.HandlerFunc {
 
 		// Record metrics (all non-blocking, async aggregation)
 		requestCounter.Add(c.Request.Context(), 1, metric.WithAttributes(attrs...))
-		requestDuration.Record(c.Request.Context(), duration, metric.WithAttributes(attrs...))
+		// trace_id/span_id are filtered out of the series and kept on the exemplar
+		durationAttrs := append(attrs, CurrentExemplarAttrs(c.Request.Context())...)
+		requestDuration.Record(c.Request.Context(), duration,
+			metric.WithAttributeSet(attribute.NewSet(durationAttrs...)),
+		)
 
 		// Record response size with status code
 		responseSize.Record(c.Request.Context(), int64(c.Writer.Size()),
@@ -172,6 +176,31 @@ func (cm *CustomMetrics) FloatGauge(name, description, unit string, callback met
 	return err
 }
 
+// ObservableFloatGauge creates an observable float gauge without a callback (see RegisterCallback)
+func (cm *CustomMetrics) ObservableFloatGauge(name, description, unit string) (metric.Float64ObservableGauge, error) {
+	return cm.meter.Float64ObservableGauge(
+		name,
+		metric.WithDescription(description),
+		metric.WithUnit(unit),
+	)
+}
+
+// ObservableGauge creates an observable int gauge without a callback (see RegisterCallback)
+func (cm *CustomMetrics) ObservableGauge(name, description, unit string) (metric.Int64ObservableGauge, error) {
+	return cm.meter.Int64ObservableGauge(
+		name,
+		metric.WithDescription(description),
+		metric.WithUnit(unit),
+	)
+}
+
+// RegisterCallback reports several observable instruments from one callback,
+// for values computed together (e.g. by a single database query per collection)
+func (cm *CustomMetrics) RegisterCallback(callback metric.Callback, instruments ...metric.Observable) error {
+	_, err := cm.meter.RegisterCallback(callback, instruments...)
+	return err
+}
+
 // normalizeMetricPrefix converts app names to valid metric prefixes
 // Examples: "ms-registration" -> "ms_registration", "go_app_base" -> "go_app_base"
 func normalizeMetricPrefix(appName string) string {
@@ -0,0 +1,39 @@
+package observability
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// PanicAttributes describes a recovered panic for span events: panic.value (formatted
+// with %+v), goroutine.id (parsed from stack, omitted when not found) and panic.stack
+// stack is the output of debug.Stack or runtime.Stack taken in the panicking goroutine
+func PanicAttributes(r interface{}, stack []byte) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("panic.value", fmt.Sprintf("%+v", r)),
+	}
+	if id, ok := GoroutineID(stack); ok {
+		attrs = append(attrs, attribute.Int64("goroutine.id", id))
+	}
+	return append(attrs, attribute.String("panic.stack", string(stack)))
+}
+
+// GoroutineID parses the goroutine ID from the "goroutine 42 [running]:" header of a stack trace
+func GoroutineID(stack []byte) (int64, bool) {
+	rest, ok := bytes.CutPrefix(stack, []byte("goroutine "))
+	if !ok {
+		return 0, false
+	}
+	digits, _, ok := bytes.Cut(rest, []byte(" "))
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(string(digits), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
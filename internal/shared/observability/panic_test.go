@@ -0,0 +1,65 @@
+package observability
+
+import (
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestGoroutineID(t *testing.T) {
+	tests := []struct {
+		stack  string
+		want   int64
+		wantOk bool
+	}{
+		{stack: "goroutine 42 [running]:\nmain.main()", want: 42, wantOk: true},
+		{stack: "goroutine 1 [chan receive]:", want: 1, wantOk: true},
+		{stack: "", wantOk: false},
+		{stack: "goroutine", wantOk: false},
+		{stack: "goroutine abc [running]:", wantOk: false},
+		{stack: "panic: boom\ngoroutine 7 [running]:", wantOk: false},
+	}
+	for _, tt := range tests {
+		id, ok := GoroutineID([]byte(tt.stack))
+		if id != tt.want || ok != tt.wantOk {
+			t.Errorf("GoroutineID(%q) = %d, %v, want %d, %v", tt.stack, id, ok, tt.want, tt.wantOk)
+		}
+	}
+
+	if id, ok := GoroutineID(debug.Stack()); !ok || id <= 0 {
+		t.Errorf("GoroutineID(debug.Stack()) = %d, %v, want the current goroutine", id, ok)
+	}
+}
+
+func TestPanicAttributes(t *testing.T) {
+	type cart struct{ Items int }
+	stack := []byte("goroutine 42 [running]:\nmain.handler()")
+
+	attrs := PanicAttributes(&cart{Items: 3}, stack)
+	want := []attribute.KeyValue{
+		attribute.String("panic.value", "&{Items:3}"),
+		attribute.Int64("goroutine.id", 42),
+		attribute.String("panic.stack", string(stack)),
+	}
+	if len(attrs) != len(want) {
+		t.Fatalf("attributes = %v, want %v", attrs, want)
+	}
+	for i := range want {
+		if attrs[i] != want[i] {
+			t.Errorf("attribute %d = %v, want %v", i, attrs[i], want[i])
+		}
+	}
+
+	// Without a goroutine header the ID is omitted
+	attrs = PanicAttributes("boom", []byte("no header"))
+	for _, attr := range attrs {
+		if attr.Key == "goroutine.id" {
+			t.Errorf("goroutine.id = %v, want it omitted", attr.Value.AsInt64())
+		}
+	}
+	if len(attrs) != 2 || !strings.Contains(attrs[0].Value.AsString(), "boom") {
+		t.Errorf("attributes = %v, want the panic value and stack", attrs)
+	}
+}
@@ -0,0 +1,51 @@
+//go:build test
+
+package observability_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/refortunato/go_app_base/configs"
+	"github.com/refortunato/go_app_base/internal/shared/observability"
+)
+
+func TestProviders_DisabledAreNoop(t *testing.T) {
+	cfg := configs.NewFakeConfigProvider()
+
+	tracerProvider, err := observability.NewTracerProvider(cfg)
+	if err != nil {
+		t.Fatalf("NewTracerProvider: %v", err)
+	}
+	_, span := tracerProvider.Tracer("test").Start(context.Background(), "operation")
+	if span.SpanContext().IsSampled() {
+		t.Error("disabled tracer provider sampled a span")
+	}
+	span.End()
+
+	meterProvider, err := observability.NewMeterProvider(cfg)
+	if err != nil {
+		t.Fatalf("NewMeterProvider: %v", err)
+	}
+	counter, err := meterProvider.Meter("test").Int64Counter("test.counter")
+	if err != nil {
+		t.Fatalf("Int64Counter: %v", err)
+	}
+	counter.Add(context.Background(), 1)
+
+	if err := tracerProvider.Shutdown(context.Background()); err != nil {
+		t.Errorf("tracer Shutdown: %v", err)
+	}
+	if err := meterProvider.Shutdown(context.Background()); err != nil {
+		t.Errorf("meter Shutdown: %v", err)
+	}
+}
+
+func TestNewTracerProvider_RejectsInvalidSamplingRules(t *testing.T) {
+	cfg := configs.NewTestConfig(configs.WithOtelEnabled())
+	cfg.OtelSamplingRules = []observability.SamplingRule{{Pattern: "", Rate: 0.5}}
+
+	if _, err := observability.NewTracerProvider(cfg); err == nil {
+		t.Fatal("expected an error for a sampling rule without pattern")
+	}
+}
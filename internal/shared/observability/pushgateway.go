@@ -0,0 +1,84 @@
+package observability
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// PushGatewayReporter periodically pushes the application metrics to a Prometheus Pushgateway
+// Intended for short-lived jobs that cannot be scraped
+type PushGatewayReporter struct {
+	pusher   *push.Pusher
+	interval time.Duration
+
+	cancel   context.CancelFunc
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewPushGatewayReporter creates a reporter pushing mp's metrics to gateway under jobName
+// mp must be created with WithPrometheusRegistry, otherwise nothing is pushed
+func NewPushGatewayReporter(gateway, jobName string, interval time.Duration, mp *MeterProvider) *PushGatewayReporter {
+	var gatherer prometheus.Gatherer = prometheus.NewRegistry()
+	if mp != nil && mp.registry != nil {
+		gatherer = mp.registry
+	}
+	if interval <= 0 {
+		interval = 15 * time.Second
+	}
+
+	return &PushGatewayReporter{
+		pusher:   push.New(gateway, jobName).Gatherer(gatherer),
+		interval: interval,
+	}
+}
+
+// Start runs the push loop in a background goroutine until ctx is done or Stop is called
+func (r *PushGatewayReporter) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+	r.done = make(chan struct{})
+
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.push(ctx)
+			}
+		}
+	}()
+
+	log.Printf("Prometheus Pushgateway reporter started: interval=%s", r.interval)
+}
+
+// Stop stops the push loop and flushes one last push
+func (r *PushGatewayReporter) Stop() {
+	r.stopOnce.Do(func() {
+		if r.cancel != nil {
+			r.cancel()
+			<-r.done
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		r.push(ctx)
+	})
+}
+
+// push replaces the job's metrics on the gateway, logging failures (never blocks the application)
+func (r *PushGatewayReporter) push(ctx context.Context) {
+	if err := r.pusher.PushContext(ctx); err != nil {
+		log.Printf("Failed to push metrics to Pushgateway: %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+//go:build test
+
+package observability_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/refortunato/go_app_base/configs"
+	"github.com/refortunato/go_app_base/internal/shared/observability"
+)
+
+// pushRecorder is a fake Pushgateway keeping every push it receives
+type pushRecorder struct {
+	mu     sync.Mutex
+	pushes []recordedPush
+}
+
+type recordedPush struct {
+	method, path, body string
+}
+
+func (g *pushRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	g.mu.Lock()
+	g.pushes = append(g.pushes, recordedPush{method: r.Method, path: r.URL.Path, body: string(body)})
+	g.mu.Unlock()
+	w.WriteHeader(http.StatusOK)
+}
+
+func (g *pushRecorder) recorded() []recordedPush {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]recordedPush(nil), g.pushes...)
+}
+
+// newPushMeterProvider returns an enabled meter provider with a Prometheus registry
+// and a counter already incremented
+func newPushMeterProvider(t *testing.T) *observability.MeterProvider {
+	t.Helper()
+	cfg := configs.NewTestConfig(configs.WithOtelEnabled())
+	// Nothing listens on the OTLP endpoint: only the Prometheus registry is used
+	cfg.JaegerEndpoint = "127.0.0.1:1"
+	cfg.OtelExportTimeout = 1
+
+	mp, err := observability.NewMeterProvider(cfg, observability.WithPrometheusRegistry())
+	if err != nil {
+		t.Fatalf("NewMeterProvider: %v", err)
+	}
+	t.Cleanup(func() { _ = mp.Shutdown(context.Background()) })
+
+	counter, err := mp.Meter("test").Int64Counter("jobs.processed")
+	if err != nil {
+		t.Fatalf("Int64Counter: %v", err)
+	}
+	counter.Add(context.Background(), 3)
+	return mp
+}
+
+func TestPushGatewayReporter_StopFlushesMetrics(t *testing.T) {
+	gateway := &pushRecorder{}
+	server := httptest.NewServer(gateway)
+	defer server.Close()
+
+	reporter := observability.NewPushGatewayReporter(server.URL, "nightly_import", time.Hour, newPushMeterProvider(t))
+	reporter.Start(context.Background())
+	reporter.Stop()
+	reporter.Stop()
+
+	pushes := gateway.recorded()
+	if len(pushes) != 1 {
+		t.Fatalf("pushes = %d, want a single push on Stop", len(pushes))
+	}
+	if pushes[0].method != http.MethodPut || pushes[0].path != "/metrics/job/nightly_import" {
+		t.Errorf("push = %s %s, want PUT /metrics/job/nightly_import", pushes[0].method, pushes[0].path)
+	}
+	if !strings.Contains(pushes[0].body, "jobs_processed_total") {
+		t.Errorf("push payload does not contain jobs_processed_total: %q", pushes[0].body)
+	}
+}
+
+func TestPushGatewayReporter_PushesEveryInterval(t *testing.T) {
+	gateway := &pushRecorder{}
+	server := httptest.NewServer(gateway)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	reporter := observability.NewPushGatewayReporter(server.URL, "worker", 10*time.Millisecond, newPushMeterProvider(t))
+	reporter.Start(ctx)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(gateway.recorded()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	reporter.Stop()
+
+	pushes := gateway.recorded()
+	if len(pushes) < 3 {
+		t.Fatalf("pushes = %d, want at least two periodic pushes and the final one", len(pushes))
+	}
+	for i, push := range pushes {
+		if !strings.Contains(push.body, "jobs_processed_total") {
+			t.Errorf("push %d payload does not contain jobs_processed_total", i)
+		}
+	}
+}
+
+func TestPushGatewayReporter_GatewayDownDoesNotBlockStop(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	reporter := observability.NewPushGatewayReporter(server.URL, "worker", time.Hour, newPushMeterProvider(t))
+	reporter.Start(context.Background())
+
+	stopped := make(chan struct{})
+	go func() {
+		reporter.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop blocked on a failing gateway")
+	}
+}
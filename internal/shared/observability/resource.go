@@ -0,0 +1,78 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"go.opentelemetry.io/contrib/detectors/gcp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// newResource builds the resource shared by traces, metrics and logs
+// When auto-detection is enabled, host, container, GCP and Kubernetes attributes are added
+// Detector failures are non-fatal and OTEL_RESOURCE_ATTRIBUTES/OTEL_SERVICE_NAME always apply last
+func newResource(cfg ConfigProvider) (*resource.Resource, error) {
+	opts := []resource.Option{
+		resource.WithAttributes(
+			semconv.ServiceName(cfg.GetOtelServiceName()),
+			semconv.ServiceVersion("1.0.0"),
+			semconv.DeploymentEnvironment(cfg.GetEnvironment()),
+		),
+	}
+
+	if cfg.GetOtelResourceAutoDetect() {
+		opts = append(opts,
+			resource.WithHost(),
+			resource.WithContainer(),
+			resource.WithDetectors(gcp.NewDetector(), k8sDetector{}),
+		)
+	}
+
+	// Environment overrides are applied last so they always win
+	opts = append(opts, resource.WithFromEnv())
+
+	res, err := resource.New(context.Background(), opts...)
+	if err != nil {
+		if res == nil {
+			return nil, fmt.Errorf("failed to create resource: %w", err)
+		}
+		// Partial resource (e.g. a detector failed or not running on that platform)
+		log.Printf("OpenTelemetry resource detection incomplete: %v", err)
+	}
+
+	return res, nil
+}
+
+// k8sDetector reads Kubernetes attributes exposed through the Downward API
+// (K8S_POD_NAME, K8S_NAMESPACE_NAME, K8S_NODE_NAME)
+// Inside a cluster without those variables, the pod name falls back to HOSTNAME
+type k8sDetector struct{}
+
+// Detect returns an empty resource when not running in Kubernetes
+func (k8sDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	if os.Getenv("KUBERNETES_SERVICE_HOST") == "" && os.Getenv("K8S_POD_NAME") == "" {
+		return resource.Empty(), nil
+	}
+
+	podName := os.Getenv("K8S_POD_NAME")
+	if podName == "" {
+		podName = os.Getenv("HOSTNAME")
+	}
+
+	var attrs []attribute.KeyValue
+	if podName != "" {
+		attrs = append(attrs, semconv.K8SPodName(podName))
+	}
+	if namespace := os.Getenv("K8S_NAMESPACE_NAME"); namespace != "" {
+		attrs = append(attrs, semconv.K8SNamespaceName(namespace))
+	}
+	if node := os.Getenv("K8S_NODE_NAME"); node != "" {
+		attrs = append(attrs, semconv.K8SNodeName(node))
+	}
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...), nil
+}
@@ -0,0 +1,108 @@
+//go:build test
+
+package observability_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/refortunato/go_app_base/configs"
+	"github.com/refortunato/go_app_base/internal/shared/observability"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// resourceAttributes returns the resource attributes of a span ended by a tracer provider built from cfg
+func resourceAttributes(t *testing.T, cfg *configs.Conf) map[string]string {
+	t.Helper()
+	tp, err := observability.NewTracerProvider(cfg)
+	if err != nil {
+		t.Fatalf("NewTracerProvider: %v", err)
+	}
+	spans := tracetest.NewSpanRecorder()
+	tp.GetProvider().RegisterSpanProcessor(spans)
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	_, span := tp.Tracer("test").Start(context.Background(), "operation")
+	span.End()
+
+	ended := spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("ended spans = %d, want 1", len(ended))
+	}
+	attrs := map[string]string{}
+	for _, kv := range ended[0].Resource().Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	return attrs
+}
+
+// newResourceConfig enables tracing towards an endpoint nothing listens on
+func newResourceConfig(autoDetect bool) *configs.Conf {
+	cfg := configs.NewTestConfig(configs.WithOtelEnabled())
+	cfg.JaegerEndpoint = "127.0.0.1:1"
+	cfg.OtelExportTimeout = 1
+	cfg.OtelResourceAutoDetect = autoDetect
+	return cfg
+}
+
+func TestNewTracerProvider_DetectsKubernetesAttributes(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantPod string
+	}{
+		{name: "downward API",
+			env:     map[string]string{"K8S_POD_NAME": "api-7d9f-x2k4", "K8S_NAMESPACE_NAME": "shop", "K8S_NODE_NAME": "node-1", "HOSTNAME": "ignored"},
+			wantPod: "api-7d9f-x2k4"},
+		{name: "pod name from HOSTNAME",
+			env:     map[string]string{"KUBERNETES_SERVICE_HOST": "10.0.0.1", "K8S_NAMESPACE_NAME": "shop", "K8S_NODE_NAME": "node-1", "HOSTNAME": "api-5c8b-q9w1"},
+			wantPod: "api-5c8b-q9w1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			attrs := resourceAttributes(t, newResourceConfig(true))
+
+			want := map[string]string{
+				"service.name":       "go_app_base_test",
+				"k8s.pod.name":       tt.wantPod,
+				"k8s.namespace.name": "shop",
+				"k8s.node.name":      "node-1",
+			}
+			for key, value := range want {
+				if attrs[key] != value {
+					t.Errorf("%s = %q, want %q", key, attrs[key], value)
+				}
+			}
+			if attrs["host.name"] == "" {
+				t.Error("host.name not detected")
+			}
+		})
+	}
+}
+
+func TestNewTracerProvider_ResourceAutoDetectDisabled(t *testing.T) {
+	t.Setenv("K8S_POD_NAME", "api-7d9f-x2k4")
+	attrs := resourceAttributes(t, newResourceConfig(false))
+
+	for _, key := range []string{"host.name", "k8s.pod.name"} {
+		if _, ok := attrs[key]; ok {
+			t.Errorf("%s detected with auto-detection disabled", key)
+		}
+	}
+	if attrs["service.name"] != "go_app_base_test" || attrs["deployment.environment"] != "test" {
+		t.Errorf("attributes = %v, want the configured service and environment", attrs)
+	}
+}
+
+func TestNewTracerProvider_EnvironmentOverridesResource(t *testing.T) {
+	t.Setenv("K8S_POD_NAME", "api-7d9f-x2k4")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "k8s.pod.name=override,team=checkout")
+	attrs := resourceAttributes(t, newResourceConfig(true))
+
+	if attrs["k8s.pod.name"] != "override" || attrs["team"] != "checkout" {
+		t.Errorf("k8s.pod.name, team = %q, %q, want the OTEL_RESOURCE_ATTRIBUTES values", attrs["k8s.pod.name"], attrs["team"])
+	}
+}
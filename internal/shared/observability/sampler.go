@@ -0,0 +1,86 @@
+package observability
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// SamplingRule samples the spans whose name matches Pattern at Rate (0.0-1.0)
+// Pattern is a glob where "*" matches any sequence (including "/") and "?" one character;
+// HTTP request spans are named after the route, e.g. "/api/v1/products/:id"
+type SamplingRule struct {
+	Pattern string  `json:"pattern"`
+	Rate    float64 `json:"rate"`
+}
+
+// ValidateSamplingRules returns an error if a rule has no pattern or a rate outside 0.0-1.0
+func ValidateSamplingRules(rules []SamplingRule) error {
+	for i, rule := range rules {
+		if rule.Pattern == "" {
+			return fmt.Errorf("sampling rule %d has no pattern", i)
+		}
+		if rule.Rate < 0 || rule.Rate > 1 {
+			return fmt.Errorf("sampling rule %q has rate %v, must be between 0.0 and 1.0", rule.Pattern, rule.Rate)
+		}
+	}
+	return nil
+}
+
+// patternSampler applies the rate of the first rule whose pattern matches the span name
+type patternSampler struct {
+	patterns    []*regexp.Regexp
+	samplers    []sdktrace.Sampler
+	fallback    sdktrace.Sampler
+	description string
+}
+
+// NewPatternSampler samples each span at the rate of the first rule (in order) whose
+// pattern matches the span name, and at defaultRate when no rule matches
+// It decides on the span name alone; wrap it in sdktrace.ParentBased so child spans
+// follow their root span
+func NewPatternSampler(rules []SamplingRule, defaultRate float64) sdktrace.Sampler {
+	s := &patternSampler{fallback: sdktrace.TraceIDRatioBased(defaultRate)}
+
+	descriptions := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		s.patterns = append(s.patterns, globToRegexp(rule.Pattern))
+		s.samplers = append(s.samplers, sdktrace.TraceIDRatioBased(rule.Rate))
+		descriptions = append(descriptions, fmt.Sprintf("%s=%g", rule.Pattern, rule.Rate))
+	}
+	s.description = fmt.Sprintf("PatternSampler{%s,default=%g}", strings.Join(descriptions, ","), defaultRate)
+	return s
+}
+
+func (s *patternSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	for i, pattern := range s.patterns {
+		if pattern.MatchString(p.Name) {
+			return s.samplers[i].ShouldSample(p)
+		}
+	}
+	return s.fallback.ShouldSample(p)
+}
+
+func (s *patternSampler) Description() string {
+	return s.description
+}
+
+// globToRegexp compiles a glob into an anchored regexp; every other character is literal
+func globToRegexp(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
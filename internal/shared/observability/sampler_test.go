@@ -0,0 +1,127 @@
+package observability
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// sampled reports whether sampler samples a root span named name
+func sampled(sampler sdktrace.Sampler, name string) bool {
+	result := sampler.ShouldSample(sdktrace.SamplingParameters{
+		ParentContext: context.Background(),
+		TraceID:       trace.TraceID{0x01, 0x02, 0x03},
+		Name:          name,
+		Kind:          trace.SpanKindServer,
+	})
+	return result.Decision == sdktrace.RecordAndSample
+}
+
+func TestPatternSampler_RulePrecedence(t *testing.T) {
+	sampler := NewPatternSampler([]SamplingRule{
+		{Pattern: "/health", Rate: 0},
+		{Pattern: "/api/*/products/:id", Rate: 1},
+		{Pattern: "/api/*", Rate: 0},
+	}, 1)
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "/health", want: false},
+		// Matches the second and third rules, the earlier one wins
+		{name: "/api/v1/products/:id", want: true},
+		{name: "/api/v1/products", want: false},
+		{name: "/api/v1/orders/:id", want: false},
+	}
+	for _, tt := range tests {
+		if got := sampled(sampler, tt.name); got != tt.want {
+			t.Errorf("%s sampled = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// The same rules in another order: the catch-all now shadows the product rule
+	reordered := NewPatternSampler([]SamplingRule{
+		{Pattern: "/api/*", Rate: 0},
+		{Pattern: "/api/*/products/:id", Rate: 1},
+	}, 1)
+	if sampled(reordered, "/api/v1/products/:id") {
+		t.Error("/api/v1/products/:id sampled, want the first matching rule to apply")
+	}
+}
+
+func TestPatternSampler_DefaultFallback(t *testing.T) {
+	rules := []SamplingRule{{Pattern: "/health", Rate: 0}}
+
+	for _, name := range []string{"/healthz", "/other", "GET /health", ""} {
+		if !sampled(NewPatternSampler(rules, 1), name) {
+			t.Errorf("%q not sampled, want the default rate 1", name)
+		}
+		if sampled(NewPatternSampler(rules, 0), name) {
+			t.Errorf("%q sampled, want the default rate 0", name)
+		}
+	}
+	if !sampled(NewPatternSampler(nil, 1), "/health") {
+		t.Error("no rules: /health not sampled, want the default rate")
+	}
+}
+
+func TestPatternSampler_Glob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "/api/*", name: "/api/v1/products/:id", want: true},
+		{pattern: "/api/v?/products", name: "/api/v2/products", want: true},
+		{pattern: "/api/v?/products", name: "/api/v10/products", want: false},
+		{pattern: "*/health", name: "/v1/health", want: true},
+		// Anything other than * and ? is literal
+		{pattern: "/api/v1.products", name: "/api/v1/products", want: false},
+		{pattern: "/products/:id", name: "/products/:id", want: true},
+	}
+	for _, tt := range tests {
+		sampler := NewPatternSampler([]SamplingRule{{Pattern: tt.pattern, Rate: 1}}, 0)
+		if got := sampled(sampler, tt.name); got != tt.want {
+			t.Errorf("pattern %q on %q matched = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPatternSampler_Description(t *testing.T) {
+	sampler := NewPatternSampler([]SamplingRule{{Pattern: "/health", Rate: 0.01}}, 1)
+	if got := sampler.Description(); got != "PatternSampler{/health=0.01,default=1}" {
+		t.Errorf("Description = %q", got)
+	}
+}
+
+func TestValidateSamplingRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []SamplingRule
+		wantErr string
+	}{
+		{name: "valid", rules: []SamplingRule{{Pattern: "/health", Rate: 0.01}, {Pattern: "*", Rate: 1}}},
+		{name: "none"},
+		{name: "no pattern", rules: []SamplingRule{{Rate: 0.5}}, wantErr: "no pattern"},
+		{name: "negative rate", rules: []SamplingRule{{Pattern: "/health", Rate: -0.1}}, wantErr: "/health"},
+		{name: "rate above 1", rules: []SamplingRule{{Pattern: "/health", Rate: 1.5}}, wantErr: "between 0.0 and 1.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSamplingRules(tt.rules)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -6,12 +6,12 @@ import (
 	"log"
 	"time"
 
+	"go.opentelemetry.io/contrib/propagators/b3"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/propagation"
-	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -26,6 +26,33 @@ type ConfigProvider interface {
 	GetOtelMaxQueueSize() int
 	GetOtelExportTimeout() int
 	GetOtelMetricExportInterval() int
+	GetOtelGRPCEndpoint() string
+	GetOtelExporterProtocol() string
+	GetOtelLogsEnabled() bool
+	GetOtelResourceAutoDetect() bool
+	GetOtelExemplarsEnabled() bool
+	GetOtelB3Enabled() bool
+	GetOtelHistogramBuckets() []float64
+	GetOtelSamplingRules() []SamplingRule
+}
+
+// OTLP exporter protocols (ConfigProvider.GetOtelExporterProtocol)
+const (
+	ExporterProtocolHTTP = "http"
+	ExporterProtocolGRPC = "grpc"
+)
+
+// useGRPCExporter reports whether traces and metrics are exported over OTLP gRPC
+// (empty means HTTP); unknown protocols are rejected so a typo does not go unnoticed
+func useGRPCExporter(cfg ConfigProvider) (bool, error) {
+	switch protocol := cfg.GetOtelExporterProtocol(); protocol {
+	case "", ExporterProtocolHTTP:
+		return false, nil
+	case ExporterProtocolGRPC:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unsupported OTLP exporter protocol %q (use %q or %q)", protocol, ExporterProtocolHTTP, ExporterProtocolGRPC)
+	}
 }
 
 // TracerProvider wraps the OpenTelemetry tracer provider
@@ -43,28 +70,19 @@ func NewTracerProvider(cfg ConfigProvider) (*TracerProvider, error) {
 		}, nil
 	}
 
-	// Create OTLP HTTP exporter for Jaeger with optimized settings
-	exporter, err := otlptracehttp.New(
-		context.Background(),
-		otlptracehttp.WithEndpoint(cfg.GetJaegerEndpoint()),
-		otlptracehttp.WithInsecure(),                                 // Use insecure for local development
-		otlptracehttp.WithCompression(otlptracehttp.GzipCompression), // Compress payloads
-	)
+	if err := ValidateSamplingRules(cfg.GetOtelSamplingRules()); err != nil {
+		return nil, err
+	}
+
+	exporter, endpoint, err := newTraceExporter(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
 	}
 
-	// Create resource with service information
-	res, err := resource.New(
-		context.Background(),
-		resource.WithAttributes(
-			semconv.ServiceName(cfg.GetOtelServiceName()),
-			semconv.ServiceVersion("1.0.0"),
-			semconv.DeploymentEnvironment(cfg.GetEnvironment()),
-		),
-	)
+	// Create resource with service information (plus auto-detected attributes)
+	res, err := newResource(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create resource: %w", err)
+		return nil, err
 	}
 
 	// Create batch span processor with optimized settings for non-blocking I/O
@@ -97,27 +115,67 @@ func NewTracerProvider(cfg ConfigProvider) (*TracerProvider, error) {
 	tp := sdktrace.NewTracerProvider(
 		sdktrace.WithSpanProcessor(batchProcessor),
 		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sdktrace.AlwaysSample()), // Sample all traces in development
+		sdktrace.WithSampler(newSampler(cfg.GetOtelSamplingRules())),
 	)
 
 	// Set global tracer provider
 	otel.SetTracerProvider(tp)
 
-	// Set global propagator for context propagation (W3C Trace Context)
-	otel.SetTextMapPropagator(
-		propagation.NewCompositeTextMapPropagator(
-			propagation.TraceContext{},
-			propagation.Baggage{},
-		),
-	)
+	// Set global propagator for context propagation (W3C Trace Context, optionally B3)
+	propagators := []propagation.TextMapPropagator{
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	}
+	if cfg.GetOtelB3Enabled() {
+		propagators = append(propagators, b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)))
+	}
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagators...))
 
-	log.Printf("OpenTelemetry tracing initialized: service=%s, endpoint=%s", cfg.GetOtelServiceName(), cfg.GetJaegerEndpoint())
+	log.Printf("OpenTelemetry tracing initialized: service=%s, endpoint=%s", cfg.GetOtelServiceName(), endpoint)
 
 	return &TracerProvider{
 		provider: tp,
 	}, nil
 }
 
+// newSampler samples root spans by route pattern when rules are configured and every
+// trace otherwise; child spans (and spans continuing a remote trace) follow their parent
+func newSampler(rules []SamplingRule) sdktrace.Sampler {
+	if len(rules) == 0 {
+		return sdktrace.AlwaysSample() // Sample all traces in development
+	}
+	return sdktrace.ParentBased(NewPatternSampler(rules, 1.0))
+}
+
+// newTraceExporter creates the OTLP exporter selected by the configured protocol
+// (HTTP to JaegerEndpoint by default) and returns the endpoint it sends to
+func newTraceExporter(cfg ConfigProvider) (sdktrace.SpanExporter, string, error) {
+	grpcExporter, err := useGRPCExporter(cfg)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if grpcExporter {
+		// Create OTLP gRPC exporter (connects lazily, so an unreachable collector never blocks startup)
+		exporter, err := otlptracegrpc.New(
+			context.Background(),
+			otlptracegrpc.WithEndpoint(cfg.GetOtelGRPCEndpoint()),
+			otlptracegrpc.WithInsecure(),
+			otlptracegrpc.WithCompressor("gzip"),
+		)
+		return exporter, cfg.GetOtelGRPCEndpoint(), err
+	}
+
+	// Create OTLP HTTP exporter for Jaeger with optimized settings
+	exporter, err := otlptracehttp.New(
+		context.Background(),
+		otlptracehttp.WithEndpoint(cfg.GetJaegerEndpoint()),
+		otlptracehttp.WithInsecure(),                                 // Use insecure for local development
+		otlptracehttp.WithCompression(otlptracehttp.GzipCompression), // Compress payloads
+	)
+	return exporter, cfg.GetJaegerEndpoint(), err
+}
+
 // Tracer returns a named tracer
 func (tp *TracerProvider) Tracer(name string) trace.Tracer {
 	return tp.provider.Tracer(name)
@@ -0,0 +1,192 @@
+//go:build test
+
+package observability_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/shared/observability"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+)
+
+func TestNewTracerProvider_B3Propagation(t *testing.T) {
+	const traceID = "463ac35c9f6413ad48485a3953bb6124"
+	previous := otel.GetTextMapPropagator()
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+
+	for _, b3Enabled := range []bool{true, false} {
+		cfg := newResourceConfig(false)
+		cfg.OtelB3Enabled = b3Enabled
+		tp, err := observability.NewTracerProvider(cfg)
+		if err != nil {
+			t.Fatalf("NewTracerProvider: %v", err)
+		}
+		_ = tp.Shutdown(context.Background())
+
+		headers := http.Header{}
+		headers.Set("X-B3-TraceId", traceID)
+		headers.Set("X-B3-SpanId", "a2fb4a1d1a96d312")
+		headers.Set("X-B3-Sampled", "1")
+		sc := trace.SpanContextFromContext(otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(headers)))
+
+		if extracted := sc.TraceID().String() == traceID; extracted != b3Enabled {
+			t.Errorf("b3 enabled = %v: extracted trace %s", b3Enabled, sc.TraceID())
+		}
+
+		// With B3 enabled, outgoing requests carry both W3C Trace Context and B3 headers
+		injected := http.Header{}
+		otel.GetTextMapPropagator().Inject(trace.ContextWithRemoteSpanContext(context.Background(), sc), propagation.HeaderCarrier(injected))
+		if b3Enabled && (injected.Get("traceparent") == "" || injected.Get("X-B3-TraceId") != traceID) {
+			t.Errorf("injected %v, want traceparent and X-B3-TraceId", injected)
+		}
+	}
+}
+
+// fakeTraceCollector is an OTLP gRPC trace collector sending the name of every span it receives
+type fakeTraceCollector struct {
+	collectortrace.UnimplementedTraceServiceServer
+	spans chan string
+}
+
+func (c *fakeTraceCollector) Export(_ context.Context, req *collectortrace.ExportTraceServiceRequest) (*collectortrace.ExportTraceServiceResponse, error) {
+	for _, resourceSpans := range req.GetResourceSpans() {
+		for _, scopeSpans := range resourceSpans.GetScopeSpans() {
+			for _, span := range scopeSpans.GetSpans() {
+				c.spans <- span.GetName()
+			}
+		}
+	}
+	return &collectortrace.ExportTraceServiceResponse{}, nil
+}
+
+// startTraceCollector serves a fake collector on a local port and returns its address
+func startTraceCollector(t *testing.T) (string, <-chan string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	collector := &fakeTraceCollector{spans: make(chan string, 10)}
+	server := grpc.NewServer()
+	collectortrace.RegisterTraceServiceServer(server, collector)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String(), collector.spans
+}
+
+// keepTracerProvider restores the global tracer provider replaced by NewTracerProvider
+func keepTracerProvider(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+}
+
+func TestNewTracerProvider_ExportsOverGRPC(t *testing.T) {
+	keepTracerProvider(t)
+	endpoint, spans := startTraceCollector(t)
+	cfg := newResourceConfig(false)
+	cfg.OtelExporterProtocol = observability.ExporterProtocolGRPC
+	cfg.OtelGRPCEndpoint = endpoint
+	cfg.OtelExportTimeout = 5
+
+	tp, err := observability.NewTracerProvider(cfg)
+	if err != nil {
+		t.Fatalf("NewTracerProvider: %v", err)
+	}
+	_, span := tp.Tracer("test").Start(context.Background(), "checkout")
+	span.End()
+	// Shutdown flushes the batch to the collector
+	if err := tp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	select {
+	case name := <-spans:
+		if name != "checkout" {
+			t.Errorf("collector received span %q, want checkout", name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("collector received no span")
+	}
+}
+
+func TestNewTracerProvider_ExportsOverHTTPByDefault(t *testing.T) {
+	keepTracerProvider(t)
+	requests := make(chan string, 10)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+	cfg := newResourceConfig(false)
+	cfg.OtelExporterProtocol = ""
+	cfg.JaegerEndpoint = strings.TrimPrefix(collector.URL, "http://")
+	cfg.OtelGRPCEndpoint = "127.0.0.1:1"
+	cfg.OtelExportTimeout = 5
+
+	tp, err := observability.NewTracerProvider(cfg)
+	if err != nil {
+		t.Fatalf("NewTracerProvider: %v", err)
+	}
+	_, span := tp.Tracer("test").Start(context.Background(), "checkout")
+	span.End()
+	if err := tp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	select {
+	case path := <-requests:
+		if path != "/v1/traces" {
+			t.Errorf("collector path = %q, want /v1/traces", path)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("HTTP collector received no request")
+	}
+}
+
+func TestNewTracerProvider_RejectsUnknownProtocol(t *testing.T) {
+	cfg := newResourceConfig(false)
+	cfg.OtelExporterProtocol = "thrift"
+
+	if _, err := observability.NewTracerProvider(cfg); err == nil || !strings.Contains(err.Error(), "thrift") {
+		t.Errorf("err = %v, want the unsupported protocol rejected", err)
+	}
+}
+
+func TestNewTracerProvider_SamplingRules(t *testing.T) {
+	keepTracerProvider(t)
+	cfg := newResourceConfig(false)
+	cfg.OtelSamplingRules = []observability.SamplingRule{{Pattern: "/health", Rate: 0}, {Pattern: "/api/*", Rate: 1}}
+
+	tp, err := observability.NewTracerProvider(cfg)
+	if err != nil {
+		t.Fatalf("NewTracerProvider: %v", err)
+	}
+	t.Cleanup(func() { tp.Shutdown(context.Background()) })
+	tracer := tp.Tracer("test")
+
+	_, health := tracer.Start(context.Background(), "/health")
+	if health.SpanContext().IsSampled() {
+		t.Error("/health sampled, want its rate 0")
+	}
+	ctx, products := tracer.Start(context.Background(), "/api/v1/products")
+	if !products.SpanContext().IsSampled() {
+		t.Error("/api/v1/products not sampled, want its rate 1")
+	}
+	// Child spans follow their root, whatever their name
+	if _, child := tracer.Start(ctx, "/health"); !child.SpanContext().IsSampled() {
+		t.Error("child of a sampled span not sampled")
+	}
+	if _, other := tracer.Start(context.Background(), "/other"); !other.SpanContext().IsSampled() {
+		t.Error("/other not sampled, want the default of sampling every trace")
+	}
+}
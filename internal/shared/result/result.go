@@ -0,0 +1,66 @@
+package result
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Result holds either a value (Ok) or an error (Err)
+// It lets use cases return a single value that callers branch on with IsOk
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok creates a successful result holding value
+func Ok[T any](value T) Result[T] {
+	return Result[T]{value: value}
+}
+
+// Err creates a failed result holding err (a nil err is treated as an unknown failure)
+func Err[T any](err error) Result[T] {
+	if err == nil {
+		err = errors.New("result: Err called with a nil error")
+	}
+	return Result[T]{err: err}
+}
+
+// IsOk reports whether the result holds a value
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// Unwrap returns the value; it panics when the result is an Err, so check IsOk first
+func (r Result[T]) Unwrap() T {
+	if r.err != nil {
+		panic(fmt.Sprintf("result: Unwrap called on an Err result: %v", r.err))
+	}
+	return r.value
+}
+
+// UnwrapErr returns the error, or nil when the result is Ok
+func (r Result[T]) UnwrapErr() error {
+	return r.err
+}
+
+// Map applies fn to the value of an Ok result; an Err result is passed through unchanged
+func Map[T, U any](r Result[T], fn func(T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return Ok(fn(r.value))
+}
+
+// Collect splits results into their values and errors, keeping the order of each
+func Collect[T any](results []Result[T]) ([]T, []error) {
+	values := make([]T, 0, len(results))
+	var errs []error
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		values = append(values, r.value)
+	}
+	return values, errs
+}
@@ -0,0 +1,95 @@
+package result
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestOk(t *testing.T) {
+	r := Ok(42)
+
+	if !r.IsOk() {
+		t.Fatal("IsOk = false, want true")
+	}
+	if r.Unwrap() != 42 {
+		t.Errorf("Unwrap = %d, want 42", r.Unwrap())
+	}
+	if r.UnwrapErr() != nil {
+		t.Errorf("UnwrapErr = %v, want nil", r.UnwrapErr())
+	}
+}
+
+func TestErr(t *testing.T) {
+	notFound := errors.New("not found")
+	r := Err[int](notFound)
+
+	if r.IsOk() {
+		t.Fatal("IsOk = true, want false")
+	}
+	if r.UnwrapErr() != notFound {
+		t.Errorf("UnwrapErr = %v, want %v", r.UnwrapErr(), notFound)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Unwrap on an Err did not panic")
+		}
+	}()
+	r.Unwrap()
+}
+
+func TestErr_NilErrorIsStillAnErr(t *testing.T) {
+	r := Err[int](nil)
+
+	if r.IsOk() || r.UnwrapErr() == nil {
+		t.Errorf("Err(nil) = ok %v, err %v, want a failed result", r.IsOk(), r.UnwrapErr())
+	}
+}
+
+func TestMap(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		r := Map(Ok(7), strconv.Itoa)
+		if !r.IsOk() || r.Unwrap() != "7" {
+			t.Errorf("Map = %v, %v, want Ok(\"7\")", r.value, r.err)
+		}
+	})
+
+	t.Run("err", func(t *testing.T) {
+		notFound := errors.New("not found")
+		called := false
+		r := Map(Err[int](notFound), func(v int) string {
+			called = true
+			return strconv.Itoa(v)
+		})
+		if called {
+			t.Error("fn called for an Err result")
+		}
+		if r.IsOk() || r.UnwrapErr() != notFound {
+			t.Errorf("Map = %v, %v, want the original error", r.value, r.err)
+		}
+	})
+}
+
+func TestCollect(t *testing.T) {
+	first, second := errors.New("first"), errors.New("second")
+	tests := []struct {
+		name       string
+		results    []Result[int]
+		wantValues []int
+		wantErrs   []error
+	}{
+		{name: "empty", wantValues: []int{}},
+		{name: "all ok", results: []Result[int]{Ok(1), Ok(2)}, wantValues: []int{1, 2}},
+		{name: "mixed", results: []Result[int]{Ok(1), Err[int](first), Ok(3), Err[int](second)}, wantValues: []int{1, 3}, wantErrs: []error{first, second}},
+		{name: "all errors", results: []Result[int]{Err[int](first)}, wantValues: []int{}, wantErrs: []error{first}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, errs := Collect(tt.results)
+			if !reflect.DeepEqual(values, tt.wantValues) || !reflect.DeepEqual(errs, tt.wantErrs) {
+				t.Errorf("Collect = %v, %v, want %v, %v", values, errs, tt.wantValues, tt.wantErrs)
+			}
+		})
+	}
+}
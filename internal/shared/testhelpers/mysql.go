@@ -0,0 +1,92 @@
+//go:build integration
+
+package testhelpers
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/refortunato/go_app_base/configs"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	mysqlImage    = "mysql:8.0"
+	mysqlPassword = "integration"
+	mysqlDatabase = "go_app_base" // created by schema.sql
+)
+
+// NewMySQLConfigForTest starts a MySQL container initialized with schema.sql and returns a test
+// configuration pointing at it. The container is removed when the test finishes
+// Requires Docker. Run with: go test -tags integration ./...
+func NewMySQLConfigForTest(t *testing.T) *configs.Conf {
+	t.Helper()
+	ctx := context.Background()
+
+	mysql, err := testcontainers.Run(ctx, mysqlImage,
+		testcontainers.WithExposedPorts("3306/tcp"),
+		testcontainers.WithEnv(map[string]string{
+			"MYSQL_ROOT_PASSWORD": mysqlPassword,
+			"MYSQL_DATABASE":      mysqlDatabase,
+		}),
+		testcontainers.WithFiles(testcontainers.ContainerFile{
+			HostFilePath:      schemaPath(),
+			ContainerFilePath: "/docker-entrypoint-initdb.d/schema.sql",
+			FileMode:          0o644,
+		}),
+		// Logged once the init scripts have run and the server accepts TCP connections
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("port: 3306  MySQL Community Server").WithStartupTimeout(3*time.Minute),
+		),
+	)
+	testcontainers.CleanupContainer(t, mysql)
+	if err != nil {
+		t.Fatalf("failed to start mysql container: %v", err)
+	}
+
+	host, err := mysql.Host(ctx)
+	if err != nil {
+		t.Fatalf("failed to read the mysql host: %v", err)
+	}
+	port, err := mysql.MappedPort(ctx, "3306/tcp")
+	if err != nil {
+		t.Fatalf("failed to read the mysql port: %v", err)
+	}
+
+	cfg := configs.NewTestConfig()
+	cfg.DBDriver = "mysql"
+	cfg.DBHost = host
+	cfg.DBPort = port.Port()
+	cfg.DBUser = "root"
+	cfg.DBPassword = mysqlPassword
+	cfg.DBName = mysqlDatabase
+	cfg.DBConnectMaxRetries = 10
+	cfg.DBConnectRetryDelayMs = 500
+	return cfg
+}
+
+// NewMySQLForTest starts a MySQL container like NewMySQLConfigForTest and connects to it
+// The connection pool is closed when the test finishes
+func NewMySQLForTest(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := configs.NewDB(context.Background(), NewMySQLConfigForTest(t))
+	if err != nil {
+		t.Fatalf("failed to connect to mysql: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+	})
+	return db
+}
+
+// schemaPath returns the path of schema.sql at the repository root
+func schemaPath() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "..", "schema.sql")
+}
@@ -0,0 +1,63 @@
+-- SQLite version of schema.sql used by NewSQLiteForTest (no sample data)
+
+CREATE TABLE IF NOT EXISTS examples (
+    id VARCHAR(36) PRIMARY KEY,
+    description TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS products (
+    id VARCHAR(40) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL,
+    price DECIMAL(10,2),
+    stock INT,
+    stock_threshold INT NOT NULL DEFAULT 0,
+    image_url VARCHAR(2048) NOT NULL DEFAULT '',
+    external_id VARCHAR(100) NULL DEFAULT NULL UNIQUE,
+    base_stock INT NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id VARCHAR(40) PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS product_tags (
+    product_id VARCHAR(40) NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    tag_id VARCHAR(40) NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (product_id, tag_id)
+);
+
+CREATE TABLE IF NOT EXISTS product_price_history (
+    id VARCHAR(40) PRIMARY KEY,
+    product_id VARCHAR(40) NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    old_price DECIMAL(10,2),
+    new_price DECIMAL(10,2),
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS product_variants (
+    id VARCHAR(40) PRIMARY KEY,
+    product_id VARCHAR(40) NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    attributes TEXT NOT NULL,
+    price DECIMAL(10,2),
+    stock INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id VARCHAR(40) PRIMARY KEY,
+    aggregate_type VARCHAR(100) NOT NULL,
+    aggregate_id VARCHAR(40) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP NULL
+);
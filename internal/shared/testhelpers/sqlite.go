@@ -0,0 +1,35 @@
+//go:build sqlite
+
+// Package testhelpers provides fixtures shared by tests
+package testhelpers
+
+import (
+	"database/sql"
+	_ "embed"
+	"testing"
+
+	"github.com/refortunato/go_app_base/configs"
+)
+
+//go:embed schema_sqlite.sql
+var sqliteSchema string
+
+// NewSQLiteForTest opens an in-memory SQLite database with the application tables
+// The database is closed when the test finishes. Run with: go test -tags sqlite ./...
+func NewSQLiteForTest(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := configs.NewSQLite(&configs.Conf{DBDriver: "sqlite", DBName: ":memory:"})
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		t.Fatalf("failed to run sqlite migrations: %v", err)
+	}
+
+	return db
+}
@@ -0,0 +1,20 @@
+package validation
+
+import (
+	"github.com/google/uuid"
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+)
+
+// ValidateUUID returns ErrInvalidID (400) unless id is a canonical, hyphenated RFC 4122/9562 UUID
+// of version 1 to 8 (shared.GenerateId creates version 7); braces, "urn:uuid:" and the
+// 32-digit form are rejected so the same product is never addressed by two different IDs
+func ValidateUUID(id string) error {
+	if len(id) != 36 {
+		return app_errors.ErrInvalidID
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil || parsed.Variant() != uuid.RFC4122 || parsed.Version() < 1 || parsed.Version() > 8 {
+		return app_errors.ErrInvalidID
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/refortunato/go_app_base/internal/shared"
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+)
+
+func TestValidateUUID(t *testing.T) {
+	tests := []struct {
+		name  string
+		id    string
+		valid bool
+	}{
+		{name: "generated v7", id: shared.GenerateId(), valid: true},
+		{name: "v1", id: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", valid: true},
+		{name: "v4", id: "550e8400-e29b-41d4-a716-446655440000", valid: true},
+		{name: "v7", id: "018f3c1e-7b2a-7c4d-8e5f-0123456789ab", valid: true},
+		{name: "uppercase", id: "550E8400-E29B-41D4-A716-446655440000", valid: true},
+		{name: "not a UUID", id: "abc"},
+		{name: "empty", id: ""},
+		{name: "numeric", id: "12345"},
+		{name: "bad hex digit", id: "550e8400-e29b-41d4-a716-44665544000g"},
+		{name: "version 0", id: "550e8400-e29b-01d4-a716-446655440000"},
+		{name: "version 9", id: "550e8400-e29b-91d4-a716-446655440000"},
+		{name: "version 15", id: "550e8400-e29b-f1d4-a716-446655440000"},
+		{name: "nil UUID", id: "00000000-0000-0000-0000-000000000000"},
+		{name: "Microsoft variant", id: "550e8400-e29b-41d4-c716-446655440000"},
+		{name: "no hyphens", id: "550e8400e29b41d4a716446655440000"},
+		{name: "braces", id: "{550e8400-e29b-41d4-a716-446655440000}"},
+		{name: "URN", id: "urn:uuid:550e8400-e29b-41d4-a716-446655440000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUUID(tt.id)
+			if tt.valid && err != nil {
+				t.Errorf("ValidateUUID(%q) = %v, want nil", tt.id, err)
+			}
+			if !tt.valid && err != app_errors.ErrInvalidID {
+				t.Errorf("ValidateUUID(%q) = %v, want ErrInvalidID", tt.id, err)
+			}
+		})
+	}
+}
@@ -2,16 +2,30 @@ package advisor
 
 import (
 	"net/http"
+	"strings"
 
 	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
 	webcontext "github.com/refortunato/go_app_base/internal/shared/web/context"
 )
 
+// problemFormats lists the media types ProblemDetails can be rendered in
+var problemFormats = []string{"application/json", "application/xml"}
+
+// writeProblem renders a ProblemDetails in the format requested by the Accept header
+// Falls back to JSON when the client accepts neither format
+func writeProblem(c webcontext.WebContext, pd *app_errors.ProblemDetails) {
+	if err := c.Negotiate(pd.Status, problemFormats, pd); err != nil {
+		c.JSON(pd.Status, pd)
+	}
+}
+
 func ReturnApplicationError(c webcontext.WebContext, err error) {
 	if err != nil {
 		// Retornar erros formatados como ProblemDetails
 		if pd, ok := err.(*app_errors.ProblemDetails); ok {
-			c.JSON(pd.Status, pd)
+			logProblem(c, pd)
+			writeProblem(c, pd)
 			return
 		}
 		c.JSON(http.StatusInternalServerError, map[string]string{"error": "could not execute operation"})
@@ -19,6 +33,30 @@ func ReturnApplicationError(c webcontext.WebContext, err error) {
 	}
 }
 
+// logProblem logs errors that identify the module or operation that produced them,
+// with "module" and "operation" as custom fields (server errors are logged as ERROR)
+func logProblem(c webcontext.WebContext, pd *app_errors.ProblemDetails) {
+	if pd.Module == "" && pd.Operation == "" {
+		return
+	}
+
+	fields := logger.CustomFields{}
+	if pd.Module != "" {
+		fields["module"] = pd.Module
+	}
+	if pd.Operation != "" {
+		fields["operation"] = pd.Operation
+	}
+
+	ctx := c.GetContext()
+	log := logger.FromContext(ctx).WithError(pd)
+	if pd.Status >= http.StatusInternalServerError {
+		log.Error(ctx, "Application error", fields)
+		return
+	}
+	log.Warn(ctx, "Application error", fields)
+}
+
 func ReturnBadRequestError(c webcontext.WebContext, err error) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
@@ -27,5 +65,23 @@ func ReturnBadRequestError(c webcontext.WebContext, err error) {
 }
 
 func ReturnNotFoundError(c webcontext.WebContext) {
-	c.JSON(http.StatusNotFound, map[string]string{"error": "resource not found"})
+	writeProblem(c, app_errors.ErrNotFound)
+}
+
+// ReturnMethodNotAllowedError responds with 405 and, when known, an Allow header listing the valid methods
+func ReturnMethodNotAllowedError(c webcontext.WebContext, allowedMethods ...string) {
+	if len(allowedMethods) > 0 {
+		c.SetHeader("Allow", strings.Join(allowedMethods, ", "))
+	}
+	writeProblem(c, app_errors.ErrMethodNotAllowed)
+}
+
+// ReturnConflictError responds with 409, using err when it is a conflict ProblemDetails
+// and the generic ErrConflict otherwise
+func ReturnConflictError(c webcontext.WebContext, err error) {
+	if pd, ok := err.(*app_errors.ProblemDetails); ok && pd.Status == http.StatusConflict {
+		writeProblem(c, pd)
+		return
+	}
+	writeProblem(c, app_errors.ErrConflict)
 }
@@ -0,0 +1,167 @@
+package advisor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	webcontext "github.com/refortunato/go_app_base/internal/shared/web/context"
+)
+
+// respond runs write with a WebContext over a JSON request and returns the recorded response
+func respond(write func(c webcontext.WebContext)) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/products", nil)
+	c.Request.Header.Set("Accept", "application/json")
+	write(webcontext.NewGinContextAdapter(c))
+	return w
+}
+
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) app_errors.ProblemDetails {
+	t.Helper()
+	var problem app_errors.ProblemDetails
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("body is not a problem: %v: %s", err, w.Body.String())
+	}
+	return problem
+}
+
+func TestReturnApplicationError_ConflictAndUnprocessableEntity(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "conflict", err: app_errors.ErrConflict, wantStatus: http.StatusConflict, wantCode: "SHARED0003"},
+		{name: "unprocessable entity", err: app_errors.ErrUnprocessableEntity, wantStatus: http.StatusUnprocessableEntity, wantCode: "SHARED0004"},
+		{name: "per-resource conflict", err: app_errors.NewConflictError("Product exists", "SKU already used", "SIP0409"), wantStatus: http.StatusConflict, wantCode: "SIP0409"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := respond(func(c webcontext.WebContext) { ReturnApplicationError(c, tt.err) })
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if problem := decodeProblem(t, w); problem.Code != tt.wantCode || problem.Status != tt.wantStatus {
+				t.Errorf("problem = %+v, want %s with status %d", problem, tt.wantCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestReturnConflictError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode string
+	}{
+		{name: "conflict problem is kept", err: app_errors.NewConflictError("Product exists", "SKU already used", "SIP0409"), wantCode: "SIP0409"},
+		{name: "other problem falls back to ErrConflict", err: app_errors.ErrUnprocessableEntity, wantCode: "SHARED0003"},
+		{name: "plain error falls back to ErrConflict", err: errors.New("duplicate"), wantCode: "SHARED0003"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := respond(func(c webcontext.WebContext) { ReturnConflictError(c, tt.err) })
+
+			if w.Code != http.StatusConflict {
+				t.Fatalf("status = %d, want 409", w.Code)
+			}
+			if problem := decodeProblem(t, w); problem.Code != tt.wantCode {
+				t.Errorf("code = %s, want %s", problem.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+// problemLogger records the level, message and fields of each entry
+type problemLogger struct {
+	logger.Logger
+	entries *[]problemLogEntry
+}
+
+type problemLogEntry struct {
+	level, message string
+	fields         logger.CustomFields
+}
+
+func (l problemLogger) WithError(error) logger.Logger { return l }
+
+func (l problemLogger) Warn(_ context.Context, message string, fields ...logger.CustomFields) {
+	l.record("WARN", message, fields)
+}
+
+func (l problemLogger) Error(_ context.Context, message string, fields ...logger.CustomFields) {
+	l.record("ERROR", message, fields)
+}
+
+func (l problemLogger) record(level, message string, fields []logger.CustomFields) {
+	entry := problemLogEntry{level: level, message: message}
+	if len(fields) > 0 {
+		entry.fields = fields[0]
+	}
+	*l.entries = append(*l.entries, entry)
+}
+
+func TestReturnApplicationError_ModuleAndOperation(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           *app_errors.ProblemDetails
+		wantLevel     string
+		wantModule    any
+		wantOperation any
+	}{
+		{name: "client error",
+			err:       app_errors.NewProblemDetailsWithContext(404, "Product not found", "", "SIP1002", app_errors.ErrorContextBusiness, "simple_module", "find_product"),
+			wantLevel: "WARN", wantModule: "simple_module", wantOperation: "find_product"},
+		{name: "server error",
+			err:       app_errors.NewProblemDetailsWithContext(500, "Save failed", "", "SIP1010", app_errors.ErrorContextInfra, "simple_module", "").WithOperation("save_product"),
+			wantLevel: "ERROR", wantModule: "simple_module", wantOperation: "save_product"},
+		{name: "module only",
+			err:       app_errors.NewProblemDetailsWithContext(400, "Invalid", "", "SIP1003", app_errors.ErrorContextBusiness, "simple_module", ""),
+			wantLevel: "WARN", wantModule: "simple_module"},
+		{name: "no context is not logged", err: app_errors.ErrConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var entries []problemLogEntry
+			log := problemLogger{Logger: logger.NewMultiLogger(), entries: &entries}
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/products/42", nil)
+			c.Request = c.Request.WithContext(logger.WithLogger(c.Request.Context(), log))
+			ReturnApplicationError(webcontext.NewGinContextAdapter(c), tt.err)
+
+			problem := decodeProblem(t, w)
+			if problem.Module != tt.err.Module || problem.Operation != tt.err.Operation {
+				t.Errorf("problem module/operation = %q/%q, want %q/%q", problem.Module, problem.Operation, tt.err.Module, tt.err.Operation)
+			}
+			if tt.wantLevel == "" {
+				if len(entries) != 0 {
+					t.Errorf("logged %+v, want nothing", entries)
+				}
+				return
+			}
+			if len(entries) != 1 {
+				t.Fatalf("log entries = %d, want 1", len(entries))
+			}
+			entry := entries[0]
+			if entry.level != tt.wantLevel {
+				t.Errorf("level = %s, want %s", entry.level, tt.wantLevel)
+			}
+			if entry.fields["module"] != tt.wantModule || entry.fields["operation"] != tt.wantOperation {
+				t.Errorf("fields = %v, want module %v and operation %v", entry.fields, tt.wantModule, tt.wantOperation)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package advisor
+
+import (
+	"net/http"
+
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ReturnGRPCApplicationError converts an application error into a gRPC status error
+// ProblemDetails are mapped to the closest gRPC code and keep their JSON body as the message
+func ReturnGRPCApplicationError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if pd, ok := err.(*app_errors.ProblemDetails); ok {
+		return status.Error(GRPCCodeFromHTTPStatus(pd.Status), pd.Error())
+	}
+	return status.Error(codes.Internal, "could not execute operation")
+}
+
+// GRPCCodeFromHTTPStatus maps an HTTP status code to the equivalent gRPC code
+func GRPCCodeFromHTTPStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,27 @@
+package advisor
+
+import (
+	"fmt"
+	"strings"
+
+	webcontext "github.com/refortunato/go_app_base/internal/shared/web/context"
+)
+
+// paginationRelations is the order relations appear in the Link header
+var paginationRelations = []string{"first", "prev", "next", "last"}
+
+// WritePaginationHeaders sets the Link header (RFC 5988) from rel→URL links
+// e.g. Link: <https://api/products?page=1&limit=10>; rel="first", <...>; rel="next"
+func WritePaginationHeaders(c webcontext.WebContext, links map[string]string) {
+	parts := make([]string, 0, len(links))
+	for _, rel := range paginationRelations {
+		if url, ok := links[rel]; ok {
+			parts = append(parts, fmt.Sprintf("<%s>; rel=\"%s\"", url, rel))
+		}
+	}
+	if len(parts) == 0 {
+		return
+	}
+
+	c.SetHeader("Link", strings.Join(parts, ", "))
+}
@@ -0,0 +1,31 @@
+package advisor
+
+import (
+	"testing"
+
+	webcontext "github.com/refortunato/go_app_base/internal/shared/web/context"
+)
+
+func TestWritePaginationHeaders(t *testing.T) {
+	tests := []struct {
+		name  string
+		links map[string]string
+		want  string
+	}{
+		{name: "relations in order",
+			links: map[string]string{"last": "/products?page=3", "next": "/products?page=3", "prev": "/products?page=1", "first": "/products?page=1"},
+			want:  `</products?page=1>; rel="first", </products?page=1>; rel="prev", </products?page=3>; rel="next", </products?page=3>; rel="last"`},
+		{name: "unknown relations ignored",
+			links: map[string]string{"first": "/products?page=1", "self": "/products?page=1"},
+			want:  `</products?page=1>; rel="first"`},
+		{name: "no links", links: map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := respond(func(c webcontext.WebContext) { WritePaginationHeaders(c, tt.links) })
+			if got := w.Header().Get("Link"); got != tt.want {
+				t.Errorf("Link = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
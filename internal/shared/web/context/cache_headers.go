@@ -0,0 +1,26 @@
+package context
+
+import (
+	"net/http"
+	"time"
+)
+
+// WriteLastModified sets the Last-Modified header to t as an HTTP date (RFC 1123, GMT)
+func WriteLastModified(ctx WebContext, t time.Time) {
+	ctx.SetHeader("Last-Modified", t.UTC().Format(http.TimeFormat))
+}
+
+// CheckIfModifiedSince reports whether the client copy is still current: the request has a
+// valid If-Modified-Since header and t is not after it, so the caller should answer 304
+// HTTP dates have second precision, so t is truncated before comparing
+func CheckIfModifiedSince(ctx WebContext, t time.Time) bool {
+	header := ctx.GetHeader("If-Modified-Since")
+	if header == "" {
+		return false
+	}
+	since, err := http.ParseTime(header)
+	if err != nil {
+		return false
+	}
+	return !t.Truncate(time.Second).After(since)
+}
@@ -1,9 +1,14 @@
 package context
 
 import (
-"context"
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
 
-"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin"
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
 )
 
 // GinContextAdapter adapts gin.Context to implement WebContext interface
@@ -17,9 +22,21 @@ func NewGinContextAdapter(ctx *gin.Context) *GinContextAdapter {
 }
 
 func (g *GinContextAdapter) JSON(code int, obj any) {
+	// Gin would append a second body to an already written response
+	if g.ctx.Writer.Written() {
+		return
+	}
 	g.ctx.JSON(code, obj)
 }
 
+func (g *GinContextAdapter) SetStatus(code int) {
+	// gin.Context.JSON(code, nil) would write "null" as the body
+	if g.ctx.Writer.Written() {
+		return
+	}
+	g.ctx.Status(code)
+}
+
 func (g *GinContextAdapter) BindJSON(obj any) error {
 	return g.ctx.BindJSON(obj)
 }
@@ -40,6 +57,90 @@ func (g *GinContextAdapter) SetHeader(key, value string) {
 	g.ctx.Header(key, value)
 }
 
+func (g *GinContextAdapter) SetContentType(contentType string) {
+	g.ctx.Header("Content-Type", contentType)
+}
+
+func (g *GinContextAdapter) Negotiate(code int, offered []string, data any) error {
+	// Gin aborts with a plain 406 on its own, so check acceptability first
+	if g.ctx.NegotiateFormat(offered...) == "" {
+		return app_errors.ErrNotAcceptable
+	}
+	g.ctx.Negotiate(code, gin.Negotiate{Offered: offered, Data: data})
+	return nil
+}
+
 func (g *GinContextAdapter) GetContext() context.Context {
 	return g.ctx.Request.Context()
 }
+
+func (g *GinContextAdapter) LimitBody(maxBytes int64) {
+	g.ctx.Request.Body = http.MaxBytesReader(g.ctx.Writer, g.ctx.Request.Body, maxBytes)
+}
+
+func (g *GinContextAdapter) FormFile(name string) (*multipart.FileHeader, error) {
+	return g.ctx.FormFile(name)
+}
+
+func (g *GinContextAdapter) SaveUploadedFile(file *multipart.FileHeader, dst string) error {
+	return g.ctx.SaveUploadedFile(file, dst)
+}
+
+func (g *GinContextAdapter) GetBody() ([]byte, error) {
+	if g.ctx.Request.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(g.ctx.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+	g.ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func (g *GinContextAdapter) SetCookie(name, value string, maxAge int, path, domain string, secure, httpOnly bool) {
+	g.ctx.SetCookie(name, value, maxAge, path, domain, secure, httpOnly)
+}
+
+func (g *GinContextAdapter) Cookie(name string) (string, error) {
+	return g.ctx.Cookie(name)
+}
+
+func (g *GinContextAdapter) Stream(code int, contentType string, fn func(w io.Writer) error) error {
+	g.ctx.Header("Content-Type", contentType)
+	g.ctx.Status(code)
+
+	// gin.Context.Stream relies on the deprecated http.CloseNotifier (missing from
+	// httptest.ResponseRecorder); client disconnects cancel the request context instead
+	err := fn(g.ctx.Writer)
+	g.ctx.Writer.Flush()
+	return err
+}
+
+func (g *GinContextAdapter) Status() int {
+	return g.ctx.Writer.Status()
+}
+
+func (g *GinContextAdapter) Written() bool {
+	return g.ctx.Writer.Written()
+}
+
+func (g *GinContextAdapter) AbortWithProblem(pd *app_errors.ProblemDetails) {
+	g.ctx.AbortWithStatusJSON(pd.Status, pd)
+}
+
+func (g *GinContextAdapter) Redirect(code int, location string) {
+	g.ctx.Redirect(code, location)
+}
+
+func (g *GinContextAdapter) PermanentRedirect(location string) {
+	g.Redirect(http.StatusMovedPermanently, location)
+}
+
+func (g *GinContextAdapter) TemporaryRedirect(location string) {
+	g.Redirect(http.StatusFound, location)
+}
+
+func (g *GinContextAdapter) Upgrade(upgrader WebSocketUpgrader) (WebSocketConn, error) {
+	return upgrader.Upgrade(g.ctx.Writer, g.ctx.Request, nil)
+}
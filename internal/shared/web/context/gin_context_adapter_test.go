@@ -0,0 +1,313 @@
+package context
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+)
+
+// serve runs handler on a Gin adapter and returns the recorded response
+func serve(handler func(WebContext)) *httptest.ResponseRecorder {
+	return serveRequest(httptest.NewRequest(http.MethodGet, "/", nil), handler)
+}
+
+// serveRequest is serve for a prepared request
+func serveRequest(req *http.Request, handler func(WebContext)) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Any("/*path", func(c *gin.Context) {
+		handler(NewGinContextAdapter(c))
+	})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSetStatus_WritesNoBody(t *testing.T) {
+	for _, code := range []int{http.StatusNoContent, http.StatusNotModified, http.StatusAccepted} {
+		t.Run(http.StatusText(code), func(t *testing.T) {
+			rec := serve(func(ctx WebContext) { ctx.SetStatus(code) })
+
+			if rec.Code != code {
+				t.Errorf("status = %d, want %d", rec.Code, code)
+			}
+			if rec.Body.Len() != 0 {
+				t.Errorf("body = %q, want no body", rec.Body.String())
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "" {
+				t.Errorf("Content-Type = %q, want none", ct)
+			}
+		})
+	}
+}
+
+func TestSetStatus_IgnoredAfterResponseWritten(t *testing.T) {
+	rec := serve(func(ctx WebContext) {
+		ctx.JSON(http.StatusOK, gin.H{"ok": true})
+		ctx.SetStatus(http.StatusNoContent)
+	})
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want the first status 200", rec.Code)
+	}
+	if rec.Body.String() != `{"ok":true}` {
+		t.Errorf("body = %q, want the first response only", rec.Body.String())
+	}
+}
+
+type negotiatedItem struct {
+	XMLName xml.Name `json:"-" xml:"item"`
+	Name    string   `json:"name" xml:"name"`
+}
+
+func TestNegotiate(t *testing.T) {
+	offered := []string{"application/json", "application/xml"}
+	tests := []struct {
+		name            string
+		accept          string
+		wantContentType string
+		wantErr         error
+	}{
+		{name: "json", accept: "application/json", wantContentType: "application/json"},
+		{name: "xml", accept: "application/xml", wantContentType: "application/xml"},
+		{name: "first listed", accept: "application/xml, application/json", wantContentType: "application/xml"},
+		{name: "any", accept: "*/*", wantContentType: "application/json"},
+		{name: "not acceptable", accept: "text/csv", wantErr: app_errors.ErrNotAcceptable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept", tt.accept)
+			var err error
+			rec := serveRequest(req, func(ctx WebContext) {
+				err = ctx.Negotiate(http.StatusOK, offered, negotiatedItem{Name: "keyboard"})
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if rec.Body.Len() != 0 {
+					t.Errorf("body = %q, want nothing written so the caller can answer the error", rec.Body.String())
+				}
+				return
+			}
+			if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, tt.wantContentType) {
+				t.Errorf("Content-Type = %q, want %s", ct, tt.wantContentType)
+			}
+			var decoded negotiatedItem
+			if tt.wantContentType == "application/xml" {
+				err = xml.Unmarshal(rec.Body.Bytes(), &decoded)
+			} else {
+				err = json.Unmarshal(rec.Body.Bytes(), &decoded)
+			}
+			if err != nil || decoded.Name != "keyboard" {
+				t.Errorf("body = %s (%v), want the item", rec.Body.String(), err)
+			}
+		})
+	}
+}
+
+func TestAbortWithProblem(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	var before, after struct {
+		status  int
+		written bool
+	}
+	nextRan := false
+	router.Use(func(c *gin.Context) {
+		ctx := NewGinContextAdapter(c)
+		before.status, before.written = ctx.Status(), ctx.Written()
+		ctx.AbortWithProblem(app_errors.ErrNotFound)
+		after.status, after.written = ctx.Status(), ctx.Written()
+		// Ignored: the problem is already written
+		ctx.JSON(http.StatusOK, map[string]string{"status": "ok"})
+	})
+	router.GET("/", func(c *gin.Context) { nextRan = true })
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if before.written || before.status != http.StatusOK {
+		t.Errorf("before: Written = %v, Status = %d, want false, 200", before.written, before.status)
+	}
+	if !after.written || after.status != app_errors.ErrNotFound.Status {
+		t.Errorf("after: Written = %v, Status = %d, want true, %d", after.written, after.status, app_errors.ErrNotFound.Status)
+	}
+	if nextRan {
+		t.Error("the handler ran after AbortWithProblem")
+	}
+	if rec.Code != app_errors.ErrNotFound.Status {
+		t.Errorf("status = %d, want %d", rec.Code, app_errors.ErrNotFound.Status)
+	}
+	var problem app_errors.ProblemDetails
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("body is not a single problem: %v: %s", err, rec.Body.String())
+	}
+	if problem.Code != app_errors.ErrNotFound.Code {
+		t.Errorf("problem code = %q, want %q", problem.Code, app_errors.ErrNotFound.Code)
+	}
+}
+
+func TestWritten_AfterJSON(t *testing.T) {
+	var written bool
+	var status int
+	rec := serve(func(ctx WebContext) {
+		ctx.JSON(http.StatusCreated, map[string]string{"id": "1"})
+		written, status = ctx.Written(), ctx.Status()
+		ctx.JSON(http.StatusOK, map[string]string{"id": "2"})
+	})
+
+	if !written || status != http.StatusCreated {
+		t.Errorf("Written = %v, Status = %d, want true, 201", written, status)
+	}
+	if rec.Body.String() != `{"id":"1"}` {
+		t.Errorf("body = %q, want only the first response", rec.Body.String())
+	}
+}
+
+func TestGetBody_RereadableAndBindable(t *testing.T) {
+	const body = `{"name":"Keyboard","price":99.9}`
+	var first, second []byte
+	var firstErr, secondErr, bindErr error
+	var bound struct {
+		Name  string  `json:"name"`
+		Price float64 `json:"price"`
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	serveRequest(req, func(ctx WebContext) {
+		first, firstErr = ctx.GetBody()
+		second, secondErr = ctx.GetBody()
+		bindErr = ctx.BindJSON(&bound)
+	})
+
+	if firstErr != nil || secondErr != nil {
+		t.Fatalf("GetBody errors = %v, %v", firstErr, secondErr)
+	}
+	if string(first) != body || string(second) != body {
+		t.Errorf("GetBody = %q then %q, want the request body twice", first, second)
+	}
+	if bindErr != nil || bound.Name != "Keyboard" || bound.Price != 99.9 {
+		t.Errorf("BindJSON after GetBody = %+v, %v, want the decoded body", bound, bindErr)
+	}
+}
+
+func TestGetBody_LimitBody(t *testing.T) {
+	var err error
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(strings.Repeat("x", 11)))
+	serveRequest(req, func(ctx WebContext) {
+		ctx.LimitBody(10)
+		_, err = ctx.GetBody()
+	})
+
+	var maxBytesErr *http.MaxBytesError
+	if !errors.As(err, &maxBytesErr) {
+		t.Errorf("GetBody past LimitBody: err = %v, want *http.MaxBytesError", err)
+	}
+}
+
+func TestStream(t *testing.T) {
+	var err error
+	rec := serve(func(ctx WebContext) {
+		err = ctx.Stream(http.StatusOK, "application/x-ndjson", func(w io.Writer) error {
+			for _, line := range []string{`{"id":"1"}`, `{"id":"2"}`} {
+				if _, err := io.WriteString(w, line+"\n"); err != nil {
+					return err
+				}
+			}
+			return errors.New("database gone")
+		})
+	})
+
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/x-ndjson" {
+		t.Errorf("status = %d, Content-Type = %q, want 200 application/x-ndjson", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if rec.Body.String() != "{\"id\":\"1\"}\n{\"id\":\"2\"}\n" {
+		t.Errorf("body = %q, want the streamed lines", rec.Body.String())
+	}
+	if err == nil || err.Error() != "database gone" {
+		t.Errorf("Stream error = %v, want the error returned by fn", err)
+	}
+}
+
+func TestRedirect(t *testing.T) {
+	tests := []struct {
+		name       string
+		redirect   func(WebContext)
+		wantStatus int
+	}{
+		{name: "permanent", redirect: func(c WebContext) { c.PermanentRedirect("/products/42") }, wantStatus: http.StatusMovedPermanently},
+		{name: "temporary", redirect: func(c WebContext) { c.TemporaryRedirect("/products/42") }, wantStatus: http.StatusFound},
+		{name: "explicit code", redirect: func(c WebContext) { c.Redirect(http.StatusTemporaryRedirect, "/products/42") }, wantStatus: http.StatusTemporaryRedirect},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(tt.redirect)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if location := rec.Header().Get("Location"); location != "/products/42" {
+				t.Errorf("Location = %q, want /products/42", location)
+			}
+		})
+	}
+}
+
+func TestUpgrade_EchoesMessages(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ws", func(c *gin.Context) {
+		conn, err := NewGinContextAdapter(c).Upgrade(NewGorillaUpgrader(nil))
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		messageType, p, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		conn.WriteMessage(messageType, append([]byte("echo: "), p...))
+	})
+	server := httptest.NewServer(router)
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+	if err := conn.WriteMessage(TextMessage, []byte("hello")); err != nil {
+		t.Fatalf("WriteMessage: %v", err)
+	}
+	messageType, p, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	if messageType != TextMessage || string(p) != "echo: hello" {
+		t.Errorf("message = %d %q, want a text echo", messageType, p)
+	}
+
+	// A plain HTTP request is not a handshake
+	resp, err := http.Get(server.URL + "/ws")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("plain GET: status = %d, want 400", resp.StatusCode)
+	}
+}
@@ -1,15 +1,67 @@
 package context
 
-import "context"
+import (
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+)
 
 // WebContext is a generic interface for HTTP request/response context
 // It abstracts web framework specifics (Gin, Echo, etc.)
 type WebContext interface {
+	// JSON writes obj as the response body; it is a no-op once the response was written
 	JSON(code int, obj any)
+	// SetStatus sets the status code without writing a body (e.g. 204 No Content)
+	// It is a no-op once the response was written
+	SetStatus(code int)
 	BindJSON(obj any) error
 	Param(key string) string
 	Query(key string) string
 	GetHeader(key string) string
 	SetHeader(key, value string)
+	SetContentType(contentType string)
+	// Negotiate renders data in the first offered format accepted by the client
+	// It returns an error when none of the offered formats is acceptable
+	Negotiate(code int, offered []string, data any) error
 	GetContext() context.Context
+	// LimitBody makes reads of the request body fail after maxBytes (see IsBodyTooLarge)
+	// Call it before FormFile, BindJSON or GetBody to bound what they read
+	LimitBody(maxBytes int64)
+	FormFile(name string) (*multipart.FileHeader, error)
+	// SaveUploadedFile writes an uploaded file to dst
+	SaveUploadedFile(file *multipart.FileHeader, dst string) error
+	// GetBody returns the raw request body and restores it, so later reads
+	// (including BindJSON) still see the full body
+	GetBody() ([]byte, error)
+	// SetCookie adds a Set-Cookie header; maxAge < 0 deletes the cookie
+	SetCookie(name, value string, maxAge int, path, domain string, secure, httpOnly bool)
+	// Cookie returns the named request cookie, or an error when it is missing
+	Cookie(name string) (string, error)
+	// Stream writes the response incrementally through fn; w implements http.Flusher
+	// Errors from fn cannot change the status once data was sent; they are returned to the caller
+	Stream(code int, contentType string, fn func(w io.Writer) error) error
+	// Status returns the response status code (200 until one is set)
+	Status() int
+	// Written reports whether the response headers and body were already written
+	Written() bool
+	// AbortWithProblem writes pd as JSON with its status and stops the remaining handlers
+	AbortWithProblem(pd *app_errors.ProblemDetails)
+	// Redirect responds with a 3xx code and a Location header pointing to location
+	Redirect(code int, location string)
+	// PermanentRedirect redirects with 301 Moved Permanently
+	PermanentRedirect(location string)
+	// TemporaryRedirect redirects with 302 Found
+	TemporaryRedirect(location string)
+	// Upgrade switches the request to the WebSocket protocol; the response must not be written yet
+	Upgrade(upgrader WebSocketUpgrader) (WebSocketConn, error)
+}
+
+// IsBodyTooLarge reports whether err comes from reading a body past the LimitBody limit
+func IsBodyTooLarge(err error) bool {
+	var tooLarge *http.MaxBytesError
+	return errors.As(err, &tooLarge)
 }
@@ -0,0 +1,54 @@
+package context
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebSocket message types (RFC 6455), as used by WebSocketConn
+const (
+	TextMessage   = websocket.TextMessage
+	BinaryMessage = websocket.BinaryMessage
+	CloseMessage  = websocket.CloseMessage
+	PingMessage   = websocket.PingMessage
+	PongMessage   = websocket.PongMessage
+)
+
+// WebSocketConn is an upgraded WebSocket connection
+// One goroutine may read and one may write at the same time
+type WebSocketConn interface {
+	ReadMessage() (messageType int, p []byte, err error)
+	WriteMessage(messageType int, data []byte) error
+	Close() error
+}
+
+// WebSocketUpgrader switches an HTTP request to the WebSocket protocol
+// On failure it has already answered the request with an HTTP error
+type WebSocketUpgrader interface {
+	Upgrade(w http.ResponseWriter, r *http.Request, responseHeader http.Header) (WebSocketConn, error)
+}
+
+// GorillaUpgrader implements WebSocketUpgrader with gorilla/websocket
+type GorillaUpgrader struct {
+	upgrader websocket.Upgrader
+}
+
+// NewGorillaUpgrader creates an upgrader; checkOrigin decides which Origin headers are accepted
+// (nil accepts only same-origin requests, gorilla's default)
+func NewGorillaUpgrader(checkOrigin func(r *http.Request) bool) *GorillaUpgrader {
+	return &GorillaUpgrader{upgrader: websocket.Upgrader{CheckOrigin: checkOrigin}}
+}
+
+func (u *GorillaUpgrader) Upgrade(w http.ResponseWriter, r *http.Request, responseHeader http.Header) (WebSocketConn, error) {
+	conn, err := u.upgrader.Upgrade(w, r, responseHeader)
+	if err != nil {
+		return nil, err
+	}
+	// The hijacked connection keeps the HTTP server read/write deadlines, which would
+	// close long-lived WebSocket connections
+	_ = conn.SetReadDeadline(time.Time{})
+	_ = conn.SetWriteDeadline(time.Time{})
+	return conn, nil
+}
@@ -0,0 +1,25 @@
+package cookies
+
+import (
+	"time"
+
+	webcontext "github.com/refortunato/go_app_base/internal/shared/web/context"
+)
+
+// JWTCookieName is the cookie holding the session JWT
+const JWTCookieName = "access_token"
+
+// SetJWTCookie stores token in a Secure, HttpOnly cookie scoped to the whole site that expires after ttl
+func SetJWTCookie(c webcontext.WebContext, token string, ttl time.Duration) {
+	c.SetCookie(JWTCookieName, token, int(ttl.Seconds()), "/", "", true, true)
+}
+
+// JWTFromCookie returns the session JWT, or an error when the cookie is missing
+func JWTFromCookie(c webcontext.WebContext) (string, error) {
+	return c.Cookie(JWTCookieName)
+}
+
+// ClearJWTCookie tells the client to delete the session JWT cookie
+func ClearJWTCookie(c webcontext.WebContext) {
+	c.SetCookie(JWTCookieName, "", -1, "/", "", true, true)
+}
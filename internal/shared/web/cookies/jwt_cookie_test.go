@@ -0,0 +1,79 @@
+package cookies
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	webcontext "github.com/refortunato/go_app_base/internal/shared/web/context"
+)
+
+// newSessionRouter sets the JWT cookie on /login, echoes it on /me and clears it on /logout
+func newSessionRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/login", func(c *gin.Context) {
+		SetJWTCookie(webcontext.NewGinContextAdapter(c), "header.payload.signature", 15*time.Minute)
+		c.Status(http.StatusNoContent)
+	})
+	router.GET("/me", func(c *gin.Context) {
+		token, err := JWTFromCookie(webcontext.NewGinContextAdapter(c))
+		if err != nil {
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+		c.String(http.StatusOK, token)
+	})
+	router.POST("/logout", func(c *gin.Context) {
+		ClearJWTCookie(webcontext.NewGinContextAdapter(c))
+		c.Status(http.StatusNoContent)
+	})
+	return router
+}
+
+// send serves a request carrying cookies and returns the response
+func send(router http.Handler, method, path string, cookies []*http.Cookie) *http.Response {
+	req := httptest.NewRequest(method, path, nil)
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec.Result()
+}
+
+func TestJWTCookie_SetReadAndClear(t *testing.T) {
+	router := newSessionRouter()
+
+	login := send(router, http.MethodPost, "/login", nil)
+	cookies := login.Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("Set-Cookie = %v, want one cookie", login.Header.Values("Set-Cookie"))
+	}
+	cookie := cookies[0]
+	if cookie.Name != JWTCookieName || cookie.Value != "header.payload.signature" {
+		t.Errorf("cookie = %s=%s, want the token in %s", cookie.Name, cookie.Value, JWTCookieName)
+	}
+	if cookie.MaxAge != 900 || cookie.Path != "/" || !cookie.Secure || !cookie.HttpOnly {
+		t.Errorf("cookie = %+v, want Max-Age=900, Path=/, Secure and HttpOnly", cookie)
+	}
+
+	// The next request sends the cookie back
+	me := send(router, http.MethodGet, "/me", cookies)
+	body, _ := io.ReadAll(me.Body)
+	if me.StatusCode != http.StatusOK || string(body) != "header.payload.signature" {
+		t.Errorf("GET /me = %d %q, want 200 with the token", me.StatusCode, body)
+	}
+	if me := send(router, http.MethodGet, "/me", nil); me.StatusCode != http.StatusUnauthorized {
+		t.Errorf("GET /me without the cookie = %d, want 401", me.StatusCode)
+	}
+
+	logout := send(router, http.MethodPost, "/logout", cookies)
+	cleared := logout.Cookies()
+	if len(cleared) != 1 || cleared[0].Name != JWTCookieName || cleared[0].Value != "" || cleared[0].MaxAge >= 0 {
+		t.Errorf("logout Set-Cookie = %v, want the cookie expired", logout.Header.Values("Set-Cookie"))
+	}
+}
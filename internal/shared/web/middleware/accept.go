@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+)
+
+// acceptExemptPrefixes are GET routes that serve their own formats (HTML docs, Prometheus text)
+var acceptExemptPrefixes = []string{"/swagger", "/metrics"}
+
+// AcceptMiddleware rejects requests whose Accept header matches none of supportedTypes with 406
+// and a ProblemDetails listing the supported types. Requests without an Accept header pass,
+// and "*/*" and "application/json" are always acceptable
+func AcceptMiddleware(supportedTypes ...string) gin.HandlerFunc {
+	offered := make([]string, 0, len(supportedTypes)+1)
+	offered = append(offered, "application/json")
+	for _, t := range supportedTypes {
+		if t != "application/json" {
+			offered = append(offered, t)
+		}
+	}
+	problem := app_errors.ErrNotAcceptable.WithSupportedMediaTypes(offered)
+
+	return func(c *gin.Context) {
+		if c.GetHeader("Accept") == "" || isAcceptExempt(c.Request) {
+			c.Next()
+			return
+		}
+
+		// NegotiateFormat resolves wildcards such as "*/*" and "application/*"
+		if c.NegotiateFormat(offered...) == "" {
+			c.AbortWithStatusJSON(problem.Status, problem)
+			return
+		}
+		c.Next()
+	}
+}
+
+func isAcceptExempt(r *http.Request) bool {
+	if r.Method != http.MethodGet {
+		return false
+	}
+	for _, prefix := range acceptExemptPrefixes {
+		if r.URL.Path == prefix || strings.HasPrefix(r.URL.Path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
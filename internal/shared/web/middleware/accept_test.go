@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+)
+
+func TestAcceptMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(AcceptMiddleware("application/json", "application/xml"))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/products", ok)
+	router.POST("/products", ok)
+	router.GET("/metrics", ok)
+	router.GET("/swagger/*any", ok)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		accept     string
+		wantStatus int
+	}{
+		{name: "no Accept header", method: http.MethodGet, path: "/products", wantStatus: http.StatusOK},
+		{name: "json", method: http.MethodGet, path: "/products", accept: "application/json", wantStatus: http.StatusOK},
+		{name: "xml", method: http.MethodGet, path: "/products", accept: "application/xml", wantStatus: http.StatusOK},
+		{name: "any", method: http.MethodGet, path: "/products", accept: "*/*", wantStatus: http.StatusOK},
+		{name: "type wildcard", method: http.MethodGet, path: "/products", accept: "application/*", wantStatus: http.StatusOK},
+		{name: "one supported among others", method: http.MethodGet, path: "/products", accept: "text/html, application/json;q=0.5", wantStatus: http.StatusOK},
+		{name: "html", method: http.MethodGet, path: "/products", accept: "text/html", wantStatus: http.StatusNotAcceptable},
+		{name: "html on POST", method: http.MethodPost, path: "/products", accept: "text/html", wantStatus: http.StatusNotAcceptable},
+		{name: "metrics are exempt", method: http.MethodGet, path: "/metrics", accept: "text/plain", wantStatus: http.StatusOK},
+		{name: "swagger is exempt", method: http.MethodGet, path: "/swagger/index.html", accept: "text/html", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusNotAcceptable {
+				return
+			}
+			var problem app_errors.ProblemDetails
+			if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("body is not a problem: %v", err)
+			}
+			if want := []string{"application/json", "application/xml"}; !reflect.DeepEqual(problem.SupportedMediaTypes, want) {
+				t.Errorf("supported_media_types = %v, want %v", problem.SupportedMediaTypes, want)
+			}
+		})
+	}
+}
+
+func TestAcceptMiddleware_JSONAlwaysOffered(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(AcceptMiddleware("application/xml"))
+	router.GET("/products", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/products", nil)
+	req.Header.Set("Accept", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200 for JSON", w.Code)
+	}
+}
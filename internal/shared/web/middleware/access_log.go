@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+)
+
+// AccessLogMiddleware emits one structured log entry per request once the handler completes
+// Requests dropped by AccessLogSamplerMiddleware are not logged
+func AccessLogMiddleware(log logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		if !accessLogSampled(c) {
+			return
+		}
+
+		log.Info(c.Request.Context(), "HTTP request", logger.CustomFields{
+			"method":        c.Request.Method,
+			"path":          c.Request.URL.Path,
+			"status":        c.Writer.Status(),
+			"duration_ms":   time.Since(start).Milliseconds(),
+			"request_id":    ginRequestID(c),
+			"ip":            c.ClientIP(),
+			"user_agent":    c.Request.UserAgent(),
+			"response_size": c.Writer.Size(),
+		})
+	}
+}
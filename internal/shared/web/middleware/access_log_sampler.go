@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"hash/fnv"
+	"math"
+
+	"github.com/gin-gonic/gin"
+)
+
+// accessLogSampledKey stores the sampling decision read by AccessLogMiddleware
+const accessLogSampledKey = "accessLogSampled"
+
+// AccessLogSamplerMiddleware decides whether AccessLogMiddleware logs the request, keeping the
+// fraction routeRates[route] of the requests to each route pattern (e.g. "/health": 0.01) and
+// defaultRate of the others. The decision is a hash of the request ID, so systems receiving the
+// same request (e.g. mirrors) sample it the same way. It must run after RequestIDMiddleware
+// and before AccessLogMiddleware; requests without an ID are always logged
+func AccessLogSamplerMiddleware(routeRates map[string]float64, defaultRate float64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rate, ok := routeRates[c.FullPath()]
+		if !ok {
+			rate = defaultRate
+		}
+		c.Set(accessLogSampledKey, sampleRequest(ginRequestID(c), rate))
+		c.Next()
+	}
+}
+
+// sampleRequest maps the request ID to [0, 1) and keeps it when the value is below rate
+func sampleRequest(requestID string, rate float64) bool {
+	if rate >= 1 || requestID == "" {
+		return true
+	}
+	if rate <= 0 {
+		return false
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(requestID))
+	return float64(h.Sum64())/math.MaxUint64 < rate
+}
+
+// accessLogSampled reports whether AccessLogSamplerMiddleware kept the request (true without a sampler)
+func accessLogSampled(c *gin.Context) bool {
+	sampled, ok := c.Get(accessLogSampledKey)
+	if !ok {
+		return true
+	}
+	keep, _ := sampled.(bool)
+	return keep
+}
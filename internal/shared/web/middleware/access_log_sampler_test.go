@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newSampledAccessLogRouter serves /health and /products behind the sampler and the access log
+func newSampledAccessLogRouter(logs *recordingLogger, routeRates map[string]float64, defaultRate float64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestIDMiddleware(logs), AccessLogSamplerMiddleware(routeRates, defaultRate), AccessLogMiddleware(logs))
+	router.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/products", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+// sendWithRequestID sends GET path with the given request ID (none when empty)
+func sendWithRequestID(router http.Handler, path, requestID string) {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if requestID != "" {
+		req.Header.Set(RequestIDHeader, requestID)
+	}
+	router.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func TestAccessLogSamplerMiddleware_SamplesHealthChecks(t *testing.T) {
+	const requests = 1000
+	const rate = 0.01
+	logs := &recordingLogger{}
+	router := newSampledAccessLogRouter(logs, map[string]float64{"/health": rate}, 1)
+
+	for i := 0; i < requests; i++ {
+		// Fixed IDs keep the test deterministic; the hash spreads them like random ones
+		sendWithRequestID(router, "/health", fmt.Sprintf("probe-%d", i))
+	}
+
+	// Binomial(1000, 0.01): mean 10, standard deviation ~3.1; accept 4 standard deviations
+	logged := float64(len(logs.find("HTTP request")))
+	mean := requests * rate
+	bound := 4 * math.Sqrt(requests*rate*(1-rate))
+	if logged < math.Max(0, mean-bound) || logged > mean+bound {
+		t.Errorf("logged %v of %d health checks, want %v ± %.1f", logged, requests, mean, bound)
+	}
+}
+
+func TestAccessLogSamplerMiddleware_DeterministicPerRequestID(t *testing.T) {
+	rates := map[string]float64{"/health": 0.5}
+	first, second := &recordingLogger{}, &recordingLogger{}
+	routers := []*gin.Engine{newSampledAccessLogRouter(first, rates, 1), newSampledAccessLogRouter(second, rates, 1)}
+
+	for i := 0; i < 200; i++ {
+		for _, router := range routers {
+			sendWithRequestID(router, "/health", fmt.Sprintf("req-%d", i))
+		}
+	}
+
+	kept := func(logs *recordingLogger) map[string]bool {
+		ids := make(map[string]bool)
+		for _, entry := range logs.find("HTTP request") {
+			ids[entry.fields["request_id"].(string)] = true
+		}
+		return ids
+	}
+	firstIDs, secondIDs := kept(first), kept(second)
+	if len(firstIDs) == 0 || len(firstIDs) == 200 {
+		t.Fatalf("kept %d of 200 requests, want about half", len(firstIDs))
+	}
+	if len(firstIDs) != len(secondIDs) {
+		t.Fatalf("routers kept %d and %d requests, want the same", len(firstIDs), len(secondIDs))
+	}
+	for id := range firstIDs {
+		if !secondIDs[id] {
+			t.Errorf("%s kept by one router only", id)
+		}
+	}
+}
+
+func TestAccessLogSamplerMiddleware_DefaultRate(t *testing.T) {
+	tests := []struct {
+		name        string
+		defaultRate float64
+		path        string
+		requestID   string
+		want        int
+	}{
+		{name: "unlisted route keeps all", defaultRate: 1, path: "/products", want: 100},
+		{name: "unlisted route drops all", defaultRate: 0, path: "/products", want: 0},
+		{name: "listed route ignores the default", defaultRate: 1, path: "/health", want: 0},
+		{name: "unmatched route uses the default", defaultRate: 0, path: "/unknown", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := &recordingLogger{}
+			router := newSampledAccessLogRouter(logs, map[string]float64{"/health": 0}, tt.defaultRate)
+			for i := 0; i < 100; i++ {
+				sendWithRequestID(router, tt.path, "")
+			}
+			if got := len(logs.find("HTTP request")); got != tt.want {
+				t.Errorf("logged %d of 100, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSampleRequest_WithoutRequestID(t *testing.T) {
+	if !sampleRequest("", 0) {
+		t.Error("request without an ID dropped, want it always logged")
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAccessLogMiddleware_LogsOneEntryPerRequest(t *testing.T) {
+	logs := &recordingLogger{}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestIDMiddleware(logs), AccessLogMiddleware(logs))
+	router.GET("/products/:id", func(c *gin.Context) {
+		c.String(http.StatusOK, "keyboard")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/products/42?fields=name", nil)
+	req.Header.Set(RequestIDHeader, "req-123")
+	req.Header.Set("User-Agent", "test-agent/1.0")
+	req.RemoteAddr = "203.0.113.7:5555"
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := logs.find("HTTP request")
+	if len(entries) != 1 {
+		t.Fatalf("logged %d access entries, want 1", len(entries))
+	}
+	if entries[0].level != "info" {
+		t.Errorf("level = %s, want info", entries[0].level)
+	}
+
+	// Decode the fields as they are serialized in the JSON log line
+	raw, err := json.Marshal(entries[0].fields)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	want := map[string]any{
+		"method":        "GET",
+		"path":          "/products/42",
+		"status":        float64(200),
+		"request_id":    "req-123",
+		"ip":            "203.0.113.7",
+		"user_agent":    "test-agent/1.0",
+		"response_size": float64(len("keyboard")),
+	}
+	for key, value := range want {
+		if fields[key] != value {
+			t.Errorf("%s = %v, want %v", key, fields[key], value)
+		}
+	}
+	if duration, ok := fields["duration_ms"].(float64); !ok || duration < 0 {
+		t.Errorf("duration_ms = %v, want a non-negative number", fields["duration_ms"])
+	}
+}
+
+func TestAccessLogMiddleware_LogsErrorStatus(t *testing.T) {
+	logs := &recordingLogger{}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(AccessLogMiddleware(logs))
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+	entries := logs.find("HTTP request")
+	if len(entries) != 1 || entries[0].fields["status"] != http.StatusNotFound {
+		t.Errorf("entries = %+v, want one entry with status 404", entries)
+	}
+}
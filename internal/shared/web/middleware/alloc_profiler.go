@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"runtime"
+
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// AllocProfilerMiddleware measures the bytes allocated while the rest of the chain runs
+// (the runtime.MemStats TotalAlloc delta) to attribute GC pressure to endpoints
+// Every request records the http.request.alloc_delta_bytes histogram by route and
+// requests above threshold bytes are logged as a warning
+//
+// For profiling only: runtime.GC and runtime.ReadMemStats stop the world twice per
+// request, and TotalAlloc is process-wide, so concurrent requests inflate each other's
+// deltas. The GC before each read makes single-request measurements (e.g. benchmarks)
+// deterministic
+func AllocProfilerMiddleware(threshold uint64) gin.HandlerFunc {
+	allocHistogram, _ := otel.Meter("alloc_profiler").Int64Histogram(
+		"http.request.alloc_delta_bytes",
+		metric.WithDescription("Bytes allocated while handling a request"),
+		metric.WithUnit("By"),
+	)
+
+	return func(c *gin.Context) {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		c.Next()
+
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		delta := after.TotalAlloc - before.TotalAlloc
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unknown"
+		}
+		ctx := c.Request.Context()
+		allocHistogram.Record(ctx, int64(delta), metric.WithAttributes(
+			attribute.String("http.method", c.Request.Method),
+			attribute.String("http.route", route),
+		))
+
+		if delta > threshold {
+			logger.FromContext(ctx).Warn(ctx, "Request allocated more memory than the profiler threshold", logger.CustomFields{
+				"method":            c.Request.Method,
+				"path":              c.Request.URL.Path,
+				"alloc_bytes_delta": delta,
+			})
+		}
+	}
+}
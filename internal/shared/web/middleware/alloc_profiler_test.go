@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// allocSize is what the handler of newAllocRouter allocates per request
+const allocSize = 4 << 20
+
+// allocSink keeps the handler allocation on the heap
+var allocSink []byte
+
+// newAllocRouter serves GET /products/:id, allocating allocSize bytes, behind AllocProfilerMiddleware
+func newAllocRouter(log logger.Logger, threshold uint64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(logger.WithLogger(c.Request.Context(), log))
+	}, AllocProfilerMiddleware(threshold))
+	router.GET("/products/:id", func(c *gin.Context) {
+		allocSink = make([]byte, allocSize)
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func getProduct(router http.Handler) {
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/products/42", nil))
+}
+
+// measuredDelta returns the alloc_bytes_delta of the last profiler warning
+func measuredDelta(t testing.TB, log *recordingLogger) uint64 {
+	t.Helper()
+	warnings := log.find("Request allocated more memory than the profiler threshold")
+	if len(warnings) == 0 {
+		t.Fatal("no profiler warning logged")
+	}
+	return warnings[len(warnings)-1].fields["alloc_bytes_delta"].(uint64)
+}
+
+// assertWithin5Percent fails unless delta is within 5% of allocSize
+func assertWithin5Percent(t testing.TB, delta uint64) {
+	t.Helper()
+	if diff := math.Abs(float64(delta) - allocSize); diff > 0.05*allocSize {
+		t.Errorf("alloc_bytes_delta = %d, want %d ±5%%", delta, allocSize)
+	}
+}
+
+func TestAllocProfilerMiddleware_MeasuresHandlerAllocation(t *testing.T) {
+	log := &recordingLogger{}
+	router := newAllocRouter(log, 1)
+
+	getProduct(router)
+
+	warning := log.find("Request allocated more memory than the profiler threshold")
+	if len(warning) != 1 || warning[0].level != "warn" {
+		t.Fatalf("warnings = %+v, want one WARN", warning)
+	}
+	if warning[0].fields["method"] != http.MethodGet || warning[0].fields["path"] != "/products/42" {
+		t.Errorf("fields = %v, want the method and path", warning[0].fields)
+	}
+	assertWithin5Percent(t, measuredDelta(t, log))
+}
+
+func TestAllocProfilerMiddleware_BelowThresholdNotLogged(t *testing.T) {
+	log := &recordingLogger{}
+	router := newAllocRouter(log, 2*allocSize)
+
+	getProduct(router)
+
+	if len(log.entries) != 0 {
+		t.Errorf("logged %+v, want nothing below the threshold", log.entries)
+	}
+}
+
+func TestAllocProfilerMiddleware_RecordsHistogramByRoute(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	previous := otel.GetMeterProvider()
+	otel.SetMeterProvider(provider)
+	t.Cleanup(func() {
+		otel.SetMeterProvider(previous)
+		provider.Shutdown(context.Background())
+	})
+	router := newAllocRouter(&recordingLogger{}, 2*allocSize)
+
+	getProduct(router)
+	getProduct(router)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "http.request.alloc_delta_bytes" {
+				continue
+			}
+			points := m.Data.(metricdata.Histogram[int64]).DataPoints
+			if len(points) != 1 {
+				t.Fatalf("data points = %d, want one per route", len(points))
+			}
+			route, _ := points[0].Attributes.Value("http.route")
+			if route.AsString() != "/products/:id" || points[0].Count != 2 {
+				t.Errorf("route %s with %d observations, want /products/:id with 2", route.AsString(), points[0].Count)
+			}
+			if points[0].Sum < 2*allocSize {
+				t.Errorf("sum = %d, want at least the two handler allocations", points[0].Sum)
+			}
+			return
+		}
+	}
+	t.Error("http.request.alloc_delta_bytes not recorded")
+}
+
+func BenchmarkAllocProfilerMiddleware(b *testing.B) {
+	log := &recordingLogger{}
+	router := newAllocRouter(log, 1)
+	for b.Loop() {
+		getProduct(router)
+	}
+	assertWithin5Percent(b, measuredDelta(b, log))
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/shared/auth"
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+)
+
+// APIKeyHeader is the request header carrying the API key
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuth identifies the caller from the X-API-Key header and stores its APIKeyInfo in the context
+// Requests without a key pass through unauthenticated (RequireScope rejects them where needed);
+// requests with an unknown key are rejected with 401
+func APIKeyAuth(keys map[string]auth.APIKeyInfo) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(APIKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		info, ok := keys[key]
+		if !ok {
+			c.AbortWithStatusJSON(app_errors.ErrUnauthorized.Status, app_errors.ErrUnauthorized)
+			return
+		}
+
+		c.Set(APIKeyInfoContextKey, info)
+		c.Next()
+	}
+}
+
+// anonymousKey is the key of the principal granted by AnonymousAuth
+const anonymousKey = "anonymous"
+
+// AnonymousAuth grants every request an anonymous principal with all scopes
+// Use it only when authentication is disabled (e.g. local development)
+func AnonymousAuth() gin.HandlerFunc {
+	anonymous := auth.APIKeyInfo{Key: anonymousKey, Scopes: []string{auth.ScopeAll}}
+	return func(c *gin.Context) {
+		c.Set(APIKeyInfoContextKey, anonymous)
+		c.Next()
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// B3TraceIDHeader carries the trace ID in the B3 multi-header encoding
+const B3TraceIDHeader = "X-B3-TraceId"
+
+// b3Propagator reads both the multi-header (X-B3-*) and single-header (b3) encodings
+var b3Propagator = b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader))
+
+// B3PropagationMiddleware continues Zipkin B3 traces (X-B3-TraceId/X-B3-SpanId)
+// When X-B3-TraceId is present, the remote span context is injected into the request
+// context so spans started later (including the tracing middleware's) join that trace
+// Must be registered before the tracing middleware
+func B3PropagationMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader(B3TraceIDHeader) == "" {
+			c.Next()
+			return
+		}
+
+		ctx := b3Propagator.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
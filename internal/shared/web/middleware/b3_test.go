@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newB3Router serves GET /products behind B3PropagationMiddleware, reporting the
+// span context of a span started by the handler
+func newB3Router(started *trace.SpanContext, parent *trace.SpanContext) *gin.Engine {
+	tracer := sdktrace.NewTracerProvider().Tracer("test")
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(B3PropagationMiddleware())
+	router.GET("/products", func(c *gin.Context) {
+		*parent = trace.SpanContextFromContext(c.Request.Context())
+		_, span := tracer.Start(c.Request.Context(), "handler")
+		defer span.End()
+		*started = span.SpanContext()
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestB3PropagationMiddleware(t *testing.T) {
+	const traceID, spanID = "463ac35c9f6413ad48485a3953bb6124", "a2fb4a1d1a96d312"
+
+	tests := []struct {
+		name      string
+		headers   map[string]string
+		wantTrace string
+	}{
+		{name: "multi-header", headers: map[string]string{"X-B3-TraceId": traceID, "X-B3-SpanId": spanID, "X-B3-Sampled": "1"}, wantTrace: traceID},
+		{name: "64-bit trace ID", headers: map[string]string{"X-B3-TraceId": "48485a3953bb6124", "X-B3-SpanId": spanID, "X-B3-Sampled": "1"},
+			wantTrace: "000000000000000048485a3953bb6124"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var started, parent trace.SpanContext
+			router := newB3Router(&started, &parent)
+
+			req := httptest.NewRequest(http.MethodGet, "/products", nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			if started.TraceID().String() != tt.wantTrace {
+				t.Errorf("span trace ID = %s, want %s", started.TraceID(), tt.wantTrace)
+			}
+			if !parent.IsRemote() || parent.SpanID().String() != spanID || !parent.IsSampled() {
+				t.Errorf("parent = %+v, want the remote sampled B3 span %s", parent, spanID)
+			}
+		})
+	}
+
+	t.Run("no B3 headers", func(t *testing.T) {
+		var started, parent trace.SpanContext
+		router := newB3Router(&started, &parent)
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/products", nil))
+
+		if parent.IsValid() {
+			t.Errorf("parent = %+v, want none", parent)
+		}
+		if !started.IsValid() || started.TraceID().String() == traceID {
+			t.Errorf("span = %+v, want a new trace", started)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+)
+
+// jsonContentType is the media type required by RequireJSONContentType (parameters such as
+// "; charset=utf-8" are ignored)
+const jsonContentType = "application/json"
+
+// RequireJSONContentType rejects POST, PUT and PATCH requests with a body whose Content-Type is not
+// application/json with 415 (HTTP0415). exceptions maps "METHOD /route/:pattern" to the media types
+// that route accepts instead (e.g. "POST /products/import": {"multipart/form-data"}); a pattern also
+// matches the route mounted under a module prefix. Requests without a body and unknown routes pass
+func RequireJSONContentType(exceptions map[string][]string) gin.HandlerFunc {
+	jsonOnly := app_errors.ErrUnsupportedContentType.WithSupportedMediaTypes([]string{jsonContentType})
+
+	return func(c *gin.Context) {
+		method := c.Request.Method
+		if method != http.MethodPost && method != http.MethodPut && method != http.MethodPatch {
+			c.Next()
+			return
+		}
+		route := c.FullPath()
+		if route == "" || c.Request.ContentLength == 0 {
+			c.Next()
+			return
+		}
+
+		contentType := strings.ToLower(c.ContentType())
+		if contentType == jsonContentType {
+			c.Next()
+			return
+		}
+		allowed := routeContentTypes(exceptions, method, route)
+		for _, t := range allowed {
+			if contentType == t {
+				c.Next()
+				return
+			}
+		}
+
+		problem := jsonOnly
+		if len(allowed) > 0 {
+			problem = app_errors.ErrUnsupportedContentType.WithSupportedMediaTypes(append([]string{jsonContentType}, allowed...))
+		}
+		c.AbortWithStatusJSON(problem.Status, problem)
+	}
+}
+
+// routeContentTypes returns the exception media types of route, which may carry a module prefix
+func routeContentTypes(exceptions map[string][]string, method, route string) []string {
+	for key, types := range exceptions {
+		exceptionMethod, pattern, ok := strings.Cut(key, " ")
+		if !ok || exceptionMethod != method {
+			continue
+		}
+		if route == pattern || (strings.HasPrefix(pattern, "/") && strings.HasSuffix(route, pattern)) {
+			return types
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequireJSONContentType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequireJSONContentType(map[string][]string{
+		"POST /products/import": {"multipart/form-data"},
+	}))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/products", ok)
+	router.POST("/products", ok)
+	router.PUT("/products/:id", ok)
+	router.PATCH("/products/:id", ok)
+	router.POST("/v1/products/import", ok)
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		body        string
+		want        int
+		wantTypes   []string
+	}{
+		{name: "POST JSON", method: http.MethodPost, path: "/products", contentType: "application/json", body: "{}", want: http.StatusOK},
+		{name: "POST JSON with charset", method: http.MethodPost, path: "/products", contentType: "application/json; charset=utf-8", body: "{}", want: http.StatusOK},
+		{name: "POST uppercase JSON", method: http.MethodPost, path: "/products", contentType: "Application/JSON", body: "{}", want: http.StatusOK},
+		{name: "POST text", method: http.MethodPost, path: "/products", contentType: "text/plain", body: "{}", want: http.StatusUnsupportedMediaType, wantTypes: []string{"application/json"}},
+		{name: "POST without Content-Type", method: http.MethodPost, path: "/products", body: "{}", want: http.StatusUnsupportedMediaType, wantTypes: []string{"application/json"}},
+		{name: "PUT form", method: http.MethodPut, path: "/products/1", contentType: "application/x-www-form-urlencoded", body: "a=b", want: http.StatusUnsupportedMediaType, wantTypes: []string{"application/json"}},
+		{name: "PATCH XML", method: http.MethodPatch, path: "/products/1", contentType: "application/xml", body: "<a/>", want: http.StatusUnsupportedMediaType, wantTypes: []string{"application/json"}},
+		{name: "GET bypassed", method: http.MethodGet, path: "/products", contentType: "text/plain", body: "x", want: http.StatusOK},
+		{name: "POST without body", method: http.MethodPost, path: "/products", contentType: "text/plain", want: http.StatusOK},
+		{name: "exception under a prefix", method: http.MethodPost, path: "/v1/products/import", contentType: "multipart/form-data; boundary=x", body: "x", want: http.StatusOK},
+		{name: "exception still accepts JSON", method: http.MethodPost, path: "/v1/products/import", contentType: "application/json", body: "{}", want: http.StatusOK},
+		{name: "exception rejects other types", method: http.MethodPost, path: "/v1/products/import", contentType: "text/csv", body: "x", want: http.StatusUnsupportedMediaType, wantTypes: []string{"application/json", "multipart/form-data"}},
+		{name: "multipart outside the exceptions", method: http.MethodPost, path: "/products", contentType: "multipart/form-data; boundary=x", body: "x", want: http.StatusUnsupportedMediaType, wantTypes: []string{"application/json"}},
+		{name: "unknown route", method: http.MethodPost, path: "/unknown", contentType: "text/plain", body: "x", want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+			if tt.want != http.StatusUnsupportedMediaType {
+				return
+			}
+			var problem struct {
+				Code                string   `json:"code"`
+				SupportedMediaTypes []string `json:"supported_media_types"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+			if problem.Code != "HTTP0415" || !reflect.DeepEqual(problem.SupportedMediaTypes, tt.wantTypes) {
+				t.Errorf("problem = %+v, want HTTP0415 with %v", problem, tt.wantTypes)
+			}
+		})
+	}
+}
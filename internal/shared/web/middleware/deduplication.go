@@ -0,0 +1,235 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+	webcontext "github.com/refortunato/go_app_base/internal/shared/web/context"
+)
+
+// DeduplicationStore records request keys for a time window
+// MarkIfAbsent must atomically report whether the key was already seen and record it otherwise
+type DeduplicationStore interface {
+	MarkIfAbsent(ctx context.Context, key string, window time.Duration) (duplicate bool, err error)
+	Release(ctx context.Context, key string) error
+}
+
+// IdempotencyKeyHeader lets clients name a request; retries with the same key are deduplicated
+// whatever their body
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// DeduplicationMiddleware rejects repeated POST/PUT requests seen within windowDuration
+// using an in-memory store. keyFn defaults to SHA256(subject + method + path + body), where subject
+// is the authenticated principal (see principalSubject); the Idempotency-Key header replaces the body
+// Mount it only on non-idempotent routes: a legitimate repeat of a PUT must not be rejected
+func DeduplicationMiddleware(windowDuration time.Duration, keyFn func(*http.Request) string) gin.HandlerFunc {
+	return DeduplicationMiddlewareWithStore(NewInMemoryDeduplicationStore(), windowDuration, keyFn)
+}
+
+// DeduplicationMiddlewareWithStore is like DeduplicationMiddleware but uses the given store,
+// allowing deduplication to be shared across instances (e.g. RedisDeduplicationStore)
+func DeduplicationMiddlewareWithStore(store DeduplicationStore, windowDuration time.Duration, keyFn func(*http.Request) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodPost && c.Request.Method != http.MethodPut {
+			c.Next()
+			return
+		}
+
+		var key string
+		if keyFn != nil {
+			key = keyFn(c.Request)
+		} else {
+			key = contextDeduplicationKey(c)
+		}
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		duplicate, err := store.MarkIfAbsent(ctx, key, windowDuration)
+		if err != nil {
+			// Fail open: deduplication is best effort and must not block traffic
+			c.Next()
+			return
+		}
+		if duplicate {
+			c.Header("X-Duplicate-Request", "true")
+			c.AbortWithStatusJSON(app_errors.ErrDuplicateRequest.Status, app_errors.ErrDuplicateRequest)
+			return
+		}
+
+		c.Next()
+
+		// Failed requests may be retried, so they must not be treated as duplicates
+		if c.Writer.Status() >= http.StatusBadRequest {
+			_ = store.Release(ctx, key)
+		}
+	}
+}
+
+// DefaultDeduplicationKey hashes method, path and body (or the Idempotency-Key header),
+// restoring the body for later handlers. It has no access to the authenticated principal,
+// so identical requests from different callers share a key
+func DefaultDeduplicationKey(r *http.Request) string {
+	if idempotencyKey := r.Header.Get(IdempotencyKeyHeader); idempotencyKey != "" {
+		return deduplicationHash("", r.Method, r.URL.Path, []byte(idempotencyKeyPrefix+idempotencyKey))
+	}
+	var body []byte
+	if r.Body != nil {
+		var err error
+		if body, err = io.ReadAll(r.Body); err != nil {
+			return ""
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return deduplicationHash("", r.Method, r.URL.Path, body)
+}
+
+// idempotencyKeyPrefix keeps an Idempotency-Key from colliding with a body of the same bytes
+const idempotencyKeyPrefix = "idempotency-key:"
+
+// contextDeduplicationKey is DefaultDeduplicationKey scoped to the authenticated principal,
+// reading the body through WebContext.GetBody
+func contextDeduplicationKey(c *gin.Context) string {
+	subject := principalSubject(c)
+	if idempotencyKey := c.GetHeader(IdempotencyKeyHeader); idempotencyKey != "" {
+		return deduplicationHash(subject, c.Request.Method, c.Request.URL.Path, []byte(idempotencyKeyPrefix+idempotencyKey))
+	}
+	body, err := webcontext.NewGinContextAdapter(c).GetBody()
+	if err != nil {
+		return ""
+	}
+	return deduplicationHash(subject, c.Request.Method, c.Request.URL.Path, body)
+}
+
+// deduplicationHash returns the hex SHA256 of subject, method, path and body
+// Each part is length-prefixed, so no two different inputs produce the same sequence
+func deduplicationHash(subject, method, path string, body []byte) string {
+	hash := sha256.New()
+	for _, part := range [][]byte{[]byte(subject), []byte(method), []byte(path), body} {
+		fmt.Fprintf(hash, "%d:", len(part))
+		hash.Write(part)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+type deduplicationEntry struct {
+	key    string
+	seenAt time.Time
+}
+
+// InMemoryDeduplicationStore keeps keys in a time-ordered ring buffer
+// Expired entries are evicted lazily on each call, so no background goroutine is needed
+type InMemoryDeduplicationStore struct {
+	mu      sync.Mutex
+	entries []deduplicationEntry
+	head    int
+	size    int
+	seen    map[string]time.Time
+	now     func() time.Time
+}
+
+// NewInMemoryDeduplicationStore creates an empty in-memory store
+func NewInMemoryDeduplicationStore() *InMemoryDeduplicationStore {
+	return &InMemoryDeduplicationStore{
+		entries: make([]deduplicationEntry, 64),
+		seen:    make(map[string]time.Time),
+		now:     time.Now,
+	}
+}
+
+func (s *InMemoryDeduplicationStore) MarkIfAbsent(ctx context.Context, key string, window time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.expire(now, window)
+
+	if _, ok := s.seen[key]; ok {
+		return true, nil
+	}
+
+	s.push(deduplicationEntry{key: key, seenAt: now})
+	s.seen[key] = now
+	return false, nil
+}
+
+func (s *InMemoryDeduplicationStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The ring entry is left in place and skipped when it expires
+	delete(s.seen, key)
+	return nil
+}
+
+// expire drops entries older than window from the head of the ring
+func (s *InMemoryDeduplicationStore) expire(now time.Time, window time.Duration) {
+	for s.size > 0 {
+		entry := s.entries[s.head]
+		if now.Sub(entry.seenAt) < window {
+			return
+		}
+		// Only delete if the key was not released and re-marked since
+		if seenAt, ok := s.seen[entry.key]; ok && seenAt.Equal(entry.seenAt) {
+			delete(s.seen, entry.key)
+		}
+		s.entries[s.head] = deduplicationEntry{}
+		s.head = (s.head + 1) % len(s.entries)
+		s.size--
+	}
+}
+
+// push appends an entry at the tail, doubling the ring when it is full
+func (s *InMemoryDeduplicationStore) push(entry deduplicationEntry) {
+	if s.size == len(s.entries) {
+		grown := make([]deduplicationEntry, len(s.entries)*2)
+		for i := 0; i < s.size; i++ {
+			grown[i] = s.entries[(s.head+i)%len(s.entries)]
+		}
+		s.entries = grown
+		s.head = 0
+	}
+	s.entries[(s.head+s.size)%len(s.entries)] = entry
+	s.size++
+}
+
+// RedisClient is the minimal Redis API needed by RedisDeduplicationStore
+// Adapt your Redis client of choice (e.g. go-redis) to this interface
+type RedisClient interface {
+	SetNX(ctx context.Context, key string, value any, expiration time.Duration) (bool, error)
+	Del(ctx context.Context, keys ...string) error
+}
+
+// RedisDeduplicationStore shares deduplication state across instances using SET NX with a TTL
+type RedisDeduplicationStore struct {
+	client RedisClient
+	prefix string
+}
+
+// NewRedisDeduplicationStore creates a Redis-backed store; keys are namespaced with prefix
+func NewRedisDeduplicationStore(client RedisClient, prefix string) *RedisDeduplicationStore {
+	return &RedisDeduplicationStore{client: client, prefix: prefix}
+}
+
+func (s *RedisDeduplicationStore) MarkIfAbsent(ctx context.Context, key string, window time.Duration) (bool, error) {
+	created, err := s.client.SetNX(ctx, s.prefix+key, 1, window)
+	if err != nil {
+		return false, err
+	}
+	return !created, nil
+}
+
+func (s *RedisDeduplicationStore) Release(ctx context.Context, key string) error {
+	return s.client.Del(ctx, s.prefix+key)
+}
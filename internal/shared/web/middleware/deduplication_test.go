@@ -0,0 +1,272 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/shared/auth"
+)
+
+func newDeduplicationRouter(handlerStatus int, handlerDelay time.Duration) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(DeduplicationMiddleware(time.Minute, nil))
+	handler := func(c *gin.Context) {
+		time.Sleep(handlerDelay)
+		c.Status(handlerStatus)
+	}
+	router.POST("/products", handler)
+	router.GET("/products", handler)
+	return router
+}
+
+func sendJSON(router *gin.Engine, method, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/products", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestDeduplicationMiddleware_ConcurrentIdenticalPosts(t *testing.T) {
+	router := newDeduplicationRouter(http.StatusCreated, 20*time.Millisecond)
+	body := `{"name":"Laptop","price":10}`
+
+	start := make(chan struct{})
+	responses := make([]*httptest.ResponseRecorder, 2)
+	var wg sync.WaitGroup
+	for i := range responses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			responses[i] = sendJSON(router, http.MethodPost, body)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	counts := map[int]int{}
+	for _, w := range responses {
+		counts[w.Code]++
+		if w.Code == http.StatusConflict && w.Header().Get("X-Duplicate-Request") != "true" {
+			t.Error("409 response without X-Duplicate-Request: true")
+		}
+	}
+	if counts[http.StatusCreated] != 1 || counts[http.StatusConflict] != 1 {
+		t.Errorf("status codes = %v, want exactly one 201 and one 409", counts)
+	}
+}
+
+func TestDeduplicationMiddleware_DifferentBodiesAndMethods(t *testing.T) {
+	router := newDeduplicationRouter(http.StatusCreated, 0)
+
+	if w := sendJSON(router, http.MethodPost, `{"name":"A"}`); w.Code != http.StatusCreated {
+		t.Fatalf("first POST: status = %d", w.Code)
+	}
+	if w := sendJSON(router, http.MethodPost, `{"name":"B"}`); w.Code != http.StatusCreated {
+		t.Errorf("POST with another body: status = %d, want %d", w.Code, http.StatusCreated)
+	}
+	for i := 0; i < 2; i++ {
+		if w := sendJSON(router, http.MethodGet, ""); w.Code != http.StatusCreated {
+			t.Errorf("GET %d: status = %d, GET must not be deduplicated", i, w.Code)
+		}
+	}
+}
+
+func TestDeduplicationMiddleware_FailedRequestCanBeRetried(t *testing.T) {
+	router := newDeduplicationRouter(http.StatusServiceUnavailable, 0)
+	body := `{"name":"Laptop"}`
+
+	for i := 0; i < 2; i++ {
+		if w := sendJSON(router, http.MethodPost, body); w.Code != http.StatusServiceUnavailable {
+			t.Errorf("attempt %d: status = %d, want the handler's %d", i, w.Code, http.StatusServiceUnavailable)
+		}
+	}
+}
+
+func TestInMemoryDeduplicationStore_ExpiresAfterWindow(t *testing.T) {
+	store := NewInMemoryDeduplicationStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if duplicate, _ := store.MarkIfAbsent(ctx, "key", time.Minute); duplicate {
+		t.Fatal("first mark reported a duplicate")
+	}
+	now = now.Add(30 * time.Second)
+	if duplicate, _ := store.MarkIfAbsent(ctx, "key", time.Minute); !duplicate {
+		t.Error("mark inside the window was not reported as a duplicate")
+	}
+	now = now.Add(31 * time.Second)
+	if duplicate, _ := store.MarkIfAbsent(ctx, "key", time.Minute); duplicate {
+		t.Error("mark after the window was reported as a duplicate")
+	}
+}
+
+// newAuthenticatedDeduplicationRouter identifies callers by API key like the API does
+func newAuthenticatedDeduplicationRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(APIKeyAuth(auth.ParseAPIKeys("key-a=product:write,key-b=product:write")))
+	router.Use(DeduplicationMiddleware(time.Minute, nil))
+	router.POST("/products", func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+	return router
+}
+
+func sendAs(router *gin.Engine, apiKey, idempotencyKey, body string) int {
+	req := httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		req.Header.Set(APIKeyHeader, apiKey)
+	}
+	if idempotencyKey != "" {
+		req.Header.Set(IdempotencyKeyHeader, idempotencyKey)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestDeduplicationMiddleware_KeyedByCaller(t *testing.T) {
+	router := newAuthenticatedDeduplicationRouter()
+	body := `{"name":"Laptop"}`
+
+	if code := sendAs(router, "key-a", "", body); code != http.StatusCreated {
+		t.Fatalf("key-a: status = %d, want 201", code)
+	}
+	if code := sendAs(router, "key-b", "", body); code != http.StatusCreated {
+		t.Errorf("key-b with the same body: status = %d, want 201 (another caller)", code)
+	}
+	if code := sendAs(router, "key-a", "", body); code != http.StatusConflict {
+		t.Errorf("key-a retry: status = %d, want 409", code)
+	}
+}
+
+func TestDeduplicationMiddleware_IdempotencyKey(t *testing.T) {
+	router := newAuthenticatedDeduplicationRouter()
+
+	if code := sendAs(router, "key-a", "order-1", `{"name":"Laptop"}`); code != http.StatusCreated {
+		t.Fatalf("first request: status = %d, want 201", code)
+	}
+	// The key identifies the operation, whatever the body
+	if code := sendAs(router, "key-a", "order-1", `{"name":"Laptop","price":10}`); code != http.StatusConflict {
+		t.Errorf("same Idempotency-Key, other body: status = %d, want 409", code)
+	}
+	if code := sendAs(router, "key-a", "order-2", `{"name":"Laptop"}`); code != http.StatusCreated {
+		t.Errorf("new Idempotency-Key, same body: status = %d, want 201", code)
+	}
+	if code := sendAs(router, "key-b", "order-1", `{"name":"Laptop"}`); code != http.StatusCreated {
+		t.Errorf("Idempotency-Key of another caller: status = %d, want 201", code)
+	}
+}
+
+func TestDeduplicationMiddleware_AnonymousCallersKeyedByIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(AnonymousAuth())
+	router.Use(DeduplicationMiddleware(time.Minute, nil))
+	router.POST("/products", func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	send := func(remoteAddr string) int {
+		req := httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(`{"name":"Laptop"}`))
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := send("10.0.0.1:1234"); code != http.StatusCreated {
+		t.Fatalf("first client: status = %d, want 201", code)
+	}
+	if code := send("10.0.0.2:1234"); code != http.StatusCreated {
+		t.Errorf("second client: status = %d, want 201", code)
+	}
+	if code := send("10.0.0.1:5678"); code != http.StatusConflict {
+		t.Errorf("first client retry: status = %d, want 409", code)
+	}
+}
+
+func TestInMemoryDeduplicationStore_RingGrowsAndExpiresInOrder(t *testing.T) {
+	store := NewInMemoryDeduplicationStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// More keys than the initial ring capacity, 200ms apart (all inside the window)
+	const keys = 150
+	for i := 0; i < keys; i++ {
+		if duplicate, _ := store.MarkIfAbsent(ctx, fmt.Sprintf("key-%d", i), time.Minute); duplicate {
+			t.Fatalf("key-%d reported as a duplicate on first mark", i)
+		}
+		now = now.Add(200 * time.Millisecond)
+	}
+	if len(store.entries) < keys || store.size != keys {
+		t.Fatalf("ring capacity = %d, size = %d, want room for %d entries", len(store.entries), store.size, keys)
+	}
+
+	// 45s later keys 0..75 (marked up to 15s after the first) are older than the window
+	now = now.Add(45 * time.Second)
+	if duplicate, _ := store.MarkIfAbsent(ctx, "key-149", time.Minute); !duplicate {
+		t.Error("recent key not reported as a duplicate after the ring grew")
+	}
+	if duplicate, _ := store.MarkIfAbsent(ctx, "key-0", time.Minute); duplicate {
+		t.Error("expired key reported as a duplicate")
+	}
+	if got, want := len(store.seen), keys-76+1; got != want {
+		t.Errorf("tracked keys = %d, want %d after evicting the expired ones", got, want)
+	}
+}
+
+func TestInMemoryDeduplicationStore_ReleasedKeyMarkedAgain(t *testing.T) {
+	store := NewInMemoryDeduplicationStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	store.MarkIfAbsent(ctx, "key", time.Minute)
+	store.Release(ctx, "key")
+	if duplicate, _ := store.MarkIfAbsent(ctx, "key", time.Minute); duplicate {
+		t.Fatal("released key reported as a duplicate")
+	}
+
+	// Re-marked 40s later: the stale ring entry of the first mark must not evict the new one
+	now = now.Add(40 * time.Second)
+	store.Release(ctx, "key")
+	store.MarkIfAbsent(ctx, "key", time.Minute)
+	now = now.Add(30 * time.Second)
+	if duplicate, _ := store.MarkIfAbsent(ctx, "key", time.Minute); !duplicate {
+		t.Error("key re-marked 30s ago was evicted by the expired entry of its first mark")
+	}
+}
+
+func TestDeduplicationMiddleware_HandlerStillReadsBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(DeduplicationMiddleware(time.Minute, nil))
+	router.POST("/products", func(c *gin.Context) {
+		var product struct {
+			Name string `json:"name"`
+		}
+		if err := c.BindJSON(&product); err != nil {
+			return
+		}
+		c.String(http.StatusCreated, product.Name)
+	})
+
+	w := sendJSON(router, http.MethodPost, `{"name":"Laptop"}`)
+	if w.Code != http.StatusCreated || w.Body.String() != "Laptop" {
+		t.Errorf("POST = %d %q, want 201 with the bound name", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// sunsetDateLayouts are the formats accepted by ParseSunsetDate
+var sunsetDateLayouts = []string{"2006-01-02", time.RFC3339}
+
+// DeprecationMiddleware marks every response as deprecated (RFC 8594 / draft-ietf-httpapi-deprecation-header)
+// Sets "Deprecation: true", "Sunset: <HTTP-date>" and, when deprecationLink is not empty,
+// "Link: <deprecationLink>; rel=\"deprecation\""
+func DeprecationMiddleware(sunsetDate time.Time, deprecationLink string) gin.HandlerFunc {
+	// RFC 7231 IMF-fixdate, always in GMT
+	sunset := sunsetDate.UTC().Format(http.TimeFormat)
+	link := ""
+	if deprecationLink != "" {
+		link = fmt.Sprintf("<%s>; rel=\"deprecation\"", deprecationLink)
+	}
+
+	return func(c *gin.Context) {
+		c.Header("Deprecation", "true")
+		c.Header("Sunset", sunset)
+		if link != "" {
+			c.Header("Link", link)
+		}
+		c.Next()
+	}
+}
+
+// ParseSunsetDate parses a sunset date given as YYYY-MM-DD (midnight UTC) or RFC 3339
+func ParseSunsetDate(value string) (time.Time, error) {
+	for _, layout := range sunsetDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid sunset date %q: expected YYYY-MM-DD or RFC 3339", value)
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDeprecationMiddleware(t *testing.T) {
+	// 18:00 in São Paulo is 21:00 GMT
+	sunset := time.Date(2025, 6, 30, 18, 0, 0, 0, time.FixedZone("BRT", -3*60*60))
+
+	tests := []struct {
+		name     string
+		link     string
+		wantLink string
+	}{
+		{name: "with link", link: "https://api.example.com/docs/v2-migration", wantLink: `<https://api.example.com/docs/v2-migration>; rel="deprecation"`},
+		{name: "without link"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			v1 := router.Group("/v1", DeprecationMiddleware(sunset, tt.link))
+			v1.GET("/products", func(c *gin.Context) { c.Status(http.StatusOK) })
+			router.GET("/v2/products", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/products", nil))
+			want := map[string]string{
+				"Deprecation": "true",
+				"Sunset":      "Mon, 30 Jun 2025 21:00:00 GMT",
+				"Link":        tt.wantLink,
+			}
+			for header, value := range want {
+				if got := w.Header().Get(header); got != value {
+					t.Errorf("%s = %q, want %q", header, got, value)
+				}
+			}
+
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/products", nil))
+			if w.Header().Get("Deprecation") != "" || w.Header().Get("Sunset") != "" {
+				t.Errorf("routes outside the group are deprecated: %v", w.Header())
+			}
+		})
+	}
+}
+
+func TestParseSunsetDate(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "2025-06-30", want: time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)},
+		{value: "2025-06-30T18:00:00-03:00", want: time.Date(2025, 6, 30, 21, 0, 0, 0, time.UTC)},
+		{value: "30/06/2025", wantErr: true},
+		{value: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSunsetDate(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSunsetDate(%q): err = %v, want error %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !got.Equal(tt.want) {
+			t.Errorf("ParseSunsetDate(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
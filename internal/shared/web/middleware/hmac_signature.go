@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+	webcontext "github.com/refortunato/go_app_base/internal/shared/web/context"
+)
+
+// HMACSignatureMiddleware verifies webhook signatures: the hex-encoded HMAC of the raw body,
+// computed with secret and algo (e.g. crypto.SHA256), must match the value in headerName
+// Missing or wrong signatures are rejected with 401. The body stays readable by the handler
+// It panics when secret is empty or algo is not linked into the binary (fail fast)
+func HMACSignatureMiddleware(secret string, headerName string, algo crypto.Hash) gin.HandlerFunc {
+	if secret == "" {
+		panic("hmac signature middleware: empty secret")
+	}
+	if !algo.Available() {
+		panic(fmt.Sprintf("hmac signature middleware: hash %v is not available", algo))
+	}
+	key := []byte(secret)
+
+	return func(c *gin.Context) {
+		signature, err := hex.DecodeString(strings.TrimSpace(c.GetHeader(headerName)))
+		if err != nil || len(signature) == 0 {
+			c.AbortWithStatusJSON(app_errors.ErrInvalidSignature.Status, app_errors.ErrInvalidSignature)
+			return
+		}
+
+		body, err := webcontext.NewGinContextAdapter(c).GetBody()
+		if err != nil {
+			c.AbortWithStatusJSON(app_errors.ErrInvalidSignature.Status, app_errors.ErrInvalidSignature)
+			return
+		}
+
+		mac := hmac.New(algo.New, key)
+		mac.Write(body)
+		// Constant-time comparison so the signature cannot be guessed byte by byte
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			c.AbortWithStatusJSON(app_errors.ErrInvalidSignature.Status, app_errors.ErrInvalidSignature)
+			return
+		}
+
+		c.Next()
+	}
+}
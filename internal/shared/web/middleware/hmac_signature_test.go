@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	testWebhookSecret = "webhook-secret"
+	testSignatureHdr  = "X-Signature"
+)
+
+func newHMACRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(HMACSignatureMiddleware(testWebhookSecret, testSignatureHdr, crypto.SHA256))
+	router.POST("/webhooks", func(c *gin.Context) {
+		// The handler must still see the body the signature was computed on
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	})
+	return router
+}
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestHMACSignatureMiddleware(t *testing.T) {
+	const body = `{"event":"order.paid","id":"42"}`
+	router := newHMACRouter()
+
+	tests := []struct {
+		name      string
+		body      string
+		signature string
+		want      int
+	}{
+		{name: "valid signature", body: body, signature: sign(testWebhookSecret, body), want: http.StatusOK},
+		{name: "valid uppercase signature", body: body, signature: strings.ToUpper(sign(testWebhookSecret, body)), want: http.StatusOK},
+		{name: "wrong secret", body: body, signature: sign("other-secret", body), want: http.StatusUnauthorized},
+		{name: "tampered body", body: body + " ", signature: sign(testWebhookSecret, body), want: http.StatusUnauthorized},
+		{name: "missing signature", body: body, signature: "", want: http.StatusUnauthorized},
+		{name: "not hex", body: body, signature: "not-a-signature", want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(tt.body))
+			if tt.signature != "" {
+				req.Header.Set(testSignatureHdr, tt.signature)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+			if tt.want == http.StatusOK && w.Body.String() != tt.body {
+				t.Errorf("handler body = %q, want %q", w.Body.String(), tt.body)
+			}
+			if tt.want == http.StatusUnauthorized && !strings.Contains(w.Body.String(), "HMAC001") {
+				t.Errorf("expected HMAC001 in response, got %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHMACSignatureMiddleware_PanicsOnEmptySecret(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for an empty secret")
+		}
+	}()
+	HMACSignatureMiddleware("", testSignatureHdr, crypto.SHA256)
+}
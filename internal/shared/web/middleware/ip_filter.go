@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+)
+
+// IPFilterMiddleware rejects requests whose client IP is in blockCIDRs or not in allowCIDRs
+// The block list is checked first. "*" in allowCIDRs allows every address not blocked
+// Entries may be CIDRs or single IPs; invalid entries panic at construction (Conf.Validate
+// rejects invalid SERVER_APP_ADMIN_*_CIDRS values before routes are registered)
+func IPFilterMiddleware(allowCIDRs, blockCIDRs []string) gin.HandlerFunc {
+	allowAll := false
+	var allowNets []*net.IPNet
+	for _, cidr := range allowCIDRs {
+		if strings.TrimSpace(cidr) == "*" {
+			allowAll = true
+			continue
+		}
+		allowNets = append(allowNets, mustParseCIDRs([]string{cidr})...)
+	}
+	blockNets := mustParseCIDRs(blockCIDRs)
+
+	return func(c *gin.Context) {
+		ip := net.ParseIP(c.ClientIP())
+		if ip == nil || containsIP(blockNets, ip) || (!allowAll && !containsIP(allowNets, ip)) {
+			c.AbortWithStatusJSON(app_errors.ErrIPNotAllowed.Status, app_errors.ErrIPNotAllowed)
+			return
+		}
+		c.Next()
+	}
+}
+
+func mustParseCIDRs(cidrs []string) []*net.IPNet {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+
+		// Single addresses are treated as /32 (IPv4) or /128 (IPv6)
+		if !strings.Contains(cidr, "/") {
+			if ip := net.ParseIP(cidr); ip != nil && ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(fmt.Sprintf("invalid CIDR %q in IP filter: %v", cidr, err))
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets
+}
+
+func containsIP(nets []*net.IPNet, ip net.IP) bool {
+	for _, ipNet := range nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newIPFilterRouter(allowCIDRs, blockCIDRs []string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(IPFilterMiddleware(allowCIDRs, blockCIDRs))
+	router.GET("/admin/ping", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func requestFrom(router *gin.Engine, remoteAddr string) int {
+	req := httptest.NewRequest(http.MethodGet, "/admin/ping", nil)
+	req.RemoteAddr = remoteAddr
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestIPFilterMiddleware(t *testing.T) {
+	router := newIPFilterRouter(
+		[]string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fd00::/8", "2001:db8::1"},
+		[]string{"10.0.13.0/24", "fd00:bad::/32"},
+	)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		want       int
+	}{
+		{name: "RFC 1918 10/8", remoteAddr: "10.1.2.3:1234", want: http.StatusOK},
+		{name: "RFC 1918 172.16/12", remoteAddr: "172.31.255.1:1234", want: http.StatusOK},
+		{name: "RFC 1918 192.168/16", remoteAddr: "192.168.1.10:1234", want: http.StatusOK},
+		{name: "outside 172.16/12", remoteAddr: "172.32.0.1:1234", want: http.StatusForbidden},
+		{name: "public IPv4", remoteAddr: "8.8.8.8:1234", want: http.StatusForbidden},
+		{name: "blocked inside allowed range", remoteAddr: "10.0.13.7:1234", want: http.StatusForbidden},
+		{name: "IPv6 unique local", remoteAddr: "[fd00::1]:1234", want: http.StatusOK},
+		{name: "IPv6 single address", remoteAddr: "[2001:db8::1]:1234", want: http.StatusOK},
+		{name: "IPv6 public", remoteAddr: "[2001:4860:4860::8888]:1234", want: http.StatusForbidden},
+		{name: "IPv6 blocked", remoteAddr: "[fd00:bad::1]:1234", want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := requestFrom(router, tt.remoteAddr); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIPFilterMiddleware_WildcardStillHonoursBlockList(t *testing.T) {
+	router := newIPFilterRouter([]string{"*"}, []string{"203.0.113.0/24"})
+
+	if got := requestFrom(router, "8.8.8.8:1234"); got != http.StatusOK {
+		t.Errorf("public IP with wildcard: status = %d, want %d", got, http.StatusOK)
+	}
+	if got := requestFrom(router, "[2001:db8::2]:1234"); got != http.StatusOK {
+		t.Errorf("IPv6 with wildcard: status = %d, want %d", got, http.StatusOK)
+	}
+	if got := requestFrom(router, "203.0.113.9:1234"); got != http.StatusForbidden {
+		t.Errorf("blocked IP with wildcard: status = %d, want %d", got, http.StatusForbidden)
+	}
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+	webcontext "github.com/refortunato/go_app_base/internal/shared/web/context"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// compiledSchemas caches compiled schemas by path, shared by every middleware using the same file
+var compiledSchemas sync.Map
+
+// JSONSchemaMiddleware validates the raw request body against the JSON Schema at schemaPath
+// before the handler runs. Malformed JSON is rejected with 400 and schema violations with 422,
+// listing each failing field (as a JSON Pointer) in ValidationErrors
+// The schema is loaded when the middleware is created; it panics if the schema is invalid
+func JSONSchemaMiddleware(schemaPath string) gin.HandlerFunc {
+	schema, err := loadJSONSchema(schemaPath)
+	if err != nil {
+		panic(fmt.Sprintf("json schema middleware: %v", err))
+	}
+
+	return func(c *gin.Context) {
+		body, err := webcontext.NewGinContextAdapter(c).GetBody()
+		if err != nil {
+			c.AbortWithStatusJSON(app_errors.ErrRequestBodyMalformed.Status, app_errors.ErrRequestBodyMalformed)
+			return
+		}
+
+		document, err := decodeJSONDocument(body)
+		if err != nil {
+			c.AbortWithStatusJSON(app_errors.ErrRequestBodyMalformed.Status, app_errors.ErrRequestBodyMalformed)
+			return
+		}
+
+		if err := schema.Validate(document); err != nil {
+			problem := app_errors.ErrRequestSchemaInvalid
+			var validationErr *jsonschema.ValidationError
+			if errors.As(err, &validationErr) {
+				validationErrors := schemaValidationErrors(validationErr)
+				sort.SliceStable(validationErrors, func(i, j int) bool {
+					return validationErrors[i].Field < validationErrors[j].Field
+				})
+				problem = problem.WithValidationErrors(validationErrors)
+			}
+			c.AbortWithStatusJSON(problem.Status, problem)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// loadJSONSchema compiles the schema at path, reusing a previously compiled one
+func loadJSONSchema(path string) (*jsonschema.Schema, error) {
+	if schema, ok := compiledSchemas.Load(path); ok {
+		return schema.(*jsonschema.Schema), nil
+	}
+
+	schema, err := jsonschema.NewCompiler().Compile(path)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := compiledSchemas.LoadOrStore(path, schema)
+	return actual.(*jsonschema.Schema), nil
+}
+
+// decodeJSONDocument decodes a single JSON value the way the validator expects (numbers as json.Number)
+func decodeJSONDocument(body []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+	return document, nil
+}
+
+// schemaValidationErrors flattens the validation error tree into its leaf failures
+func schemaValidationErrors(err *jsonschema.ValidationError) []app_errors.ValidationError {
+	if len(err.Causes) == 0 {
+		return []app_errors.ValidationError{{Field: err.InstanceLocation, Message: err.Message}}
+	}
+
+	var validationErrors []app_errors.ValidationError
+	for _, cause := range err.Causes {
+		validationErrors = append(validationErrors, schemaValidationErrors(cause)...)
+	}
+	return validationErrors
+}
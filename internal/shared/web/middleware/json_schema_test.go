@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+)
+
+// createProductSchema is the schema wired on POST /products
+var createProductSchema = filepath.Join("..", "..", "..", "simple_module", "schemas", "CreateProductSchema.json")
+
+func TestJSONSchemaMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/products", JSONSchemaMiddleware(createProductSchema), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantFields []string
+		// wantMessage is a part of the first validation message, when it matters
+		wantMessage string
+	}{
+		{name: "valid", body: `{"name":"Keyboard","price":99.9,"stock":3,"tags":["office"]}`, wantStatus: http.StatusCreated},
+		{name: "missing required fields", body: `{"description":"no name"}`, wantStatus: http.StatusUnprocessableEntity, wantFields: []string{""}, wantMessage: "'name', 'price'"},
+		{name: "wrong types", body: `{"name":42,"price":"cheap"}`, wantStatus: http.StatusUnprocessableEntity, wantFields: []string{"/name", "/price"}},
+		{name: "out of range", body: `{"name":"Keyboard","price":-1,"stock":1.5}`, wantStatus: http.StatusUnprocessableEntity, wantFields: []string{"/price", "/stock"}},
+		{name: "nested item", body: `{"name":"Keyboard","price":1,"tags":["ok",""]}`, wantStatus: http.StatusUnprocessableEntity, wantFields: []string{"/tags/1"}},
+		{name: "unknown property", body: `{"name":"Keyboard","price":1,"color":"red"}`, wantStatus: http.StatusUnprocessableEntity, wantFields: []string{""}},
+		{name: "not an object", body: `[1,2]`, wantStatus: http.StatusUnprocessableEntity, wantFields: []string{""}},
+		{name: "malformed JSON", body: `{"name":`, wantStatus: http.StatusBadRequest},
+		{name: "trailing data", body: `{"name":"Keyboard","price":1} {}`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(tt.body)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusUnprocessableEntity {
+				return
+			}
+			var problem app_errors.ProblemDetails
+			if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("body is not a problem: %v", err)
+			}
+			if problem.Code != app_errors.ErrRequestSchemaInvalid.Code {
+				t.Errorf("code = %s, want %s", problem.Code, app_errors.ErrRequestSchemaInvalid.Code)
+			}
+			var fields []string
+			for _, validationErr := range problem.ValidationErrors {
+				if validationErr.Message == "" {
+					t.Errorf("validation error on %q has no message", validationErr.Field)
+				}
+				fields = append(fields, validationErr.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Fatalf("fields = %q, want %q", fields, tt.wantFields)
+			}
+			if message := problem.ValidationErrors[0].Message; !strings.Contains(message, tt.wantMessage) {
+				t.Errorf("message = %q, want it to mention %s", message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestJSONSchemaMiddleware_CachesCompiledSchemas(t *testing.T) {
+	first, err := loadJSONSchema(createProductSchema)
+	if err != nil {
+		t.Fatalf("loadJSONSchema: %v", err)
+	}
+	second, _ := loadJSONSchema(createProductSchema)
+	if first != second {
+		t.Error("schema compiled again, want the cached one")
+	}
+}
+
+func TestJSONSchemaMiddleware_PanicsOnMissingSchema(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("no panic for a missing schema")
+		}
+	}()
+	JSONSchemaMiddleware(filepath.Join(t.TempDir(), "missing.json"))
+}
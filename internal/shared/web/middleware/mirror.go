@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	webcontext "github.com/refortunato/go_app_base/internal/shared/web/context"
+)
+
+// MirroredRequestHeader marks mirrored requests; requests carrying it are never mirrored again
+const MirroredRequestHeader = "X-Mirrored-Request"
+
+// defaultMirrorTimeout is used when no positive timeout is given
+const defaultMirrorTimeout = 5 * time.Second
+
+// hopByHopHeaders are connection-specific and not forwarded to the mirror target
+var hopByHopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Proxy-Connection",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// MirrorMiddleware replays every request to targetURL after the handler responded
+// Mirrored requests are bound to context.Background; servers should prefer
+// MirrorMiddlewareWithContext with their base context
+func MirrorMiddleware(targetURL string, timeout time.Duration) gin.HandlerFunc {
+	return MirrorMiddlewareWithContext(context.Background(), targetURL, timeout)
+}
+
+// MirrorMiddlewareWithContext replays every request (method, path, query, headers and body)
+// to targetURL in a goroutine once the handler has responded. The mirror response is
+// discarded and failures are logged as WARN, so the primary response is never affected
+// Each mirrored request is canceled after timeout or when baseCtx is done
+// Panics if targetURL is not an absolute URL
+func MirrorMiddlewareWithContext(baseCtx context.Context, targetURL string, timeout time.Duration) gin.HandlerFunc {
+	target, err := url.Parse(targetURL)
+	if err != nil || target.Scheme == "" || target.Host == "" {
+		panic(fmt.Sprintf("mirror middleware: invalid target URL %q", targetURL))
+	}
+	if timeout <= 0 {
+		timeout = defaultMirrorTimeout
+	}
+	client := &http.Client{}
+
+	return func(c *gin.Context) {
+		if c.GetHeader(MirroredRequestHeader) != "" {
+			c.Next()
+			return
+		}
+
+		// The body must be captured before the handler consumes it
+		body, err := webcontext.NewGinContextAdapter(c).GetBody()
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		c.Next()
+
+		mirrorURL := *target
+		mirrorURL.Path = strings.TrimSuffix(target.Path, "/") + c.Request.URL.Path
+		mirrorURL.RawQuery = c.Request.URL.RawQuery
+
+		method := c.Request.Method
+		header := c.Request.Header.Clone()
+		for _, name := range hopByHopHeaders {
+			header.Del(name)
+		}
+		header.Set(MirroredRequestHeader, "true")
+		log := logger.FromContext(c.Request.Context())
+
+		go func() {
+			ctx, cancel := context.WithTimeout(baseCtx, timeout)
+			defer cancel()
+
+			if err := sendMirrorRequest(ctx, client, method, mirrorURL.String(), header, body); err != nil {
+				log.Warn(ctx, "Failed to mirror request", logger.CustomFields{
+					"method": method,
+					"url":    mirrorURL.String(),
+					"error":  err.Error(),
+				})
+			}
+		}()
+	}
+}
+
+// sendMirrorRequest sends the copy of the request and drains the response
+func sendMirrorRequest(ctx context.Context, client *http.Client, method, mirrorURL string, header http.Header, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, method, mirrorURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header = header
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(io.Discard, resp.Body)
+	return err
+}
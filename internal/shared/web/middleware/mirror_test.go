@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+)
+
+// mirroredRequest is what the mirror target received
+type mirroredRequest struct {
+	method, path, query, body string
+	header                    http.Header
+}
+
+// startMirrorTarget serves the mirror target, sending each request it receives on the returned channel
+// Responses are held until release is closed
+func startMirrorTarget(t *testing.T, release <-chan struct{}) (*httptest.Server, <-chan mirroredRequest) {
+	t.Helper()
+	received := make(chan mirroredRequest, 10)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- mirroredRequest{method: r.Method, path: r.URL.Path, query: r.URL.RawQuery, body: string(body), header: r.Header}
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	t.Cleanup(target.Close)
+	return target, received
+}
+
+// newMirrorRouter serves POST /products behind mw, echoing the body the handler read
+func newMirrorRouter(log logger.Logger, mw gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(logger.WithLogger(c.Request.Context(), log))
+	}, mw)
+	router.POST("/products", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusCreated, string(body))
+	})
+	return router
+}
+
+func sendMirrored(router http.Handler, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/products?source=web", strings.NewReader(`{"name":"Keyboard"}`))
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMirrorMiddleware_ReplaysRequestWithoutBlocking(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	target, received := startMirrorTarget(t, release)
+	log := &recordingLogger{}
+	router := newMirrorRouter(log, MirrorMiddleware(target.URL+"/shadow/", time.Second))
+
+	// The target holds its response, so the primary response must not wait for it
+	w := sendMirrored(router, map[string]string{"X-Tenant": "acme", "Connection": "keep-alive"})
+	if w.Code != http.StatusCreated || w.Body.String() != `{"name":"Keyboard"}` {
+		t.Fatalf("primary response = %d %q, want 201 echoing the body", w.Code, w.Body.String())
+	}
+
+	select {
+	case got := <-received:
+		if got.method != http.MethodPost || got.path != "/shadow/products" || got.query != "source=web" {
+			t.Errorf("mirrored %s %s?%s, want POST /shadow/products?source=web", got.method, got.path, got.query)
+		}
+		if got.body != `{"name":"Keyboard"}` {
+			t.Errorf("mirrored body = %q, want the original body", got.body)
+		}
+		if got.header.Get("X-Tenant") != "acme" || got.header.Get("Content-Type") != "application/json" {
+			t.Errorf("mirrored headers = %v, want the original headers", got.header)
+		}
+		if got.header.Get(MirroredRequestHeader) != "true" {
+			t.Errorf("%s = %q, want true", MirroredRequestHeader, got.header.Get(MirroredRequestHeader))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("target did not receive the mirrored request")
+	}
+}
+
+func TestMirrorMiddleware_SkipsMirroredRequests(t *testing.T) {
+	release := make(chan struct{})
+	close(release)
+	target, received := startMirrorTarget(t, release)
+	router := newMirrorRouter(&recordingLogger{}, MirrorMiddleware(target.URL, time.Second))
+
+	if w := sendMirrored(router, map[string]string{MirroredRequestHeader: "true"}); w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201", w.Code)
+	}
+	select {
+	case got := <-received:
+		t.Errorf("mirrored %s %s again, want mirrored requests skipped", got.method, got.path)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestMirrorMiddleware_FailuresAreLoggedAsWarn(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T) gin.HandlerFunc
+	}{
+		{name: "unreachable target", setup: func(t *testing.T) gin.HandlerFunc {
+			target := httptest.NewServer(http.NotFoundHandler())
+			target.Close()
+			return MirrorMiddleware(target.URL, time.Second)
+		}},
+		{name: "timeout", setup: func(t *testing.T) gin.HandlerFunc {
+			target, _ := startMirrorTarget(t, make(chan struct{}))
+			return MirrorMiddleware(target.URL, 50*time.Millisecond)
+		}},
+		{name: "base context canceled", setup: func(t *testing.T) gin.HandlerFunc {
+			target, received := startMirrorTarget(t, make(chan struct{}))
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				<-received
+				cancel()
+			}()
+			return MirrorMiddlewareWithContext(ctx, target.URL, time.Minute)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := &recordingLogger{}
+			router := newMirrorRouter(log, tt.setup(t))
+
+			if w := sendMirrored(router, nil); w.Code != http.StatusCreated {
+				t.Fatalf("primary status = %d, want 201", w.Code)
+			}
+
+			deadline := time.Now().Add(5 * time.Second)
+			for len(log.find("Failed to mirror request")) == 0 {
+				if time.Now().After(deadline) {
+					t.Fatal("mirror failure not logged")
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			entry := log.find("Failed to mirror request")[0]
+			if entry.level != "warn" || entry.fields["method"] != http.MethodPost || entry.fields["error"] == "" {
+				t.Errorf("entry = %+v, want a WARN with the method and error", entry)
+			}
+		})
+	}
+}
+
+func TestMirrorMiddleware_PanicsOnInvalidTarget(t *testing.T) {
+	for _, targetURL := range []string{"", "shadow.internal", "://bad"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("no panic for target %q", targetURL)
+				}
+			}()
+			MirrorMiddleware(targetURL, time.Second)
+		}()
+	}
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// PanicAlertFunc is called when too many panics happen within the alerting window
+// count is the number of panics in the window and err the panic value that triggered the alert
+type PanicAlertFunc func(count int, err interface{})
+
+// PanicAlertingMiddleware calls alertFn when threshold panics happen within window
+// It must wrap PanicRecoveryMiddleware (registered before it): recovery handles the
+// response and stores the panic value, which this middleware reads after the request
+// The window is cleared after each alert, so a new alert needs threshold more panics
+func PanicAlertingMiddleware(threshold int, window time.Duration, alertFn PanicAlertFunc) gin.HandlerFunc {
+	tracker := &panicWindow{threshold: threshold, window: window}
+
+	return func(c *gin.Context) {
+		c.Next()
+
+		value, panicked := c.Get(PanicValueKey)
+		if !panicked {
+			return
+		}
+
+		if count, alert := tracker.record(time.Now()); alert {
+			alertFn(count, value)
+		}
+	}
+}
+
+// panicWindow keeps the panic timestamps within a sliding window
+type panicWindow struct {
+	mu        sync.Mutex
+	threshold int
+	window    time.Duration
+	times     []time.Time
+}
+
+// record adds a panic at now and reports whether the threshold was reached
+func (w *panicWindow) record(now time.Time) (int, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Drop panics that left the window
+	cutoff := now.Add(-w.window)
+	kept := w.times[:0]
+	for _, t := range w.times {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	w.times = append(kept, now)
+
+	count := len(w.times)
+	if count < w.threshold {
+		return count, false
+	}
+
+	w.times = w.times[:0]
+	return count, true
+}
+
+// DefaultPanicAlert logs an error flagged with "alert": true and increments
+// the panics.alert.triggered counter
+func DefaultPanicAlert(log logger.Logger) PanicAlertFunc {
+	alertCounter, _ := otel.Meter("panic_alerting").Int64Counter(
+		"panics.alert.triggered",
+		metric.WithDescription("Number of times the panic alert threshold was reached"),
+		metric.WithUnit("{alert}"),
+	)
+
+	return func(count int, err interface{}) {
+		ctx := context.Background()
+		alertCounter.Add(ctx, 1)
+		log.Error(ctx, "Panic alert threshold reached", logger.CustomFields{
+			"alert":      true,
+			"panicCount": count,
+			"panic":      fmt.Sprint(err),
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// alertCall is a call recorded by the alert function of newAlertingRouter
+type alertCall struct {
+	count int
+	err   interface{}
+}
+
+// newAlertingRouter wires PanicAlertingMiddleware around PanicRecoveryMiddleware like the server does
+func newAlertingRouter(threshold int, window time.Duration) (*gin.Engine, *[]alertCall) {
+	var calls []alertCall
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(PanicAlertingMiddleware(threshold, window, func(count int, err interface{}) {
+		calls = append(calls, alertCall{count: count, err: err})
+	}))
+	router.Use(PanicRecoveryMiddleware(&recordingLogger{}, false))
+	router.GET("/panic", func(c *gin.Context) { panic("boom") })
+	router.GET("/ok", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router, &calls
+}
+
+func get(router *gin.Engine, path string) int {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w.Code
+}
+
+func TestPanicAlertingMiddleware_AlertsAtThreshold(t *testing.T) {
+	router, calls := newAlertingRouter(3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		get(router, "/panic")
+		get(router, "/ok")
+	}
+	if len(*calls) != 0 {
+		t.Fatalf("alerted after 2 panics: %+v", *calls)
+	}
+
+	// threshold+1 panics: the alert fires once, then the window starts over
+	for i := 0; i < 2; i++ {
+		if code := get(router, "/panic"); code != http.StatusInternalServerError {
+			t.Fatalf("status = %d, want the recovery response", code)
+		}
+	}
+	if len(*calls) != 1 {
+		t.Fatalf("alerts = %d, want 1", len(*calls))
+	}
+	if call := (*calls)[0]; call.count != 3 || call.err != "boom" {
+		t.Errorf("alert = %+v, want count 3 with the panic value", call)
+	}
+}
+
+func TestPanicWindow_DropsPanicsOutsideWindow(t *testing.T) {
+	w := &panicWindow{threshold: 3, window: time.Minute}
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	w.record(start)
+	w.record(start.Add(30 * time.Second))
+	// The first panic left the window: only two are counted
+	if count, alert := w.record(start.Add(70 * time.Second)); alert || count != 2 {
+		t.Fatalf("record = %d, %v, want 2 panics and no alert", count, alert)
+	}
+	if count, alert := w.record(start.Add(80 * time.Second)); !alert || count != 3 {
+		t.Errorf("record = %d, %v, want an alert for 3 panics", count, alert)
+	}
+}
+
+func TestDefaultPanicAlert(t *testing.T) {
+	logs := &recordingLogger{}
+	DefaultPanicAlert(logs)(5, "nil map write")
+
+	entries := logs.find("Panic alert threshold reached")
+	if len(entries) != 1 || entries[0].level != "error" {
+		t.Fatalf("logged %+v, want one error entry", entries)
+	}
+	fields := entries[0].fields
+	if fields["alert"] != true || fields["panicCount"] != 5 || fields["panic"] != "nil map write" {
+		t.Errorf("fields = %v, want alert=true with the count and panic value", fields)
+	}
+}
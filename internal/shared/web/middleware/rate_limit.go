@@ -0,0 +1,209 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+)
+
+// rateLimitSweepInterval is how often idle buckets are dropped from memory
+const rateLimitSweepInterval = time.Minute
+
+// KeyFunc extracts the rate-limit key from a request; an empty key skips rate limiting
+type KeyFunc func(*http.Request) string
+
+// clientIPContextKey carries Gin's ClientIP (which honors the trusted proxies) to IPKeyFunc
+type clientIPContextKey struct{}
+
+// IPKeyFunc keys requests by client IP, as resolved by Gin when called from RateLimitMiddleware
+// and from RemoteAddr otherwise
+func IPKeyFunc(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPContextKey{}).(string); ok && ip != "" {
+		return ip
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// APIKeyKeyFunc keys requests by the X-API-Key header (empty when the header is missing)
+func APIKeyKeyFunc(r *http.Request) string {
+	return r.Header.Get(APIKeyHeader)
+}
+
+// FallbackKeyFunc uses primary when it returns a key and fallback otherwise
+// e.g. FallbackKeyFunc(APIKeyKeyFunc, IPKeyFunc) limits anonymous callers by IP
+func FallbackKeyFunc(primary, fallback KeyFunc) KeyFunc {
+	return func(r *http.Request) string {
+		if key := primary(r); key != "" {
+			return key
+		}
+		return fallback(r)
+	}
+}
+
+// RateLimit allows RequestsPerSecond on average with bursts of up to Burst requests
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// RateLimitOverrideStore returns a custom limit for a key (e.g. higher limits for trusted partners)
+type RateLimitOverrideStore interface {
+	Limit(key string) (RateLimit, bool)
+}
+
+// StaticRateLimitOverrides is a fixed RateLimitOverrideStore keyed by rate-limit key
+type StaticRateLimitOverrides map[string]RateLimit
+
+func (s StaticRateLimitOverrides) Limit(key string) (RateLimit, bool) {
+	limit, ok := s[key]
+	return limit, ok
+}
+
+// ParseRateLimitOverrides parses overrides in the format "key1=rps:burst,key2=rps:burst"
+// Entries without a key or with an invalid limit are ignored; a missing burst defaults to ceil(rps)
+func ParseRateLimitOverrides(raw string) StaticRateLimitOverrides {
+	overrides := make(StaticRateLimitOverrides)
+	for _, entry := range strings.Split(raw, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(entry), "=")
+		if key == "" {
+			continue
+		}
+
+		rpsValue, burstValue, hasBurst := strings.Cut(value, ":")
+		rps, err := strconv.ParseFloat(strings.TrimSpace(rpsValue), 64)
+		if err != nil || rps <= 0 {
+			continue
+		}
+		burst := int(math.Ceil(rps))
+		if hasBurst {
+			if burst, err = strconv.Atoi(strings.TrimSpace(burstValue)); err != nil || burst <= 0 {
+				continue
+			}
+		}
+		overrides[key] = RateLimit{RequestsPerSecond: rps, Burst: burst}
+	}
+	return overrides
+}
+
+// RateLimitMiddleware limits each key returned by keyFn to limit (or its override) with a
+// token bucket per key, answering 429 with Retry-After when the bucket is empty
+// keyFn defaults to IPKeyFunc; overrides may be nil
+func RateLimitMiddleware(limit RateLimit, keyFn KeyFunc, overrides RateLimitOverrideStore) gin.HandlerFunc {
+	if keyFn == nil {
+		keyFn = IPKeyFunc
+	}
+	// A bucket must hold at least one token, otherwise no request would ever pass
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	limiter := newRateLimiter(limit, overrides)
+
+	return func(c *gin.Context) {
+		r := c.Request.WithContext(context.WithValue(c.Request.Context(), clientIPContextKey{}, c.ClientIP()))
+		key := keyFn(r)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		allowed, retryAfter := limiter.allow(key)
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.AbortWithStatusJSON(app_errors.ErrRateLimited.Status, app_errors.ErrRateLimited)
+			return
+		}
+		c.Next()
+	}
+}
+
+type tokenBucket struct {
+	limit    RateLimit
+	tokens   float64
+	lastSeen time.Time
+}
+
+// refill adds the tokens earned since the last request, up to the burst
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.lastSeen).Seconds()
+	b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed*b.limit.RequestsPerSecond)
+	b.lastSeen = now
+}
+
+// rateLimiter keeps one bucket per key; full buckets are dropped lazily, so no background goroutine is needed
+type rateLimiter struct {
+	mu        sync.Mutex
+	limit     RateLimit
+	overrides RateLimitOverrideStore
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newRateLimiter(limit RateLimit, overrides RateLimitOverrideStore) *rateLimiter {
+	return &rateLimiter{
+		limit:     limit,
+		overrides: overrides,
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// allow takes a token from the key's bucket, or reports how long until one is available
+func (l *rateLimiter) allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		limit := l.limitFor(key)
+		bucket = &tokenBucket{limit: limit, tokens: float64(limit.Burst), lastSeen: now}
+		l.buckets[key] = bucket
+	}
+	bucket.refill(now)
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	missing := 1 - bucket.tokens
+	return false, time.Duration(missing / bucket.limit.RequestsPerSecond * float64(time.Second))
+}
+
+func (l *rateLimiter) limitFor(key string) RateLimit {
+	if l.overrides != nil {
+		if limit, ok := l.overrides.Limit(key); ok {
+			return limit
+		}
+	}
+	return l.limit
+}
+
+// sweep drops buckets that have refilled completely (they are equivalent to new ones)
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, bucket := range l.buckets {
+		bucket.refill(now)
+		if bucket.tokens >= float64(bucket.limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newRateLimitRouter(limit RateLimit, keyFn KeyFunc, overrides RateLimitOverrideStore) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RateLimitMiddleware(limit, keyFn, overrides))
+	router.GET("/products", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func rateLimitedRequest(router *gin.Engine, apiKey, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/products", nil)
+	req.RemoteAddr = remoteAddr
+	if apiKey != "" {
+		req.Header.Set(APIKeyHeader, apiKey)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitMiddleware_APIKeysAreLimitedIndependently(t *testing.T) {
+	// A negligible refill rate makes the burst the number of allowed requests
+	limit := RateLimit{RequestsPerSecond: 0.001, Burst: 3}
+	overrides := StaticRateLimitOverrides{"partner-key": {RequestsPerSecond: 0.001, Burst: 8}}
+	router := newRateLimitRouter(limit, FallbackKeyFunc(APIKeyKeyFunc, IPKeyFunc), overrides)
+
+	const requestsPerKey = 10
+	keys := []string{"key-a", "key-b", "key-c", "partner-key"}
+
+	var mu sync.Mutex
+	allowed := make(map[string]int)
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		for i := 0; i < requestsPerKey; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				// Every key comes from the same address, as behind a shared NAT
+				w := rateLimitedRequest(router, key, "203.0.113.7:1234")
+				mu.Lock()
+				defer mu.Unlock()
+				switch w.Code {
+				case http.StatusOK:
+					allowed[key]++
+				case http.StatusTooManyRequests:
+					if w.Header().Get("Retry-After") == "" {
+						t.Errorf("%s: 429 without Retry-After", key)
+					}
+				default:
+					t.Errorf("%s: unexpected status %d", key, w.Code)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	want := map[string]int{"key-a": 3, "key-b": 3, "key-c": 3, "partner-key": 8}
+	for key, expected := range want {
+		if allowed[key] != expected {
+			t.Errorf("%s: %d requests allowed, want %d", key, allowed[key], expected)
+		}
+	}
+}
+
+func TestRateLimitMiddleware_FallsBackToClientIP(t *testing.T) {
+	router := newRateLimitRouter(RateLimit{RequestsPerSecond: 0.001, Burst: 1}, FallbackKeyFunc(APIKeyKeyFunc, IPKeyFunc), nil)
+
+	if w := rateLimitedRequest(router, "", "198.51.100.1:1234"); w.Code != http.StatusOK {
+		t.Fatalf("first anonymous request: status = %d", w.Code)
+	}
+	if w := rateLimitedRequest(router, "", "198.51.100.1:5678"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("second request from the same IP: status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+	if w := rateLimitedRequest(router, "", "198.51.100.2:1234"); w.Code != http.StatusOK {
+		t.Errorf("request from another IP: status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if w := rateLimitedRequest(router, "key-a", "198.51.100.1:1234"); w.Code != http.StatusOK {
+		t.Errorf("request with an API key from a limited IP: status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestParseRateLimitOverrides(t *testing.T) {
+	overrides := ParseRateLimitOverrides("partner=50:100, light=2.5, =1:1, bad=x:1, zero=0:5, badburst=5:0")
+
+	want := StaticRateLimitOverrides{
+		"partner": {RequestsPerSecond: 50, Burst: 100},
+		"light":   {RequestsPerSecond: 2.5, Burst: 3},
+	}
+	if len(overrides) != len(want) {
+		t.Fatalf("overrides = %v, want %v", overrides, want)
+	}
+	for key, expected := range want {
+		if got, ok := overrides.Limit(key); !ok || got != expected {
+			t.Errorf("%s = %+v, want %+v", key, got, expected)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/shared/auth"
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+)
+
+// Gin context keys where authentication middlewares store the authenticated principal
+const (
+	APIKeyInfoContextKey = "apiKeyInfo"
+	JWTClaimsContextKey  = "jwtClaims"
+)
+
+// RequireScope allows the request only if the authenticated principal has the given scope
+// Returns 401 when no principal is present and 403 when the scope is missing
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scopes, authenticated := principalScopes(c)
+		if !authenticated {
+			c.AbortWithStatusJSON(app_errors.ErrUnauthorized.Status, app_errors.ErrUnauthorized)
+			return
+		}
+
+		if !auth.HasScope(scopes, scope) {
+			c.AbortWithStatusJSON(app_errors.ErrInsufficientScope.Status, app_errors.ErrInsufficientScope)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// principalScopes returns the scopes of the API key or JWT stored in the context
+func principalScopes(c *gin.Context) ([]string, bool) {
+	if value, ok := c.Get(APIKeyInfoContextKey); ok {
+		if info, ok := value.(auth.APIKeyInfo); ok {
+			return info.Scopes, true
+		}
+	}
+	if value, ok := c.Get(JWTClaimsContextKey); ok {
+		if claims, ok := value.(auth.JWTClaims); ok {
+			return claims.Scopes, true
+		}
+	}
+	return nil, false
+}
+
+// principalSubject identifies the caller for per-client state (e.g. deduplication keys)
+// The anonymous principal of AnonymousAuth is shared by every caller, so it falls back to the client IP
+func principalSubject(c *gin.Context) string {
+	if value, ok := c.Get(APIKeyInfoContextKey); ok {
+		if info, ok := value.(auth.APIKeyInfo); ok && info.Key != anonymousKey {
+			return "api_key:" + info.Key
+		}
+	}
+	if value, ok := c.Get(JWTClaimsContextKey); ok {
+		if claims, ok := value.(auth.JWTClaims); ok && claims.Subject != "" {
+			return "jwt:" + claims.Subject
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/shared/auth"
+)
+
+// newScopedRouter protects GET /products with RequireScope(scope) behind the given authentication
+func newScopedRouter(authentication gin.HandlerFunc, scope string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(authentication)
+	router.GET("/products", RequireScope(scope), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+// withJWTClaims stands in for a JWT middleware storing validated claims
+func withJWTClaims(claims auth.JWTClaims) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(JWTClaimsContextKey, claims)
+		c.Next()
+	}
+}
+
+func TestRequireScope_APIKey(t *testing.T) {
+	keys := auth.ParseAPIKeys("reader=product:read,writer=product:read|product:write,admin=*")
+	router := newScopedRouter(APIKeyAuth(keys), auth.ScopeProductWrite)
+
+	tests := []struct {
+		name     string
+		apiKey   string
+		want     int
+		wantCode string
+	}{
+		{name: "no auth", want: http.StatusUnauthorized, wantCode: "HTTP1002"},
+		{name: "unknown key", apiKey: "stolen", want: http.StatusUnauthorized, wantCode: "HTTP1002"},
+		{name: "wrong scope", apiKey: "reader", want: http.StatusForbidden, wantCode: "RBAC0001"},
+		{name: "correct scope", apiKey: "writer", want: http.StatusOK},
+		{name: "wildcard scope", apiKey: "admin", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/products", nil)
+			if tt.apiKey != "" {
+				req.Header.Set(APIKeyHeader, tt.apiKey)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+			if tt.wantCode != "" && !strings.Contains(w.Body.String(), tt.wantCode) {
+				t.Errorf("body = %s, want problem code %s", w.Body.String(), tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestRequireScope_JWTClaims(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []string
+		want   int
+	}{
+		{name: "wrong scope", scopes: []string{auth.ScopeAdminRead}, want: http.StatusForbidden},
+		{name: "no scopes", want: http.StatusForbidden},
+		{name: "correct scope", scopes: []string{auth.ScopeAdminRead, auth.ScopeProductRead}, want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newScopedRouter(withJWTClaims(auth.JWTClaims{Subject: "user-1", Scopes: tt.scopes}), auth.ScopeProductRead)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products", nil))
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireScope_AnonymousAuthGrantsEveryScope(t *testing.T) {
+	for _, scope := range []string{auth.ScopeProductRead, auth.ScopeProductWrite, auth.ScopeAdminRead} {
+		router := newScopedRouter(AnonymousAuth(), scope)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products", nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: status = %d, want 200", scope, w.Code)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"github.com/refortunato/go_app_base/internal/shared/observability"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PanicValueKey is the gin context key holding the recovered panic value,
+// so outer middlewares (e.g. PanicAlertingMiddleware) can react to it
+const PanicValueKey = "panicValue"
+
+// panicProblemDetails extends ProblemDetails with the stack trace (debug mode only)
+type panicProblemDetails struct {
+	*app_errors.ProblemDetails
+	StackTrace string `json:"stackTrace"`
+}
+
+// PanicRecoveryMiddleware recovers from panics in later handlers, logs the panic with the
+// request details (goroutine, method, path, request ID, user agent, client IP, stack trace),
+// records a "request.panic" span event with the same details and responds with a 500 ProblemDetails
+// The stack trace is included in the response body only when debugMode is true
+func PanicRecoveryMiddleware(log logger.Logger, debugMode bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+
+			c.Set(PanicValueKey, r)
+
+			ctx := c.Request.Context()
+			stackBytes := debug.Stack()
+			stack := string(stackBytes)
+			goroutineID, _ := observability.GoroutineID(stackBytes)
+
+			fields := logger.CustomFields{
+				"goroutineID":   goroutineID,
+				"requestMethod": c.Request.Method,
+				"requestPath":   c.Request.URL.Path,
+				"requestID":     ginRequestID(c),
+				"userAgent":     c.Request.UserAgent(),
+				"clientIP":      c.ClientIP(),
+				"panicValue":    fmt.Sprintf("%+v", r),
+				"stackTrace":    stack,
+			}
+
+			attrs := append(observability.PanicAttributes(r, stackBytes),
+				attribute.String("http.request.method", c.Request.Method),
+				attribute.String("url.path", c.Request.URL.Path),
+				attribute.String("http.request.id", ginRequestID(c)),
+				attribute.String("user_agent.original", c.Request.UserAgent()),
+				attribute.String("client.address", c.ClientIP()),
+			)
+			span := trace.SpanFromContext(ctx)
+			span.AddEvent("request.panic", trace.WithAttributes(attrs...))
+			span.SetStatus(codes.Error, "panic recovered")
+
+			log.Error(ctx, "Recovered from panic", fields)
+
+			// Nothing sensible can be sent once the response has started
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+
+			if debugMode {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, panicProblemDetails{
+					ProblemDetails: app_errors.ErrPanic,
+					StackTrace:     stack,
+				})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, app_errors.ErrPanic)
+		}()
+
+		c.Next()
+	}
+}
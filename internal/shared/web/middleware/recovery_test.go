@@ -0,0 +1,231 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// logEntry is a message recorded by recordingLogger
+type logEntry struct {
+	level   string
+	message string
+	fields  logger.CustomFields
+}
+
+// recordingLogger keeps every entry so tests can assert on what was logged
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *recordingLogger) record(level, message string, customFields []logger.CustomFields) {
+	fields := logger.CustomFields{}
+	for _, f := range customFields {
+		for k, v := range f {
+			fields[k] = v
+		}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, logEntry{level: level, message: message, fields: fields})
+}
+
+func (l *recordingLogger) Debug(_ context.Context, message string, customFields ...logger.CustomFields) {
+	l.record("debug", message, customFields)
+}
+
+func (l *recordingLogger) Info(_ context.Context, message string, customFields ...logger.CustomFields) {
+	l.record("info", message, customFields)
+}
+
+func (l *recordingLogger) Warn(_ context.Context, message string, customFields ...logger.CustomFields) {
+	l.record("warn", message, customFields)
+}
+
+func (l *recordingLogger) Error(_ context.Context, message string, customFields ...logger.CustomFields) {
+	l.record("error", message, customFields)
+}
+
+func (l *recordingLogger) With(logger.CustomFields) logger.Logger { return l }
+
+func (l *recordingLogger) WithError(error) logger.Logger { return l }
+
+// find returns the entries with the given message
+func (l *recordingLogger) find(message string) []logEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var found []logEntry
+	for _, e := range l.entries {
+		if e.message == message {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// newPanicRouter serves GET /panic, which panics, and GET /ok behind PanicRecoveryMiddleware
+func newPanicRouter(log logger.Logger, debugMode bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(PanicRecoveryMiddleware(log, debugMode))
+	router.GET("/panic", func(c *gin.Context) {
+		var products map[string]int
+		products["keyboard"] = 1
+	})
+	router.GET("/ok", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestPanicRecoveryMiddleware_RespondsWithProblemDetails(t *testing.T) {
+	logs := &recordingLogger{}
+	router := newPanicRouter(logs, false)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", w.Code)
+	}
+	var problem map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("body is not JSON: %v: %s", err, w.Body.String())
+	}
+	if problem["code"] != app_errors.ErrPanic.Code || problem["title"] != app_errors.ErrPanic.Title || problem["status"] != float64(500) {
+		t.Errorf("problem = %v, want the PANIC001 ProblemDetails", problem)
+	}
+	if _, ok := problem["stackTrace"]; ok {
+		t.Error("stack trace exposed outside debug mode")
+	}
+
+	entries := logs.find("Recovered from panic")
+	if len(entries) != 1 || entries[0].level != "error" {
+		t.Fatalf("logged %+v, want one error entry", entries)
+	}
+	if stack, _ := entries[0].fields["stackTrace"].(string); !strings.Contains(stack, "recovery_test.go") {
+		t.Errorf("logged stackTrace = %q, want the panicking handler in it", stack)
+	}
+
+	// The server keeps serving after the panic
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ok", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("request after the panic: status = %d, want 200", w.Code)
+	}
+}
+
+func TestPanicRecoveryMiddleware_LogsRequestDetails(t *testing.T) {
+	logs := &recordingLogger{}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestIDMiddleware(logs), PanicRecoveryMiddleware(logs, false))
+	router.POST("/orders/:id", func(c *gin.Context) { panic(fmt.Errorf("order %s: %w", c.Param("id"), errors.New("nil cart"))) })
+
+	req := httptest.NewRequest(http.MethodPost, "/orders/42?debug=1", nil)
+	req.Header.Set(RequestIDHeader, "req-123")
+	req.Header.Set("User-Agent", "test-agent/1.0")
+	req.RemoteAddr = "203.0.113.7:5555"
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := logs.find("Recovered from panic")
+	if len(entries) != 1 {
+		t.Fatalf("logged %d panic entries, want a single one", len(entries))
+	}
+	fields := entries[0].fields
+	for _, key := range []string{"goroutineID", "requestMethod", "requestPath", "requestID", "userAgent", "clientIP", "panicValue", "stackTrace"} {
+		if value, ok := fields[key]; !ok || value == nil || fmt.Sprint(value) == "" || fmt.Sprint(value) == "0" {
+			t.Errorf("%s = %v, want it present and non-empty", key, value)
+		}
+	}
+	want := map[string]any{
+		"requestMethod": http.MethodPost,
+		"requestPath":   "/orders/42",
+		"requestID":     "req-123",
+		"userAgent":     "test-agent/1.0",
+		"clientIP":      "203.0.113.7",
+		"panicValue":    "order 42: nil cart",
+	}
+	for key, value := range want {
+		if fields[key] != value {
+			t.Errorf("%s = %v, want %v", key, fields[key], value)
+		}
+	}
+	if id, _ := fields["goroutineID"].(int64); id <= 0 {
+		t.Errorf("goroutineID = %v, want the panicking goroutine", fields["goroutineID"])
+	}
+}
+
+func TestPanicRecoveryMiddleware_DebugModeIncludesStackTrace(t *testing.T) {
+	router := newPanicRouter(&recordingLogger{}, true)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	var problem struct {
+		Code       string `json:"code"`
+		StackTrace string `json:"stackTrace"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("body is not JSON: %v", err)
+	}
+	if problem.Code != app_errors.ErrPanic.Code || !strings.Contains(problem.StackTrace, "goroutine") {
+		t.Errorf("problem = %+v, want PANIC001 with the stack trace", problem)
+	}
+}
+
+func TestPanicRecoveryMiddleware_RecordsSpanEvent(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)).Tracer("test")
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		ctx, span := tracer.Start(c.Request.Context(), "request")
+		defer span.End()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	})
+	router.Use(PanicRecoveryMiddleware(&recordingLogger{}, false))
+	router.GET("/panic", func(c *gin.Context) { panic("boom") })
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	ended := spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("ended spans = %d, want 1", len(ended))
+	}
+	events := ended[0].Events()
+	if len(events) != 1 || events[0].Name != "request.panic" {
+		t.Fatalf("events = %+v, want a request.panic event", events)
+	}
+	attrs := make(map[attribute.Key]string)
+	for _, attr := range events[0].Attributes {
+		attrs[attr.Key] = attr.Value.Emit()
+	}
+	for _, key := range []attribute.Key{"panic.value", "goroutine.id", "panic.stack", "http.request.method", "url.path", "client.address"} {
+		if attrs[key] == "" {
+			t.Errorf("event attribute %s missing", key)
+		}
+	}
+	if attrs["panic.value"] != "boom" || attrs["url.path"] != "/panic" {
+		t.Errorf("event attributes = %v, want the panic value and path", attrs)
+	}
+	if status := ended[0].Status(); status.Description != "panic recovered" {
+		t.Errorf("span status = %+v, want an error for the recovered panic", status)
+	}
+}
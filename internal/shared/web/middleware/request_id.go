@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/shared"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+)
+
+// RequestIDMiddleware gives every request an ID: the caller's X-Request-ID or a new one
+// The ID is echoed in the X-Request-ID response header and attached as "requestId"
+// to the request-scoped logger returned by logger.FromContext
+func RequestIDMiddleware(log logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" {
+			id = shared.GenerateId()
+		}
+		c.Header(RequestIDHeader, id)
+
+		requestLogger := log.With(logger.CustomFields{"requestId": id})
+		c.Request = c.Request.WithContext(logger.WithLogger(c.Request.Context(), requestLogger))
+
+		c.Next()
+	}
+}
+
+// ginRequestID returns the request ID sent by the caller or set on the response, if any
+func ginRequestID(c *gin.Context) string {
+	if id := c.GetHeader(RequestIDHeader); id != "" {
+		return id
+	}
+	return c.Writer.Header().Get(RequestIDHeader)
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+)
+
+// fieldsLogger discards log entries but keeps the fields attached with With
+type fieldsLogger struct {
+	logger.Logger
+	fields logger.CustomFields
+}
+
+func (l fieldsLogger) With(fields logger.CustomFields) logger.Logger {
+	merged := logger.CustomFields{}
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return fieldsLogger{Logger: l.Logger, fields: merged}
+}
+
+func TestRequestIDMiddleware_InjectsRequestLogger(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+	}{
+		{name: "caller request ID", incoming: "req-from-gateway"},
+		{name: "generated request ID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(RequestIDMiddleware(fieldsLogger{Logger: logger.NewMultiLogger()}))
+			var requestLogger logger.Logger
+			router.GET("/products", func(c *gin.Context) {
+				requestLogger = logger.FromContext(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/products", nil)
+			if tt.incoming != "" {
+				req.Header.Set(RequestIDHeader, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			id := w.Header().Get(RequestIDHeader)
+			if id == "" || (tt.incoming != "" && id != tt.incoming) {
+				t.Fatalf("%s = %q, want the caller ID %q or a generated one", RequestIDHeader, id, tt.incoming)
+			}
+			fields, ok := requestLogger.(fieldsLogger)
+			if !ok {
+				t.Fatalf("request logger = %T, want the middleware logger", requestLogger)
+			}
+			if fields.fields["requestId"] != id {
+				t.Errorf("request logger requestId = %v, want %q", fields.fields["requestId"], id)
+			}
+		})
+	}
+}
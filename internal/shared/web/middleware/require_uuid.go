@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+	"github.com/refortunato/go_app_base/internal/shared/validation"
+)
+
+// RequireUUID rejects the request with 400 (VAL0002) when the path parameter paramName
+// is not a valid UUID (see validation.ValidateUUID)
+func RequireUUID(paramName string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := validation.ValidateUUID(c.Param(paramName)); err != nil {
+			c.AbortWithStatusJSON(app_errors.ErrInvalidID.Status, app_errors.ErrInvalidID)
+			return
+		}
+		c.Next()
+	}
+}
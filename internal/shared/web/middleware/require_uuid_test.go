@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequireUUID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/products/:id", RequireUUID("id"), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name string
+		id   string
+		want int
+	}{
+		{name: "v4", id: "550e8400-e29b-41d4-a716-446655440000", want: http.StatusOK},
+		{name: "v7", id: "018f3c1e-7b2a-7c4d-8e5f-0123456789ab", want: http.StatusOK},
+		{name: "not a UUID", id: "abc", want: http.StatusBadRequest},
+		{name: "version 0", id: "550e8400-e29b-01d4-a716-446655440000", want: http.StatusBadRequest},
+		{name: "version 9", id: "550e8400-e29b-91d4-a716-446655440000", want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products/"+tt.id, nil))
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+			if tt.want != http.StatusBadRequest {
+				return
+			}
+			var problem map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+			if problem["code"] != "VAL0002" || problem["detail"] != "Invalid ID format, expected UUID" {
+				t.Errorf("problem = %v, want VAL0002", problem)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/shared"
+)
+
+const (
+	// RawResponseHeader lets clients opt out of the envelope with "X-Raw-Response: true"
+	RawResponseHeader = "X-Raw-Response"
+	// RequestIDHeader carries the request ID reported in the envelope metadata
+	RequestIDHeader = "X-Request-ID"
+)
+
+// ResponseEnvelope is the structure wrapping successful JSON responses
+type ResponseEnvelope struct {
+	Data json.RawMessage      `json:"data" swaggertype:"object"`
+	Meta ResponseEnvelopeMeta `json:"meta"`
+}
+
+// ResponseEnvelopeMeta holds metadata about the request that produced the response
+type ResponseEnvelopeMeta struct {
+	RequestID string `json:"requestId" example:"0190a1b2-c3d4-7e5f-8a9b-0c1d2e3f4a5b"`
+	Timestamp string `json:"timestamp" example:"2024-01-01T10:00:00Z"`
+}
+
+// bufferedResponseWriter holds the response body so it can be rewritten after the handler runs
+// Status and headers still go to the underlying writer, which delays sending them until the first write
+type bufferedResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedResponseWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedResponseWriter) Written() bool {
+	return w.body.Len() > 0 || w.ResponseWriter.Written()
+}
+
+// ResponseEnvelopeMiddleware wraps successful JSON responses in {"data": ..., "meta": {...}}
+// Non-2xx responses (ProblemDetails), non-JSON content and requests sending
+// "X-Raw-Response: true" are passed through unchanged
+func ResponseEnvelopeMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader(RawResponseHeader) == "true" {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		writer := &bufferedResponseWriter{ResponseWriter: original}
+		c.Writer = writer
+		// Restore the writer even on panic so the recovery middleware can respond
+		defer func() { c.Writer = original }()
+
+		c.Next()
+
+		body := writer.body.Bytes()
+		if shouldEnvelope(original, body) {
+			enveloped, err := json.Marshal(ResponseEnvelope{
+				Data: body,
+				Meta: ResponseEnvelopeMeta{
+					RequestID: requestID(c),
+					Timestamp: time.Now().UTC().Format(time.RFC3339),
+				},
+			})
+			if err == nil {
+				body = enveloped
+			}
+		}
+
+		if len(body) > 0 {
+			_, _ = original.Write(body)
+		}
+	}
+}
+
+// shouldEnvelope reports whether the buffered response is a successful JSON document
+func shouldEnvelope(w gin.ResponseWriter, body []byte) bool {
+	if w.Status() < http.StatusOK || w.Status() >= http.StatusMultipleChoices {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return false
+	}
+	return json.Valid(body)
+}
+
+// requestID returns the caller's X-Request-ID, the one set on the response, or a new ID
+func requestID(c *gin.Context) string {
+	if id := c.GetHeader(RequestIDHeader); id != "" {
+		return id
+	}
+	if id := c.Writer.Header().Get(RequestIDHeader); id != "" {
+		return id
+	}
+	return shared.GenerateId()
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newEnvelopeRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ResponseEnvelopeMiddleware())
+	router.GET("/product", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"id": "1", "name": "Keyboard"})
+	})
+	router.GET("/missing", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"code": "SIP1002"})
+	})
+	router.GET("/text", func(c *gin.Context) {
+		c.String(http.StatusOK, "plain")
+	})
+	router.DELETE("/product", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	return router
+}
+
+func TestResponseEnvelopeMiddleware(t *testing.T) {
+	router := newEnvelopeRouter()
+
+	t.Run("wraps successful JSON", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/product", nil)
+		req.Header.Set(RequestIDHeader, "req-123")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var envelope struct {
+			Data map[string]string    `json:"data"`
+			Meta ResponseEnvelopeMeta `json:"meta"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &envelope); err != nil {
+			t.Fatalf("invalid envelope %s: %v", w.Body.String(), err)
+		}
+		if envelope.Data["id"] != "1" || envelope.Data["name"] != "Keyboard" {
+			t.Errorf("data = %v, want the handler response", envelope.Data)
+		}
+		if envelope.Meta.RequestID != "req-123" {
+			t.Errorf("meta.requestId = %q, want the caller's X-Request-ID", envelope.Meta.RequestID)
+		}
+		if _, err := time.Parse(time.RFC3339, envelope.Meta.Timestamp); err != nil {
+			t.Errorf("meta.timestamp = %q, want RFC 3339: %v", envelope.Meta.Timestamp, err)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		raw      bool
+		wantBody string
+		wantCode int
+	}{
+		{name: "raw response header", method: http.MethodGet, path: "/product", raw: true, wantBody: `{"id":"1","name":"Keyboard"}`, wantCode: http.StatusOK},
+		{name: "error response", method: http.MethodGet, path: "/missing", wantBody: `{"code":"SIP1002"}`, wantCode: http.StatusNotFound},
+		{name: "non-JSON response", method: http.MethodGet, path: "/text", wantBody: "plain", wantCode: http.StatusOK},
+		{name: "no content", method: http.MethodDelete, path: "/product", wantBody: "", wantCode: http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.raw {
+				req.Header.Set(RawResponseHeader, "true")
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode || w.Body.String() != tt.wantBody {
+				t.Errorf("response = %d %q, want %d %q unchanged", w.Code, w.Body.String(), tt.wantCode, tt.wantBody)
+			}
+		})
+	}
+}
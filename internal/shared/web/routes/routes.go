@@ -0,0 +1,95 @@
+package routes
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// routeTags holds the tags of each route, keyed by "METHOD path"
+var routeTags sync.Map
+
+// RouteInfo describes a registered route in GET /routes
+type RouteInfo struct {
+	Method string   `json:"method" example:"GET"`
+	Path   string   `json:"path" example:"/v1/products/:id"`
+	Tags   []string `json:"tags" example:"products"`
+}
+
+// TagRoute attaches tags to the route with the given method and full path
+// Modules call it while registering their routes; untagged routes are listed with no tags
+func TagRoute(method, path string, tags ...string) {
+	routeTags.Store(routeKey(method, path), append([]string(nil), tags...))
+}
+
+// Tagger returns a TagRoute for paths relative to router (a route group), applying tags
+func Tagger(router gin.IRoutes, tags ...string) func(method, relativePath string) {
+	base := "/"
+	if group, ok := router.(interface{ BasePath() string }); ok {
+		base = group.BasePath()
+	}
+	return func(method, relativePath string) {
+		TagRoute(method, joinPath(base, relativePath), tags...)
+	}
+}
+
+// Tags returns the tags of a route (never nil)
+func Tags(method, path string) []string {
+	if tags, ok := routeTags.Load(routeKey(method, path)); ok {
+		return tags.([]string)
+	}
+	return []string{}
+}
+
+// List converts Gin routes into RouteInfo sorted by path and method,
+// leaving out routes under any of excludedPrefixes
+func List(routes gin.RoutesInfo, excludedPrefixes ...string) []RouteInfo {
+	result := make([]RouteInfo, 0, len(routes))
+	for _, route := range routes {
+		if hasAnyPrefix(route.Path, excludedPrefixes) {
+			continue
+		}
+		result = append(result, RouteInfo{
+			Method: route.Method,
+			Path:   route.Path,
+			Tags:   Tags(route.Method, route.Path),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Path != result[j].Path {
+			return result[i].Path < result[j].Path
+		}
+		return result[i].Method < result[j].Method
+	})
+	return result
+}
+
+func routeKey(method, path string) string {
+	return method + " " + path
+}
+
+// joinPath joins a group base path and a relative path the way Gin does
+func joinPath(base, relativePath string) string {
+	if relativePath == "" {
+		return base
+	}
+	joined := path.Join(base, relativePath)
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(joined, "/") {
+		return joined + "/"
+	}
+	return joined
+}
+
+// hasAnyPrefix matches whole path segments ("/admin" matches "/admin/x", not "/administrators")
+func hasAnyPrefix(p string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if p == prefix || strings.HasPrefix(p, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package routes
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := func(c *gin.Context) {}
+
+	products := router.Group("/v1/products")
+	tag := Tagger(products, "products")
+	products.GET("", handler)
+	tag(http.MethodGet, "")
+	products.POST("", handler)
+	tag(http.MethodPost, "")
+	products.GET("/:id", handler)
+	tag(http.MethodGet, "/:id")
+	products.DELETE("/:id", handler)
+	tag(http.MethodDelete, "/:id")
+	router.GET("/health", handler)
+	TagRoute(http.MethodGet, "/health", "health", "probes")
+	router.GET("/untagged", handler)
+	router.GET("/admin/stats", handler)
+	router.GET("/administrators", handler)
+
+	got := List(router.Routes(), "/admin")
+
+	want := []RouteInfo{
+		{Method: http.MethodGet, Path: "/administrators", Tags: []string{}},
+		{Method: http.MethodGet, Path: "/health", Tags: []string{"health", "probes"}},
+		{Method: http.MethodGet, Path: "/untagged", Tags: []string{}},
+		{Method: http.MethodGet, Path: "/v1/products", Tags: []string{"products"}},
+		{Method: http.MethodPost, Path: "/v1/products", Tags: []string{"products"}},
+		{Method: http.MethodDelete, Path: "/v1/products/:id", Tags: []string{"products"}},
+		{Method: http.MethodGet, Path: "/v1/products/:id", Tags: []string{"products"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("List =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestTagRoute_CopiesTags(t *testing.T) {
+	tags := []string{"products"}
+	TagRoute(http.MethodPut, "/copied", tags...)
+	tags[0] = "changed"
+
+	if got := Tags(http.MethodPut, "/copied"); !reflect.DeepEqual(got, []string{"products"}) {
+		t.Errorf("Tags = %v, want the tags as given", got)
+	}
+}
+
+func TestJoinPath(t *testing.T) {
+	tests := []struct {
+		base, relativePath, want string
+	}{
+		{"/", "/health", "/health"},
+		{"/v1/products", "", "/v1/products"},
+		{"/v1/products", "/:id", "/v1/products/:id"},
+		{"/v1/products", "/export/", "/v1/products/export/"},
+		{"/v1/", "products", "/v1/products"},
+	}
+	for _, tt := range tests {
+		if got := joinPath(tt.base, tt.relativePath); got != tt.want {
+			t.Errorf("joinPath(%q, %q) = %q, want %q", tt.base, tt.relativePath, got, tt.want)
+		}
+	}
+}
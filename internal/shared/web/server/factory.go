@@ -1,38 +1,159 @@
 package server
 
 import (
+	"context"
+	"strings"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/shared/lifecycle"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
 	"github.com/refortunato/go_app_base/internal/shared/observability"
+	"github.com/refortunato/go_app_base/internal/shared/web/advisor"
+	webcontext "github.com/refortunato/go_app_base/internal/shared/web/context"
+	"github.com/refortunato/go_app_base/internal/shared/web/middleware"
 )
 
 // RouteSetupFunc defines a function that configures routes on a Gin router
 // This allows generic server creation while keeping route definitions in infra layer
 type RouteSetupFunc func(*gin.Engine)
 
+// GinServerConfig holds the settings used by NewGinServerWithRoutes
+type GinServerConfig struct {
+	Port        string
+	ServiceName string
+	// AppName is used as metric prefix for better identification
+	AppName     string
+	OtelEnabled bool
+	// B3Enabled continues Zipkin B3 traces sent by upstream services
+	B3Enabled bool
+	// DebugMode includes stack traces in panic responses
+	DebugMode bool
+	// AccessLogEnabled logs one structured entry per request
+	AccessLogEnabled bool
+	// AccessLogSamplingRates keeps a fraction of the access log entries per route pattern (nil logs all)
+	AccessLogSamplingRates map[string]float64
+	Logger                 logger.Logger
+	// StartupGate, when set, answers GET /startupz ahead of every middleware
+	StartupGate *lifecycle.ReadinessGate
+	// TrustedProxies are the proxies whose client IP headers are honored (empty trusts none)
+	TrustedProxies      []string
+	ForwardedByClientIP bool
+	// RemoteIPHeaders are read in order to find the client IP (empty keeps Gin's defaults)
+	RemoteIPHeaders []string
+	// HTTP holds the http.Server timeouts and header limit
+	HTTP HTTPServerConfig
+	// PanicAlertThreshold panics within PanicAlertWindow trigger PanicAlertFn (0 disables)
+	PanicAlertThreshold int
+	PanicAlertWindow    time.Duration
+	// PanicAlertFn defaults to middleware.DefaultPanicAlert (error log + counter)
+	PanicAlertFn middleware.PanicAlertFunc
+	// MirrorTargetURL, when set, receives a copy of every request (traffic shadowing)
+	MirrorTargetURL string
+	MirrorTimeout   time.Duration
+	// ContentTypeExceptions lists the routes accepting a body other than JSON, keyed by
+	// "METHOD /route" (see middleware.RequireJSONContentType)
+	ContentTypeExceptions map[string][]string
+	// AllocProfilerThreshold enables middleware.AllocProfilerMiddleware (0 disables, profiling only)
+	AllocProfilerThreshold uint64
+}
+
 // NewGinServerWithRoutes creates a new HTTP server with custom route setup
 // The setupRoutes function is called to register application-specific routes
-func NewGinServerWithRoutes(port string, setupRoutes RouteSetupFunc, serviceName, appName string, otelEnabled bool) *GinServer {
-	if port == "" {
-		port = "8080"
+func NewGinServerWithRoutes(cfg GinServerConfig, setupRoutes RouteSetupFunc) *GinServer {
+	// Create a Gin router with explicit middleware instead of gin.Default()
+	router := gin.New()
+	router.HandleMethodNotAllowed = true
+	srv := NewGinServer(router, cfg.Port, cfg.HTTP)
+
+	// Client IP detection behind load balancers (c.ClientIP)
+	router.ForwardedByClientIP = cfg.ForwardedByClientIP
+	if len(cfg.RemoteIPHeaders) > 0 {
+		router.RemoteIPHeaders = cfg.RemoteIPHeaders
+	}
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		// Invalid entries: trust no proxy rather than every proxy
+		cfg.Logger.Error(context.Background(), "Invalid trusted proxies, client IP headers will be ignored", logger.CustomFields{
+			"error": err.Error(),
+		})
+		_ = router.SetTrustedProxies(nil)
 	}
 
-	// Create a Gin router with default middleware (logger and recovery)
-	router := gin.Default()
+	// Request ID first so every later middleware and handler can log it
+	router.Use(middleware.RequestIDMiddleware(cfg.Logger))
 
 	// Add OpenTelemetry middlewares if enabled (non-blocking, async processing)
-	if otelEnabled {
+	if cfg.OtelEnabled {
+		// B3 context must be in place before the request span is started
+		if cfg.B3Enabled {
+			router.Use(middleware.B3PropagationMiddleware())
+		}
+
 		// Tracing middleware (traces HTTP requests)
-		router.Use(observability.TracingMiddleware(serviceName))
+		router.Use(observability.TracingMiddleware(cfg.ServiceName))
 
 		// Metrics middleware (collects HTTP metrics without blocking I/O)
-		// appName is used as metric prefix for better identification
-		router.Use(observability.MetricsMiddleware(serviceName, appName))
+		router.Use(observability.MetricsMiddleware(cfg.ServiceName, cfg.AppName))
 	}
 
+	// Access log runs inside tracing so entries carry the trace context
+	if cfg.AccessLogEnabled {
+		if len(cfg.AccessLogSamplingRates) > 0 {
+			router.Use(middleware.AccessLogSamplerMiddleware(cfg.AccessLogSamplingRates, 1))
+		}
+		router.Use(middleware.AccessLogMiddleware(cfg.Logger))
+	}
+
+	// Panic alerting wraps recovery and reads the panic value it stores
+	if cfg.PanicAlertThreshold > 0 {
+		alertFn := cfg.PanicAlertFn
+		if alertFn == nil {
+			alertFn = middleware.DefaultPanicAlert(cfg.Logger)
+		}
+		router.Use(middleware.PanicAlertingMiddleware(cfg.PanicAlertThreshold, cfg.PanicAlertWindow, alertFn))
+	}
+
+	// Recovery runs inside tracing and metrics so panics are recorded on the request span
+	// and counted with their 500 status
+	router.Use(middleware.PanicRecoveryMiddleware(cfg.Logger, cfg.DebugMode))
+
+	// Mirrored requests are sent after the response and canceled when the server shuts down
+	if cfg.MirrorTargetURL != "" {
+		router.Use(middleware.MirrorMiddlewareWithContext(srv.BaseContext(), cfg.MirrorTargetURL, cfg.MirrorTimeout))
+	}
+
+	// Allocation profiling forces a GC per request, so it is closest to the handlers
+	if cfg.AllocProfilerThreshold > 0 {
+		router.Use(middleware.AllocProfilerMiddleware(cfg.AllocProfilerThreshold))
+	}
+
+	// POST/PUT/PATCH bodies must be JSON unless the route is listed as an exception (415)
+	router.Use(middleware.RequireJSONContentType(cfg.ContentTypeExceptions))
+
 	// Call the provided setup function to register routes
 	if setupRoutes != nil {
 		setupRoutes(router)
 	}
 
-	return NewGinServer(router, port)
+	// Unknown routes and wrong methods answer with ProblemDetails instead of Gin's plain text
+	router.NoRoute(notFoundHandler)
+	router.NoMethod(methodNotAllowedHandler)
+
+	if cfg.StartupGate != nil {
+		srv.httpServer.Handler = lifecycle.StartupProbeHandler(cfg.StartupGate, router)
+	}
+	return srv
+}
+
+func notFoundHandler(c *gin.Context) {
+	advisor.ReturnNotFoundError(webcontext.NewGinContextAdapter(c))
+}
+
+// methodNotAllowedHandler reuses the Allow header Gin computes from the registered routes
+func methodNotAllowedHandler(c *gin.Context) {
+	var allowed []string
+	if header := c.Writer.Header().Get("Allow"); header != "" {
+		allowed = strings.Split(header, ", ")
+	}
+	advisor.ReturnMethodNotAllowedError(webcontext.NewGinContextAdapter(c), allowed...)
 }
@@ -0,0 +1,203 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/shared/lifecycle"
+)
+
+// serveHTTP sends a request with no body to the handler of srv
+func serveHTTP(srv *GinServer, method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	srv.httpServer.Handler.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w
+}
+
+// decodeProblem decodes a ProblemDetails response body
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) map[string]any {
+	t.Helper()
+	if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" && ct != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want JSON", ct)
+	}
+	var problem map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("body is not JSON: %v: %s", err, w.Body.String())
+	}
+	return problem
+}
+
+func TestNewGinServerWithRoutes_RecoversPanics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logs := useRecordingLogger(t)
+	srv := NewGinServerWithRoutes(GinServerConfig{Logger: logs}, func(router *gin.Engine) {
+		router.GET("/panic", func(c *gin.Context) { panic("boom") })
+		router.GET("/ok", func(c *gin.Context) { c.Status(http.StatusOK) })
+	})
+
+	w := serveHTTP(srv, http.MethodGet, "/panic")
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", w.Code)
+	}
+	if problem := decodeProblem(t, w); problem["code"] != "PANIC001" {
+		t.Errorf("problem = %v, want PANIC001", problem)
+	}
+	if len(logs.find("Recovered from panic")) != 1 {
+		t.Error("panic not logged through the configured logger")
+	}
+
+	if w := serveHTTP(srv, http.MethodGet, "/ok"); w.Code != http.StatusOK {
+		t.Errorf("request after the panic: status = %d, want 200", w.Code)
+	}
+}
+
+func TestNewGinServerWithRoutes_NotFoundAndMethodNotAllowed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	srv := NewGinServerWithRoutes(GinServerConfig{Logger: useRecordingLogger(t)}, func(router *gin.Engine) {
+		router.GET("/products", func(c *gin.Context) { c.Status(http.StatusOK) })
+		router.DELETE("/products", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	})
+
+	t.Run("unknown route", func(t *testing.T) {
+		w := serveHTTP(srv, http.MethodGet, "/unknown")
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("status = %d, want 404", w.Code)
+		}
+		problem := decodeProblem(t, w)
+		if problem["status"] != float64(404) || problem["title"] == "" || problem["code"] == "" {
+			t.Errorf("problem = %v, want a 404 ProblemDetails", problem)
+		}
+	})
+
+	t.Run("wrong method", func(t *testing.T) {
+		w := serveHTTP(srv, http.MethodPatch, "/products")
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Fatalf("status = %d, want 405", w.Code)
+		}
+		problem := decodeProblem(t, w)
+		if problem["status"] != float64(405) || problem["code"] != "HTTP0405" {
+			t.Errorf("problem = %v, want the HTTP0405 ProblemDetails", problem)
+		}
+		allow := w.Header().Get("Allow")
+		for _, method := range []string{http.MethodGet, http.MethodDelete} {
+			if !strings.Contains(allow, method) {
+				t.Errorf("Allow = %q, want it to list %s", allow, method)
+			}
+		}
+	})
+}
+
+func TestNewGinServerWithRoutes_StartupProbe(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	gate := lifecycle.NewReadinessGate()
+	srv := NewGinServerWithRoutes(GinServerConfig{Port: "0", Logger: useRecordingLogger(t), StartupGate: gate}, func(router *gin.Engine) {
+		// The probe is answered before any middleware, even one rejecting every request
+		router.Use(func(c *gin.Context) { c.AbortWithStatus(http.StatusUnauthorized) })
+	})
+
+	if w := serveHTTP(srv, http.MethodGet, lifecycle.StartupProbePath); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("before the port is bound: status = %d, want 503", w.Code)
+	}
+
+	// Like main: the gate opens once Start has bound the port
+	go srv.Start()
+	t.Cleanup(func() { srv.Shutdown(context.Background()) })
+	<-srv.Listening()
+	gate.SetReady()
+
+	resp, err := http.Get("http://" + srv.Addr().String() + lifecycle.StartupProbePath)
+	if err != nil {
+		t.Fatalf("GET /startupz: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("after SetReady: status = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestNewGinServerWithRoutes_ClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name    string
+		cfg     GinServerConfig
+		headers map[string]string
+		want    string
+	}{
+		{name: "trusted proxy",
+			cfg:     GinServerConfig{TrustedProxies: []string{"10.0.0.0/8"}, ForwardedByClientIP: true},
+			headers: map[string]string{"X-Forwarded-For": "203.0.113.7"}, want: "203.0.113.7"},
+		{name: "trusted proxy chain",
+			cfg:     GinServerConfig{TrustedProxies: []string{"10.0.0.0/8"}, ForwardedByClientIP: true},
+			headers: map[string]string{"X-Forwarded-For": "203.0.113.7, 10.0.0.2"}, want: "203.0.113.7"},
+		{name: "untrusted proxy",
+			cfg:     GinServerConfig{TrustedProxies: []string{"192.168.0.0/16"}, ForwardedByClientIP: true},
+			headers: map[string]string{"X-Forwarded-For": "203.0.113.7"}, want: "10.0.0.1"},
+		{name: "no trusted proxies",
+			cfg:     GinServerConfig{ForwardedByClientIP: true},
+			headers: map[string]string{"X-Forwarded-For": "203.0.113.7"}, want: "10.0.0.1"},
+		{name: "forwarding disabled",
+			cfg:     GinServerConfig{TrustedProxies: []string{"10.0.0.0/8"}},
+			headers: map[string]string{"X-Forwarded-For": "203.0.113.7"}, want: "10.0.0.1"},
+		{name: "custom header",
+			cfg:     GinServerConfig{TrustedProxies: []string{"10.0.0.0/8"}, ForwardedByClientIP: true, RemoteIPHeaders: []string{"X-Real-IP"}},
+			headers: map[string]string{"X-Forwarded-For": "198.51.100.9", "X-Real-IP": "203.0.113.7"}, want: "203.0.113.7"},
+		{name: "invalid proxies trust none",
+			cfg:     GinServerConfig{TrustedProxies: []string{"not-a-cidr"}, ForwardedByClientIP: true},
+			headers: map[string]string{"X-Forwarded-For": "203.0.113.7"}, want: "10.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Logger = useRecordingLogger(t)
+			srv := NewGinServerWithRoutes(tt.cfg, func(router *gin.Engine) {
+				router.GET("/ip", func(c *gin.Context) { c.String(http.StatusOK, c.ClientIP()) })
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+			req.RemoteAddr = "10.0.0.1:52000"
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			w := httptest.NewRecorder()
+			srv.httpServer.Handler.ServeHTTP(w, req)
+
+			if w.Body.String() != tt.want {
+				t.Errorf("ClientIP = %q, want %q", w.Body.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNewGinServerWithRoutes_RequiresJSONContentType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	srv := NewGinServerWithRoutes(GinServerConfig{
+		Logger:                useRecordingLogger(t),
+		ContentTypeExceptions: map[string][]string{"POST /upload": {"multipart/form-data"}},
+	}, func(router *gin.Engine) {
+		router.POST("/products", func(c *gin.Context) { c.Status(http.StatusOK) })
+		router.POST("/upload", func(c *gin.Context) { c.Status(http.StatusOK) })
+	})
+
+	tests := []struct {
+		path        string
+		contentType string
+		want        int
+	}{
+		{path: "/products", contentType: "application/json", want: http.StatusOK},
+		{path: "/products", contentType: "text/plain", want: http.StatusUnsupportedMediaType},
+		{path: "/upload", contentType: "multipart/form-data; boundary=x", want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader("{}"))
+		req.Header.Set("Content-Type", tt.contentType)
+		w := httptest.NewRecorder()
+		srv.httpServer.Handler.ServeHTTP(w, req)
+		if w.Code != tt.want {
+			t.Errorf("POST %s as %s: status = %d, want %d", tt.path, tt.contentType, w.Code, tt.want)
+		}
+	}
+}
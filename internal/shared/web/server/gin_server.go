@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"time"
 
@@ -12,38 +13,131 @@ import (
 // GinServer wraps http.Server for graceful shutdown
 type GinServer struct {
 	httpServer *http.Server
+	// listening is closed once the port is bound
+	listening chan struct{}
+	// addr is the bound address, set before listening is closed
+	addr net.Addr
+	// baseCtx is the parent of every request context, canceled once the server is shut down
+	baseCtx    context.Context
+	cancelBase context.CancelFunc
 }
 
-// Shutdown gracefully shuts down the server
+// Shutdown gracefully shuts down the server, then cancels its base context
 func (s *GinServer) Shutdown(ctx context.Context) error {
 	fmt.Println("Shutting down HTTP server...")
+	defer s.cancelBase()
 	return s.httpServer.Shutdown(ctx)
 }
 
+// BaseContext returns the server base context, canceled when Shutdown completes
+// Work started by a request that outlives it (e.g. mirroring) should use this context
+func (s *GinServer) BaseContext() context.Context {
+	return s.baseCtx
+}
+
 // Start starts the server and blocks until it's stopped
 func (s *GinServer) Start() error {
 	fmt.Printf("Starting HTTP server on %s\n", s.httpServer.Addr)
-	if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	fmt.Printf("HTTP server timeouts: read=%s read_header=%s write=%s idle=%s\n",
+		s.httpServer.ReadTimeout, s.httpServer.ReadHeaderTimeout, s.httpServer.WriteTimeout, s.httpServer.IdleTimeout)
+	listener, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		return err
+	}
+	s.addr = listener.Addr()
+	close(s.listening)
+
+	if err := s.httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
 		return err
 	}
 	return nil
 }
 
-// NewGinServer creates a new GinServer with the provided router and port
-func NewGinServer(router *gin.Engine, port string) *GinServer {
+// Listening returns a channel closed once Start has bound the port
+func (s *GinServer) Listening() <-chan struct{} {
+	return s.listening
+}
+
+// Addr returns the address the server is bound to, or nil before Listening is closed
+// With port "0" (e.g. in tests) this is the port picked by the OS
+func (s *GinServer) Addr() net.Addr {
+	select {
+	case <-s.listening:
+		return s.addr
+	default:
+		return nil
+	}
+}
+
+// HTTPServerConfig holds the http.Server limits (zero values use the defaults below)
+type HTTPServerConfig struct {
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	MaxHeaderBytes    int
+}
+
+// Default http.Server limits
+const (
+	DefaultReadTimeout       = 5 * time.Second
+	DefaultWriteTimeout      = 10 * time.Second
+	DefaultIdleTimeout       = 60 * time.Second
+	DefaultReadHeaderTimeout = 5 * time.Second
+	DefaultMaxHeaderBytes    = 1 << 20 // 1 MB
+)
+
+// withDefaults fills the zero values with the defaults
+func (c HTTPServerConfig) withDefaults() HTTPServerConfig {
+	if c.ReadTimeout <= 0 {
+		c.ReadTimeout = DefaultReadTimeout
+	}
+	if c.WriteTimeout <= 0 {
+		c.WriteTimeout = DefaultWriteTimeout
+	}
+	if c.IdleTimeout <= 0 {
+		c.IdleTimeout = DefaultIdleTimeout
+	}
+	if c.ReadHeaderTimeout <= 0 {
+		c.ReadHeaderTimeout = DefaultReadHeaderTimeout
+	}
+	if c.MaxHeaderBytes <= 0 {
+		c.MaxHeaderBytes = DefaultMaxHeaderBytes
+	}
+	return c
+}
+
+// apply sets the limits on srv, using the defaults for the zero values
+func (c HTTPServerConfig) apply(srv *http.Server) {
+	c = c.withDefaults()
+	srv.ReadTimeout = c.ReadTimeout
+	srv.WriteTimeout = c.WriteTimeout
+	srv.IdleTimeout = c.IdleTimeout
+	srv.ReadHeaderTimeout = c.ReadHeaderTimeout
+	srv.MaxHeaderBytes = c.MaxHeaderBytes
+}
+
+// NewGinServer creates a new GinServer with the provided router, port and http.Server limits
+func NewGinServer(router *gin.Engine, port string, httpCfg HTTPServerConfig) *GinServer {
 	if port == "" {
 		port = "8080"
 	}
 
 	httpServer := &http.Server{
-		Addr:           ":" + port,
-		Handler:        router,
-		ReadTimeout:    10 * time.Second,
-		WriteTimeout:   10 * time.Second,
-		MaxHeaderBytes: 1 << 20, // 1 MB
+		Addr:    ":" + port,
+		Handler: router,
+	}
+	httpCfg.apply(httpServer)
+
+	baseCtx, cancelBase := context.WithCancel(context.Background())
+	httpServer.BaseContext = func(net.Listener) context.Context {
+		return baseCtx
 	}
 
 	return &GinServer{
 		httpServer: httpServer,
+		listening:  make(chan struct{}),
+		baseCtx:    baseCtx,
+		cancelBase: cancelBase,
 	}
 }
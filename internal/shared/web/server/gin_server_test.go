@@ -0,0 +1,100 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNewGinServer_AppliesTimeouts(t *testing.T) {
+	srv := NewGinServer(gin.New(), "0", HTTPServerConfig{
+		ReadTimeout:       3 * time.Second,
+		WriteTimeout:      7 * time.Second,
+		IdleTimeout:       90 * time.Second,
+		ReadHeaderTimeout: 2 * time.Second,
+	})
+
+	httpServer := reflect.ValueOf(srv).Elem().FieldByName("httpServer").Elem()
+	want := map[string]time.Duration{
+		"ReadTimeout":       3 * time.Second,
+		"WriteTimeout":      7 * time.Second,
+		"IdleTimeout":       90 * time.Second,
+		"ReadHeaderTimeout": 2 * time.Second,
+	}
+	for field, expected := range want {
+		if got := time.Duration(httpServer.FieldByName(field).Int()); got != expected {
+			t.Errorf("http.Server.%s = %s, want %s", field, got, expected)
+		}
+	}
+	if got := httpServer.FieldByName("MaxHeaderBytes").Int(); got != DefaultMaxHeaderBytes {
+		t.Errorf("http.Server.MaxHeaderBytes = %d, want the default %d", got, DefaultMaxHeaderBytes)
+	}
+}
+
+func TestNewGinServer_DefaultTimeouts(t *testing.T) {
+	srv := NewGinServer(gin.New(), "0", HTTPServerConfig{})
+
+	httpServer := srv.httpServer
+	if httpServer.ReadTimeout >= httpServer.WriteTimeout {
+		t.Errorf("default ReadTimeout %s must be less than WriteTimeout %s", httpServer.ReadTimeout, httpServer.WriteTimeout)
+	}
+	if httpServer.IdleTimeout < httpServer.WriteTimeout {
+		t.Errorf("default IdleTimeout %s must be at least WriteTimeout %s", httpServer.IdleTimeout, httpServer.WriteTimeout)
+	}
+}
+
+func TestHTTPServerConfig_AppliedToServer(t *testing.T) {
+	httpCfg := HTTPServerConfig{
+		ReadTimeout:       1500 * time.Millisecond,
+		WriteTimeout:      3 * time.Second,
+		IdleTimeout:       45 * time.Second,
+		ReadHeaderTimeout: 500 * time.Millisecond,
+		MaxHeaderBytes:    4096,
+	}
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	httpCfg.apply(ts.Config)
+	ts.Start()
+	defer ts.Close()
+
+	if ts.Config.ReadTimeout != httpCfg.ReadTimeout || ts.Config.WriteTimeout != httpCfg.WriteTimeout ||
+		ts.Config.IdleTimeout != httpCfg.IdleTimeout || ts.Config.ReadHeaderTimeout != httpCfg.ReadHeaderTimeout {
+		t.Errorf("timeouts read=%s write=%s idle=%s read_header=%s do not match the config %+v",
+			ts.Config.ReadTimeout, ts.Config.WriteTimeout, ts.Config.IdleTimeout, ts.Config.ReadHeaderTimeout, httpCfg)
+	}
+	if ts.Config.MaxHeaderBytes != httpCfg.MaxHeaderBytes {
+		t.Errorf("MaxHeaderBytes = %d, want %d", ts.Config.MaxHeaderBytes, httpCfg.MaxHeaderBytes)
+	}
+
+	// The limits leave a regular request working
+	resp, err := ts.Client().Get(ts.URL)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("status = %d, want 204", resp.StatusCode)
+	}
+}
+
+func TestHTTPServerConfig_ZeroValuesUseDefaults(t *testing.T) {
+	srv := &http.Server{}
+	HTTPServerConfig{WriteTimeout: 20 * time.Second}.apply(srv)
+
+	if srv.WriteTimeout != 20*time.Second {
+		t.Errorf("WriteTimeout = %s, want 20s", srv.WriteTimeout)
+	}
+	if srv.ReadTimeout != DefaultReadTimeout || srv.IdleTimeout != DefaultIdleTimeout ||
+		srv.ReadHeaderTimeout != DefaultReadHeaderTimeout || srv.MaxHeaderBytes != DefaultMaxHeaderBytes {
+		t.Errorf("zero values not replaced by the defaults: %+v", srv)
+	}
+	if DefaultWriteTimeout != 10*time.Second {
+		t.Errorf("DefaultWriteTimeout = %s, want 10s", DefaultWriteTimeout)
+	}
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"context"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	app_errors "github.com/refortunato/go_app_base/internal/shared/errors"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"github.com/refortunato/go_app_base/internal/shared/web/advisor"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// JWTValidator validates bearer tokens received in the gRPC authorization metadata
+// Implementations may return a derived context carrying the authenticated identity
+type JWTValidator interface {
+	Validate(ctx context.Context, token string) (context.Context, error)
+}
+
+// ChainedUnaryInterceptor composes unary interceptors; the first one is the outermost
+func ChainedUnaryInterceptor(interceptors ...grpc.UnaryServerInterceptor) grpc.ServerOption {
+	return grpc.ChainUnaryInterceptor(interceptors...)
+}
+
+// ChainedStreamInterceptor composes stream interceptors; the first one is the outermost
+func ChainedStreamInterceptor(interceptors ...grpc.StreamServerInterceptor) grpc.ServerOption {
+	return grpc.ChainStreamInterceptor(interceptors...)
+}
+
+// LoggingInterceptor logs method, status code and duration of every unary call
+func LoggingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logRPC(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamLoggingInterceptor logs method, status code and duration of every streaming call
+func StreamLoggingInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logRPC(ss.Context(), info.FullMethod, start, err)
+		return err
+	}
+}
+
+// RecoveryInterceptor converts panics into an INTERNAL status carrying a ProblemDetails message
+func RecoveryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverRPC(ctx, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecoveryInterceptor converts panics in streaming calls into an INTERNAL status
+func StreamRecoveryInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverRPC(ss.Context(), info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// AuthInterceptor requires a valid "authorization: Bearer <token>" metadata entry on unary calls
+func AuthInterceptor(validator JWTValidator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		authCtx, err := authenticate(ctx, validator)
+		if err != nil {
+			return nil, err
+		}
+		return handler(authCtx, req)
+	}
+}
+
+// StreamAuthInterceptor requires a valid bearer token on streaming calls
+func StreamAuthInterceptor(validator JWTValidator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		authCtx, err := authenticate(ss.Context(), validator)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: authCtx})
+	}
+}
+
+func logRPC(ctx context.Context, method string, start time.Time, err error) {
+	logger.Info(ctx, "gRPC request handled", logger.CustomFields{
+		"method":     method,
+		"statusCode": status.Code(err).String(),
+		"durationMs": time.Since(start).Milliseconds(),
+	})
+}
+
+func recoverRPC(ctx context.Context, method string, r any) error {
+	logger.Error(ctx, "Recovered from panic in gRPC handler", logger.CustomFields{
+		"method": method,
+		"panic":  r,
+		"stack":  string(debug.Stack()),
+	})
+	return advisor.ReturnGRPCApplicationError(app_errors.ErrInternalServer)
+}
+
+func authenticate(ctx context.Context, validator JWTValidator) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, advisor.ReturnGRPCApplicationError(app_errors.ErrUnauthorized)
+	}
+
+	token, found := strings.CutPrefix(values[0], "Bearer ")
+	if !found || token == "" {
+		return nil, advisor.ReturnGRPCApplicationError(app_errors.ErrUnauthorized)
+	}
+
+	authCtx, err := validator.Validate(ctx, token)
+	if err != nil {
+		return nil, advisor.ReturnGRPCApplicationError(app_errors.ErrUnauthorized)
+	}
+	return authCtx, nil
+}
+
+// contextServerStream overrides the stream context with the authenticated one
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
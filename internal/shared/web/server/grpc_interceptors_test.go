@@ -0,0 +1,286 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// logEntry is a message recorded by recordingLogger
+type logEntry struct {
+	level   string
+	message string
+	fields  logger.CustomFields
+}
+
+// recordingLogger keeps every entry so tests can assert on what was logged
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *recordingLogger) record(level, message string, customFields []logger.CustomFields) {
+	fields := logger.CustomFields{}
+	for _, f := range customFields {
+		for k, v := range f {
+			fields[k] = v
+		}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, logEntry{level: level, message: message, fields: fields})
+}
+
+func (l *recordingLogger) Debug(_ context.Context, message string, customFields ...logger.CustomFields) {
+	l.record("debug", message, customFields)
+}
+
+func (l *recordingLogger) Info(_ context.Context, message string, customFields ...logger.CustomFields) {
+	l.record("info", message, customFields)
+}
+
+func (l *recordingLogger) Warn(_ context.Context, message string, customFields ...logger.CustomFields) {
+	l.record("warn", message, customFields)
+}
+
+func (l *recordingLogger) Error(_ context.Context, message string, customFields ...logger.CustomFields) {
+	l.record("error", message, customFields)
+}
+
+func (l *recordingLogger) With(logger.CustomFields) logger.Logger { return l }
+
+func (l *recordingLogger) WithError(error) logger.Logger { return l }
+
+// find returns the entries with the given message
+func (l *recordingLogger) find(message string) []logEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var found []logEntry
+	for _, e := range l.entries {
+		if e.message == message {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// useRecordingLogger installs a recordingLogger as the global logger for the test
+func useRecordingLogger(t *testing.T) *recordingLogger {
+	t.Helper()
+	rec := &recordingLogger{}
+	logger.SetGlobalLogger(rec)
+	t.Cleanup(func() { logger.SetGlobalLogger(logger.NewMultiLogger()) })
+	return rec
+}
+
+type principalKey struct{}
+
+// tokenValidator accepts the tokens of its map, storing the matching principal in the context
+type tokenValidator map[string]string
+
+func (v tokenValidator) Validate(ctx context.Context, token string) (context.Context, error) {
+	principal, ok := v[token]
+	if !ok {
+		return nil, errors.New("invalid token")
+	}
+	return context.WithValue(ctx, principalKey{}, principal), nil
+}
+
+// testHealthServer runs check for unary calls and answers a single SERVING for streams,
+// recording the principal each handler saw
+type testHealthServer struct {
+	healthpb.UnimplementedHealthServer
+	check func() error
+
+	mu         sync.Mutex
+	principals []any
+}
+
+func (s *testHealthServer) seen(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.principals = append(s.principals, ctx.Value(principalKey{}))
+}
+
+func (s *testHealthServer) Check(ctx context.Context, _ *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	s.seen(ctx)
+	if s.check != nil {
+		if err := s.check(); err != nil {
+			return nil, err
+		}
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+func (s *testHealthServer) Watch(_ *healthpb.HealthCheckRequest, stream grpc.ServerStreamingServer[healthpb.HealthCheckResponse]) error {
+	s.seen(stream.Context())
+	if s.check != nil {
+		if err := s.check(); err != nil {
+			return err
+		}
+	}
+	return stream.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING})
+}
+
+// newInterceptedClient serves svc with the interceptors NewGRPCServer installs on a bufconn listener
+func newInterceptedClient(t *testing.T, svc *testHealthServer, validator JWTValidator) healthpb.HealthClient {
+	t.Helper()
+	unary := []grpc.UnaryServerInterceptor{LoggingInterceptor(), RecoveryInterceptor()}
+	stream := []grpc.StreamServerInterceptor{StreamLoggingInterceptor(), StreamRecoveryInterceptor()}
+	if validator != nil {
+		unary = append(unary, AuthInterceptor(validator))
+		stream = append(stream, StreamAuthInterceptor(validator))
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(ChainedUnaryInterceptor(unary...), ChainedStreamInterceptor(stream...))
+	healthpb.RegisterHealthServer(server, svc)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return healthpb.NewHealthClient(conn)
+}
+
+// watchOnce opens a Watch stream and reads its first message
+func watchOnce(ctx context.Context, client healthpb.HealthClient) error {
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return err
+	}
+	_, err = stream.Recv()
+	return err
+}
+
+func TestLoggingInterceptor_LogsMethodStatusAndDuration(t *testing.T) {
+	logs := useRecordingLogger(t)
+	svc := &testHealthServer{}
+	client := newInterceptedClient(t, svc, nil)
+
+	if _, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	svc.check = func() error { return status.Error(codes.NotFound, "unknown service") }
+	client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err := watchOnce(context.Background(), client); status.Code(err) != codes.NotFound {
+		t.Fatalf("Watch: code = %v, want %v", status.Code(err), codes.NotFound)
+	}
+
+	entries := logs.find("gRPC request handled")
+	if len(entries) != 3 {
+		t.Fatalf("logged %d requests, want 3", len(entries))
+	}
+	want := []struct{ method, code string }{
+		{"/grpc.health.v1.Health/Check", "OK"},
+		{"/grpc.health.v1.Health/Check", "NotFound"},
+		{"/grpc.health.v1.Health/Watch", "NotFound"},
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.level != "info" || e.fields["method"] != w.method || e.fields["statusCode"] != w.code {
+			t.Errorf("entry %d = %+v, want info for %s with %s", i, e, w.method, w.code)
+		}
+		if _, ok := e.fields["durationMs"].(int64); !ok {
+			t.Errorf("entry %d durationMs = %v, want milliseconds", i, e.fields["durationMs"])
+		}
+	}
+}
+
+func TestRecoveryInterceptor_ReturnsInternal(t *testing.T) {
+	logs := useRecordingLogger(t)
+	svc := &testHealthServer{check: func() error { panic("nil map write") }}
+	client := newInterceptedClient(t, svc, nil)
+
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("Check: code = %v, want %v", status.Code(err), codes.Internal)
+	}
+	// The message is the ProblemDetails of the generic internal error, never the panic value
+	if msg := status.Convert(err).Message(); strings.Contains(msg, "nil map write") {
+		t.Errorf("message %q leaks the panic value", msg)
+	}
+
+	if err := watchOnce(context.Background(), client); status.Code(err) != codes.Internal {
+		t.Errorf("Watch: code = %v, want %v", status.Code(err), codes.Internal)
+	}
+
+	recovered := logs.find("Recovered from panic in gRPC handler")
+	if len(recovered) != 2 {
+		t.Fatalf("logged %d panics, want 2", len(recovered))
+	}
+	if recovered[0].level != "error" || recovered[0].fields["panic"] != "nil map write" {
+		t.Errorf("panic entry = %+v, want an error with the panic value", recovered[0])
+	}
+	// The server keeps serving after a panic
+	svc.check = nil
+	if _, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+		t.Errorf("Check after a panic: %v", err)
+	}
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	useRecordingLogger(t)
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantCode      codes.Code
+	}{
+		{name: "valid token", authorization: "Bearer good-token", wantCode: codes.OK},
+		{name: "missing metadata", wantCode: codes.Unauthenticated},
+		{name: "not a bearer token", authorization: "Basic Z29vZC10b2tlbg==", wantCode: codes.Unauthenticated},
+		{name: "empty token", authorization: "Bearer ", wantCode: codes.Unauthenticated},
+		{name: "invalid token", authorization: "Bearer bad-token", wantCode: codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &testHealthServer{}
+			client := newInterceptedClient(t, svc, tokenValidator{"good-token": "user-1"})
+			ctx := context.Background()
+			if tt.authorization != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", tt.authorization)
+			}
+
+			_, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("Check: code = %v, want %v", got, tt.wantCode)
+			}
+			err = watchOnce(ctx, client)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("Watch: code = %v, want %v", got, tt.wantCode)
+			}
+
+			// Handlers only run for authenticated calls, with the validator's context
+			if tt.wantCode != codes.OK {
+				if len(svc.principals) != 0 {
+					t.Errorf("handler ran %d times for a rejected call", len(svc.principals))
+				}
+				return
+			}
+			if len(svc.principals) != 2 || svc.principals[0] != "user-1" || svc.principals[1] != "user-1" {
+				t.Errorf("principals = %v, want user-1 for both calls", svc.principals)
+			}
+		})
+	}
+}
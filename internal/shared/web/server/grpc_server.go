@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync/atomic"
+
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// ServiceSetupFunc defines a function that registers services on a gRPC server
+// This mirrors RouteSetupFunc, keeping service definitions in the infra layer
+type ServiceSetupFunc func(*grpc.Server)
+
+// GRPCServer wraps grpc.Server to implement the Server interface
+type GRPCServer struct {
+	grpcServer *grpc.Server
+	addr       string
+	// healthServer answers grpc.health.v1 checks (NOT_SERVING once draining)
+	healthServer *health.Server
+	draining     atomic.Bool
+}
+
+// GRPCServerConfig holds the settings used by NewGRPCServer
+type GRPCServerConfig struct {
+	Port              string
+	OtelEnabled       bool
+	ReflectionEnabled bool
+	Environment       string
+	// Validator enables bearer token authentication when not nil
+	Validator JWTValidator
+}
+
+// NewGRPCServer creates a new gRPC server and registers services through setupServices
+// Every call goes through logging and panic recovery; bearer token authentication
+// is enforced only when a validator is provided
+func NewGRPCServer(cfg GRPCServerConfig, setupServices ServiceSetupFunc) *GRPCServer {
+	port := cfg.Port
+	if port == "" {
+		port = "50051"
+	}
+	validator := cfg.Validator
+
+	unaryInterceptors := []grpc.UnaryServerInterceptor{LoggingInterceptor(), RecoveryInterceptor()}
+	streamInterceptors := []grpc.StreamServerInterceptor{StreamLoggingInterceptor(), StreamRecoveryInterceptor()}
+	if validator != nil {
+		unaryInterceptors = append(unaryInterceptors, AuthInterceptor(validator))
+		streamInterceptors = append(streamInterceptors, StreamAuthInterceptor(validator))
+	}
+
+	opts := []grpc.ServerOption{
+		ChainedUnaryInterceptor(unaryInterceptors...),
+		ChainedStreamInterceptor(streamInterceptors...),
+	}
+
+	// Add OpenTelemetry instrumentation if enabled (traces and metrics per RPC)
+	if cfg.OtelEnabled {
+		opts = append(opts, grpc.StatsHandler(otelgrpc.NewServerHandler()))
+	}
+
+	grpcServer := grpc.NewServer(opts...)
+
+	// Call the provided setup function to register services
+	if setupServices != nil {
+		setupServices(grpcServer)
+	}
+
+	// Standard health service so load balancers stop routing to a draining server
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	// Reflection lets tools like grpcurl and gRPC UI discover services without .proto files
+	if cfg.ReflectionEnabled {
+		reflection.Register(grpcServer)
+		if cfg.Environment == "production" {
+			logger.Warn(context.Background(), "gRPC server reflection is enabled in production", logger.CustomFields{
+				"environment": cfg.Environment,
+			})
+		}
+	}
+
+	return &GRPCServer{
+		grpcServer:   grpcServer,
+		addr:         ":" + port,
+		healthServer: healthServer,
+	}
+}
+
+// Start starts the server and blocks until it's stopped
+func (s *GRPCServer) Start() error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(listener)
+}
+
+// Serve accepts connections on the provided listener and blocks until the server stops
+func (s *GRPCServer) Serve(listener net.Listener) error {
+	fmt.Printf("Starting gRPC server on %s\n", listener.Addr())
+	if err := s.grpcServer.Serve(listener); err != nil && err != grpc.ErrServerStopped {
+		return err
+	}
+	return nil
+}
+
+// Draining reports whether graceful stop has begun
+func (s *GRPCServer) Draining() bool {
+	return s.draining.Load()
+}
+
+// Shutdown gracefully stops the server, forcing a stop if ctx expires first
+// Health checks report NOT_SERVING from the start so no new calls are routed here,
+// while in-flight calls (including streams) are allowed to finish
+func (s *GRPCServer) Shutdown(ctx context.Context) error {
+	fmt.Println("Shutting down gRPC server...")
+
+	s.draining.Store(true)
+	s.healthServer.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+		return ctx.Err()
+	}
+}
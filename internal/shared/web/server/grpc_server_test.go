@@ -0,0 +1,214 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/simple_module/grpc/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// serveGRPC starts srv on a bufconn listener and returns a client connection to it
+func serveGRPC(t *testing.T, srv *GRPCServer) *grpc.ClientConn {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	go srv.Serve(listener)
+	t.Cleanup(srv.grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func registerProductService(s *grpc.Server) {
+	pb.RegisterProductServiceServer(s, pb.UnimplementedProductServiceServer{})
+}
+
+// listServices asks the reflection service for the registered services, like `grpcurl list`
+func listServices(t *testing.T, conn *grpc.ClientConn) ([]string, error) {
+	t.Helper()
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer stream.CloseSend()
+
+	if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}); err != nil {
+		return nil, err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		names = append(names, service.GetName())
+	}
+	return names, nil
+}
+
+func TestNewGRPCServer_Reflection(t *testing.T) {
+	useRecordingLogger(t)
+
+	t.Run("enabled", func(t *testing.T) {
+		srv := NewGRPCServer(GRPCServerConfig{ReflectionEnabled: true}, registerProductService)
+
+		services, err := listServices(t, serveGRPC(t, srv))
+		if err != nil {
+			t.Fatalf("list services: %v", err)
+		}
+		want := map[string]bool{"simple_module.ProductService": false, "grpc.health.v1.Health": false}
+		for _, name := range services {
+			if _, ok := want[name]; ok {
+				want[name] = true
+			}
+		}
+		for name, found := range want {
+			if !found {
+				t.Errorf("%s missing from the reflection response %v", name, services)
+			}
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		srv := NewGRPCServer(GRPCServerConfig{}, registerProductService)
+
+		if services, err := listServices(t, serveGRPC(t, srv)); err == nil {
+			t.Errorf("reflection answered with %v, want it unavailable", services)
+		}
+	})
+}
+
+func TestNewGRPCServer_WarnsAboutReflectionInProduction(t *testing.T) {
+	tests := []struct {
+		environment string
+		reflection  bool
+		wantWarning bool
+	}{
+		{environment: "production", reflection: true, wantWarning: true},
+		{environment: "production", reflection: false, wantWarning: false},
+		{environment: "development", reflection: true, wantWarning: false},
+	}
+	for _, tt := range tests {
+		logs := useRecordingLogger(t)
+		NewGRPCServer(GRPCServerConfig{Environment: tt.environment, ReflectionEnabled: tt.reflection}, nil)
+
+		warned := len(logs.find("gRPC server reflection is enabled in production")) > 0
+		if warned != tt.wantWarning {
+			t.Errorf("environment=%s reflection=%v: warned = %v, want %v", tt.environment, tt.reflection, warned, tt.wantWarning)
+		}
+	}
+}
+
+// slowStreamDesc describes /test.Drain/Slow, a server stream that sends one message once release is closed
+func slowStreamDesc(started chan<- struct{}, release <-chan struct{}) grpc.ServiceDesc {
+	return grpc.ServiceDesc{
+		ServiceName: "test.Drain",
+		HandlerType: (*any)(nil),
+		Streams: []grpc.StreamDesc{{
+			StreamName:    "Slow",
+			ServerStreams: true,
+			Handler: func(_ any, stream grpc.ServerStream) error {
+				if err := stream.RecvMsg(&emptypb.Empty{}); err != nil {
+					return err
+				}
+				close(started)
+				select {
+				case <-release:
+				case <-stream.Context().Done():
+					return stream.Context().Err()
+				}
+				return stream.SendMsg(&emptypb.Empty{})
+			},
+		}},
+	}
+}
+
+// startSlowStream serves the slow stream on srv and opens a call to it, returning once the handler runs
+func startSlowStream(t *testing.T, release <-chan struct{}) (*GRPCServer, grpc.ClientStream) {
+	t.Helper()
+	started := make(chan struct{})
+	desc := slowStreamDesc(started, release)
+	srv := NewGRPCServer(GRPCServerConfig{}, func(s *grpc.Server) { s.RegisterService(&desc, nil) })
+	conn := serveGRPC(t, srv)
+
+	stream, err := conn.NewStream(context.Background(), &desc.Streams[0], "/test.Drain/Slow")
+	if err != nil {
+		t.Fatalf("NewStream: %v", err)
+	}
+	if err := stream.SendMsg(&emptypb.Empty{}); err != nil {
+		t.Fatalf("SendMsg: %v", err)
+	}
+	stream.CloseSend()
+	<-started
+	return srv, stream
+}
+
+func TestGRPCServer_ShutdownDrainsActiveStreams(t *testing.T) {
+	useRecordingLogger(t)
+	release := make(chan struct{})
+	srv, stream := startSlowStream(t, release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- srv.Shutdown(ctx) }()
+
+	for !srv.Draining() {
+		time.Sleep(time.Millisecond)
+	}
+	resp, err := srv.healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("health while draining = %v, %v, want NOT_SERVING", resp, err)
+	}
+
+	select {
+	case err := <-shutdown:
+		t.Fatalf("Shutdown returned %v while a stream was still active", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	if err := stream.RecvMsg(&emptypb.Empty{}); err != nil {
+		t.Errorf("stream response: %v, want it delivered before the server exits", err)
+	}
+	if err := <-shutdown; err != nil {
+		t.Errorf("Shutdown: %v, want a graceful stop", err)
+	}
+}
+
+func TestGRPCServer_ShutdownStopsWhenDrainWindowExpires(t *testing.T) {
+	useRecordingLogger(t)
+	// The stream is never released
+	srv, stream := startSlowStream(t, make(chan struct{}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := srv.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown: %v, want the drain window to expire", err)
+	}
+	if err := stream.RecvMsg(&emptypb.Empty{}); status.Code(err) == codes.OK {
+		t.Error("stream still open after the forced stop")
+	}
+}
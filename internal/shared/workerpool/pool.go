@@ -0,0 +1,155 @@
+// Package workerpool runs CPU-intensive jobs (imports, reports) on a fixed set of
+// background goroutines so they do not hold up HTTP handlers
+package workerpool
+
+import (
+	"context"
+	"sync"
+
+	sharedErrors "github.com/refortunato/go_app_base/internal/shared/errors"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Job is a unit of work; ctx carries the values and span of the submitting context
+type Job func(ctx context.Context) error
+
+type queuedJob struct {
+	ctx context.Context
+	job Job
+}
+
+// WorkerPool executes submitted jobs on a fixed number of workers, buffering up to
+// queueSize pending jobs
+type WorkerPool struct {
+	jobs chan queuedJob
+	wg   sync.WaitGroup
+
+	// mu guards closed so Submit never sends on the closed channel
+	mu     sync.RWMutex
+	closed bool
+
+	tracer    trace.Tracer
+	submitted metric.Int64Counter
+	completed metric.Int64Counter
+	failed    metric.Int64Counter
+}
+
+// New starts workers goroutines (at least 1) reading from a queue of queueSize jobs
+func New(workers int, queueSize int) *WorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	meter := otel.Meter("workerpool")
+	p := &WorkerPool{
+		jobs:   make(chan queuedJob, queueSize),
+		tracer: otel.Tracer("workerpool"),
+	}
+	p.submitted, _ = meter.Int64Counter(
+		"workerpool.jobs.submitted",
+		metric.WithDescription("Number of jobs accepted by the worker pool"),
+		metric.WithUnit("{job}"),
+	)
+	p.completed, _ = meter.Int64Counter(
+		"workerpool.jobs.completed",
+		metric.WithDescription("Number of worker pool jobs that finished without error"),
+		metric.WithUnit("{job}"),
+	)
+	p.failed, _ = meter.Int64Counter(
+		"workerpool.jobs.failed",
+		metric.WithDescription("Number of worker pool jobs that returned an error or panicked"),
+		metric.WithUnit("{job}"),
+	)
+
+	p.wg.Add(workers)
+	for range workers {
+		go p.work()
+	}
+	return p
+}
+
+// Submit queues job without waiting for it to run
+// The job keeps the values and span of ctx but not its cancellation, so it can outlive
+// the request that submitted it
+// Returns ErrPoolFull when the queue is full and ErrPoolClosed after Shutdown
+func (p *WorkerPool) Submit(ctx context.Context, job Job) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return sharedErrors.ErrPoolClosed
+	}
+
+	select {
+	case p.jobs <- queuedJob{ctx: context.WithoutCancel(ctx), job: job}:
+		p.submitted.Add(ctx, 1)
+		return nil
+	default:
+		return sharedErrors.ErrPoolFull
+	}
+}
+
+// Shutdown stops accepting jobs and waits for the queued and running ones to finish,
+// or for ctx to be done
+func (p *WorkerPool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.jobs)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// work runs jobs until the queue is closed and drained
+func (p *WorkerPool) work() {
+	defer p.wg.Done()
+	for queued := range p.jobs {
+		p.run(queued)
+	}
+}
+
+// run executes a job in a child span of the submitting context, recovering panics
+// so a failing job never takes a worker down
+func (p *WorkerPool) run(queued queuedJob) {
+	ctx, span := p.tracer.Start(queued.ctx, "workerpool.job")
+	defer span.End()
+
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = sharedErrors.ErrInternalServer
+				logger.FromContext(ctx).Error(ctx, "Worker pool job panicked", logger.CustomFields{"panic": r})
+			}
+		}()
+		return queued.job(ctx)
+	}()
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		p.failed.Add(ctx, 1)
+		logger.FromContext(ctx).WithError(err).Warn(ctx, "Worker pool job failed", logger.CustomFields{})
+		return
+	}
+	p.completed.Add(ctx, 1)
+}
@@ -0,0 +1,143 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sharedErrors "github.com/refortunato/go_app_base/internal/shared/errors"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+)
+
+func TestMain(m *testing.M) {
+	// Failed jobs are logged; a MultiLogger without loggers discards the entries
+	logger.SetGlobalLogger(logger.NewMultiLogger())
+	m.Run()
+}
+
+func TestWorkerPool_RunsAllJobs(t *testing.T) {
+	const workers, jobs = 4, 100
+	pool := New(workers, jobs)
+
+	var (
+		mu        sync.Mutex
+		processed = make(map[int]int)
+		running   atomic.Int32
+		maxActive atomic.Int32
+	)
+	for i := 0; i < jobs; i++ {
+		err := pool.Submit(context.Background(), func(ctx context.Context) error {
+			active := running.Add(1)
+			defer running.Add(-1)
+			for {
+				current := maxActive.Load()
+				if active <= current || maxActive.CompareAndSwap(current, active) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+
+			mu.Lock()
+			processed[i]++
+			mu.Unlock()
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Submit job %d: %v", i, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := pool.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	if len(processed) != jobs {
+		t.Errorf("%d distinct jobs ran, want %d", len(processed), jobs)
+	}
+	for i, count := range processed {
+		if count != 1 {
+			t.Errorf("job %d ran %d times", i, count)
+		}
+	}
+	if got := maxActive.Load(); got > workers {
+		t.Errorf("%d jobs ran at the same time, want at most %d", got, workers)
+	}
+}
+
+func TestWorkerPool_SubmitWhenFull(t *testing.T) {
+	pool := New(1, 1)
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	blocking := func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	}
+	if err := pool.Submit(context.Background(), blocking); err != nil {
+		t.Fatalf("Submit running job: %v", err)
+	}
+	<-started
+	if err := pool.Submit(context.Background(), func(ctx context.Context) error { return nil }); err != nil {
+		t.Fatalf("Submit queued job: %v", err)
+	}
+	if err := pool.Submit(context.Background(), func(ctx context.Context) error { return nil }); !errors.Is(err, sharedErrors.ErrPoolFull) {
+		t.Errorf("Submit on a full queue: err = %v, want ErrPoolFull", err)
+	}
+
+	close(release)
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if err := pool.Submit(context.Background(), func(ctx context.Context) error { return nil }); !errors.Is(err, sharedErrors.ErrPoolClosed) {
+		t.Errorf("Submit after Shutdown: err = %v, want ErrPoolClosed", err)
+	}
+}
+
+func TestWorkerPool_FailingJobsDoNotStopWorkers(t *testing.T) {
+	pool := New(1, 10)
+	var completed atomic.Int32
+
+	jobs := []Job{
+		func(ctx context.Context) error { panic("boom") },
+		func(ctx context.Context) error { return errors.New("failed") },
+		func(ctx context.Context) error { completed.Add(1); return nil },
+	}
+	for _, job := range jobs {
+		if err := pool.Submit(context.Background(), job); err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+	}
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if completed.Load() != 1 {
+		t.Error("the job after a panic and an error did not run")
+	}
+}
+
+func TestWorkerPool_JobOutlivesSubmitterCancellation(t *testing.T) {
+	pool := New(1, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	jobErr := make(chan error, 1)
+	if err := pool.Submit(ctx, func(ctx context.Context) error {
+		jobErr <- ctx.Err()
+		return nil
+	}); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	cancel()
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if err := <-jobErr; err != nil {
+		t.Errorf("job context err = %v, want nil after the submitter was canceled", err)
+	}
+}
@@ -0,0 +1,91 @@
+package simple_module
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"github.com/refortunato/go_app_base/internal/simple_module/repositories"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// StatsAggregator periodically pre-computes the product count so paginated product
+// lists read it from ProductRepository.CountCached instead of running COUNT(*) per request
+type StatsAggregator struct {
+	repository *repositories.ProductRepository
+	interval   time.Duration
+	runs       metric.Int64Counter
+
+	cancel   context.CancelFunc
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewStatsAggregator creates an aggregator refreshing the count every interval (defaults to 30 seconds)
+func NewStatsAggregator(repository *repositories.ProductRepository, interval time.Duration) *StatsAggregator {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	runs, _ := otel.Meter("simple_module").Int64Counter(
+		"stats.aggregation.runs",
+		metric.WithDescription("Number of background statistics aggregations"),
+		metric.WithUnit("{run}"),
+	)
+
+	return &StatsAggregator{repository: repository, interval: interval, runs: runs}
+}
+
+// Start runs a first aggregation right away and then one every interval, in a background
+// goroutine, until ctx is done or Stop is called
+func (a *StatsAggregator) Start(ctx context.Context) {
+	ctx, a.cancel = context.WithCancel(ctx)
+	a.done = make(chan struct{})
+
+	go func() {
+		defer close(a.done)
+
+		ticker := time.NewTicker(a.interval)
+		defer ticker.Stop()
+
+		for {
+			a.Aggregate(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	logger.Info(ctx, "Stats aggregator started", logger.CustomFields{"interval": a.interval.String()})
+}
+
+// Stop stops the aggregation loop and waits for a running aggregation to finish
+func (a *StatsAggregator) Stop() {
+	a.stopOnce.Do(func() {
+		if a.cancel != nil {
+			a.cancel()
+			<-a.done
+		}
+	})
+}
+
+// Aggregate counts the products and caches the result; on failure the previous value is
+// kept (a cold cache stays cold, so list requests keep counting for real)
+func (a *StatsAggregator) Aggregate(ctx context.Context) {
+	count, err := a.repository.Count(ctx)
+	if err != nil {
+		a.runs.Add(ctx, 1, metric.WithAttributes(attribute.String("status", "failure")))
+		if ctx.Err() == nil {
+			logger.WithError(err).Warn(ctx, "Stats aggregation failed")
+		}
+		return
+	}
+
+	a.repository.StoreCachedCount(int64(count))
+	a.runs.Add(ctx, 1, metric.WithAttributes(attribute.String("status", "success")))
+}
@@ -0,0 +1,119 @@
+//go:build sqlite
+
+package simple_module
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+	"github.com/refortunato/go_app_base/internal/simple_module/models"
+	"github.com/refortunato/go_app_base/internal/simple_module/repositories"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// aggregationRuns returns the stats.aggregation.runs count per status
+func aggregationRuns(t *testing.T, reader *sdkmetric.ManualReader) map[string]int64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	runs := make(map[string]int64)
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != "stats.aggregation.runs" {
+				continue
+			}
+			for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				status, _ := point.Attributes.Value(attribute.Key("status"))
+				runs[status.AsString()] = point.Value
+			}
+		}
+	}
+	return runs
+}
+
+// newAggregatorMetricsReader installs a meter provider collecting on demand, before the aggregator creates its counter
+func newAggregatorMetricsReader(t *testing.T) *sdkmetric.ManualReader {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	previous := otel.GetMeterProvider()
+	otel.SetMeterProvider(provider)
+	t.Cleanup(func() {
+		otel.SetMeterProvider(previous)
+		provider.Shutdown(context.Background())
+	})
+	return reader
+}
+
+func saveProduct(t *testing.T, repo *repositories.ProductRepository, id string) {
+	t.Helper()
+	now := time.Now().UTC()
+	if err := repo.Save(context.Background(), &models.Product{ID: id, Name: id, CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+}
+
+func TestStatsAggregator_Aggregate(t *testing.T) {
+	reader := newAggregatorMetricsReader(t)
+	repo := repositories.NewProductRepository(testhelpers.NewSQLiteForTest(t))
+	aggregator := NewStatsAggregator(repo, time.Hour)
+
+	if cached := repo.CountCached(); cached != -1 {
+		t.Fatalf("before the first run: CountCached = %d, want -1", cached)
+	}
+	saveProduct(t, repo, "product-1")
+	saveProduct(t, repo, "product-2")
+	aggregator.Aggregate(context.Background())
+	if cached := repo.CountCached(); cached != 2 {
+		t.Errorf("CountCached = %d, want 2", cached)
+	}
+
+	// A failed run keeps the previous value
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	saveProduct(t, repo, "product-3")
+	aggregator.Aggregate(canceled)
+	if cached := repo.CountCached(); cached != 2 {
+		t.Errorf("after a failed run: CountCached = %d, want the previous 2", cached)
+	}
+
+	if runs := aggregationRuns(t, reader); runs["success"] != 1 || runs["failure"] != 1 {
+		t.Errorf("runs = %v, want one success and one failure", runs)
+	}
+}
+
+func TestStatsAggregator_RunsPeriodically(t *testing.T) {
+	newAggregatorMetricsReader(t)
+	repo := repositories.NewProductRepository(testhelpers.NewSQLiteForTest(t))
+	saveProduct(t, repo, "product-1")
+
+	aggregator := NewStatsAggregator(repo, 10*time.Millisecond)
+	aggregator.Start(context.Background())
+	defer aggregator.Stop()
+
+	// The first run happens on Start, later ones pick up new products
+	waitForCount := func(want int64) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for repo.CountCached() != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("CountCached = %d, want %d", repo.CountCached(), want)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	waitForCount(1)
+	saveProduct(t, repo, "product-2")
+	waitForCount(2)
+
+	aggregator.Stop()
+	// Stop is idempotent
+	aggregator.Stop()
+}
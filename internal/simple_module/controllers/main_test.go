@@ -0,0 +1,16 @@
+//go:build sqlite
+
+package controllers
+
+import (
+	"os"
+	"testing"
+
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+)
+
+func TestMain(m *testing.M) {
+	// Rejected requests and service errors are logged through the global logger
+	logger.SetGlobalLogger(logger.NewMultiLogger())
+	os.Exit(m.Run())
+}
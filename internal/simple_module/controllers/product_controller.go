@@ -1,30 +1,87 @@
 package controllers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/refortunato/go_app_base/internal/shared"
 	"github.com/refortunato/go_app_base/internal/shared/dto"
+	sharedErrors "github.com/refortunato/go_app_base/internal/shared/errors"
+	"github.com/refortunato/go_app_base/internal/shared/hateoas"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"github.com/refortunato/go_app_base/internal/shared/validation"
 	"github.com/refortunato/go_app_base/internal/shared/web/advisor"
 	"github.com/refortunato/go_app_base/internal/shared/web/context"
+	"github.com/refortunato/go_app_base/internal/simple_module/errors"
+	"github.com/refortunato/go_app_base/internal/simple_module/models"
 	"github.com/refortunato/go_app_base/internal/simple_module/services"
 )
 
 // ProductController handles HTTP requests for products
 type ProductController struct {
 	service *services.ProductService
+	// baseURL prefixes the pagination links returned with product lists
+	baseURL string
+	// transformer shapes products into responses with HATEOAS links and CDN image URLs
+	transformer dto.Transformer[*models.Product, *ProductResponse]
+	// maxPageLimit is the largest page size accepted by ListProducts
+	maxPageLimit int
+	// uploadDir is where UploadProductImage stores images
+	uploadDir string
+	// maxUploadSize is the largest image accepted by UploadProductImage, in bytes
+	maxUploadSize int64
+	// cacheMaxAge is the Cache-Control max-age of GetProduct responses, in seconds
+	cacheMaxAge int
+	// upgrader switches SubscribeStock requests to WebSocket (same-origin browsers and non-browser clients)
+	upgrader context.WebSocketUpgrader
 }
 
 // NewProductController creates a new product controller instance
-func NewProductController(service *services.ProductService) *ProductController {
-	return &ProductController{service: service}
+func NewProductController(service *services.ProductService, baseURL, cdnBaseURL string, maxPageLimit int, uploadDir string, maxUploadSizeMB, cacheMaxAge int) *ProductController {
+	return &ProductController{
+		service:       service,
+		baseURL:       baseURL,
+		transformer:   NewProductTransformer(baseURL, cdnBaseURL),
+		maxPageLimit:  maxPageLimit,
+		uploadDir:     uploadDir,
+		maxUploadSize: int64(maxUploadSizeMB) << 20,
+		cacheMaxAge:   cacheMaxAge,
+		upgrader:      context.NewGorillaUpgrader(nil),
+	}
+}
+
+// returnError logs err with its error fields and writes the matching ProblemDetails response
+// Errors carrying their module are logged by the advisor, with the module and operation
+func (c *ProductController) returnError(ctx context.WebContext, err error) {
+	if pd, ok := err.(*sharedErrors.ProblemDetails); !ok || pd.Module == "" {
+		logger.FromContext(ctx.GetContext()).WithError(err).Error(ctx.GetContext(), "Failed to process request")
+	}
+	advisor.ReturnApplicationError(ctx, err)
 }
 
-// CreateProductRequest represents the request body for creating a product
-type CreateProductRequest struct {
-	Name        string  `json:"name" example:"Laptop Dell XPS 15"`
-	Description string  `json:"description" example:"High-performance laptop"`
-	Price       float64 `json:"price" example:"5499.99"`
-	Stock       int     `json:"stock" example:"10"`
+// maxImportFileSize limits the size of CSV files accepted by ImportProducts (10 MB)
+const maxImportFileSize = 10 << 20
+
+// multipartOverhead is the room left for the multipart boundaries and part headers
+// when the request body is limited to the size of the uploaded file
+const multipartOverhead = 64 << 10
+
+// allowedImageExtensions lists the file extensions accepted by UploadProductImage
+var allowedImageExtensions = map[string]bool{".jpg": true, ".jpeg": true, ".png": true, ".webp": true}
+
+// ProductImageResponse is returned after a product image upload
+type ProductImageResponse struct {
+	Path string `json:"path" example:"uploads/750e8400-e29b-41d4-a716-446655440000.jpg"`
 }
 
 // UpdateProductRequest represents the request body for updating a product
@@ -33,74 +90,164 @@ type UpdateProductRequest struct {
 	Description string  `json:"description" example:"Updated description"`
 	Price       float64 `json:"price" example:"4999.99"`
 	Stock       int     `json:"stock" example:"15"`
+	ImageURL    string  `json:"image_url,omitempty" example:"https://cdn.example.com/products/xps15-v2.jpg"`
+}
+
+// GetLatestProduct godoc
+// @Summary      Redirect to the latest product
+// @Description  Redirects (302) to the URL of the most recently created product
+// @Tags         products
+// @Success      302  "Location: URL of the latest product"
+// @Header       302  {string}  Location  "URL of the latest product"
+// @Failure      401  {object}  errors.ProblemDetails  "Authentication required"
+// @Failure      403  {object}  errors.ProblemDetails  "Missing required scope"
+// @Failure      404  {object}  errors.ProblemDetails  "No product exists"
+// @Failure      500  {object}  errors.ProblemDetails  "Internal server error"
+// @Security     ApiKeyAuth
+// @Router       /products/latest [get]
+func (c *ProductController) GetLatestProduct(ctx context.WebContext) {
+	product, err := c.service.GetLatestProduct(ctx.GetContext())
+	if err != nil {
+		c.returnError(ctx, err)
+		return
+	}
+
+	// Temporary: the latest product changes whenever a product is created
+	ctx.TemporaryRedirect(hateoas.ProductLinks(c.baseURL, product.ID)["self"])
 }
 
 // GetProduct godoc
 // @Summary      Get product by ID
-// @Description  Retrieves a specific product from the database
+// @Description  Retrieves a specific product from the database (JSON or XML, based on the Accept header)
+// @Description  Responses carry Cache-Control, Last-Modified and ETag; If-Modified-Since returns 304 when the product did not change
 // @Tags         products
-// @Produce      json
-// @Param        id   path      string  true  "Product ID (UUID format)"
-// @Success      200  {object}  models.Product
+// @Produce      json,xml
+// @Param        id                 path      string  true   "Product ID (UUID format)"
+// @Param        If-Modified-Since  header    string  false  "HTTP date of the cached copy"
+// @Success      200  {object}  controllers.ProductResponse
+// @Header       200  {string}  Last-Modified  "Last product update (RFC 1123)"
+// @Header       200  {string}  ETag           "Weak validator of the product version"
+// @Success      304  "Not modified"
+// @Failure      400  {object}  errors.ProblemDetails  "Invalid product ID (not a UUID)"
+// @Failure      401  {object}  errors.ProblemDetails  "Authentication required"
+// @Failure      403  {object}  errors.ProblemDetails  "Missing required scope"
 // @Failure      404  {object}  errors.ProblemDetails  "Product not found"
+// @Failure      406  {object}  errors.ProblemDetails  "Requested media type not supported"
 // @Failure      500  {object}  errors.ProblemDetails  "Internal server error"
+// @Security     ApiKeyAuth
 // @Router       /products/{id} [get]
 func (c *ProductController) GetProduct(ctx context.WebContext) {
 	id := ctx.Param("id")
+	if validation.ValidateUUID(id) != nil {
+		ctx.AbortWithProblem(sharedErrors.ErrInvalidID)
+		return
+	}
 
 	product, err := c.service.GetProduct(ctx.GetContext(), id)
 	if err != nil {
-		advisor.ReturnApplicationError(ctx, err)
+		c.returnError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, product)
+	c.writeCacheHeaders(ctx, product)
+	if context.CheckIfModifiedSince(ctx, product.UpdatedAt) {
+		ctx.SetStatus(http.StatusNotModified)
+		return
+	}
+
+	response, err := c.transformer.Transform(ctx.GetContext(), product)
+	if err != nil {
+		c.returnError(ctx, err)
+		return
+	}
+	if err := ctx.Negotiate(http.StatusOK, []string{"application/json", "application/xml"}, response); err != nil {
+		c.returnError(ctx, err)
+	}
+}
+
+// writeCacheHeaders sets the caching headers of a product response
+// The ETag is weak because the same version is served as JSON or XML
+func (c *ProductController) writeCacheHeaders(ctx context.WebContext, product *models.Product) {
+	ctx.SetHeader("Cache-Control", fmt.Sprintf("private, max-age=%d", c.cacheMaxAge))
+	ctx.SetHeader("Vary", "Accept")
+	context.WriteLastModified(ctx, product.UpdatedAt)
+
+	hash := sha256.Sum256([]byte(product.ID + "|" + product.UpdatedAt.UTC().Format(time.RFC3339Nano)))
+	ctx.SetHeader("ETag", `W/"`+hex.EncodeToString(hash[:8])+`"`)
 }
 
 // ListProducts godoc
 // @Summary      List all products
-// @Description  Returns a paginated list of products
+// @Description  Returns a paginated list of products, optionally filtered by tag
 // @Tags         products
 // @Produce      json
-// @Param        page   query  int  false  "Page number" default(1)
-// @Param        limit  query  int  false  "Items per page" default(10)
-// @Success      200    {object}  services.ListProductsResponse
-// @Failure      400    {object}  errors.ProblemDetails   "Invalid pagination parameters"
+// @Param        page   query  int     false  "Page number" default(1)
+// @Param        limit  query  int     false  "Items per page (max SERVER_APP_PAGINATION_MAX_LIMIT)" default(10)
+// @Param        tag    query  string  false  "Only products labeled with this tag"
+// @Success      200    {object}  controllers.ProductListResponse
+// @Header       200    {string}  Link  "Pagination links (rel=first, prev, next, last)"
+// @Failure      400    {object}  errors.ProblemDetails   "Invalid pagination parameters or tag"
+// @Failure      401    {object}  errors.ProblemDetails   "Authentication required"
+// @Failure      403    {object}  errors.ProblemDetails   "Missing required scope"
 // @Failure      500    {object}  errors.ProblemDetails   "Internal server error"
+// @Security     ApiKeyAuth
 // @Router       /products [get]
 func (c *ProductController) ListProducts(ctx context.WebContext) {
 	// Parse pagination parameters from query string
 	pageStr := ctx.Query("page")
 	limitStr := ctx.Query("limit")
 
-	pagination, err := dto.NewPaginationRequestDTO(pageStr, limitStr)
+	pagination, err := dto.NewPaginationRequestDTOWithMax(pageStr, limitStr, c.maxPageLimit)
 	if err != nil {
 		advisor.ReturnBadRequestError(ctx, err)
 		return
 	}
 
-	result, err := c.service.ListProducts(ctx.GetContext(), pagination.Page, pagination.Limit)
+	tag := ctx.Query("tag")
+
+	result, err := c.service.ListProducts(ctx.GetContext(), pagination.Page, pagination.Limit, tag)
 	if err != nil {
-		advisor.ReturnApplicationError(ctx, err)
+		c.returnError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, result)
+	items, err := c.transformer.TransformMany(ctx.GetContext(), result.Items)
+	if err != nil {
+		c.returnError(ctx, err)
+		return
+	}
+
+	// Link header with first/prev/next/last pages, keeping the tag filter
+	links := dto.PaginationLinks(c.baseURL, "/products", result.Pagination.Page, result.Pagination.Limit, result.Pagination.TotalPages)
+	if tag != "" {
+		for rel, link := range links {
+			links[rel] = link + "&tag=" + url.QueryEscape(tag)
+		}
+	}
+	advisor.WritePaginationHeaders(ctx, links)
+
+	ctx.JSON(http.StatusOK, ProductListResponse{Items: items, Pagination: result.Pagination})
 }
 
 // CreateProduct godoc
 // @Summary      Create new product
 // @Description  Creates a new product in the system
+// @Description  The body is validated against schemas/CreateProductSchema.json before the handler runs
 // @Tags         products
 // @Accept       json
 // @Produce      json
-// @Param        request  body      CreateProductRequest  true  "Product data"
+// @Param        request  body      services.CreateProductRequest  true  "Product data"
 // @Success      201      {object}  models.Product
 // @Failure      400      {object}  errors.ProblemDetails  "Invalid input"
+// @Failure      401      {object}  errors.ProblemDetails  "Authentication required"
+// @Failure      403      {object}  errors.ProblemDetails  "Missing required scope"
+// @Failure      409      {object}  errors.ProblemDetails  "Product already exists"
+// @Failure      422      {object}  errors.ProblemDetails  "Body does not match the schema (see validation_errors)"
 // @Failure      500      {object}  errors.ProblemDetails  "Internal server error"
+// @Security     ApiKeyAuth
 // @Router       /products [post]
 func (c *ProductController) CreateProduct(ctx context.WebContext) {
-	var request CreateProductRequest
+	var request services.CreateProductRequest
 
 	if err := ctx.BindJSON(&request); err != nil {
 		advisor.ReturnBadRequestError(ctx, err)
@@ -113,15 +260,157 @@ func (c *ProductController) CreateProduct(ctx context.WebContext) {
 		request.Description,
 		request.Price,
 		request.Stock,
+		request.ImageURL,
+		request.Tags,
 	)
 	if err != nil {
-		advisor.ReturnApplicationError(ctx, err)
+		c.returnError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusCreated, product)
 }
 
+// UploadProductImage godoc
+// @Summary      Upload product image
+// @Description  Stores an image (.jpg, .jpeg, .png or .webp, max SERVER_APP_MAX_UPLOAD_SIZE_MB) for a product and returns the stored file path
+// @Tags         products
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id    path      string  true  "Product ID"
+// @Param        file  formData  file    true  "Image file"
+// @Success      201   {object}  controllers.ProductImageResponse
+// @Failure      400   {object}  errors.ProblemDetails  "Missing file, unsupported image type or invalid product ID"
+// @Failure      401   {object}  errors.ProblemDetails  "Authentication required"
+// @Failure      403   {object}  errors.ProblemDetails  "Missing required scope"
+// @Failure      404   {object}  errors.ProblemDetails  "Product not found"
+// @Failure      413   {object}  errors.ProblemDetails  "File too large"
+// @Failure      500   {object}  errors.ProblemDetails  "Internal server error"
+// @Security     ApiKeyAuth
+// @Router       /products/{id}/image [post]
+func (c *ProductController) UploadProductImage(ctx context.WebContext) {
+	// Bound the multipart parsing, which would otherwise read the whole body before the size check
+	ctx.LimitBody(c.maxUploadSize + multipartOverhead)
+	id := ctx.Param("id")
+
+	if _, err := c.service.GetProduct(ctx.GetContext(), id); err != nil {
+		c.returnError(ctx, err)
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		if context.IsBodyTooLarge(err) {
+			ctx.AbortWithProblem(errors.ErrProductImageTooLarge)
+			return
+		}
+		ctx.AbortWithProblem(errors.ErrProductImageRequired)
+		return
+	}
+	ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
+	if !allowedImageExtensions[ext] {
+		ctx.AbortWithProblem(errors.ErrProductImageTypeInvalid)
+		return
+	}
+	if fileHeader.Size > c.maxUploadSize {
+		ctx.AbortWithProblem(errors.ErrProductImageTooLarge)
+		return
+	}
+
+	// The client file name is never used on disk, only its extension
+	if err := os.MkdirAll(c.uploadDir, 0o755); err != nil {
+		c.returnError(ctx, err)
+		return
+	}
+	dst := filepath.Join(c.uploadDir, shared.GenerateId()+ext)
+	if err := ctx.SaveUploadedFile(fileHeader, dst); err != nil {
+		c.returnError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, ProductImageResponse{Path: dst})
+}
+
+// ExportProducts godoc
+// @Summary      Export all products
+// @Description  Streams every product as NDJSON (one JSON object per line). The response is chunked, so a failure after the first line truncates the stream instead of returning an error status
+// @Tags         products
+// @Produce      application/x-ndjson
+// @Success      200  {object}  models.Product  "One product per line"
+// @Failure      401  {object}  errors.ProblemDetails  "Authentication required"
+// @Failure      403  {object}  errors.ProblemDetails  "Missing required scope"
+// @Security     ApiKeyAuth
+// @Router       /products/export [get]
+func (c *ProductController) ExportProducts(ctx context.WebContext) {
+	ctx.SetHeader("Transfer-Encoding", "chunked")
+
+	err := ctx.Stream(http.StatusOK, "application/x-ndjson", func(w io.Writer) error {
+		return c.service.ExportAll(ctx.GetContext(), w)
+	})
+	if err != nil {
+		logger.FromContext(ctx.GetContext()).WithError(err).Error(ctx.GetContext(), "Product export interrupted")
+	}
+}
+
+// ImportProducts godoc
+// @Summary      Import products from CSV
+// @Description  Creates products in bulk from a CSV file with the header: name,description,price,stock and an optional external_id column. Rows whose external_id was already imported update that product instead (reported as updated), so a retried import does not duplicate products. Invalid rows are skipped and reported by line number
+// @Tags         products
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file  formData  file  true  "CSV file (max 10 MB)"
+// @Success      202   {object}  services.BulkImportResult
+// @Failure      400   {object}  errors.ProblemDetails  "Missing file or invalid CSV header"
+// @Failure      401   {object}  errors.ProblemDetails  "Authentication required"
+// @Failure      403   {object}  errors.ProblemDetails  "Missing required scope"
+// @Failure      413   {object}  errors.ProblemDetails  "File too large"
+// @Failure      500   {object}  errors.ProblemDetails  "Internal server error"
+// @Security     ApiKeyAuth
+// @Router       /products/import [post]
+func (c *ProductController) ImportProducts(ctx context.WebContext) {
+	ctx.LimitBody(maxImportFileSize + multipartOverhead)
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		if context.IsBodyTooLarge(err) {
+			ctx.AbortWithProblem(errors.ErrImportFileTooLarge)
+			return
+		}
+		ctx.AbortWithProblem(errors.ErrImportFileRequired)
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		ctx.AbortWithProblem(errors.ErrImportFileTooLarge)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.AbortWithProblem(errors.ErrGeneric)
+		return
+	}
+	defer file.Close()
+
+	parsed, err := parseProductCSV(io.LimitReader(file, maxImportFileSize))
+	if err != nil {
+		c.returnError(ctx, err)
+		return
+	}
+
+	result := c.service.BulkImport(ctx.GetContext(), parsed.requests)
+
+	// Translate service row positions into CSV line numbers and merge parse errors
+	for i := range result.Errors {
+		result.Errors[i].Row = parsed.lines[result.Errors[i].Row-1]
+	}
+	result.Errors = append(result.Errors, parsed.errors...)
+	result.Skipped += len(parsed.errors)
+	sort.SliceStable(result.Errors, func(i, j int) bool {
+		return result.Errors[i].Row < result.Errors[j].Row
+	})
+
+	ctx.JSON(http.StatusAccepted, result)
+}
+
 // UpdateProduct godoc
 // @Summary      Update product
 // @Description  Updates an existing product
@@ -131,12 +420,20 @@ func (c *ProductController) CreateProduct(ctx context.WebContext) {
 // @Param        id       path      string                 true  "Product ID"
 // @Param        request  body      UpdateProductRequest   true  "Updated product data"
 // @Success      200      {object}  models.Product
-// @Failure      400      {object}  errors.ProblemDetails  "Invalid input"
+// @Failure      400      {object}  errors.ProblemDetails  "Invalid input or product ID"
+// @Failure      401      {object}  errors.ProblemDetails  "Authentication required"
+// @Failure      403      {object}  errors.ProblemDetails  "Missing required scope"
 // @Failure      404      {object}  errors.ProblemDetails  "Product not found"
+// @Failure      422      {object}  errors.ProblemDetails  "Stock change on a product with variants"
 // @Failure      500      {object}  errors.ProblemDetails  "Internal server error"
+// @Security     ApiKeyAuth
 // @Router       /products/{id} [put]
 func (c *ProductController) UpdateProduct(ctx context.WebContext) {
 	id := ctx.Param("id")
+	if validation.ValidateUUID(id) != nil {
+		ctx.AbortWithProblem(sharedErrors.ErrInvalidID)
+		return
+	}
 
 	var request UpdateProductRequest
 
@@ -152,31 +449,261 @@ func (c *ProductController) UpdateProduct(ctx context.WebContext) {
 		request.Description,
 		request.Price,
 		request.Stock,
+		request.ImageURL,
 	)
 	if err != nil {
-		advisor.ReturnApplicationError(ctx, err)
+		c.returnError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, product)
+}
+
+// UpsertProduct godoc
+// @Summary      Create or replace product
+// @Description  Creates the product or, when a product with the given ID exists, replaces its data. Without an ID a new product is created
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        request  body      services.UpsertProductRequest  true  "Product data"
+// @Success      200      {object}  models.Product  "Product updated"
+// @Success      201      {object}  models.Product  "Product created"
+// @Failure      400      {object}  errors.ProblemDetails  "Invalid input or ID (not a UUID)"
+// @Failure      401      {object}  errors.ProblemDetails  "Authentication required"
+// @Failure      403      {object}  errors.ProblemDetails  "Missing required scope"
+// @Failure      422      {object}  errors.ProblemDetails  "Stock change on a product with variants"
+// @Failure      500      {object}  errors.ProblemDetails  "Internal server error"
+// @Security     ApiKeyAuth
+// @Router       /products [put]
+func (c *ProductController) UpsertProduct(ctx context.WebContext) {
+	var request services.UpsertProductRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		advisor.ReturnBadRequestError(ctx, err)
+		return
+	}
+
+	// Client-chosen IDs must be UUIDs too (an empty ID creates a new product)
+	if request.ID != "" {
+		if validation.ValidateUUID(request.ID) != nil {
+			ctx.AbortWithProblem(sharedErrors.ErrInvalidID)
+			return
+		}
+	}
+
+	product, created, err := c.service.UpsertProduct(ctx.GetContext(), &request)
+	if err != nil {
+		c.returnError(ctx, err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	ctx.JSON(status, product)
+}
+
+// PatchProduct godoc
+// @Summary      Partially update product
+// @Description  Applies a JSON Merge Patch (RFC 7396) to an existing product. Only fields present in the body are changed; null clears a field
+// @Tags         products
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Param        id       path      string                        true  "Product ID"
+// @Param        request  body      services.PatchProductRequest  true  "Fields to update"
+// @Success      200      {object}  models.Product
+// @Failure      400      {object}  errors.ProblemDetails  "Invalid input or product ID"
+// @Failure      401      {object}  errors.ProblemDetails  "Authentication required"
+// @Failure      403      {object}  errors.ProblemDetails  "Missing required scope"
+// @Failure      404      {object}  errors.ProblemDetails  "Product not found"
+// @Failure      415      {object}  errors.ProblemDetails  "Unsupported media type"
+// @Failure      422      {object}  errors.ProblemDetails  "Stock change on a product with variants"
+// @Failure      500      {object}  errors.ProblemDetails  "Internal server error"
+// @Security     ApiKeyAuth
+// @Router       /products/{id} [patch]
+func (c *ProductController) PatchProduct(ctx context.WebContext) {
+	id := ctx.Param("id")
+
+	mediaType, _, err := mime.ParseMediaType(ctx.GetHeader("Content-Type"))
+	if err != nil || mediaType != "application/merge-patch+json" {
+		ctx.AbortWithProblem(sharedErrors.ErrUnsupportedMediaType)
+		return
+	}
+
+	var request services.PatchProductRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		advisor.ReturnBadRequestError(ctx, err)
+		return
+	}
+
+	product, err := c.service.PatchProduct(ctx.GetContext(), id, &request)
+	if err != nil {
+		c.returnError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, product)
 }
 
+// GetPriceHistory godoc
+// @Summary      Get product price history
+// @Description  Returns the price changes of a product, newest first
+// @Tags         products
+// @Produce      json
+// @Param        id     path   string  true   "Product ID"
+// @Param        page   query  int     false  "Page number" default(1)
+// @Param        limit  query  int     false  "Items per page (max SERVER_APP_PAGINATION_MAX_LIMIT)" default(10)
+// @Success      200    {object}  services.PriceHistoryResponse
+// @Header       200    {string}  Link  "Pagination links (rel=first, prev, next, last)"
+// @Failure      400    {object}  errors.ProblemDetails  "Invalid pagination parameters or product ID"
+// @Failure      401    {object}  errors.ProblemDetails  "Authentication required"
+// @Failure      403    {object}  errors.ProblemDetails  "Missing required scope"
+// @Failure      404    {object}  errors.ProblemDetails  "Product not found"
+// @Failure      500    {object}  errors.ProblemDetails  "Internal server error"
+// @Security     ApiKeyAuth
+// @Router       /products/{id}/price-history [get]
+func (c *ProductController) GetPriceHistory(ctx context.WebContext) {
+	id := ctx.Param("id")
+
+	pagination, err := dto.NewPaginationRequestDTOWithMax(ctx.Query("page"), ctx.Query("limit"), c.maxPageLimit)
+	if err != nil {
+		advisor.ReturnBadRequestError(ctx, err)
+		return
+	}
+
+	result, err := c.service.GetPriceHistory(ctx.GetContext(), id, pagination.Page, pagination.Limit)
+	if err != nil {
+		c.returnError(ctx, err)
+		return
+	}
+
+	path := "/products/" + url.PathEscape(id) + "/price-history"
+	links := dto.PaginationLinks(c.baseURL, path, result.Pagination.Page, result.Pagination.Limit, result.Pagination.TotalPages)
+	advisor.WritePaginationHeaders(ctx, links)
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// SetStockThreshold godoc
+// @Summary      Set product stock threshold
+// @Description  Sets the stock level below which the product raises low-stock alerts (product.stock.low_threshold metric and a warning log). 0 disables the alerts
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                             true  "Product ID"
+// @Param        request  body      services.SetStockThresholdRequest  true  "Stock threshold"
+// @Success      200      {object}  models.Product
+// @Failure      400      {object}  errors.ProblemDetails  "Invalid threshold or product ID"
+// @Failure      401      {object}  errors.ProblemDetails  "Authentication required"
+// @Failure      403      {object}  errors.ProblemDetails  "Missing required scope"
+// @Failure      404      {object}  errors.ProblemDetails  "Product not found"
+// @Failure      500      {object}  errors.ProblemDetails  "Internal server error"
+// @Security     ApiKeyAuth
+// @Router       /products/{id}/stock-threshold [post]
+func (c *ProductController) SetStockThreshold(ctx context.WebContext) {
+	id := ctx.Param("id")
+
+	var request services.SetStockThresholdRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		advisor.ReturnBadRequestError(ctx, err)
+		return
+	}
+
+	product, err := c.service.SetStockThreshold(ctx.GetContext(), id, request.Threshold)
+	if err != nil {
+		c.returnError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, product)
+}
+
+// AddProductTags godoc
+// @Summary      Tag product
+// @Description  Labels a product with one or more tags. Tags are lowercased and created on first use; tags the product already has are ignored
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                          true  "Product ID"
+// @Param        request  body      services.AddProductTagsRequest  true  "Tags to add"
+// @Success      200      {object}  models.Product
+// @Failure      400      {object}  errors.ProblemDetails  "Invalid tags or product ID"
+// @Failure      401      {object}  errors.ProblemDetails  "Authentication required"
+// @Failure      403      {object}  errors.ProblemDetails  "Missing required scope"
+// @Failure      404      {object}  errors.ProblemDetails  "Product not found"
+// @Failure      500      {object}  errors.ProblemDetails  "Internal server error"
+// @Security     ApiKeyAuth
+// @Router       /products/{id}/tags [post]
+func (c *ProductController) AddProductTags(ctx context.WebContext) {
+	id := ctx.Param("id")
+
+	var request services.AddProductTagsRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		advisor.ReturnBadRequestError(ctx, err)
+		return
+	}
+
+	product, err := c.service.AddProductTags(ctx.GetContext(), id, request.Tags)
+	if err != nil {
+		c.returnError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, product)
+}
+
+// RemoveProductTag godoc
+// @Summary      Untag product
+// @Description  Removes a tag from a product. Removing a tag the product does not have succeeds without changes
+// @Tags         products
+// @Param        id   path  string  true  "Product ID"
+// @Param        tag  path  string  true  "Tag name"
+// @Success      204  "No content"
+// @Failure      400  {object}  errors.ProblemDetails  "Invalid tag or product ID"
+// @Failure      401  {object}  errors.ProblemDetails  "Authentication required"
+// @Failure      403  {object}  errors.ProblemDetails  "Missing required scope"
+// @Failure      404  {object}  errors.ProblemDetails  "Product not found"
+// @Failure      500  {object}  errors.ProblemDetails  "Internal server error"
+// @Security     ApiKeyAuth
+// @Router       /products/{id}/tags/{tag} [delete]
+func (c *ProductController) RemoveProductTag(ctx context.WebContext) {
+	id := ctx.Param("id")
+	tag := ctx.Param("tag")
+
+	if err := c.service.RemoveProductTag(ctx.GetContext(), id, tag); err != nil {
+		c.returnError(ctx, err)
+		return
+	}
+
+	ctx.SetStatus(http.StatusNoContent)
+}
+
 // DeleteProduct godoc
 // @Summary      Delete product
 // @Description  Removes a product from the system
 // @Tags         products
 // @Param        id   path  string  true  "Product ID"
 // @Success      204  "No content"
+// @Failure      400  {object}  errors.ProblemDetails  "Invalid product ID (not a UUID)"
+// @Failure      401  {object}  errors.ProblemDetails  "Authentication required"
+// @Failure      403  {object}  errors.ProblemDetails  "Missing required scope"
 // @Failure      404  {object}  errors.ProblemDetails  "Product not found"
 // @Failure      500  {object}  errors.ProblemDetails  "Internal server error"
+// @Security     ApiKeyAuth
 // @Router       /products/{id} [delete]
 func (c *ProductController) DeleteProduct(ctx context.WebContext) {
 	id := ctx.Param("id")
+	if validation.ValidateUUID(id) != nil {
+		ctx.AbortWithProblem(sharedErrors.ErrInvalidID)
+		return
+	}
 
 	if err := c.service.DeleteProduct(ctx.GetContext(), id); err != nil {
-		advisor.ReturnApplicationError(ctx, err)
+		c.returnError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusNoContent, nil)
+	ctx.SetStatus(http.StatusNoContent)
 }
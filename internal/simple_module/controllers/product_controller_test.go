@@ -0,0 +1,660 @@
+//go:build sqlite
+
+package controllers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+	"github.com/refortunato/go_app_base/internal/shared/web/context"
+	"github.com/refortunato/go_app_base/internal/simple_module/models"
+	"github.com/refortunato/go_app_base/internal/simple_module/repositories"
+	"github.com/refortunato/go_app_base/internal/simple_module/services"
+)
+
+// testMaxUploadSizeMB is the image size limit of the controllers built by newTestController
+const testMaxUploadSizeMB = 1
+
+// newTestController returns a controller over an in-memory database, storing uploads in a temp dir
+func newTestController(t *testing.T) (*ProductController, *services.ProductService) {
+	t.Helper()
+	db := testhelpers.NewSQLiteForTest(t)
+	service := services.NewProductService(
+		repositories.NewProductRepository(db),
+		repositories.NewPriceHistoryRepository(db),
+		repositories.NewProductVariantRepository(db),
+		nil, nil, nil, 0, 0, "", "v7",
+	)
+	controller := NewProductController(service, "", "", 100, t.TempDir(), testMaxUploadSizeMB, 60)
+	return controller, service
+}
+
+// newTestRouter mounts a single controller handler
+func newTestRouter(method, path string, handler func(context.WebContext)) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Handle(method, path, func(c *gin.Context) {
+		handler(context.NewGinContextAdapter(c))
+	})
+	return router
+}
+
+func createTestProduct(t *testing.T, service *services.ProductService) *models.Product {
+	t.Helper()
+	product, err := service.CreateProduct(t.Context(), "Keyboard", "Mechanical keyboard", 99.9, 10, "", nil)
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	return product
+}
+
+// multipartBody encodes content as the "file" part of a multipart form
+func multipartBody(t *testing.T, filename string, content []byte) (*bytes.Buffer, string) {
+	t.Helper()
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatalf("CreateFormFile: %v", err)
+	}
+	part.Write(content)
+	writer.Close()
+	return body, writer.FormDataContentType()
+}
+
+// countingReader records how many bytes the server read from the request body
+type countingReader struct {
+	r    io.Reader
+	read int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.read += n
+	return n, err
+}
+
+func TestUploadProductImage(t *testing.T) {
+	controller, service := newTestController(t)
+	product := createTestProduct(t, service)
+	router := newTestRouter(http.MethodPost, "/products/:id/image", controller.UploadProductImage)
+
+	maxSize := testMaxUploadSizeMB << 20
+	tests := []struct {
+		name      string
+		productID string
+		filename  string
+		size      int
+		noFile    bool
+		want      int
+		wantCode  string
+	}{
+		{name: "valid image", productID: product.ID, filename: "photo.PNG", size: 1024, want: http.StatusCreated},
+		{name: "image at the limit", productID: product.ID, filename: "photo.jpg", size: maxSize, want: http.StatusCreated},
+		{name: "unsupported type", productID: product.ID, filename: "photo.gif", size: 1024, want: http.StatusBadRequest, wantCode: "SIP1015"},
+		{name: "slightly over the limit", productID: product.ID, filename: "photo.jpg", size: maxSize + 1, want: http.StatusRequestEntityTooLarge, wantCode: "SIP1016"},
+		{name: "body over the limit", productID: product.ID, filename: "photo.jpg", size: 3 * maxSize, want: http.StatusRequestEntityTooLarge, wantCode: "SIP1016"},
+		{name: "missing file", productID: product.ID, noFile: true, want: http.StatusBadRequest, wantCode: "SIP1014"},
+		{name: "unknown product", productID: "00000000-0000-0000-0000-000000000000", filename: "photo.png", size: 1024, want: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body *bytes.Buffer
+			contentType := "multipart/form-data; boundary=empty"
+			if tt.noFile {
+				body = bytes.NewBufferString("--empty--\r\n")
+			} else {
+				body, contentType = multipartBody(t, tt.filename, bytes.Repeat([]byte("x"), tt.size))
+			}
+			req := httptest.NewRequest(http.MethodPost, "/products/"+tt.productID+"/image", body)
+			req.Header.Set("Content-Type", contentType)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+			if tt.wantCode != "" && !strings.Contains(w.Body.String(), tt.wantCode) {
+				t.Errorf("expected %s in %s", tt.wantCode, w.Body.String())
+			}
+		})
+	}
+
+	entries, err := os.ReadDir(controller.uploadDir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("stored files = %d, want only the 2 accepted images", len(entries))
+	}
+}
+
+func TestUploadProductImage_StopsReadingPastTheLimit(t *testing.T) {
+	controller, service := newTestController(t)
+	product := createTestProduct(t, service)
+	router := newTestRouter(http.MethodPost, "/products/:id/image", controller.UploadProductImage)
+
+	size := 20 << 20
+	body, contentType := multipartBody(t, "photo.jpg", bytes.Repeat([]byte("x"), size))
+	reader := &countingReader{r: body}
+	req := httptest.NewRequest(http.MethodPost, "/products/"+product.ID+"/image", reader)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want 413: %s", w.Code, w.Body.String())
+	}
+	if limit := controller.maxUploadSize + multipartOverhead; int64(reader.read) > 2*limit {
+		t.Errorf("read %d bytes of a %d bytes body, want about the %d bytes limit", reader.read, size, limit)
+	}
+}
+
+// postImport sends content as the import file and returns the response
+func postImport(t *testing.T, router http.Handler, content string) *httptest.ResponseRecorder {
+	t.Helper()
+	body, contentType := multipartBody(t, "products.csv", []byte(content))
+	req := httptest.NewRequest(http.MethodPost, "/products/import", body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestImportProducts(t *testing.T) {
+	controller, _ := newTestController(t)
+	router := newTestRouter(http.MethodPost, "/products/import", controller.ImportProducts)
+
+	csv := "name,description,price,stock,external_id\n" +
+		"Keyboard,Mechanical keyboard,99.90,10,ext-1\n" +
+		",Missing name,10,1,ext-2\n" +
+		"Mouse,Wireless mouse,not-a-price,5,ext-3\n" +
+		"Monitor,27 inch monitor,1299.00,3,ext-4\n"
+
+	w := postImport(t, router, csv)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want 202: %s", w.Code, w.Body.String())
+	}
+	var result services.BulkImportResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if result.Created != 2 || result.Updated != 0 || result.Skipped != 2 {
+		t.Errorf("result = %+v, want 2 created and 2 skipped", result)
+	}
+	// Errors point at CSV lines (the header is line 1), sorted
+	if len(result.Errors) != 2 || result.Errors[0].Row != 3 || result.Errors[1].Row != 4 {
+		t.Errorf("errors = %+v, want lines 3 and 4", result.Errors)
+	}
+
+	// Re-importing the same external IDs updates the products instead of duplicating them
+	w = postImport(t, router, "name,description,price,stock,external_id\nKeyboard,Mechanical keyboard,89.90,8,ext-1\n")
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want 202: %s", w.Code, w.Body.String())
+	}
+	result = services.BulkImportResult{}
+	json.Unmarshal(w.Body.Bytes(), &result)
+	if result.Created != 0 || result.Updated != 1 {
+		t.Errorf("re-import result = %+v, want 1 updated", result)
+	}
+}
+
+func TestImportProducts_SameFileTwice(t *testing.T) {
+	controller, service := newTestController(t)
+	router := newTestRouter(http.MethodPost, "/products/import", controller.ImportProducts)
+	csv := "name,description,price,stock,external_id\n" +
+		"Keyboard,Mechanical keyboard,99.90,10,ext-1\n" +
+		"Mouse,Wireless mouse,49.90,5,ext-2\n" +
+		"Monitor,27 inch monitor,1299.00,3,ext-3\n"
+
+	for i, want := range []services.BulkImportResult{{Created: 3}, {Updated: 3}} {
+		w := postImport(t, router, csv)
+		if w.Code != http.StatusAccepted {
+			t.Fatalf("import %d: status = %d, want 202: %s", i+1, w.Code, w.Body.String())
+		}
+		var result services.BulkImportResult
+		if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+			t.Fatalf("invalid response: %v", err)
+		}
+		if result.Created != want.Created || result.Updated != want.Updated || result.Skipped != 0 {
+			t.Errorf("import %d = %+v, want %d created and %d updated", i+1, result, want.Created, want.Updated)
+		}
+	}
+
+	list, err := service.ListProducts(t.Context(), 1, 100, "")
+	if err != nil {
+		t.Fatalf("ListProducts: %v", err)
+	}
+	if list.Pagination.TotalItems != 3 {
+		t.Errorf("products = %d, want 3 after importing the file twice", list.Pagination.TotalItems)
+	}
+}
+
+func TestImportProducts_RejectedFiles(t *testing.T) {
+	controller, _ := newTestController(t)
+	router := newTestRouter(http.MethodPost, "/products/import", controller.ImportProducts)
+
+	header := "name,description,price,stock\n"
+	row := "Keyboard,Mechanical keyboard,99.90,10\n"
+	oversized := header + strings.Repeat(row, maxImportFileSize/len(row)+1)
+
+	tests := []struct {
+		name     string
+		content  string
+		want     int
+		wantCode string
+	}{
+		{name: "missing columns", content: "name,price\nKeyboard,99.90\n", want: http.StatusBadRequest, wantCode: "SIP1008"},
+		{name: "empty file", content: "", want: http.StatusBadRequest, wantCode: "SIP1008"},
+		{name: "over the limit", content: oversized, want: http.StatusRequestEntityTooLarge, wantCode: "SIP1007"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := postImport(t, router, tt.content)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.wantCode) {
+				t.Errorf("expected %s in %s", tt.wantCode, w.Body.String())
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/products/import", strings.NewReader("--empty--\r\n"))
+		req.Header.Set("Content-Type", "multipart/form-data; boundary=empty")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "SIP1006") {
+			t.Errorf("status = %d, body = %s, want 400 SIP1006", w.Code, w.Body.String())
+		}
+	})
+}
+
+func TestImportProducts_StopsReadingPastTheLimit(t *testing.T) {
+	controller, _ := newTestController(t)
+	router := newTestRouter(http.MethodPost, "/products/import", controller.ImportProducts)
+
+	size := 5 * maxImportFileSize
+	body, contentType := multipartBody(t, "products.csv", bytes.Repeat([]byte("x"), size))
+	reader := &countingReader{r: body}
+	req := httptest.NewRequest(http.MethodPost, "/products/import", reader)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want 413: %s", w.Code, w.Body.String())
+	}
+	if limit := maxImportFileSize + multipartOverhead; reader.read > 2*limit {
+		t.Errorf("read %d bytes of a %d bytes body, want about the %d bytes limit", reader.read, size, limit)
+	}
+}
+
+func TestGetProduct_ConditionalGet(t *testing.T) {
+	controller, service := newTestController(t)
+	product := createTestProduct(t, service)
+	router := newTestRouter(http.MethodGet, "/products/:id", controller.GetProduct)
+	lastModified := product.UpdatedAt.UTC().Format(http.TimeFormat)
+	before := product.UpdatedAt.Add(-time.Hour).UTC().Format(http.TimeFormat)
+
+	tests := []struct {
+		name            string
+		ifModifiedSince string
+		wantStatus      int
+	}{
+		{name: "no header", wantStatus: http.StatusOK},
+		{name: "not modified since", ifModifiedSince: lastModified, wantStatus: http.StatusNotModified},
+		{name: "modified since", ifModifiedSince: before, wantStatus: http.StatusOK},
+		{name: "invalid date", ifModifiedSince: "yesterday", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/products/"+product.ID, nil)
+			if tt.ifModifiedSince != "" {
+				req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if got := rec.Header().Get("Cache-Control"); got != "private, max-age=60" {
+				t.Errorf("Cache-Control = %q, want private, max-age=60", got)
+			}
+			if got := rec.Header().Get("Last-Modified"); got != lastModified {
+				t.Errorf("Last-Modified = %q, want %q", got, lastModified)
+			}
+			if etag := rec.Header().Get("ETag"); !strings.HasPrefix(etag, `W/"`) {
+				t.Errorf("ETag = %q, want a weak ETag", etag)
+			}
+			if tt.wantStatus == http.StatusNotModified && rec.Body.Len() != 0 {
+				t.Errorf("304 body = %q, want no body", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestDeleteProduct_NoContent(t *testing.T) {
+	controller, service := newTestController(t)
+	product := createTestProduct(t, service)
+	router := newTestRouter(http.MethodDelete, "/products/:id", controller.DeleteProduct)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/products/"+product.ID, nil))
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want 204: %s", rec.Code, rec.Body.String())
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("body = %q, want zero bytes", rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "" {
+		t.Errorf("Content-Type = %q, want none", ct)
+	}
+
+	// The product is gone, so a second delete is a 404 with a problem body
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/products/"+product.ID, nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("second delete status = %d, want 404", rec.Code)
+	}
+}
+
+func TestGetProduct_NotModifiedHasNoContentType(t *testing.T) {
+	controller, service := newTestController(t)
+	product := createTestProduct(t, service)
+	router := newTestRouter(http.MethodGet, "/products/:id", controller.GetProduct)
+
+	req := httptest.NewRequest(http.MethodGet, "/products/"+product.ID, nil)
+	req.Header.Set("If-Modified-Since", product.UpdatedAt.UTC().Format(http.TimeFormat))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotModified {
+		t.Fatalf("status = %d, want 304", rec.Code)
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("body = %q, want no body", rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "" {
+		t.Errorf("Content-Type = %q, want none", ct)
+	}
+}
+
+func TestPatchProduct_ContentType(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		wantStatus  int
+	}{
+		{name: "merge patch", contentType: "application/merge-patch+json", wantStatus: http.StatusOK},
+		{name: "merge patch with charset", contentType: "application/merge-patch+json; charset=utf-8", wantStatus: http.StatusOK},
+		{name: "plain json", contentType: "application/json", wantStatus: http.StatusUnsupportedMediaType},
+		{name: "missing", contentType: "", wantStatus: http.StatusUnsupportedMediaType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller, service := newTestController(t)
+			product := createTestProduct(t, service)
+			router := newTestRouter(http.MethodPatch, "/products/:id", controller.PatchProduct)
+
+			req := httptest.NewRequest(http.MethodPatch, "/products/"+product.ID, strings.NewReader(`{"price": 49.9}`))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var patched models.Product
+			if err := json.Unmarshal(rec.Body.Bytes(), &patched); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if patched.Price != 49.9 || patched.Name != product.Name || patched.Stock != product.Stock {
+				t.Errorf("patched = %+v, want only the price changed", patched)
+			}
+		})
+	}
+}
+
+func TestGetProduct_ContentNegotiation(t *testing.T) {
+	controller, service := newTestController(t)
+	product := createTestProduct(t, service)
+	router := newTestRouter(http.MethodGet, "/products/:id", controller.GetProduct)
+
+	get := func(path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept", accept)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("xml", func(t *testing.T) {
+		rec := get("/products/"+product.ID, "application/xml")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+		}
+		if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/xml") {
+			t.Errorf("Content-Type = %q, want application/xml", ct)
+		}
+		var decoded struct {
+			XMLName xml.Name `xml:"product"`
+			ID      string   `xml:"id"`
+			Name    string   `xml:"name"`
+		}
+		if err := xml.Unmarshal(rec.Body.Bytes(), &decoded); err != nil {
+			t.Fatalf("body is not valid XML: %v: %s", err, rec.Body.String())
+		}
+		if decoded.ID != product.ID || decoded.Name != product.Name {
+			t.Errorf("decoded = %+v, want the product", decoded)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		rec := get("/products/"+product.ID, "application/json")
+		var decoded models.Product
+		if err := json.Unmarshal(rec.Body.Bytes(), &decoded); err != nil || decoded.ID != product.ID {
+			t.Errorf("JSON body = %s (%v), want the product", rec.Body.String(), err)
+		}
+	})
+
+	t.Run("not acceptable", func(t *testing.T) {
+		rec := get("/products/"+product.ID, "text/csv")
+		if rec.Code != http.StatusNotAcceptable || !strings.Contains(rec.Body.String(), "HTTP0406") {
+			t.Errorf("status = %d, body = %s, want 406 HTTP0406", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("xml error", func(t *testing.T) {
+		rec := get("/products/0190a5e8-0000-7000-8000-000000000000", "application/xml")
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("status = %d, want 404", rec.Code)
+		}
+		var problem struct {
+			Code string `xml:"code"`
+		}
+		if err := xml.Unmarshal(rec.Body.Bytes(), &problem); err != nil || problem.Code != "SIP1002" {
+			t.Errorf("body = %s (%v), want the SIP1002 problem as XML", rec.Body.String(), err)
+		}
+	})
+}
+
+func TestGetProduct_Links(t *testing.T) {
+	_, service := newTestController(t)
+	controller := NewProductController(service, "https://api.example.com/v1", "", 100, t.TempDir(), testMaxUploadSizeMB, 60)
+	product := createTestProduct(t, service)
+	router := newTestRouter(http.MethodGet, "/products/:id", controller.GetProduct)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/products/"+product.ID, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+
+	var response struct {
+		ID    string            `json:"id"`
+		Links map[string]string `json:"_links"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	item := "https://api.example.com/v1/products/" + product.ID
+	want := map[string]string{
+		"self":   item,
+		"update": item,
+		"delete": item,
+		"list":   "https://api.example.com/v1/products",
+	}
+	if response.ID != product.ID {
+		t.Errorf("id = %q, want %q", response.ID, product.ID)
+	}
+	for rel, href := range want {
+		if response.Links[rel] != href {
+			t.Errorf("_links.%s = %q, want %q", rel, response.Links[rel], href)
+		}
+	}
+}
+
+func TestProductResponses_RewriteImagesToCDN(t *testing.T) {
+	db := testhelpers.NewSQLiteForTest(t)
+	service := services.NewProductService(repositories.NewProductRepository(db), nil, nil, nil, nil, nil, 0, 0, "https://cdn.example.com/", "v7")
+	controller := NewProductController(service, "", "https://cdn.example.com/", 100, t.TempDir(), testMaxUploadSizeMB, 60)
+	relative, err := service.CreateProduct(t.Context(), "Keyboard", "Mechanical keyboard", 99.9, 10, "/products/keyboard.jpg", nil)
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if _, err := service.CreateProduct(t.Context(), "Mouse", "Wireless mouse", 49.9, 5, "https://images.example.com/mouse.jpg", nil); err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	newTestRouter(http.MethodGet, "/products/:id", controller.GetProduct).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/products/"+relative.ID, nil))
+	var product struct {
+		ImageURL string `json:"image_url"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &product); err != nil {
+		t.Fatalf("decode response: %v: %s", err, rec.Body.String())
+	}
+	if product.ImageURL != "https://cdn.example.com/products/keyboard.jpg" {
+		t.Errorf("GET image_url = %q, want the CDN URL", product.ImageURL)
+	}
+
+	rec = httptest.NewRecorder()
+	newTestRouter(http.MethodGet, "/products", controller.ListProducts).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/products", nil))
+	var list struct {
+		Items []struct {
+			Name     string `json:"name"`
+			ImageURL string `json:"image_url"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode response: %v: %s", err, rec.Body.String())
+	}
+	want := map[string]string{
+		"Keyboard": "https://cdn.example.com/products/keyboard.jpg",
+		// Absolute URLs are served as stored
+		"Mouse": "https://images.example.com/mouse.jpg",
+	}
+	if len(list.Items) != 2 {
+		t.Fatalf("items = %+v, want 2 products", list.Items)
+	}
+	for _, item := range list.Items {
+		if item.ImageURL != want[item.Name] {
+			t.Errorf("list %s image_url = %q, want %q", item.Name, item.ImageURL, want[item.Name])
+		}
+	}
+}
+
+func TestExportProducts_StreamsEveryProduct(t *testing.T) {
+	// One count below, at and above multiples of the export batch size
+	for _, count := range []int{0, 100, 250} {
+		t.Run(fmt.Sprintf("%d products", count), func(t *testing.T) {
+			controller, service := newTestController(t)
+			want := map[string]bool{}
+			for i := 0; i < count; i++ {
+				product, err := service.CreateProduct(t.Context(), fmt.Sprintf("Product %d", i), "", 10, i, "", nil)
+				if err != nil {
+					t.Fatalf("CreateProduct: %v", err)
+				}
+				want[product.ID] = true
+			}
+
+			rec := httptest.NewRecorder()
+			newTestRouter(http.MethodGet, "/products/export", controller.ExportProducts).
+				ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/products/export", nil))
+
+			if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/x-ndjson" {
+				t.Fatalf("status = %d, Content-Type = %q, want 200 application/x-ndjson", rec.Code, rec.Header().Get("Content-Type"))
+			}
+			got := map[string]bool{}
+			scanner := bufio.NewScanner(rec.Body)
+			for scanner.Scan() {
+				var product models.Product
+				if err := json.Unmarshal(scanner.Bytes(), &product); err != nil {
+					t.Fatalf("line %q is not a product: %v", scanner.Text(), err)
+				}
+				if got[product.ID] {
+					t.Errorf("product %s exported twice", product.ID)
+				}
+				got[product.ID] = true
+			}
+			if len(got) != len(want) {
+				t.Errorf("exported %d products, want %d", len(got), len(want))
+			}
+			for id := range want {
+				if !got[id] {
+					t.Errorf("product %s missing from the export", id)
+				}
+			}
+		})
+	}
+}
+
+func TestGetLatestProduct_RedirectsToNewestProduct(t *testing.T) {
+	_, service := newTestController(t)
+	controller := NewProductController(service, "https://api.example.com/v1", "", 100, t.TempDir(), testMaxUploadSizeMB, 60)
+	router := newTestRouter(http.MethodGet, "/products/latest", controller.GetLatestProduct)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/products/latest", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("empty catalog: status = %d, want 404", rec.Code)
+	}
+
+	createTestProduct(t, service)
+	latest := createTestProduct(t, service)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/products/latest", nil))
+	if rec.Code != http.StatusFound {
+		t.Fatalf("status = %d, want 302: %s", rec.Code, rec.Body.String())
+	}
+	if location := rec.Header().Get("Location"); location != "https://api.example.com/v1/products/"+latest.ID {
+		t.Errorf("Location = %q, want the URL of product %s", location, latest.ID)
+	}
+}
@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/refortunato/go_app_base/internal/simple_module/errors"
+	"github.com/refortunato/go_app_base/internal/simple_module/services"
+)
+
+// productCSVColumns lists the header columns required in a product import file
+// An optional external_id column makes re-imports update products instead of duplicating them
+var productCSVColumns = []string{"name", "description", "price", "stock"}
+
+// productCSV holds the rows parsed from an import file
+// lines maps each request to its line number in the file (the header is line 1)
+type productCSV struct {
+	requests []*services.CreateProductRequest
+	lines    []int
+	errors   []services.BulkImportError
+}
+
+// parseProductCSV reads a product import file, returning the parsed rows and per-row parse errors
+// It fails only when the file itself is unreadable or the header is missing required columns
+func parseProductCSV(r io.Reader) (*productCSV, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.ErrImportInvalidCSV
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range productCSVColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.ErrImportInvalidCSV
+		}
+	}
+
+	parsed := &productCSV{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.ErrImportInvalidCSV
+		}
+
+		line, _ := reader.FieldPos(0)
+		value := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		price, err := strconv.ParseFloat(value("price"), 64)
+		if err != nil {
+			parsed.errors = append(parsed.errors, services.BulkImportError{
+				Row:     line,
+				Message: "Product price must be a valid number",
+			})
+			continue
+		}
+
+		stock := 0
+		if raw := value("stock"); raw != "" {
+			stock, err = strconv.Atoi(raw)
+			if err != nil {
+				parsed.errors = append(parsed.errors, services.BulkImportError{
+					Row:     line,
+					Message: "Product stock must be a valid integer",
+				})
+				continue
+			}
+		}
+
+		parsed.requests = append(parsed.requests, &services.CreateProductRequest{
+			Name:        value("name"),
+			Description: value("description"),
+			Price:       price,
+			Stock:       stock,
+			ExternalID:  value("external_id"),
+		})
+		parsed.lines = append(parsed.lines, line)
+	}
+
+	return parsed, nil
+}
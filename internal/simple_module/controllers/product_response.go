@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"context"
+	"encoding/xml"
+
+	"github.com/refortunato/go_app_base/internal/shared/dto"
+	"github.com/refortunato/go_app_base/internal/shared/hateoas"
+	"github.com/refortunato/go_app_base/internal/simple_module/models"
+	"github.com/refortunato/go_app_base/internal/simple_module/services"
+)
+
+// ProductResponse wraps a product with the links to its related resources
+type ProductResponse struct {
+	XMLName xml.Name `json:"-" xml:"product" swaggerignore:"true"`
+	*models.Product
+	Links hateoas.Links `json:"_links" xml:"links"`
+}
+
+// ProductListResponse is a page of products with their links
+type ProductListResponse struct {
+	Items      []*ProductResponse         `json:"items"`
+	Pagination *dto.PaginationResponseDTO `json:"pagination"`
+}
+
+// ProductTransformer maps products to ProductResponse, adding HATEOAS links built
+// from baseURL and rewriting relative image paths under cdnBaseURL
+type ProductTransformer struct {
+	baseURL    string
+	cdnBaseURL string
+}
+
+// NewProductTransformer creates a product transformer
+func NewProductTransformer(baseURL, cdnBaseURL string) *ProductTransformer {
+	return &ProductTransformer{baseURL: baseURL, cdnBaseURL: cdnBaseURL}
+}
+
+// Transform builds the response for a product without modifying it
+func (t *ProductTransformer) Transform(_ context.Context, product *models.Product) (*ProductResponse, error) {
+	shaped := *product
+	shaped.ImageURL = services.ResolveImageURL(t.cdnBaseURL, product.ImageURL)
+
+	return &ProductResponse{
+		Product: &shaped,
+		Links:   hateoas.ProductLinks(t.baseURL, product.ID),
+	}, nil
+}
+
+// TransformMany builds the responses for several products
+func (t *ProductTransformer) TransformMany(ctx context.Context, products []*models.Product) ([]*ProductResponse, error) {
+	return dto.TransformEach(ctx, t, products)
+}
+
+var _ dto.Transformer[*models.Product, *ProductResponse] = (*ProductTransformer)(nil)
@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/refortunato/go_app_base/internal/shared/hateoas"
+	"github.com/refortunato/go_app_base/internal/simple_module/models"
+	"github.com/refortunato/go_app_base/internal/simple_module/services"
+)
+
+func TestProductTransformer_Transform(t *testing.T) {
+	transformer := NewProductTransformer("https://api.example.com/v1", "https://cdn.example.com")
+	product := &models.Product{ID: "42", Name: "Keyboard", ImageURL: "products/42.jpg"}
+
+	response, err := transformer.Transform(context.Background(), product)
+	if err != nil {
+		t.Fatalf("Transform: %v", err)
+	}
+	if response.ID != "42" || response.Name != "Keyboard" {
+		t.Errorf("response = %+v, want the product fields", response.Product)
+	}
+	if response.ImageURL != "https://cdn.example.com/products/42.jpg" {
+		t.Errorf("ImageURL = %q, want the CDN URL", response.ImageURL)
+	}
+	if response.Links["self"] != "https://api.example.com/v1/products/42" || response.Links["list"] != "https://api.example.com/v1/products" {
+		t.Errorf("links = %v, want links under the base URL", response.Links)
+	}
+	// The input is left as stored, so it can be transformed (or cached) again
+	if product.ImageURL != "products/42.jpg" {
+		t.Errorf("input ImageURL = %q, want it unchanged", product.ImageURL)
+	}
+}
+
+func TestProductTransformer_TransformMany(t *testing.T) {
+	transformer := NewProductTransformer("", "")
+	products := benchmarkProducts(3)
+
+	responses, err := transformer.TransformMany(context.Background(), products)
+	if err != nil {
+		t.Fatalf("TransformMany: %v", err)
+	}
+	if len(responses) != len(products) {
+		t.Fatalf("responses = %d, want %d", len(responses), len(products))
+	}
+	for i, response := range responses {
+		if response.ID != products[i].ID || response.Links["self"] != "/products/"+products[i].ID {
+			t.Errorf("response %d = %+v %v, want product %s in order", i, response.Product, response.Links, products[i].ID)
+		}
+	}
+}
+
+// benchmarkProducts returns n products with relative image paths
+func benchmarkProducts(n int) []*models.Product {
+	products := make([]*models.Product, n)
+	for i := range products {
+		id := fmt.Sprintf("0190a5e8-0000-7000-8000-%012d", i)
+		products[i] = &models.Product{ID: id, Name: "Product", Price: 10, Stock: i, ImageURL: "products/" + id + ".jpg"}
+	}
+	return products
+}
+
+// directProductResponses maps products the way controllers did before the transformer
+func directProductResponses(baseURL, cdnBaseURL string, products []*models.Product) []*ProductResponse {
+	responses := make([]*ProductResponse, 0, len(products))
+	for _, product := range products {
+		shaped := *product
+		shaped.ImageURL = services.ResolveImageURL(cdnBaseURL, product.ImageURL)
+		responses = append(responses, &ProductResponse{Product: &shaped, Links: hateoas.ProductLinks(baseURL, product.ID)})
+	}
+	return responses
+}
+
+func BenchmarkProductResponses_Direct(b *testing.B) {
+	products := benchmarkProducts(100)
+	b.ReportAllocs()
+	for b.Loop() {
+		directProductResponses("https://api.example.com/v1", "https://cdn.example.com", products)
+	}
+}
+
+func BenchmarkProductResponses_Transformer(b *testing.B) {
+	products := benchmarkProducts(100)
+	transformer := NewProductTransformer("https://api.example.com/v1", "https://cdn.example.com")
+	ctx := context.Background()
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := transformer.TransformMany(ctx, products); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
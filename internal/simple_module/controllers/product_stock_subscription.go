@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"github.com/refortunato/go_app_base/internal/shared/web/context"
+)
+
+// stockSubscriptionPingInterval keeps idle subscriptions alive through proxies and detects dead clients
+const stockSubscriptionPingInterval = 30 * time.Second
+
+// SubscribeStock godoc
+// @Summary      Subscribe to product stock changes (WebSocket)
+// @Description  Upgrades the connection to WebSocket and sends a JSON text message (services.ProductStockChangedPayload) each time the product stock changes through this instance. Messages sent by the client are ignored
+// @Tags         products
+// @Param        id   path  string  true  "Product ID"
+// @Success      101  {object}  services.ProductStockChangedPayload  "Switching Protocols, then one message per stock change"
+// @Failure      400  {string}  string                 "Not a WebSocket handshake, or invalid product ID (VAL0002)"
+// @Failure      401  {object}  errors.ProblemDetails  "Authentication required"
+// @Failure      403  {object}  errors.ProblemDetails  "Missing required scope"
+// @Failure      404  {object}  errors.ProblemDetails  "Product not found"
+// @Security     ApiKeyAuth
+// @Router       /products/{id}/subscribe [get]
+func (c *ProductController) SubscribeStock(ctx context.WebContext) {
+	id := ctx.Param("id")
+	reqCtx := ctx.GetContext()
+	log := logger.FromContext(reqCtx)
+
+	if _, err := c.service.GetProduct(reqCtx, id); err != nil {
+		c.returnError(ctx, err)
+		return
+	}
+
+	// Subscribe before upgrading so no change between the handshake and the loop is missed
+	changes, unsubscribe := c.service.SubscribeStockChanges(id)
+	defer unsubscribe()
+
+	conn, err := ctx.Upgrade(c.upgrader)
+	if err != nil {
+		// The upgrader already answered with an HTTP error
+		log.WithError(err).Warn(reqCtx, "WebSocket upgrade failed", logger.CustomFields{"productId": id})
+		return
+	}
+	defer conn.Close()
+
+	// Reading is the only way to notice the client closing the connection
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(stockSubscriptionPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-reqCtx.Done():
+			// Server shutting down
+			return
+		case <-ping.C:
+			if err := conn.WriteMessage(context.PingMessage, nil); err != nil {
+				return
+			}
+		case event, ok := <-changes:
+			if !ok {
+				return
+			}
+			message, err := json.Marshal(event.Payload)
+			if err != nil {
+				log.WithError(err).Error(reqCtx, "Failed to encode stock change", logger.CustomFields{"productId": id})
+				continue
+			}
+			if err := conn.WriteMessage(context.TextMessage, message); err != nil {
+				return
+			}
+		}
+	}
+}
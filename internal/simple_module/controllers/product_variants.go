@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/refortunato/go_app_base/internal/shared/web/advisor"
+	"github.com/refortunato/go_app_base/internal/shared/web/context"
+	"github.com/refortunato/go_app_base/internal/simple_module/services"
+)
+
+// AddProductVariant godoc
+// @Summary      Add product variant
+// @Description  Adds a variant (e.g. color and size) with its own price and stock. The product stock becomes the sum of its variant stock
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                      true  "Product ID"
+// @Param        request  body      services.AddVariantRequest  true  "Variant data"
+// @Success      201      {object}  models.ProductVariant
+// @Failure      400      {object}  errors.ProblemDetails  "Invalid attributes, price, stock or product ID"
+// @Failure      401      {object}  errors.ProblemDetails  "Authentication required"
+// @Failure      403      {object}  errors.ProblemDetails  "Missing required scope"
+// @Failure      404      {object}  errors.ProblemDetails  "Product not found"
+// @Failure      500      {object}  errors.ProblemDetails  "Internal server error"
+// @Security     ApiKeyAuth
+// @Router       /products/{id}/variants [post]
+func (c *ProductController) AddProductVariant(ctx context.WebContext) {
+	id := ctx.Param("id")
+
+	var request services.AddVariantRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		advisor.ReturnBadRequestError(ctx, err)
+		return
+	}
+
+	variant, err := c.service.AddVariant(ctx.GetContext(), id, request.Attributes, request.Price, request.Stock)
+	if err != nil {
+		c.returnError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, variant)
+}
+
+// ListProductVariants godoc
+// @Summary      List product variants
+// @Description  Returns the variants of a product, oldest first
+// @Tags         products
+// @Produce      json
+// @Param        id   path      string  true  "Product ID"
+// @Success      200  {array}   models.ProductVariant
+// @Failure      400  {object}  errors.ProblemDetails  "Invalid product ID (not a UUID)"
+// @Failure      401  {object}  errors.ProblemDetails  "Authentication required"
+// @Failure      403  {object}  errors.ProblemDetails  "Missing required scope"
+// @Failure      404  {object}  errors.ProblemDetails  "Product not found"
+// @Failure      500  {object}  errors.ProblemDetails  "Internal server error"
+// @Security     ApiKeyAuth
+// @Router       /products/{id}/variants [get]
+func (c *ProductController) ListProductVariants(ctx context.WebContext) {
+	id := ctx.Param("id")
+
+	variants, err := c.service.ListVariants(ctx.GetContext(), id)
+	if err != nil {
+		c.returnError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, variants)
+}
+
+// DeleteProductVariant godoc
+// @Summary      Delete product variant
+// @Description  Removes a variant. The product stock becomes the sum of the remaining variants (0 when none is left)
+// @Tags         products
+// @Param        id         path  string  true  "Product ID"
+// @Param        variantId  path  string  true  "Variant ID"
+// @Success      204  "No content"
+// @Failure      400  {object}  errors.ProblemDetails  "Invalid product ID (not a UUID)"
+// @Failure      401  {object}  errors.ProblemDetails  "Authentication required"
+// @Failure      403  {object}  errors.ProblemDetails  "Missing required scope"
+// @Failure      404  {object}  errors.ProblemDetails  "Product or variant not found"
+// @Failure      500  {object}  errors.ProblemDetails  "Internal server error"
+// @Security     ApiKeyAuth
+// @Router       /products/{id}/variants/{variantId} [delete]
+func (c *ProductController) DeleteProductVariant(ctx context.WebContext) {
+	id := ctx.Param("id")
+	variantID := ctx.Param("variantId")
+
+	if err := c.service.DeleteVariant(ctx.GetContext(), id, variantID); err != nil {
+		c.returnError(ctx, err)
+		return
+	}
+
+	ctx.SetStatus(http.StatusNoContent)
+}
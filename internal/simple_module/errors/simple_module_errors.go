@@ -4,50 +4,202 @@ import (
 	sharedErrors "github.com/refortunato/go_app_base/internal/shared/errors"
 )
 
+// moduleName identifies this module in the errors it produces
+const moduleName = "simple_module"
+
 var (
 	// Product errors
-	ErrProductIdRequired = sharedErrors.NewProblemDetails(
+	ErrProductIdRequired = sharedErrors.NewProblemDetailsWithContext(
 		400,
 		"Invalid product ID",
 		"Product ID is required",
 		"SIP1001",
 		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"validate_product_id",
 	)
-	ErrProductNotFound = sharedErrors.NewProblemDetails(
+	ErrProductNotFound = sharedErrors.NewProblemDetailsWithContext(
 		404,
 		"Product not found",
 		"The requested product was not found",
 		"SIP1002",
 		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"find_product",
 	)
-	ErrProductNameRequired = sharedErrors.NewProblemDetails(
+	ErrProductNameRequired = sharedErrors.NewProblemDetailsWithContext(
 		400,
 		"Invalid product name",
 		"Product name is required",
 		"SIP1003",
 		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"validate_product",
 	)
-	ErrProductPriceInvalid = sharedErrors.NewProblemDetails(
+	ErrProductPriceInvalid = sharedErrors.NewProblemDetailsWithContext(
 		400,
 		"Invalid product price",
 		"Product price cannot be negative",
 		"SIP1004",
 		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"validate_product",
 	)
-	ErrProductStockInvalid = sharedErrors.NewProblemDetails(
+	ErrProductStockInvalid = sharedErrors.NewProblemDetailsWithContext(
 		400,
 		"Invalid product stock",
 		"Product stock cannot be negative",
 		"SIP1005",
 		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"validate_product",
+	)
+	ErrImportFileRequired = sharedErrors.NewProblemDetailsWithContext(
+		400,
+		"Invalid import file",
+		"A CSV file must be sent in the 'file' form field",
+		"SIP1006",
+		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"import_products",
+	)
+	ErrImportFileTooLarge = sharedErrors.NewProblemDetailsWithContext(
+		413,
+		"Import file too large",
+		"The import file must not exceed 10 MB",
+		"SIP1007",
+		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"import_products",
+	)
+	ErrImportInvalidCSV = sharedErrors.NewProblemDetailsWithContext(
+		400,
+		"Invalid CSV file",
+		"The CSV file must have a header row with the columns: name, description, price, stock",
+		"SIP1008",
+		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"import_products",
+	)
+	ErrImportDuplicateExternalID = sharedErrors.NewProblemDetailsWithContext(
+		400,
+		"Duplicate external ID",
+		"The external_id is repeated in the import file, only its first row is imported",
+		"SIP1019",
+		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"import_products",
+	)
+	ErrProductIdsRequired = sharedErrors.NewProblemDetailsWithContext(
+		400,
+		"Invalid product IDs",
+		"At least one product ID is required",
+		"SIP1009",
+		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"get_products_by_ids",
+	)
+	ErrProductIdsTooMany = sharedErrors.NewProblemDetailsWithContext(
+		400,
+		"Too many product IDs",
+		"The number of product IDs exceeds the batch lookup limit",
+		"SIP1010",
+		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"get_products_by_ids",
+	)
+	ErrProductImageURLInvalid = sharedErrors.NewProblemDetailsWithContext(
+		400,
+		"Invalid product image URL",
+		"The image URL must be an absolute http(s) URL, or a path when a CDN base URL is configured",
+		"SIP1011",
+		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"validate_image_url",
+	)
+	ErrProductTagInvalid = sharedErrors.NewProblemDetailsWithContext(
+		400,
+		"Invalid product tag",
+		"Tags must be between 1 and 50 characters long",
+		"SIP1012",
+		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"validate_tags",
+	)
+	ErrProductStockThresholdInvalid = sharedErrors.NewProblemDetailsWithContext(
+		400,
+		"Invalid stock threshold",
+		"The stock threshold cannot be negative",
+		"SIP1013",
+		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"set_stock_threshold",
+	)
+	ErrProductImageRequired = sharedErrors.NewProblemDetailsWithContext(
+		400,
+		"Invalid image upload",
+		"An image file must be sent in the 'file' form field",
+		"SIP1014",
+		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"upload_product_image",
+	)
+	ErrProductImageTypeInvalid = sharedErrors.NewProblemDetailsWithContext(
+		400,
+		"Unsupported image type",
+		"Only .jpg, .jpeg, .png and .webp images are accepted",
+		"SIP1015",
+		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"upload_product_image",
+	)
+	ErrProductImageTooLarge = sharedErrors.NewProblemDetailsWithContext(
+		413,
+		"Image too large",
+		"The image exceeds the maximum upload size",
+		"SIP1016",
+		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"upload_product_image",
+	)
+
+	// Product variant errors
+	ErrVariantAttributesInvalid = sharedErrors.NewProblemDetailsWithContext(
+		400,
+		"Invalid variant attributes",
+		"A variant needs at least one attribute, each with a non-empty name and value",
+		"SIP1017",
+		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"validate_variant",
+	)
+	ErrVariantNotFound = sharedErrors.NewProblemDetailsWithContext(
+		404,
+		"Variant not found",
+		"The requested product variant was not found",
+		"SIP1018",
+		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"find_variant",
+	)
+	ErrProductStockManagedByVariants = sharedErrors.NewProblemDetailsWithContext(
+		422,
+		"Stock managed by variants",
+		"The product has variants, so its stock is the sum of the variant stock and cannot be changed directly",
+		"SIP1020",
+		sharedErrors.ErrorContextBusiness,
+		moduleName,
+		"update_product_stock",
 	)
 
 	// Generic errors
-	ErrGeneric = sharedErrors.NewProblemDetails(
+	ErrGeneric = sharedErrors.NewProblemDetailsWithContext(
 		500,
 		"Internal server error",
 		"An unexpected error occurred",
 		"SIP9999",
 		sharedErrors.ErrorContextInfra,
+		moduleName,
+		"",
 	)
 )
@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/refortunato/go_app_base/internal/shared/dto"
+	"github.com/refortunato/go_app_base/internal/shared/web/advisor"
+	"github.com/refortunato/go_app_base/internal/simple_module/grpc/pb"
+	"github.com/refortunato/go_app_base/internal/simple_module/models"
+	"github.com/refortunato/go_app_base/internal/simple_module/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GRPCProductService exposes ProductService over gRPC
+type GRPCProductService struct {
+	pb.UnimplementedProductServiceServer
+	service  *services.ProductService
+	maxLimit int
+}
+
+// NewGRPCProductService creates a new gRPC product service instance
+// maxLimit caps the page size of ListProducts, like PaginationMaxLimit does for the HTTP API
+// (<= 0 uses dto.DefaultPaginationMaxLimit)
+func NewGRPCProductService(service *services.ProductService, maxLimit int) *GRPCProductService {
+	if maxLimit <= 0 {
+		maxLimit = dto.DefaultPaginationMaxLimit
+	}
+	return &GRPCProductService{service: service, maxLimit: maxLimit}
+}
+
+func (s *GRPCProductService) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.GetProductResponse, error) {
+	product, err := s.service.GetProduct(ctx, req.GetId())
+	if err != nil {
+		return nil, advisor.ReturnGRPCApplicationError(err)
+	}
+	return &pb.GetProductResponse{Product: mapToProto(product)}, nil
+}
+
+func (s *GRPCProductService) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid limit parameter")
+	}
+	if int(req.GetLimit()) > s.maxLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit exceeds maximum of %d", s.maxLimit)
+	}
+	result, err := s.service.ListProducts(ctx, int(req.GetPage()), int(req.GetLimit()), "")
+	if err != nil {
+		return nil, advisor.ReturnGRPCApplicationError(err)
+	}
+
+	items := make([]*pb.Product, 0, len(result.Items))
+	for _, product := range result.Items {
+		items = append(items, mapToProto(product))
+	}
+
+	return &pb.ListProductsResponse{
+		Items: items,
+		Pagination: &pb.Pagination{
+			Page:       int32(result.Pagination.Page),
+			Limit:      int32(result.Pagination.Limit),
+			TotalItems: int32(result.Pagination.TotalItems),
+			TotalPages: int32(result.Pagination.TotalPages),
+		},
+	}, nil
+}
+
+func (s *GRPCProductService) CreateProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.CreateProductResponse, error) {
+	product, err := s.service.CreateProduct(ctx, req.GetName(), req.GetDescription(), req.GetPrice(), int(req.GetStock()), "", nil)
+	if err != nil {
+		return nil, advisor.ReturnGRPCApplicationError(err)
+	}
+	return &pb.CreateProductResponse{Product: mapToProto(product)}, nil
+}
+
+func (s *GRPCProductService) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.UpdateProductResponse, error) {
+	// The proto has no image field, so patch the remaining fields to keep the stored image
+	name, description, price, stock := req.GetName(), req.GetDescription(), req.GetPrice(), int(req.GetStock())
+	product, err := s.service.PatchProduct(ctx, req.GetId(), &services.PatchProductRequest{
+		Name:        &name,
+		Description: &description,
+		Price:       &price,
+		Stock:       &stock,
+	})
+	if err != nil {
+		return nil, advisor.ReturnGRPCApplicationError(err)
+	}
+	return &pb.UpdateProductResponse{Product: mapToProto(product)}, nil
+}
+
+func (s *GRPCProductService) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*pb.DeleteProductResponse, error) {
+	if err := s.service.DeleteProduct(ctx, req.GetId()); err != nil {
+		return nil, advisor.ReturnGRPCApplicationError(err)
+	}
+	return &pb.DeleteProductResponse{}, nil
+}
+
+func mapToProto(product *models.Product) *pb.Product {
+	return &pb.Product{
+		Id:          product.ID,
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       product.Price,
+		Stock:       int32(product.Stock),
+		CreatedAt:   timestamppb.New(product.CreatedAt),
+		UpdatedAt:   timestamppb.New(product.UpdatedAt),
+	}
+}
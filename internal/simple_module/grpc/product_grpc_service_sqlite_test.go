@@ -0,0 +1,146 @@
+//go:build sqlite
+
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+	"github.com/refortunato/go_app_base/internal/simple_module/grpc/pb"
+	"github.com/refortunato/go_app_base/internal/simple_module/repositories"
+	"github.com/refortunato/go_app_base/internal/simple_module/services"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient serves a GRPCProductService over an in-memory database on an in-process
+// listener and returns a client connected to it
+func newTestClient(t *testing.T) pb.ProductServiceClient {
+	t.Helper()
+	db := testhelpers.NewSQLiteForTest(t)
+	service := services.NewProductService(
+		repositories.NewProductRepository(db),
+		repositories.NewPriceHistoryRepository(db),
+		repositories.NewProductVariantRepository(db),
+		nil, nil, nil, 0, 0, "", "v7",
+	)
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	pb.RegisterProductServiceServer(server, NewGRPCProductService(service, 100))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewProductServiceClient(conn)
+}
+
+func TestGRPCProductService_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	created, err := client.CreateProduct(ctx, &pb.CreateProductRequest{Name: "Keyboard", Description: "Mechanical", Price: 99.9, Stock: 10})
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	id := created.GetProduct().GetId()
+	if id == "" || created.GetProduct().GetName() != "Keyboard" || created.GetProduct().GetCreatedAt() == nil {
+		t.Fatalf("created = %+v, want the stored product", created.GetProduct())
+	}
+
+	got, err := client.GetProduct(ctx, &pb.GetProductRequest{Id: id})
+	if err != nil {
+		t.Fatalf("GetProduct: %v", err)
+	}
+	if p := got.GetProduct(); p.GetName() != "Keyboard" || p.GetDescription() != "Mechanical" || p.GetPrice() != 99.9 || p.GetStock() != 10 {
+		t.Errorf("GetProduct = %+v, want the created product", p)
+	}
+
+	updated, err := client.UpdateProduct(ctx, &pb.UpdateProductRequest{Id: id, Name: "Keyboard TKL", Description: "Compact", Price: 89.9, Stock: 7})
+	if err != nil {
+		t.Fatalf("UpdateProduct: %v", err)
+	}
+	if p := updated.GetProduct(); p.GetName() != "Keyboard TKL" || p.GetPrice() != 89.9 || p.GetStock() != 7 {
+		t.Errorf("UpdateProduct = %+v, want the new values", p)
+	}
+
+	list, err := client.ListProducts(ctx, &pb.ListProductsRequest{Page: 1, Limit: 10})
+	if err != nil {
+		t.Fatalf("ListProducts: %v", err)
+	}
+	if len(list.GetItems()) != 1 || list.GetItems()[0].GetId() != id || list.GetPagination().GetPage() != 1 {
+		t.Errorf("ListProducts = %+v, want the single product on page 1", list)
+	}
+
+	if _, err := client.DeleteProduct(ctx, &pb.DeleteProductRequest{Id: id}); err != nil {
+		t.Fatalf("DeleteProduct: %v", err)
+	}
+	if _, err := client.GetProduct(ctx, &pb.GetProductRequest{Id: id}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetProduct after delete: code = %v, want %v", status.Code(err), codes.NotFound)
+	}
+}
+
+func TestGRPCProductService_ErrorCodes(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	tests := []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{
+			name: "unknown product",
+			call: func() error {
+				_, err := client.GetProduct(ctx, &pb.GetProductRequest{Id: "0190a5e8-0000-7000-8000-000000000000"})
+				return err
+			},
+			want: codes.NotFound,
+		},
+		{
+			name: "missing name",
+			call: func() error {
+				_, err := client.CreateProduct(ctx, &pb.CreateProductRequest{Price: 1, Stock: 1})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "negative price",
+			call: func() error {
+				_, err := client.CreateProduct(ctx, &pb.CreateProductRequest{Name: "Mouse", Price: -1})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "update unknown product",
+			call: func() error {
+				_, err := client.UpdateProduct(ctx, &pb.UpdateProductRequest{Id: "0190a5e8-0000-7000-8000-000000000000", Name: "Mouse"})
+				return err
+			},
+			want: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(tt.call()); got != tt.want {
+				t.Errorf("code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
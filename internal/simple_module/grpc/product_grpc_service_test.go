@@ -0,0 +1,22 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/refortunato/go_app_base/internal/simple_module/grpc/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListProducts_RejectsLimitAboveMax(t *testing.T) {
+	// The limit is validated before the service is called, so no service is needed
+	svc := NewGRPCProductService(nil, 100)
+
+	for _, limit := range []int32{101, 999999, -1} {
+		_, err := svc.ListProducts(context.Background(), &pb.ListProductsRequest{Page: 1, Limit: limit})
+		if got := status.Code(err); got != codes.InvalidArgument {
+			t.Errorf("limit=%d: code = %v, want %v", limit, got, codes.InvalidArgument)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package simple_module
+
+import (
+	"github.com/refortunato/go_app_base/internal/simple_module/grpc/pb"
+	"google.golang.org/grpc"
+)
+
+// RegisterGRPCServices registers all gRPC services for the simple_module
+func RegisterGRPCServices(server *grpc.Server, module *SimpleModule) {
+	pb.RegisterProductServiceServer(server, module.ProductGRPCService)
+}
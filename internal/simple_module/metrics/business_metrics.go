@@ -0,0 +1,92 @@
+// Package metrics holds the business KPIs published by the simple_module
+package metrics
+
+import (
+	"context"
+
+	"github.com/refortunato/go_app_base/internal/shared/observability"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// BusinessMetrics publishes product KPIs next to the infrastructure metrics
+// A nil *BusinessMetrics is valid and records nothing (SERVER_APP_BUSINESS_METRICS_ENABLED=false)
+type BusinessMetrics struct {
+	metrics *observability.CustomMetrics
+
+	ProductsCreatedTotal metric.Int64Counter
+	ProductsDeletedTotal metric.Int64Counter
+}
+
+// InventoryStatsFunc computes the average product price and the out-of-stock count in one query
+type InventoryStatsFunc func(ctx context.Context) (averagePrice float64, outOfStock int, err error)
+
+// NewBusinessMetrics creates the product counters
+// Call RegisterGauges to also publish the inventory gauges
+func NewBusinessMetrics() *BusinessMetrics {
+	m := &BusinessMetrics{metrics: observability.NewCustomMetrics("simple_module")}
+
+	m.ProductsCreatedTotal, _ = m.metrics.Counter(
+		"products.created.total",
+		"Total number of products created",
+		"{product}",
+	)
+	m.ProductsDeletedTotal, _ = m.metrics.Counter(
+		"products.deleted.total",
+		"Total number of products deleted",
+		"{product}",
+	)
+
+	return m
+}
+
+// RegisterGauges registers the AverageProductPrice and OutOfStockProducts gauges
+// Both are observed by one callback running stats when the metrics are collected,
+// so product writes never pay for the aggregate query
+func (m *BusinessMetrics) RegisterGauges(stats InventoryStatsFunc) error {
+	if m == nil {
+		return nil
+	}
+
+	averagePrice, err := m.metrics.ObservableFloatGauge(
+		"products.price.average",
+		"Average price over all products",
+		"{currency}",
+	)
+	if err != nil {
+		return err
+	}
+	outOfStock, err := m.metrics.ObservableGauge(
+		"products.out_of_stock",
+		"Number of products with no stock left",
+		"{product}",
+	)
+	if err != nil {
+		return err
+	}
+
+	return m.metrics.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		average, count, err := stats(ctx)
+		if err != nil {
+			return err
+		}
+		o.ObserveFloat64(averagePrice, average)
+		o.ObserveInt64(outOfStock, int64(count))
+		return nil
+	}, averagePrice, outOfStock)
+}
+
+// RecordProductsCreated adds count to ProductsCreatedTotal
+func (m *BusinessMetrics) RecordProductsCreated(ctx context.Context, count int) {
+	if m == nil || count <= 0 {
+		return
+	}
+	m.ProductsCreatedTotal.Add(ctx, int64(count))
+}
+
+// RecordProductDeleted increments ProductsDeletedTotal
+func (m *BusinessMetrics) RecordProductDeleted(ctx context.Context) {
+	if m == nil {
+		return
+	}
+	m.ProductsDeletedTotal.Add(ctx, 1)
+}
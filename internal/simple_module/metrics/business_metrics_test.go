@@ -0,0 +1,123 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// newTestReader installs a meter provider collecting on demand
+func newTestReader(t *testing.T) *sdkmetric.ManualReader {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	previous := otel.GetMeterProvider()
+	otel.SetMeterProvider(provider)
+	t.Cleanup(func() {
+		otel.SetMeterProvider(previous)
+		provider.Shutdown(context.Background())
+	})
+	return reader
+}
+
+// collectGauges returns the last value of every gauge, keyed by metric name
+func collectGauges(t *testing.T, reader *sdkmetric.ManualReader) map[string]float64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+
+	values := make(map[string]float64)
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Gauge[float64]:
+				for _, point := range data.DataPoints {
+					values[m.Name] = point.Value
+				}
+			case metricdata.Gauge[int64]:
+				for _, point := range data.DataPoints {
+					values[m.Name] = float64(point.Value)
+				}
+			}
+		}
+	}
+	return values
+}
+
+func TestRegisterGauges_QueriesOncePerCollection(t *testing.T) {
+	reader := newTestReader(t)
+
+	calls := 0
+	average, outOfStock := 12.5, 3
+	m := NewBusinessMetrics()
+	err := m.RegisterGauges(func(context.Context) (float64, int, error) {
+		calls++
+		return average, outOfStock, nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterGauges: %v", err)
+	}
+
+	// Nothing is computed until the metrics are collected
+	if calls != 0 {
+		t.Fatalf("stats calls = %d before any collection, want 0", calls)
+	}
+
+	values := collectGauges(t, reader)
+	if calls != 1 {
+		t.Errorf("stats calls = %d, want 1 for both gauges", calls)
+	}
+	if values["products.price.average"] != 12.5 {
+		t.Errorf("products.price.average = %v, want 12.5", values["products.price.average"])
+	}
+	if values["products.out_of_stock"] != 3 {
+		t.Errorf("products.out_of_stock = %v, want 3", values["products.out_of_stock"])
+	}
+
+	// Each collection reports the current inventory
+	average, outOfStock = 20, 0
+	values = collectGauges(t, reader)
+	if calls != 2 {
+		t.Errorf("stats calls = %d, want 2", calls)
+	}
+	if values["products.price.average"] != 20 || values["products.out_of_stock"] != 0 {
+		t.Errorf("gauges = %v, want the updated inventory", values)
+	}
+}
+
+func TestRegisterGauges_StatsErrorSkipsObservation(t *testing.T) {
+	reader := newTestReader(t)
+
+	m := NewBusinessMetrics()
+	err := m.RegisterGauges(func(context.Context) (float64, int, error) {
+		return 0, 0, errors.New("database down")
+	})
+	if err != nil {
+		t.Fatalf("RegisterGauges: %v", err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	reader.Collect(context.Background(), &rm)
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name == "products.price.average" || m.Name == "products.out_of_stock" {
+				t.Errorf("%s reported although the stats query failed", m.Name)
+			}
+		}
+	}
+}
+
+func TestBusinessMetrics_NilRecordsNothing(t *testing.T) {
+	var m *BusinessMetrics
+	if err := m.RegisterGauges(nil); err != nil {
+		t.Errorf("RegisterGauges on nil: %v", err)
+	}
+	m.RecordProductsCreated(context.Background(), 1)
+	m.RecordProductDeleted(context.Background())
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// PriceHistory records a single change of a product's price
+type PriceHistory struct {
+	ID        string    `json:"id" example:"750e8400-e29b-41d4-a716-446655440000"`
+	ProductID string    `json:"product_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OldPrice  float64   `json:"old_price" example:"5499.99"`
+	NewPrice  float64   `json:"new_price" example:"4999.99"`
+	ChangedAt time.Time `json:"changed_at" example:"2024-01-01T10:00:00Z"`
+}
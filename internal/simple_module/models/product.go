@@ -1,14 +1,23 @@
 package models
 
-import "time"
+import (
+	"encoding/xml"
+	"time"
+)
 
 // Product represents a simple product data structure
 type Product struct {
-	ID          string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Name        string    `json:"name" example:"Laptop Dell XPS 15"`
-	Description string    `json:"description" example:"High-performance laptop for professionals"`
-	Price       float64   `json:"price" example:"5499.99"`
-	Stock       int       `json:"stock" example:"10"`
-	CreatedAt   time.Time `json:"created_at" example:"2024-01-01T10:00:00Z"`
-	UpdatedAt   time.Time `json:"updated_at" example:"2024-01-01T10:00:00Z"`
+	XMLName        xml.Name          `json:"-" xml:"product" swaggerignore:"true"`
+	ID             string            `json:"id" xml:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name           string            `json:"name" xml:"name" example:"Laptop Dell XPS 15"`
+	Description    string            `json:"description" xml:"description" example:"High-performance laptop for professionals"`
+	Price          float64           `json:"price" xml:"price" example:"5499.99"`
+	Stock          int               `json:"stock" xml:"stock" example:"10"`                    // sum of the variant stock when the product has variants
+	StockThreshold int               `json:"stock_threshold" xml:"stock_threshold" example:"5"` // low-stock alert threshold, 0 disables
+	ImageURL       string            `json:"image_url,omitempty" xml:"image_url,omitempty" example:"https://cdn.example.com/products/xps15.jpg"`
+	ExternalID     string            `json:"external_id,omitempty" xml:"external_id,omitempty" example:"ERP-1001"` // ID in the source system of imports
+	Tags           []string          `json:"tags,omitempty" xml:"tags>tag,omitempty" example:"electronics,laptops"`
+	Variants       []*ProductVariant `json:"variants,omitempty" xml:"variants>variant,omitempty"` // only loaded by FindById
+	CreatedAt      time.Time         `json:"created_at" xml:"created_at" example:"2024-01-01T10:00:00Z"`
+	UpdatedAt      time.Time         `json:"updated_at" xml:"updated_at" example:"2024-01-01T10:00:00Z"`
 }
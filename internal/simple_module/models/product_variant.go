@@ -0,0 +1,44 @@
+package models
+
+import (
+	"encoding/xml"
+	"sort"
+	"time"
+)
+
+// ProductVariant is a sellable variation of a product (e.g. color and size) with its own price and stock
+type ProductVariant struct {
+	ID         string            `json:"id" xml:"id" example:"850e8400-e29b-41d4-a716-446655440000"`
+	ProductID  string            `json:"product_id" xml:"product_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Attributes VariantAttributes `json:"attributes" xml:"attributes" swaggertype:"object,string" example:"color:black,size:15-inch"`
+	Price      float64           `json:"price" xml:"price" example:"5699.99"`
+	Stock      int               `json:"stock" xml:"stock" example:"4"`
+	CreatedAt  time.Time         `json:"created_at" xml:"created_at" example:"2024-01-01T10:00:00Z"`
+}
+
+// VariantAttributes are the name/value pairs that distinguish a variant
+type VariantAttributes map[string]string
+
+// MarshalXML encodes the attributes as <attribute name="color">black</attribute> elements
+// sorted by name (encoding/xml does not support maps)
+func (a VariantAttributes) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, name := range names {
+		attribute := xml.StartElement{
+			Name: xml.Name{Local: "attribute"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "name"}, Value: name}},
+		}
+		if err := e.EncodeElement(a[name], attribute); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
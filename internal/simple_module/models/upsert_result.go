@@ -0,0 +1,6 @@
+package models
+
+// UpsertResult reports whether an upsert inserted a new row or updated an existing one
+type UpsertResult struct {
+	WasInserted bool
+}
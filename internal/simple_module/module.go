@@ -1,9 +1,20 @@
 package simple_module
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
+	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/configs"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"github.com/refortunato/go_app_base/internal/shared/messaging/eventbus"
+	"github.com/refortunato/go_app_base/internal/shared/messaging/outbox"
+	"github.com/refortunato/go_app_base/internal/shared/web/middleware"
 	"github.com/refortunato/go_app_base/internal/simple_module/controllers"
+	"github.com/refortunato/go_app_base/internal/simple_module/grpc"
+	"github.com/refortunato/go_app_base/internal/simple_module/metrics"
+	"github.com/refortunato/go_app_base/internal/simple_module/notifications"
 	"github.com/refortunato/go_app_base/internal/simple_module/repositories"
 	"github.com/refortunato/go_app_base/internal/simple_module/services"
 )
@@ -11,24 +22,131 @@ import (
 // SimpleModule holds all initialized dependencies for the simple_module (4-tier architecture)
 // This module demonstrates a simpler architecture pattern for CRUD operations
 type SimpleModule struct {
-	ProductController *controllers.ProductController
-	ProductService    *services.ProductService
+	ProductController  *controllers.ProductController
+	ProductService     *services.ProductService
+	ProductGRPCService *grpc.GRPCProductService
+	// StockMonitor checks stock levels in the background (nil when disabled, started by the container)
+	StockMonitor *services.StockMonitor
+	// StatsAggregator caches the product count for paginated lists (nil when disabled, started by the container)
+	StatsAggregator *StatsAggregator
+	// EmailNotifier emails out-of-stock alerts (nil when SMTP is not configured, started by the container)
+	EmailNotifier *notifications.EmailNotifier
+
+	// deduplication rejects retries of the product POST routes that create resources
+	deduplication gin.HandlerFunc
+
+	db *sql.DB
 }
 
 // NewSimpleModule creates and wires all dependencies for the simple_module
-func NewSimpleModule(db *sql.DB) *SimpleModule {
-	// Step 1: Initialize repository
+// Product events are written to outboxRepo (shared with the outbox poller)
+func NewSimpleModule(db *sql.DB, cfg *configs.Conf, outboxRepo *outbox.OutboxRepository) *SimpleModule {
+	// Step 1: Initialize repositories (product calls go through the circuit breaker)
 	productRepo := repositories.NewProductRepository(db)
+	productStore := repositories.NewCircuitBreakerRepository(
+		productRepo,
+		uint32(cfg.DBCircuitBreakerMaxRequests),
+		time.Duration(cfg.DBCircuitBreakerIntervalSeconds)*time.Second,
+		time.Duration(cfg.DBCircuitBreakerTimeoutSeconds)*time.Second,
+	)
+	priceHistoryRepo := repositories.NewPriceHistoryRepository(db)
+	variantRepo := repositories.NewProductVariantRepository(db)
+
+	// Step 2: Initialize business metrics (product KPIs; nil when disabled)
+	// The inventory gauges query the product store when the metrics are collected
+	businessMetrics := newBusinessMetrics(cfg, productStore.InventoryStats)
+
+	// Step 3: Initialize service (inject repositories, metrics and the in-process bus for stock subscriptions)
+	productService := services.NewProductService(productStore, priceHistoryRepo, variantRepo, outboxRepo, eventbus.New(), businessMetrics, cfg.MaxImportBatchSize, cfg.MaxBatchLookupSize, cfg.CDNBaseURL, cfg.IDVersion)
 
-	// Step 2: Initialize service (inject repository)
-	productService := services.NewProductService(productRepo)
+	// Step 4: Initialize controller (inject service)
+	productController := controllers.NewProductController(productService, cfg.BaseURL, cfg.CDNBaseURL, cfg.PaginationMaxLimit, cfg.UploadDirectory, cfg.MaxUploadSizeMB, cfg.CacheControlMaxAge)
 
-	// Step 3: Initialize controller (inject service)
-	productController := controllers.NewProductController(productService)
+	// Step 5: Initialize gRPC service (inject service)
+	productGRPCService := grpc.NewGRPCProductService(productService, cfg.PaginationMaxLimit)
 
-	// Step 4: Return module with all dependencies wired
+	// Step 6: Initialize the stock monitor (disabled when the interval is 0)
+	var stockMonitor *services.StockMonitor
+	if cfg.StockCheckIntervalMinutes > 0 {
+		stockMonitor = services.NewStockMonitor(productService, time.Duration(cfg.StockCheckIntervalMinutes)*time.Minute)
+	}
+
+	// Step 7: Initialize the stats aggregator (disabled when the interval is 0)
+	var statsAggregator *StatsAggregator
+	if cfg.StatsAggregationIntervalSeconds > 0 {
+		statsAggregator = NewStatsAggregator(productRepo, time.Duration(cfg.StatsAggregationIntervalSeconds)*time.Second)
+	}
+
+	// Step 8: Initialize the out-of-stock email alerts (disabled without an SMTP host or recipients)
+	var emailNotifier *notifications.EmailNotifier
+	if recipients := cfg.GetStockAlertEmailTo(); cfg.SMTPHost != "" && len(recipients) > 0 {
+		emailClient := notifications.NewSMTPEmailClient(notifications.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		})
+		emailNotifier = notifications.NewEmailNotifier(emailClient, recipients)
+	}
+
+	// Step 9: Return module with all dependencies wired
 	return &SimpleModule{
-		ProductController: productController,
-		ProductService:    productService,
+		ProductController:  productController,
+		ProductService:     productService,
+		ProductGRPCService: productGRPCService,
+		StockMonitor:       stockMonitor,
+		StatsAggregator:    statsAggregator,
+		EmailNotifier:      emailNotifier,
+		deduplication:      newDeduplication(cfg),
+		db:                 db,
+	}
+}
+
+// newBusinessMetrics creates the product KPIs and registers their gauge callbacks,
+// returning nil when SERVER_APP_BUSINESS_METRICS_ENABLED is false
+func newBusinessMetrics(cfg *configs.Conf, inventoryStats metrics.InventoryStatsFunc) *metrics.BusinessMetrics {
+	if !cfg.BusinessMetricsEnabled {
+		return nil
 	}
+	businessMetrics := metrics.NewBusinessMetrics()
+	if err := businessMetrics.RegisterGauges(inventoryStats); err != nil {
+		logger.WithError(err).Warn(context.Background(), "Failed to register business metric gauges")
+	}
+	return businessMetrics
+}
+
+// newDeduplication rejects identical requests of the same caller within SERVER_APP_DEDUPLICATION_WINDOW
+// (a pass-through when the window is 0)
+func newDeduplication(cfg *configs.Conf) gin.HandlerFunc {
+	if cfg.DeduplicationWindow <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return middleware.DeduplicationMiddleware(time.Duration(cfg.DeduplicationWindow)*time.Second, nil)
+}
+
+// ExpectedSchema lists the tables and columns used by the module (see configs.ValidateSchema)
+func ExpectedSchema() map[string][]string {
+	return map[string][]string{
+		"products":              {"id", "name", "description", "price", "stock", "stock_threshold", "image_url", "external_id", "base_stock", "created_at", "updated_at"},
+		"tags":                  {"id", "name", "created_at"},
+		"product_tags":          {"product_id", "tag_id"},
+		"product_price_history": {"id", "product_id", "old_price", "new_price", "changed_at"},
+		"product_variants":      {"id", "product_id", "attributes", "price", "stock", "created_at"},
+	}
+}
+
+// Name identifies the module in configuration (implements module.Module)
+func (m *SimpleModule) Name() string {
+	return "simple"
+}
+
+// RoutePrefix is the default prefix for the module routes (implements module.Module)
+func (m *SimpleModule) RoutePrefix() string {
+	return "/"
+}
+
+// HealthCheck reports whether the module's database is reachable (implements module.Module)
+func (m *SimpleModule) HealthCheck(ctx context.Context) error {
+	return m.db.PingContext(ctx)
 }
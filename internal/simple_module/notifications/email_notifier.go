@@ -0,0 +1,221 @@
+package notifications
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"github.com/refortunato/go_app_base/internal/simple_module/services"
+)
+
+// EmailClient sends plain text emails
+type EmailClient interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// EmailClientFunc adapts a function to the EmailClient interface (e.g. a fake client in tests)
+type EmailClientFunc func(ctx context.Context, to, subject, body string) error
+
+func (f EmailClientFunc) Send(ctx context.Context, to, subject, body string) error {
+	return f(ctx, to, subject, body)
+}
+
+// SMTPConfig holds the SMTP server settings of SMTPEmailClient
+type SMTPConfig struct {
+	Host string
+	Port int
+	// Username and Password enable PLAIN authentication (empty Username sends without it)
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPEmailClient sends emails through an SMTP server with net/smtp
+// STARTTLS is used when the server offers it
+type SMTPEmailClient struct {
+	cfg SMTPConfig
+}
+
+// NewSMTPEmailClient creates an email client for the given SMTP server
+func NewSMTPEmailClient(cfg SMTPConfig) *SMTPEmailClient {
+	return &SMTPEmailClient{cfg: cfg}
+}
+
+// Send delivers one email to the comma-separated recipients in to
+// The SMTP conversation is bounded by the ctx deadline (smtpTimeout when ctx has none) and
+// aborted when ctx is cancelled, so an unresponsive server cannot block the caller
+func (c *SMTPEmailClient) Send(ctx context.Context, to, subject, body string) error {
+	recipients := strings.Split(to, ",")
+	for i := range recipients {
+		recipients[i] = strings.TrimSpace(recipients[i])
+	}
+	message := "From: " + c.cfg.From + "\r\n" +
+		"To: " + strings.Join(recipients, ", ") + "\r\n" +
+		"Subject: " + subject + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" + body
+
+	addr := net.JoinHostPort(c.cfg.Host, strconv.Itoa(c.cfg.Port))
+	if err := c.send(ctx, addr, recipients, []byte(message)); err != nil {
+		return fmt.Errorf("smtp send to %s: %w", addr, err)
+	}
+	return nil
+}
+
+// send runs the SMTP conversation of one message on a connection with a deadline
+func (c *SMTPEmailClient) send(ctx context.Context, addr string, recipients []string, message []byte) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// net/smtp has no context support: the deadline fails any blocked read or write
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	client, err := smtp.NewClient(conn, c.cfg.Host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: c.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if c.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", c.cfg.Username, c.cfg.Password, c.cfg.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(c.cfg.From); err != nil {
+		return err
+	}
+	for _, recipient := range recipients {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(message); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// stockEventsBuffer is how many stock changes the notifier may lag behind before missing some
+const stockEventsBuffer = 64
+
+// sendTimeout bounds each alert so a stuck SMTP server cannot block the following ones for long
+const sendTimeout = 30 * time.Second
+
+// smtpTimeout bounds an SMTP conversation when the context of Send has no deadline
+const smtpTimeout = time.Minute
+
+// EmailNotifier emails an alert to its recipients when a product runs out of stock
+// It handles the product.stock_changed events of every product (see ProductService.SubscribeAllStockChanges)
+// rather than product.updated, whose payload is the updated product: only the stock change
+// carries the previous stock, which tells a product that just ran out from one still at zero
+type EmailNotifier struct {
+	client EmailClient
+	to     string
+
+	cancel   context.CancelFunc
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewEmailNotifier creates a notifier sending the alerts to the recipients through client
+func NewEmailNotifier(client EmailClient, recipients []string) *EmailNotifier {
+	return &EmailNotifier{client: client, to: strings.Join(recipients, ",")}
+}
+
+// Handle sends an alert when the stock of the product dropped to zero; other changes are ignored
+func (n *EmailNotifier) Handle(ctx context.Context, event services.ProductStockChangedPayload) error {
+	if event.Stock != 0 || event.PreviousStock <= 0 {
+		return nil
+	}
+
+	subject := "Product out of stock: " + event.ProductID
+	body := fmt.Sprintf("Product %s ran out of stock at %s (previous stock: %d).\n",
+		event.ProductID, event.ChangedAt.Format(time.RFC3339), event.PreviousStock)
+	return n.client.Send(ctx, n.to, subject, body)
+}
+
+// Start handles the stock changes of service in a background goroutine until ctx is done or Stop is called,
+// so sending emails never slows down the request that changed the stock
+func (n *EmailNotifier) Start(ctx context.Context, service *services.ProductService) {
+	ctx, n.cancel = context.WithCancel(ctx)
+	n.done = make(chan struct{})
+	events, unsubscribe := service.SubscribeAllStockChanges(stockEventsBuffer)
+
+	go func() {
+		defer close(n.done)
+		defer unsubscribe()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				if payload, ok := event.Payload.(services.ProductStockChangedPayload); ok {
+					n.handle(ctx, payload)
+				}
+			}
+		}
+	}()
+
+	logger.Info(ctx, "Stock email notifier started")
+}
+
+// Stop stops handling events and waits for an alert being sent
+func (n *EmailNotifier) Stop() {
+	n.stopOnce.Do(func() {
+		if n.cancel != nil {
+			n.cancel()
+			<-n.done
+		}
+	})
+}
+
+// handle runs Handle with a timeout, logging failures (never stops the loop)
+func (n *EmailNotifier) handle(ctx context.Context, event services.ProductStockChangedPayload) {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	if err := n.Handle(ctx, event); err != nil {
+		logger.WithError(err).Error(ctx, "Failed to send out-of-stock email", logger.CustomFields{
+			"product.id": event.ProductID,
+		})
+	}
+}
@@ -0,0 +1,50 @@
+//go:build sqlite
+
+package notifications
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/shared/messaging/eventbus"
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+	"github.com/refortunato/go_app_base/internal/simple_module/repositories"
+	"github.com/refortunato/go_app_base/internal/simple_module/services"
+)
+
+func TestEmailNotifier_StartSendsAlertWhenUpdateEmptiesStock(t *testing.T) {
+	ctx := context.Background()
+	db := testhelpers.NewSQLiteForTest(t)
+	service := services.NewProductService(
+		repositories.NewProductRepository(db),
+		repositories.NewPriceHistoryRepository(db),
+		repositories.NewProductVariantRepository(db),
+		nil, eventbus.New(), nil, 0, 0, "", "v7",
+	)
+
+	sent := make(chan string, 1)
+	notifier := NewEmailNotifier(EmailClientFunc(func(_ context.Context, _, subject, _ string) error {
+		sent <- subject
+		return nil
+	}), []string{"ops@example.com"})
+	notifier.Start(ctx, service)
+	defer notifier.Stop()
+
+	product, err := service.CreateProduct(ctx, "Laptop", "", 100, 3, "", nil)
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if _, err := service.UpdateProduct(ctx, product.ID, "Laptop", "", 100, 0, ""); err != nil {
+		t.Fatalf("UpdateProduct: %v", err)
+	}
+
+	select {
+	case subject := <-sent:
+		if subject == "" {
+			t.Error("empty subject")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no alert sent after the stock dropped to 0")
+	}
+}
@@ -0,0 +1,221 @@
+package notifications
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"github.com/refortunato/go_app_base/internal/simple_module/services"
+)
+
+func TestMain(m *testing.M) {
+	// Send failures are logged through the global logger
+	logger.SetGlobalLogger(logger.NewMultiLogger())
+	os.Exit(m.Run())
+}
+
+// sentEmail is an email recorded by the fake client
+type sentEmail struct {
+	to, subject, body string
+}
+
+// fakeEmailClient records the emails it is asked to send
+type fakeEmailClient struct {
+	mu   sync.Mutex
+	sent []sentEmail
+	err  error
+}
+
+func (c *fakeEmailClient) Send(_ context.Context, to, subject, body string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent = append(c.sent, sentEmail{to: to, subject: subject, body: body})
+	return c.err
+}
+
+func (c *fakeEmailClient) Sent() []sentEmail {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]sentEmail(nil), c.sent...)
+}
+
+func TestEmailNotifier_Handle(t *testing.T) {
+	tests := []struct {
+		name          string
+		previousStock int
+		stock         int
+		wantEmail     bool
+	}{
+		{name: "runs out of stock", previousStock: 3, stock: 0, wantEmail: true},
+		{name: "stock decreases", previousStock: 3, stock: 2},
+		{name: "restocked", previousStock: 0, stock: 5},
+		{name: "still out of stock", previousStock: 0, stock: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeEmailClient{}
+			notifier := NewEmailNotifier(client, []string{"ops@example.com", "sales@example.com"})
+
+			err := notifier.Handle(context.Background(), services.ProductStockChangedPayload{
+				Event:         services.ProductStockChangedEvent,
+				ProductID:     "product-1",
+				Stock:         tt.stock,
+				PreviousStock: tt.previousStock,
+				ChangedAt:     time.Now(),
+			})
+			if err != nil {
+				t.Fatalf("Handle: %v", err)
+			}
+
+			sent := client.Sent()
+			if !tt.wantEmail {
+				if len(sent) != 0 {
+					t.Fatalf("sent %d emails, want none", len(sent))
+				}
+				return
+			}
+			if len(sent) != 1 {
+				t.Fatalf("sent %d emails, want 1", len(sent))
+			}
+			if sent[0].to != "ops@example.com,sales@example.com" {
+				t.Errorf("to = %q", sent[0].to)
+			}
+			if sent[0].subject == "" || !strings.Contains(sent[0].subject, "product-1") {
+				t.Errorf("subject = %q", sent[0].subject)
+			}
+			if !strings.Contains(sent[0].body, "previous stock: 3") {
+				t.Errorf("body = %q", sent[0].body)
+			}
+		})
+	}
+}
+
+func TestEmailNotifier_HandleReturnsClientError(t *testing.T) {
+	client := &fakeEmailClient{err: errors.New("smtp down")}
+	notifier := NewEmailNotifier(client, []string{"ops@example.com"})
+
+	err := notifier.Handle(context.Background(), services.ProductStockChangedPayload{ProductID: "p", PreviousStock: 1})
+	if err == nil || err.Error() != "smtp down" {
+		t.Fatalf("expected the client error, got %v", err)
+	}
+}
+
+// startSMTPServer serves a minimal SMTP dialogue on a local port and returns the port and
+// a channel receiving the DATA of each message
+func startSMTPServer(t *testing.T) (int, <-chan string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	messages := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		reply("220 localhost ESMTP")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch command := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 localhost")
+			case command == "DATA":
+				reply("354 end with .")
+				var data strings.Builder
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				messages <- data.String()
+				reply("250 queued")
+			case command == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port, messages
+}
+
+func TestSMTPEmailClient_Send(t *testing.T) {
+	port, messages := startSMTPServer(t)
+	client := NewSMTPEmailClient(SMTPConfig{Host: "127.0.0.1", Port: port, From: "alerts@example.com"})
+
+	if err := client.Send(context.Background(), "ops@example.com, sales@example.com", "Out of stock", "Product 1\n"); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	select {
+	case message := <-messages:
+		for _, want := range []string{"From: alerts@example.com", "To: ops@example.com, sales@example.com", "Subject: Out of stock", "Product 1"} {
+			if !strings.Contains(message, want) {
+				t.Errorf("message %q does not contain %q", message, want)
+			}
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no message received")
+	}
+}
+
+func TestSMTPEmailClient_SendHonorsContextDeadline(t *testing.T) {
+	// Accepts connections but never greets, like a stuck server
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	port := listener.Addr().(*net.TCPAddr).Port
+	client := NewSMTPEmailClient(SMTPConfig{Host: "127.0.0.1", Port: port, From: "alerts@example.com"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = client.Send(ctx, "ops@example.com", "Out of stock", "body")
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("Send returned after %s, the deadline was not applied", elapsed)
+	}
+	if !strings.Contains(err.Error(), "127.0.0.1:"+strconv.Itoa(port)) {
+		t.Errorf("error %q does not name the server", err)
+	}
+}
@@ -0,0 +1,223 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sharedErrors "github.com/refortunato/go_app_base/internal/shared/errors"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"github.com/refortunato/go_app_base/internal/simple_module/models"
+	"github.com/sony/gobreaker"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// circuitBreakerFailureThreshold is the number of consecutive failures that opens the breaker
+const circuitBreakerFailureThreshold = 5
+
+// ProductStore is the set of product repository operations used by the product service,
+// implemented by ProductRepository and CircuitBreakerRepository
+type ProductStore interface {
+	Transactional(ctx context.Context, fn func(repo *ProductRepository) error) error
+	FindById(ctx context.Context, id string) (*models.Product, error)
+	FindAll(ctx context.Context, limit, offset int) ([]*models.Product, error)
+	FindAfterID(ctx context.Context, afterID string, limit int) ([]*models.Product, error)
+	FindByIds(ctx context.Context, ids []string) ([]*models.Product, error)
+	ExistsByIds(ctx context.Context, ids []string) (map[string]bool, error)
+	CountWhere(ctx context.Context, conditions map[string]any) (int, error)
+	CountCached() int64
+	InventoryStats(ctx context.Context) (float64, int, error)
+	FindIDByExternalID(ctx context.Context, externalID string) (string, error)
+	BulkCreate(ctx context.Context, products []*models.Product) error
+	Update(ctx context.Context, product *models.Product) error
+	Upsert(ctx context.Context, product *models.Product) (*models.UpsertResult, error)
+	FilterByTag(ctx context.Context, tag string, limit, offset int) ([]*models.Product, error)
+	CountByTag(ctx context.Context, tag string) (int, error)
+	AddTags(ctx context.Context, productID string, tags []string) error
+	RemoveTag(ctx context.Context, productID, tag string) error
+}
+
+// CircuitBreakerRepository wraps ProductRepository with a circuit breaker so a slow or
+// failing database makes product operations fail fast (ErrCircuitOpen) instead of piling up
+type CircuitBreakerRepository struct {
+	repository *ProductRepository
+	breaker    *gobreaker.CircuitBreaker
+}
+
+// NewCircuitBreakerRepository creates the wrapper
+// The breaker opens after 5 consecutive failures, stays open for timeout and then lets
+// maxRequests calls through (half-open); interval is the cyclic period in which the closed
+// state clears its counts (0 never clears them)
+func NewCircuitBreakerRepository(repository *ProductRepository, maxRequests uint32, interval, timeout time.Duration) *CircuitBreakerRepository {
+	stateChanges, _ := otel.Meter("simple_module").Int64Counter(
+		"db.circuit_breaker.state_changes",
+		metric.WithDescription("Number of product repository circuit breaker state changes"),
+		metric.WithUnit("{change}"),
+	)
+
+	breaker := gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:        "product_repository",
+		MaxRequests: maxRequests,
+		Interval:    interval,
+		Timeout:     timeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= circuitBreakerFailureThreshold
+		},
+		OnStateChange: func(name string, from, to gobreaker.State) {
+			ctx := context.Background()
+			stateChanges.Add(ctx, 1, metric.WithAttributes(attribute.String("state", to.String())))
+			logger.Warn(ctx, "Circuit breaker state changed", logger.CustomFields{
+				"breaker": name,
+				"from":    from.String(),
+				"to":      to.String(),
+			})
+		},
+		IsSuccessful: isBreakerSuccess,
+	})
+
+	return &CircuitBreakerRepository{repository: repository, breaker: breaker}
+}
+
+// isBreakerSuccess counts business errors (conflicts, not found) as successes,
+// since the database answered; only infrastructure errors trip the breaker
+func isBreakerSuccess(err error) bool {
+	var problem *sharedErrors.ProblemDetails
+	return err == nil || errors.Is(err, sql.ErrNoRows) || errors.As(err, &problem)
+}
+
+// State returns the current breaker state (closed, half-open or open)
+func (r *CircuitBreakerRepository) State() gobreaker.State {
+	return r.breaker.State()
+}
+
+// execute runs fn through the breaker, returning ErrCircuitOpen without calling fn
+// while the breaker is open (or half-open with all trial requests in flight)
+func execute[T any](r *CircuitBreakerRepository, fn func() (T, error)) (T, error) {
+	var result T
+	_, err := r.breaker.Execute(func() (any, error) {
+		var err error
+		result, err = fn()
+		return nil, err
+	})
+	if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+		return result, sharedErrors.ErrCircuitOpen
+	}
+	return result, err
+}
+
+// run is execute for operations without a result
+func (r *CircuitBreakerRepository) run(fn func() error) error {
+	_, err := execute(r, func() (struct{}, error) {
+		return struct{}{}, fn()
+	})
+	return err
+}
+
+// Transactional runs the whole transaction as a single breaker call
+func (r *CircuitBreakerRepository) Transactional(ctx context.Context, fn func(repo *ProductRepository) error) error {
+	return r.run(func() error { return r.repository.Transactional(ctx, fn) })
+}
+
+// Tx returns the transaction of the wrapped repository (nil outside Transactional)
+func (r *CircuitBreakerRepository) Tx() *sql.Tx {
+	return r.repository.Tx()
+}
+
+// The methods below run the ProductRepository method of the same name through the breaker
+
+func (r *CircuitBreakerRepository) FindById(ctx context.Context, id string) (*models.Product, error) {
+	return execute(r, func() (*models.Product, error) { return r.repository.FindById(ctx, id) })
+}
+
+func (r *CircuitBreakerRepository) FindAll(ctx context.Context, limit, offset int) ([]*models.Product, error) {
+	return execute(r, func() ([]*models.Product, error) { return r.repository.FindAll(ctx, limit, offset) })
+}
+
+func (r *CircuitBreakerRepository) FindAfterID(ctx context.Context, afterID string, limit int) ([]*models.Product, error) {
+	return execute(r, func() ([]*models.Product, error) { return r.repository.FindAfterID(ctx, afterID, limit) })
+}
+
+func (r *CircuitBreakerRepository) FindByIds(ctx context.Context, ids []string) ([]*models.Product, error) {
+	return execute(r, func() ([]*models.Product, error) { return r.repository.FindByIds(ctx, ids) })
+}
+
+func (r *CircuitBreakerRepository) ExistsByIds(ctx context.Context, ids []string) (map[string]bool, error) {
+	return execute(r, func() (map[string]bool, error) { return r.repository.ExistsByIds(ctx, ids) })
+}
+
+func (r *CircuitBreakerRepository) CountWhere(ctx context.Context, conditions map[string]any) (int, error) {
+	return execute(r, func() (int, error) { return r.repository.CountWhere(ctx, conditions) })
+}
+
+func (r *CircuitBreakerRepository) CountSearch(ctx context.Context, query string) (int, error) {
+	return execute(r, func() (int, error) { return r.repository.CountSearch(ctx, query) })
+}
+
+func (r *CircuitBreakerRepository) Count(ctx context.Context) (int, error) {
+	return execute(r, func() (int, error) { return r.repository.Count(ctx) })
+}
+
+// CountCached reads the in-memory count and never touches the database
+func (r *CircuitBreakerRepository) CountCached() int64 {
+	return r.repository.CountCached()
+}
+
+// StoreCachedCount updates the in-memory count and never touches the database
+func (r *CircuitBreakerRepository) StoreCachedCount(count int64) {
+	r.repository.StoreCachedCount(count)
+}
+
+func (r *CircuitBreakerRepository) InventoryStats(ctx context.Context) (float64, int, error) {
+	type stats struct {
+		value float64
+		count int
+	}
+	s, err := execute(r, func() (stats, error) {
+		value, count, err := r.repository.InventoryStats(ctx)
+		return stats{value: value, count: count}, err
+	})
+	return s.value, s.count, err
+}
+
+func (r *CircuitBreakerRepository) Save(ctx context.Context, product *models.Product) error {
+	return r.run(func() error { return r.repository.Save(ctx, product) })
+}
+
+func (r *CircuitBreakerRepository) FindIDByExternalID(ctx context.Context, externalID string) (string, error) {
+	return execute(r, func() (string, error) { return r.repository.FindIDByExternalID(ctx, externalID) })
+}
+
+func (r *CircuitBreakerRepository) BulkCreate(ctx context.Context, products []*models.Product) error {
+	return r.run(func() error { return r.repository.BulkCreate(ctx, products) })
+}
+
+func (r *CircuitBreakerRepository) Update(ctx context.Context, product *models.Product) error {
+	return r.run(func() error { return r.repository.Update(ctx, product) })
+}
+
+func (r *CircuitBreakerRepository) Upsert(ctx context.Context, product *models.Product) (*models.UpsertResult, error) {
+	return execute(r, func() (*models.UpsertResult, error) { return r.repository.Upsert(ctx, product) })
+}
+
+func (r *CircuitBreakerRepository) Delete(ctx context.Context, id string) error {
+	return r.run(func() error { return r.repository.Delete(ctx, id) })
+}
+
+func (r *CircuitBreakerRepository) FilterByTag(ctx context.Context, tag string, limit, offset int) ([]*models.Product, error) {
+	return execute(r, func() ([]*models.Product, error) { return r.repository.FilterByTag(ctx, tag, limit, offset) })
+}
+
+func (r *CircuitBreakerRepository) CountByTag(ctx context.Context, tag string) (int, error) {
+	return execute(r, func() (int, error) { return r.repository.CountByTag(ctx, tag) })
+}
+
+func (r *CircuitBreakerRepository) AddTags(ctx context.Context, productID string, tags []string) error {
+	return r.run(func() error { return r.repository.AddTags(ctx, productID, tags) })
+}
+
+func (r *CircuitBreakerRepository) RemoveTag(ctx context.Context, productID, tag string) error {
+	return r.run(func() error { return r.repository.RemoveTag(ctx, productID, tag) })
+}
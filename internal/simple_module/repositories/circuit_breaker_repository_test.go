@@ -0,0 +1,114 @@
+//go:build sqlite
+
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	sharedErrors "github.com/refortunato/go_app_base/internal/shared/errors"
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+	moduleErrors "github.com/refortunato/go_app_base/internal/simple_module/errors"
+	"github.com/sony/gobreaker"
+)
+
+func newTestBreaker(t *testing.T, timeout time.Duration) *CircuitBreakerRepository {
+	t.Helper()
+	return NewCircuitBreakerRepository(NewProductRepository(testhelpers.NewSQLiteForTest(t)), 1, 0, timeout)
+}
+
+// failingContext makes every query fail before reaching the database, like an unreachable server
+func failingContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func TestCircuitBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	repo := newTestBreaker(t, time.Minute)
+
+	for i := 1; i < circuitBreakerFailureThreshold; i++ {
+		if _, err := repo.Count(failingContext()); !errors.Is(err, context.Canceled) {
+			t.Fatalf("failure %d: expected context.Canceled, got %v", i, err)
+		}
+		if repo.State() != gobreaker.StateClosed {
+			t.Fatalf("breaker opened after %d failures", i)
+		}
+	}
+
+	if _, err := repo.Count(failingContext()); !errors.Is(err, context.Canceled) {
+		t.Fatalf("failure %d: expected context.Canceled, got %v", circuitBreakerFailureThreshold, err)
+	}
+	if repo.State() != gobreaker.StateOpen {
+		t.Fatalf("expected open breaker after %d failures, got %s", circuitBreakerFailureThreshold, repo.State())
+	}
+
+	// Healthy calls are rejected without reaching the database while the breaker is open
+	if _, err := repo.Count(context.Background()); err != sharedErrors.ErrCircuitOpen {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_SuccessResetsConsecutiveFailures(t *testing.T) {
+	repo := newTestBreaker(t, time.Minute)
+
+	for round := 0; round < 3; round++ {
+		for i := 1; i < circuitBreakerFailureThreshold; i++ {
+			repo.Count(failingContext())
+		}
+		if _, err := repo.Count(context.Background()); err != nil {
+			t.Fatalf("Count: %v", err)
+		}
+	}
+
+	if repo.State() != gobreaker.StateClosed {
+		t.Fatalf("expected closed breaker, got %s", repo.State())
+	}
+}
+
+func TestCircuitBreaker_BusinessErrorsDoNotTrip(t *testing.T) {
+	repo := newTestBreaker(t, time.Minute)
+
+	for i := 0; i < 2*circuitBreakerFailureThreshold; i++ {
+		var businessErr error = moduleErrors.ErrProductNotFound
+		if i%2 == 1 {
+			businessErr = sql.ErrNoRows
+		}
+		err := repo.Transactional(context.Background(), func(*ProductRepository) error {
+			return businessErr
+		})
+		if !errors.Is(err, businessErr) {
+			t.Fatalf("expected %v, got %v", businessErr, err)
+		}
+	}
+
+	if repo.State() != gobreaker.StateClosed {
+		t.Fatalf("expected closed breaker, got %s", repo.State())
+	}
+}
+
+func TestCircuitBreaker_ClosesAfterSuccessfulTrial(t *testing.T) {
+	repo := newTestBreaker(t, 20*time.Millisecond)
+
+	for i := 0; i < circuitBreakerFailureThreshold; i++ {
+		repo.Count(failingContext())
+	}
+	if repo.State() != gobreaker.StateOpen {
+		t.Fatalf("expected open breaker, got %s", repo.State())
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if repo.State() != gobreaker.StateHalfOpen {
+		t.Fatalf("expected half-open breaker after the timeout, got %s", repo.State())
+	}
+
+	if _, err := repo.Count(context.Background()); err != nil {
+		t.Fatalf("Count: %v", err)
+	}
+	if repo.State() != gobreaker.StateClosed {
+		t.Fatalf("expected closed breaker after a successful trial, got %s", repo.State())
+	}
+}
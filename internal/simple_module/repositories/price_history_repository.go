@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/refortunato/go_app_base/internal/simple_module/models"
+)
+
+// PriceHistoryRepository reads the price changes recorded by ProductRepository.Update
+type PriceHistoryRepository struct {
+	db *sql.DB
+}
+
+// NewPriceHistoryRepository creates a new price history repository instance
+func NewPriceHistoryRepository(db *sql.DB) *PriceHistoryRepository {
+	return &PriceHistoryRepository{db: db}
+}
+
+// FindByProductId retrieves the price changes of a product, newest first, with pagination
+func (r *PriceHistoryRepository) FindByProductId(ctx context.Context, productID string, limit, offset int) ([]*models.PriceHistory, error) {
+	query := `
+		SELECT id, product_id, old_price, new_price, changed_at
+		FROM product_price_history
+		WHERE product_id = ?
+		ORDER BY changed_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, productID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []*models.PriceHistory{}
+	for rows.Next() {
+		var entry models.PriceHistory
+		err := rows.Scan(
+			&entry.ID,
+			&entry.ProductID,
+			&entry.OldPrice,
+			&entry.NewPrice,
+			&entry.ChangedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, &entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// CountByProductId returns the number of price changes recorded for a product
+func (r *PriceHistoryRepository) CountByProductId(ctx context.Context, productID string) (int, error) {
+	query := `SELECT COUNT(*) FROM product_price_history WHERE product_id = ?`
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, productID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
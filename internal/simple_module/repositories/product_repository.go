@@ -3,53 +3,152 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/go-sql-driver/mysql"
+	"github.com/refortunato/go_app_base/internal/shared"
+	"github.com/refortunato/go_app_base/internal/shared/db"
+	sharedErrors "github.com/refortunato/go_app_base/internal/shared/errors"
 	"github.com/refortunato/go_app_base/internal/simple_module/models"
 )
 
+// mysqlDuplicateEntry is the MySQL error number for a duplicate key (ER_DUP_ENTRY)
+const mysqlDuplicateEntry = 1062
+
+// dbExecutor is the subset of *sql.DB and *sql.Tx used by the repository,
+// allowing the same queries to run inside or outside a transaction
+type dbExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
 // ProductRepository handles database operations for products
 type ProductRepository struct {
-	db *sql.DB
+	conn *sql.DB
+	db   dbExecutor
+	// cachedCount is the product count stored by the stats aggregator (-1 until the first run),
+	// shared with the transactional copies of the repository
+	cachedCount *atomic.Int64
 }
 
 // NewProductRepository creates a new product repository instance
 func NewProductRepository(db *sql.DB) *ProductRepository {
-	return &ProductRepository{db: db}
+	cachedCount := &atomic.Int64{}
+	cachedCount.Store(-1)
+	return &ProductRepository{conn: db, db: db, cachedCount: cachedCount}
 }
 
-// FindById retrieves a product by ID
+// Transactional runs fn with a repository bound to a single database transaction
+// The transaction is committed if fn returns nil and rolled back otherwise
+func (r *ProductRepository) Transactional(ctx context.Context, fn func(repo *ProductRepository) error) error {
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(&ProductRepository{conn: r.conn, db: tx, cachedCount: r.cachedCount}); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Join(err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Tx returns the transaction the repository is bound to (nil outside Transactional)
+func (r *ProductRepository) Tx() *sql.Tx {
+	tx, _ := r.db.(*sql.Tx)
+	return tx
+}
+
+// FindById retrieves a product by ID, including its tags and variants
+// The product columns repeat on every variant row of the LEFT JOIN
 func (r *ProductRepository) FindById(ctx context.Context, id string) (*models.Product, error) {
 	query := `
-		SELECT id, name, description, price, stock, created_at, updated_at
-		FROM products
-		WHERE id = ?
+		SELECT p.id, p.name, p.description, p.price, p.stock, p.stock_threshold, p.image_url, COALESCE(p.external_id, ''), p.created_at, p.updated_at,
+			v.id, v.attributes, v.price, v.stock, v.created_at
+		FROM products p
+		LEFT JOIN product_variants v ON v.product_id = p.id
+		WHERE p.id = ?
+		ORDER BY v.created_at, v.id
 	`
 
-	var product models.Product
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&product.ID,
-		&product.Name,
-		&product.Description,
-		&product.Price,
-		&product.Stock,
-		&product.CreatedAt,
-		&product.UpdatedAt,
-	)
-
+	rows, err := r.db.QueryContext(ctx, query, id)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
+		return nil, err
+	}
+	defer rows.Close()
+
+	var product *models.Product
+	for rows.Next() {
+		var row models.Product
+		var variantID, variantAttributes sql.NullString
+		var variantPrice sql.NullFloat64
+		var variantStock sql.NullInt64
+		var variantCreatedAt sql.NullTime
+		err := rows.Scan(
+			&row.ID,
+			&row.Name,
+			&row.Description,
+			&row.Price,
+			&row.Stock,
+			&row.StockThreshold,
+			&row.ImageURL,
+			&row.ExternalID,
+			&row.CreatedAt,
+			&row.UpdatedAt,
+			&variantID,
+			&variantAttributes,
+			&variantPrice,
+			&variantStock,
+			&variantCreatedAt,
+		)
+		if err != nil {
+			return nil, err
 		}
+
+		if product == nil {
+			product = &row
+		}
+		if !variantID.Valid {
+			continue
+		}
+
+		variant := &models.ProductVariant{
+			ID:        variantID.String,
+			ProductID: product.ID,
+			Price:     variantPrice.Float64,
+			Stock:     int(variantStock.Int64),
+			CreatedAt: variantCreatedAt.Time,
+		}
+		if err := json.Unmarshal([]byte(variantAttributes.String), &variant.Attributes); err != nil {
+			return nil, err
+		}
+		product.Variants = append(product.Variants, variant)
+	}
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+	if product == nil {
+		return nil, nil
+	}
 
-	return &product, nil
+	if err := r.loadTags(ctx, []*models.Product{product}); err != nil {
+		return nil, err
+	}
+	return product, nil
 }
 
 // FindAll retrieves all products with pagination
 func (r *ProductRepository) FindAll(ctx context.Context, limit, offset int) ([]*models.Product, error) {
 	query := `
-		SELECT id, name, description, price, stock, created_at, updated_at
+		SELECT id, name, description, price, stock, stock_threshold, image_url, COALESCE(external_id, ''), created_at, updated_at
 		FROM products
 		ORDER BY created_at DESC
 		LIMIT ? OFFSET ?
@@ -70,6 +169,9 @@ func (r *ProductRepository) FindAll(ctx context.Context, limit, offset int) ([]*
 			&product.Description,
 			&product.Price,
 			&product.Stock,
+			&product.StockThreshold,
+			&product.ImageURL,
+			&product.ExternalID,
 			&product.CreatedAt,
 			&product.UpdatedAt,
 		)
@@ -78,10 +180,199 @@ func (r *ProductRepository) FindAll(ctx context.Context, limit, offset int) ([]*
 		}
 		products = append(products, &product)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
+	if err := r.loadTags(ctx, products); err != nil {
+		return nil, err
+	}
 	return products, nil
 }
 
+// FindAfterID retrieves up to limit products with an ID greater than afterID, ordered by ID
+// Pass an empty afterID for the first batch and the last returned ID for the next ones
+func (r *ProductRepository) FindAfterID(ctx context.Context, afterID string, limit int) ([]*models.Product, error) {
+	query := `
+		SELECT id, name, description, price, stock, stock_threshold, image_url, COALESCE(external_id, ''), created_at, updated_at
+		FROM products
+		WHERE id > ?
+		ORDER BY id
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var products []*models.Product
+	for rows.Next() {
+		var product models.Product
+		err := rows.Scan(
+			&product.ID,
+			&product.Name,
+			&product.Description,
+			&product.Price,
+			&product.Stock,
+			&product.StockThreshold,
+			&product.ImageURL,
+			&product.ExternalID,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, &product)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadTags(ctx, products); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// FindByIds retrieves the products with the given IDs in a single query
+// Results follow the order of ids; IDs that do not exist are skipped
+func (r *ProductRepository) FindByIds(ctx context.Context, ids []string) ([]*models.Product, error) {
+	if len(ids) == 0 {
+		return []*models.Product{}, nil
+	}
+
+	placeholders, args := inClause(ids)
+	query := `
+		SELECT id, name, description, price, stock, stock_threshold, image_url, COALESCE(external_id, ''), created_at, updated_at
+		FROM products
+		WHERE id IN (` + placeholders + `)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byID := make(map[string]*models.Product, len(ids))
+	for rows.Next() {
+		var product models.Product
+		err := rows.Scan(
+			&product.ID,
+			&product.Name,
+			&product.Description,
+			&product.Price,
+			&product.Stock,
+			&product.StockThreshold,
+			&product.ImageURL,
+			&product.ExternalID,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		byID[product.ID] = &product
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	products := make([]*models.Product, 0, len(byID))
+	for _, id := range ids {
+		if product, ok := byID[id]; ok {
+			products = append(products, product)
+		}
+	}
+
+	if err := r.loadTags(ctx, products); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// ExistsByIds reports which of the given IDs exist, using a single query
+// Every requested ID is present in the result
+func (r *ProductRepository) ExistsByIds(ctx context.Context, ids []string) (map[string]bool, error) {
+	exists := make(map[string]bool, len(ids))
+	if len(ids) == 0 {
+		return exists, nil
+	}
+	for _, id := range ids {
+		exists[id] = false
+	}
+
+	placeholders, args := inClause(ids)
+	query := `SELECT id FROM products WHERE id IN (` + placeholders + `)`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		exists[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return exists, nil
+}
+
+// inClause builds the "?,?,..." placeholder list and arguments for the distinct ids
+func inClause(ids []string) (string, []any) {
+	seen := make(map[string]struct{}, len(ids))
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		args = append(args, id)
+	}
+	return strings.TrimSuffix(strings.Repeat("?,", len(args)), ","), args
+}
+
+// productFilterColumns are the columns accepted by CountWhere
+var productFilterColumns = []string{"id", "name", "description", "price", "stock"}
+
+// CountWhere returns the number of products matching every condition (column = value)
+// Only columns in productFilterColumns are accepted
+func (r *ProductRepository) CountWhere(ctx context.Context, conditions map[string]any) (int, error) {
+	where, args, err := db.BuildWhereClause(productFilterColumns, conditions)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `SELECT COUNT(*) FROM products ` + where
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountSearch returns the number of products whose name or description contains query
+func (r *ProductRepository) CountSearch(ctx context.Context, query string) (int, error) {
+	pattern := "%" + db.EscapeLike(query) + "%"
+	sqlQuery := `SELECT COUNT(*) FROM products WHERE name LIKE ? ESCAPE '!' OR description LIKE ? ESCAPE '!'`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, sqlQuery, pattern, pattern).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 // Count returns the total number of products
 func (r *ProductRepository) Count(ctx context.Context) (int, error) {
 	query := `SELECT COUNT(*) FROM products`
@@ -93,50 +384,275 @@ func (r *ProductRepository) Count(ctx context.Context) (int, error) {
 	return count, nil
 }
 
-// Save creates a new product
+// CountCached returns the product count stored by StoreCachedCount, or -1 while the
+// cache is cold; the value lags behind writes until the next aggregation
+func (r *ProductRepository) CountCached() int64 {
+	return r.cachedCount.Load()
+}
+
+// StoreCachedCount stores the product count returned by CountCached
+func (r *ProductRepository) StoreCachedCount(count int64) {
+	r.cachedCount.Store(count)
+}
+
+// InventoryStats returns the average price over all products (0 when there are none)
+// and how many products have no stock left
+func (r *ProductRepository) InventoryStats(ctx context.Context) (float64, int, error) {
+	query := `SELECT COALESCE(AVG(price), 0), COUNT(CASE WHEN stock <= 0 THEN 1 END) FROM products`
+	var averagePrice float64
+	var outOfStock int
+	if err := r.db.QueryRowContext(ctx, query).Scan(&averagePrice, &outOfStock); err != nil {
+		return 0, 0, err
+	}
+	return averagePrice, outOfStock, nil
+}
+
+// Save creates a new product and its tag associations in a single transaction
 func (r *ProductRepository) Save(ctx context.Context, product *models.Product) error {
 	query := `
-		INSERT INTO products (id, name, description, price, stock, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO products (id, name, description, price, stock, stock_threshold, image_url, external_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	_, err := r.db.ExecContext(
-		ctx,
-		query,
-		product.ID,
-		product.Name,
-		product.Description,
-		product.Price,
-		product.Stock,
-		product.CreatedAt,
-		product.UpdatedAt,
-	)
+	return r.inTransaction(ctx, func(repo *ProductRepository) error {
+		_, err := repo.db.ExecContext(
+			ctx,
+			query,
+			product.ID,
+			product.Name,
+			product.Description,
+			product.Price,
+			product.Stock,
+			product.StockThreshold,
+			product.ImageURL,
+			externalIDValue(product.ExternalID),
+			product.CreatedAt,
+			product.UpdatedAt,
+		)
+		if isDuplicateKeyError(err) {
+			return sharedErrors.ErrConflict
+		}
+		if err != nil {
+			return err
+		}
 
-	return err
+		return repo.replaceTags(ctx, product.ID, product.Tags)
+	})
+}
+
+// productInsertColumns are the columns written by BulkCreate
+var productInsertColumns = []string{"id", "name", "description", "price", "stock", "stock_threshold", "image_url", "external_id", "created_at", "updated_at"}
+
+// externalIDValue stores an empty external ID as NULL, since the unique index allows
+// any number of NULLs but only one empty string
+func externalIDValue(externalID string) any {
+	if externalID == "" {
+		return nil
+	}
+	return externalID
+}
+
+// FindIDByExternalID returns the ID of the product imported with externalID ("" when there is none)
+func (r *ProductRepository) FindIDByExternalID(ctx context.Context, externalID string) (string, error) {
+	var id string
+	err := r.db.QueryRowContext(ctx, `SELECT id FROM products WHERE external_id = ?`, externalID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return id, err
 }
 
-// Update modifies an existing product
+// BulkCreate creates products with one multi-row INSERT, plus their tag associations,
+// in a single transaction (so either every product is created or none)
+func (r *ProductRepository) BulkCreate(ctx context.Context, products []*models.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	rows := make([][]any, 0, len(products))
+	for _, product := range products {
+		rows = append(rows, []any{
+			product.ID,
+			product.Name,
+			product.Description,
+			product.Price,
+			product.Stock,
+			product.StockThreshold,
+			product.ImageURL,
+			externalIDValue(product.ExternalID),
+			product.CreatedAt,
+			product.UpdatedAt,
+		})
+	}
+
+	return r.inTransaction(ctx, func(repo *ProductRepository) error {
+		err := db.BatchInsert(ctx, repo.db, "products", productInsertColumns, rows)
+		if isDuplicateKeyError(err) {
+			return sharedErrors.ErrConflict
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, product := range products {
+			if len(product.Tags) == 0 {
+				continue
+			}
+			if err := repo.replaceTags(ctx, product.ID, product.Tags); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// isDuplicateKeyError reports whether err is a MySQL duplicate entry error (1062)
+func isDuplicateKeyError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry
+}
+
+// Update modifies an existing product and replaces its tag associations in a single transaction
+// A price change is recorded in product_price_history within the same transaction
 func (r *ProductRepository) Update(ctx context.Context, product *models.Product) error {
 	query := `
 		UPDATE products
-		SET name = ?, description = ?, price = ?, stock = ?, updated_at = ?
+		SET name = ?, description = ?, price = ?, stock = ?, stock_threshold = ?, image_url = ?, updated_at = ?
 		WHERE id = ?
 	`
 
-	_, err := r.db.ExecContext(
-		ctx,
-		query,
-		product.Name,
-		product.Description,
-		product.Price,
-		product.Stock,
-		product.UpdatedAt,
-		product.ID,
-	)
+	return r.inTransaction(ctx, func(repo *ProductRepository) error {
+		oldPrice, found, err := repo.lockedPrice(ctx, product.ID)
+		if err != nil {
+			return err
+		}
+		if found && oldPrice != product.Price {
+			if err := repo.recordPriceChange(ctx, product.ID, oldPrice, product.Price); err != nil {
+				return err
+			}
+		}
+
+		_, err = repo.db.ExecContext(
+			ctx,
+			query,
+			product.Name,
+			product.Description,
+			product.Price,
+			product.Stock,
+			product.StockThreshold,
+			product.ImageURL,
+			product.UpdatedAt,
+			product.ID,
+		)
+		if err != nil {
+			return err
+		}
+
+		return repo.replaceTags(ctx, product.ID, product.Tags)
+	})
+}
+
+// lockRow locks the product row until the transaction ends, so concurrent writes of the
+// product are serialized (a no-op UPDATE also works on SQLite, unlike SELECT ... FOR UPDATE)
+func (r *ProductRepository) lockRow(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE products SET price = price WHERE id = ?`, id)
+	return err
+}
+
+// lockedPrice locks the product row and returns its current price, so concurrent updates
+// record consecutive old/new prices; found is false when the product does not exist
+func (r *ProductRepository) lockedPrice(ctx context.Context, id string) (price float64, found bool, err error) {
+	if err := r.lockRow(ctx, id); err != nil {
+		return 0, false, err
+	}
+	err = r.db.QueryRowContext(ctx, `SELECT price FROM products WHERE id = ?`, id).Scan(&price)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return price, true, nil
+}
+
+// FindByIdForUpdate is FindById locking the product row until the transaction ends
+// Must run inside Transactional, otherwise the lock is released right away
+func (r *ProductRepository) FindByIdForUpdate(ctx context.Context, id string) (*models.Product, error) {
+	if err := r.lockRow(ctx, id); err != nil {
+		return nil, err
+	}
+	return r.FindById(ctx, id)
+}
 
+// recordPriceChange inserts a product_price_history entry
+func (r *ProductRepository) recordPriceChange(ctx context.Context, productID string, oldPrice, newPrice float64) error {
+	query := `
+		INSERT INTO product_price_history (id, product_id, old_price, new_price, changed_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query, shared.GenerateId(), productID, oldPrice, newPrice, time.Now().UTC())
 	return err
 }
 
+// Upsert inserts the product or, if its ID already exists, replaces every column except
+// id and created_at in a single statement; tag associations are not changed
+// A price change of an existing product is recorded in product_price_history within the same transaction
+func (r *ProductRepository) Upsert(ctx context.Context, product *models.Product) (*models.UpsertResult, error) {
+	query := `
+		INSERT INTO products (id, name, description, price, stock, stock_threshold, image_url, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			name = VALUES(name),
+			description = VALUES(description),
+			price = VALUES(price),
+			stock = VALUES(stock),
+			stock_threshold = VALUES(stock_threshold),
+			image_url = VALUES(image_url),
+			updated_at = VALUES(updated_at)
+	`
+
+	var upsertResult *models.UpsertResult
+	err := r.inTransaction(ctx, func(repo *ProductRepository) error {
+		oldPrice, found, err := repo.lockedPrice(ctx, product.ID)
+		if err != nil {
+			return err
+		}
+
+		result, err := repo.db.ExecContext(
+			ctx,
+			query,
+			product.ID,
+			product.Name,
+			product.Description,
+			product.Price,
+			product.Stock,
+			product.StockThreshold,
+			product.ImageURL,
+			product.CreatedAt,
+			product.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		// MySQL reports 1 affected row for an insert, 2 for an update and 0 for an unchanged row
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		upsertResult = &models.UpsertResult{WasInserted: affected == 1}
+
+		if found && oldPrice != product.Price {
+			return repo.recordPriceChange(ctx, product.ID, oldPrice, product.Price)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return upsertResult, nil
+}
+
 // Delete removes a product by ID
 func (r *ProductRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM products WHERE id = ?`
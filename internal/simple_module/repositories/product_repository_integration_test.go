@@ -0,0 +1,126 @@
+//go:build integration
+
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	sharedErrors "github.com/refortunato/go_app_base/internal/shared/errors"
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+	"github.com/refortunato/go_app_base/internal/simple_module/models"
+)
+
+// Upsert uses MySQL's INSERT ... ON DUPLICATE KEY UPDATE, so it is tested against a MySQL container
+func TestProductRepository_Upsert(t *testing.T) {
+	ctx := context.Background()
+	db := testhelpers.NewMySQLForTest(t)
+	repo := NewProductRepository(db)
+	history := NewPriceHistoryRepository(db)
+
+	createdAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	product := &models.Product{
+		ID:             uuid.NewString(),
+		Name:           "Laptop",
+		Description:    "15 inch",
+		Price:          100,
+		Stock:          10,
+		StockThreshold: 3,
+		ImageURL:       "https://cdn.example.com/laptop.jpg",
+		CreatedAt:      createdAt,
+		UpdatedAt:      createdAt,
+	}
+
+	t.Run("inserts a new product", func(t *testing.T) {
+		result, err := repo.Upsert(ctx, product)
+		if err != nil {
+			t.Fatalf("Upsert: %v", err)
+		}
+		if !result.WasInserted {
+			t.Fatal("expected an insert")
+		}
+		if count, _ := history.CountByProductId(ctx, product.ID); count != 0 {
+			t.Errorf("price history after insert = %d entries, want 0", count)
+		}
+	})
+
+	t.Run("replaces every column except id and created_at", func(t *testing.T) {
+		replacement := &models.Product{
+			ID:             product.ID,
+			Name:           "Laptop Pro",
+			Description:    "16 inch",
+			Price:          120,
+			Stock:          0,
+			StockThreshold: 5,
+			ImageURL:       "",
+			CreatedAt:      createdAt.Add(time.Hour),
+			UpdatedAt:      createdAt.Add(2 * time.Hour),
+		}
+		result, err := repo.Upsert(ctx, replacement)
+		if err != nil {
+			t.Fatalf("Upsert: %v", err)
+		}
+		if result.WasInserted {
+			t.Fatal("expected an update")
+		}
+
+		stored, err := repo.FindById(ctx, product.ID)
+		if err != nil || stored == nil {
+			t.Fatalf("FindById: %v, %v", stored, err)
+		}
+		if stored.Name != "Laptop Pro" || stored.Description != "16 inch" || stored.Price != 120 || stored.Stock != 0 {
+			t.Errorf("stored product = %+v", stored)
+		}
+		if stored.StockThreshold != 5 || stored.ImageURL != "" {
+			t.Errorf("stock_threshold, image_url = %d, %q, want 5 and empty", stored.StockThreshold, stored.ImageURL)
+		}
+		if !stored.CreatedAt.Equal(createdAt) {
+			t.Errorf("created_at = %v, want the original %v", stored.CreatedAt, createdAt)
+		}
+		if !stored.UpdatedAt.Equal(replacement.UpdatedAt) {
+			t.Errorf("updated_at = %v, want %v", stored.UpdatedAt, replacement.UpdatedAt)
+		}
+
+		entries, err := history.FindByProductId(ctx, product.ID, 10, 0)
+		if err != nil {
+			t.Fatalf("FindByProductId: %v", err)
+		}
+		if len(entries) != 1 || entries[0].OldPrice != 100 || entries[0].NewPrice != 120 {
+			t.Fatalf("price history = %+v, want one 100 -> 120 entry", entries)
+		}
+	})
+
+	t.Run("unchanged price records no history", func(t *testing.T) {
+		stored, err := repo.FindById(ctx, product.ID)
+		if err != nil || stored == nil {
+			t.Fatalf("FindById: %v, %v", stored, err)
+		}
+		stored.Stock = 7
+		if _, err := repo.Upsert(ctx, stored); err != nil {
+			t.Fatalf("Upsert: %v", err)
+		}
+		if count, _ := history.CountByProductId(ctx, product.ID); count != 1 {
+			t.Errorf("price history = %d entries, want 1", count)
+		}
+	})
+}
+
+// Duplicate keys are detected from MySQL's error number, so they are tested against a MySQL container
+func TestProductRepository_SaveDuplicateIDIsConflict(t *testing.T) {
+	ctx := context.Background()
+	repo := NewProductRepository(testhelpers.NewMySQLForTest(t))
+
+	now := time.Now().UTC()
+	product := &models.Product{ID: uuid.NewString(), Name: "Laptop", Price: 100, Stock: 10, CreatedAt: now, UpdatedAt: now}
+	if err := repo.Save(ctx, product); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	duplicate := *product
+	duplicate.Name = "Laptop Pro"
+	if err := repo.Save(ctx, &duplicate); err != sharedErrors.ErrConflict {
+		t.Errorf("Save with a duplicate id: err = %v, want ErrConflict", err)
+	}
+}
@@ -0,0 +1,227 @@
+//go:build sqlite
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/shared/db"
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+	"github.com/refortunato/go_app_base/internal/simple_module/models"
+)
+
+// seedProducts saves n products with IDs product-1 to product-n
+func seedProducts(t *testing.T, repo *ProductRepository, n int) {
+	t.Helper()
+	now := time.Now().UTC()
+	for i := 1; i <= n; i++ {
+		product := &models.Product{
+			ID:        fmt.Sprintf("product-%d", i),
+			Name:      fmt.Sprintf("Product %d", i),
+			Price:     float64(i * 10),
+			Stock:     i,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := repo.Save(context.Background(), product); err != nil {
+			t.Fatalf("Save %s: %v", product.ID, err)
+		}
+	}
+}
+
+func TestProductRepository_FindByIds(t *testing.T) {
+	repo := NewProductRepository(testhelpers.NewSQLiteForTest(t))
+	seedProducts(t, repo, 5)
+
+	tests := []struct {
+		name string
+		ids  []string
+		want []string
+	}{
+		{name: "input order", ids: []string{"product-4", "product-1", "product-3"}, want: []string{"product-4", "product-1", "product-3"}},
+		{name: "missing ids are skipped", ids: []string{"product-2", "unknown", "product-5"}, want: []string{"product-2", "product-5"}},
+		{name: "duplicates", ids: []string{"product-1", "product-1"}, want: []string{"product-1", "product-1"}},
+		{name: "empty", ids: nil, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products, err := repo.FindByIds(context.Background(), tt.ids)
+			if err != nil {
+				t.Fatalf("FindByIds: %v", err)
+			}
+			var got []string
+			for _, p := range products {
+				got = append(got, p.ID)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("ids = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	products, _ := repo.FindByIds(context.Background(), []string{"product-3"})
+	if p := products[0]; p.Name != "Product 3" || p.Price != 30 || p.Stock != 3 {
+		t.Errorf("product = %+v, want every column loaded", p)
+	}
+}
+
+func TestProductRepository_ExistsByIds(t *testing.T) {
+	repo := NewProductRepository(testhelpers.NewSQLiteForTest(t))
+	seedProducts(t, repo, 5)
+
+	exists, err := repo.ExistsByIds(context.Background(), []string{"product-2", "unknown", "product-5"})
+	if err != nil {
+		t.Fatalf("ExistsByIds: %v", err)
+	}
+	want := map[string]bool{"product-2": true, "unknown": false, "product-5": true}
+	if fmt.Sprint(exists) != fmt.Sprint(want) {
+		t.Errorf("exists = %v, want %v", exists, want)
+	}
+}
+
+func TestProductRepository_CountWhere(t *testing.T) {
+	repo := NewProductRepository(testhelpers.NewSQLiteForTest(t))
+	seedProducts(t, repo, 5)
+
+	tests := []struct {
+		name       string
+		conditions map[string]any
+		want       int
+	}{
+		{name: "no conditions", want: 5},
+		{name: "one column", conditions: map[string]any{"stock": 3}, want: 1},
+		{name: "every condition must match", conditions: map[string]any{"stock": 3, "price": 40}, want: 0},
+		{name: "no match", conditions: map[string]any{"name": "Unknown"}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := repo.CountWhere(context.Background(), tt.conditions)
+			if err != nil {
+				t.Fatalf("CountWhere: %v", err)
+			}
+			if count != tt.want {
+				t.Errorf("count = %d, want %d", count, tt.want)
+			}
+		})
+	}
+
+	if _, err := repo.CountWhere(context.Background(), map[string]any{"created_at": "2024-01-01"}); !errors.Is(err, db.ErrColumnNotAllowed) {
+		t.Errorf("CountWhere on a column outside the allowlist: err = %v, want ErrColumnNotAllowed", err)
+	}
+}
+
+func TestProductRepository_CountSearch(t *testing.T) {
+	repo := NewProductRepository(testhelpers.NewSQLiteForTest(t))
+	seedProducts(t, repo, 5)
+	now := time.Now().UTC()
+	special := &models.Product{ID: "special", Name: "100% cotton", Description: "t_shirt", CreatedAt: now, UpdatedAt: now}
+	if err := repo.Save(context.Background(), special); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{query: "Product", want: 5},
+		{query: "Product 3", want: 1},
+		{query: "t_shirt", want: 1},
+		// Wildcards in the query are matched literally
+		{query: "%", want: 1},
+		{query: "_", want: 1},
+		{query: "missing", want: 0},
+	}
+	for _, tt := range tests {
+		count, err := repo.CountSearch(context.Background(), tt.query)
+		if err != nil {
+			t.Fatalf("CountSearch(%q): %v", tt.query, err)
+		}
+		if count != tt.want {
+			t.Errorf("CountSearch(%q) = %d, want %d", tt.query, count, tt.want)
+		}
+	}
+}
+
+func TestProductRepository_SaveFindUpdateDelete(t *testing.T) {
+	ctx := context.Background()
+	repo := NewProductRepository(testhelpers.NewSQLiteForTest(t))
+	seedProducts(t, repo, 2)
+
+	product, err := repo.FindById(ctx, "product-1")
+	if err != nil || product == nil {
+		t.Fatalf("FindById = %v, %v, want the saved product", product, err)
+	}
+	if product.Name != "Product 1" || product.Price != 10 || product.Stock != 1 {
+		t.Errorf("found %+v, want the saved fields", product)
+	}
+
+	product.Name = "Renamed"
+	product.Price = 15
+	if err := repo.Update(ctx, product); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if updated, _ := repo.FindById(ctx, "product-1"); updated.Name != "Renamed" || updated.Price != 15 {
+		t.Errorf("after Update: %+v, want the new name and price", updated)
+	}
+
+	if err := repo.Delete(ctx, "product-1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if deleted, err := repo.FindById(ctx, "product-1"); err != nil || deleted != nil {
+		t.Errorf("FindById after Delete = %v, %v, want nil, nil", deleted, err)
+	}
+	if count, err := repo.Count(ctx); err != nil || count != 1 {
+		t.Errorf("Count = %d, %v, want 1", count, err)
+	}
+}
+
+func TestProductRepository_BulkCreate(t *testing.T) {
+	ctx := context.Background()
+	repo := NewProductRepository(testhelpers.NewSQLiteForTest(t))
+	now := time.Now().UTC()
+	products := make([]*models.Product, 250)
+	for i := range products {
+		products[i] = &models.Product{
+			ID:         fmt.Sprintf("bulk-%03d", i),
+			Name:       fmt.Sprintf("Product %d", i),
+			Price:      10,
+			Stock:      i,
+			ExternalID: fmt.Sprintf("ext-%d", i),
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+	}
+
+	// Like ImportProducts: one BulkCreate per batch
+	err := db.BatchProcess(ctx, products, 100, func(ctx context.Context, batch []*models.Product) error {
+		return repo.BulkCreate(ctx, batch)
+	})
+	if err != nil {
+		t.Fatalf("BulkCreate: %v", err)
+	}
+	if count, _ := repo.Count(ctx); count != 250 {
+		t.Errorf("products = %d, want 250", count)
+	}
+	if found, _ := repo.FindById(ctx, "bulk-249"); found == nil || found.Stock != 249 {
+		t.Errorf("bulk-249 = %+v, want the last product", found)
+	}
+	if id, _ := repo.FindIDByExternalID(ctx, "ext-123"); id != "bulk-123" {
+		t.Errorf("external ID lookup = %q, want bulk-123", id)
+	}
+
+	// A batch with a duplicate ID is rolled back as a whole
+	duplicate := []*models.Product{
+		{ID: "bulk-new", Name: "New", CreatedAt: now, UpdatedAt: now},
+		{ID: "bulk-000", Name: "Duplicate", CreatedAt: now, UpdatedAt: now},
+	}
+	if err := repo.BulkCreate(ctx, duplicate); err == nil {
+		t.Fatal("BulkCreate with a duplicate ID: expected an error")
+	}
+	if found, _ := repo.FindById(ctx, "bulk-new"); found != nil {
+		t.Error("product of the failed batch was kept")
+	}
+}
@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/shared"
+	"github.com/refortunato/go_app_base/internal/simple_module/models"
+)
+
+// inTransaction runs fn in the current transaction, or in a new one when the
+// repository is not already bound to a transaction
+func (r *ProductRepository) inTransaction(ctx context.Context, fn func(repo *ProductRepository) error) error {
+	if _, ok := r.db.(*sql.Tx); ok {
+		return fn(r)
+	}
+	return r.Transactional(ctx, fn)
+}
+
+// FilterByTag retrieves the products labeled with tag, with pagination
+func (r *ProductRepository) FilterByTag(ctx context.Context, tag string, limit, offset int) ([]*models.Product, error) {
+	query := `
+		SELECT p.id, p.name, p.description, p.price, p.stock, p.stock_threshold, p.image_url, COALESCE(p.external_id, ''), p.created_at, p.updated_at
+		FROM products p
+		JOIN product_tags pt ON pt.product_id = p.id
+		JOIN tags t ON t.id = pt.tag_id
+		WHERE t.name = ?
+		ORDER BY p.created_at DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tag, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var products []*models.Product
+	for rows.Next() {
+		var product models.Product
+		err := rows.Scan(
+			&product.ID,
+			&product.Name,
+			&product.Description,
+			&product.Price,
+			&product.Stock,
+			&product.StockThreshold,
+			&product.ImageURL,
+			&product.ExternalID,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, &product)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadTags(ctx, products); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// CountByTag returns the number of products labeled with tag
+func (r *ProductRepository) CountByTag(ctx context.Context, tag string) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM product_tags pt
+		JOIN tags t ON t.id = pt.tag_id
+		WHERE t.name = ?
+	`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, tag).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// AddTags labels the product with tags, creating missing tags
+// Tags already associated with the product are left untouched
+func (r *ProductRepository) AddTags(ctx context.Context, productID string, tags []string) error {
+	return r.inTransaction(ctx, func(repo *ProductRepository) error {
+		for _, tag := range tags {
+			if err := repo.addTag(ctx, productID, tag); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RemoveTag removes the tag from the product; removing a missing tag is not an error
+func (r *ProductRepository) RemoveTag(ctx context.Context, productID, tag string) error {
+	query := `
+		DELETE FROM product_tags
+		WHERE product_id = ? AND tag_id = (SELECT id FROM tags WHERE name = ?)
+	`
+	_, err := r.db.ExecContext(ctx, query, productID, tag)
+	return err
+}
+
+// replaceTags makes tags the complete set of tags of the product
+// Must run inside a transaction so the product and its tags change together
+func (r *ProductRepository) replaceTags(ctx context.Context, productID string, tags []string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM product_tags WHERE product_id = ?`, productID); err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		if err := r.addTag(ctx, productID, tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addTag associates a single tag with the product, creating the tag if needed
+func (r *ProductRepository) addTag(ctx context.Context, productID, tag string) error {
+	tagID, err := r.ensureTag(ctx, tag)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO product_tags (product_id, tag_id)
+		VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE tag_id = tag_id
+	`
+	_, err = r.db.ExecContext(ctx, query, productID, tagID)
+	return err
+}
+
+// ensureTag returns the ID of the tag with the given name, inserting it when missing
+func (r *ProductRepository) ensureTag(ctx context.Context, name string) (string, error) {
+	insert := `
+		INSERT INTO tags (id, name, created_at)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE name = name
+	`
+	if _, err := r.db.ExecContext(ctx, insert, shared.GenerateId(), name, time.Now().UTC()); err != nil {
+		return "", err
+	}
+
+	var id string
+	if err := r.db.QueryRowContext(ctx, `SELECT id FROM tags WHERE name = ?`, name).Scan(&id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// loadTags populates the Tags of each product using a single query
+func (r *ProductRepository) loadTags(ctx context.Context, products []*models.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(products))
+	byID := make(map[string]*models.Product, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID)
+		byID[product.ID] = product
+	}
+
+	placeholders, args := inClause(ids)
+	query := `
+		SELECT pt.product_id, t.name
+		FROM product_tags pt
+		JOIN tags t ON t.id = pt.tag_id
+		WHERE pt.product_id IN (` + placeholders + `)
+		ORDER BY t.name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID, name string
+		if err := rows.Scan(&productID, &name); err != nil {
+			return err
+		}
+		if product, ok := byID[productID]; ok {
+			product.Tags = append(product.Tags, name)
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,196 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/simple_module/models"
+)
+
+// ProductVariantRepository handles database operations for product variants
+// Every write also sets the product stock to the sum of its variant stock, in the same transaction
+// The product's own stock is kept in products.base_stock while it has variants and restored
+// when the last variant is deleted
+type ProductVariantRepository struct {
+	db *sql.DB
+}
+
+// NewProductVariantRepository creates a new product variant repository instance
+func NewProductVariantRepository(db *sql.DB) *ProductVariantRepository {
+	return &ProductVariantRepository{db: db}
+}
+
+// Create inserts the variant and returns the new aggregate stock of its product
+func (r *ProductVariantRepository) Create(ctx context.Context, variant *models.ProductVariant) (int, error) {
+	attributes, err := json.Marshal(variant.Attributes)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		INSERT INTO product_variants (id, product_id, attributes, price, stock, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	return r.withStockSync(ctx, variant.ProductID, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(
+			ctx,
+			query,
+			variant.ID,
+			variant.ProductID,
+			string(attributes),
+			variant.Price,
+			variant.Stock,
+			variant.CreatedAt,
+		)
+		return err
+	})
+}
+
+// FindById retrieves a variant of the product, returning nil when it does not exist
+func (r *ProductVariantRepository) FindById(ctx context.Context, productID, id string) (*models.ProductVariant, error) {
+	query := `
+		SELECT id, product_id, attributes, price, stock, created_at
+		FROM product_variants
+		WHERE product_id = ? AND id = ?
+	`
+
+	variant, err := scanVariant(r.db.QueryRowContext(ctx, query, productID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return variant, err
+}
+
+// FindByProductId retrieves the variants of a product, oldest first
+func (r *ProductVariantRepository) FindByProductId(ctx context.Context, productID string) ([]*models.ProductVariant, error) {
+	query := `
+		SELECT id, product_id, attributes, price, stock, created_at
+		FROM product_variants
+		WHERE product_id = ?
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	variants := []*models.ProductVariant{}
+	for rows.Next() {
+		variant, err := scanVariant(rows)
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, variant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return variants, nil
+}
+
+// Update replaces the attributes, price and stock of the variant and returns the new aggregate stock
+func (r *ProductVariantRepository) Update(ctx context.Context, variant *models.ProductVariant) (int, error) {
+	attributes, err := json.Marshal(variant.Attributes)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		UPDATE product_variants
+		SET attributes = ?, price = ?, stock = ?
+		WHERE product_id = ? AND id = ?
+	`
+
+	return r.withStockSync(ctx, variant.ProductID, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, query, string(attributes), variant.Price, variant.Stock, variant.ProductID, variant.ID)
+		return err
+	})
+}
+
+// Delete removes a variant of the product and returns the new product stock
+// (the stock the product had before its first variant when no variant is left)
+func (r *ProductVariantRepository) Delete(ctx context.Context, productID, id string) (int, error) {
+	query := `DELETE FROM product_variants WHERE product_id = ? AND id = ?`
+
+	return r.withStockSync(ctx, productID, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, query, productID, id)
+		return err
+	})
+}
+
+// withStockSync runs fn and then sets the product stock to the sum of its variant stock,
+// bumping updated_at so cached product responses are invalidated, all in one transaction
+// The first variant saves the product stock in base_stock; when no variant is left the product
+// stock is restored from base_stock
+func (r *ProductVariantRepository) withStockSync(ctx context.Context, productID string, fn func(tx *sql.Tx) error) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	stock, err := func() (int, error) {
+		if err := fn(tx); err != nil {
+			return 0, err
+		}
+
+		var variants, stock int
+		err := tx.QueryRowContext(ctx, `SELECT COUNT(*), COALESCE(SUM(stock), 0) FROM product_variants WHERE product_id = ?`, productID).Scan(&variants, &stock)
+		if err != nil {
+			return 0, err
+		}
+
+		if variants == 0 {
+			// base_stock is read before it is cleared (MySQL applies the assignments left to right)
+			_, err = tx.ExecContext(ctx, `UPDATE products SET stock = COALESCE(base_stock, stock), base_stock = NULL, updated_at = ? WHERE id = ?`, time.Now().UTC(), productID)
+			if err != nil {
+				return 0, err
+			}
+			err = tx.QueryRowContext(ctx, `SELECT stock FROM products WHERE id = ?`, productID).Scan(&stock)
+			return stock, err
+		}
+
+		// base_stock is set before stock is overwritten (MySQL applies the assignments left to right)
+		_, err = tx.ExecContext(ctx, `UPDATE products SET base_stock = COALESCE(base_stock, stock), stock = ?, updated_at = ? WHERE id = ?`, stock, time.Now().UTC(), productID)
+		return stock, err
+	}()
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return 0, errors.Join(err, rbErr)
+		}
+		return 0, err
+	}
+
+	return stock, tx.Commit()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanVariant reads a product_variants row selected as id, product_id, attributes, price, stock, created_at
+func scanVariant(row rowScanner) (*models.ProductVariant, error) {
+	var variant models.ProductVariant
+	var attributes []byte
+	err := row.Scan(
+		&variant.ID,
+		&variant.ProductID,
+		&attributes,
+		&variant.Price,
+		&variant.Stock,
+		&variant.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(attributes, &variant.Attributes); err != nil {
+		return nil, err
+	}
+	return &variant, nil
+}
@@ -1,30 +1,131 @@
 package simple_module
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/refortunato/go_app_base/internal/shared/auth"
 	"github.com/refortunato/go_app_base/internal/shared/web/context"
+	"github.com/refortunato/go_app_base/internal/shared/web/middleware"
+	"github.com/refortunato/go_app_base/internal/shared/web/routes"
 )
 
+// createProductSchemaPath is relative to the working directory (the repository root, or /app in the image)
+const createProductSchemaPath = "internal/simple_module/schemas/CreateProductSchema.json"
+
+// ContentTypeExceptions lists the product routes whose body is not application/json
+// (see middleware.RequireJSONContentType)
+var ContentTypeExceptions = map[string][]string{
+	"POST /products/import":    {"multipart/form-data"},
+	"POST /products/:id/image": {"multipart/form-data"},
+	"PATCH /products/:id":      {"application/merge-patch+json"},
+}
+
+// RegisterRoutes registers the module routes on group (implements module.Module)
+func (m *SimpleModule) RegisterRoutes(group *gin.RouterGroup) {
+	RegisterRoutes(group, m)
+}
+
 // RegisterRoutes registers all routes for the simple_module (4-tier architecture)
-func RegisterRoutes(router *gin.Engine, module *SimpleModule) {
+func RegisterRoutes(router gin.IRoutes, module *SimpleModule) {
+	// Tags listed by GET /routes
+	tag := routes.Tagger(router, "products")
+
 	// Product routes
-	router.GET("/products", func(ctx *gin.Context) {
+	// The POST routes creating resources reject retries of the same request (module.deduplication);
+	// PUT, PATCH and DELETE are idempotent and may be repeated
+	router.GET("/products", middleware.RequireScope(auth.ScopeProductRead), func(ctx *gin.Context) {
 		module.ProductController.ListProducts(context.NewGinContextAdapter(ctx))
 	})
+	tag(http.MethodGet, "/products")
+
+	router.GET("/products/export", middleware.RequireScope(auth.ScopeProductRead), func(ctx *gin.Context) {
+		module.ProductController.ExportProducts(context.NewGinContextAdapter(ctx))
+	})
+	tag(http.MethodGet, "/products/export")
 
-	router.GET("/products/:id", func(ctx *gin.Context) {
+	router.GET("/products/latest", middleware.RequireScope(auth.ScopeProductRead), func(ctx *gin.Context) {
+		module.ProductController.GetLatestProduct(context.NewGinContextAdapter(ctx))
+	})
+	tag(http.MethodGet, "/products/latest")
+
+	router.GET("/products/:id", middleware.RequireScope(auth.ScopeProductRead), func(ctx *gin.Context) {
 		module.ProductController.GetProduct(context.NewGinContextAdapter(ctx))
 	})
+	tag(http.MethodGet, "/products/:id")
 
-	router.POST("/products", func(ctx *gin.Context) {
+	router.POST("/products", middleware.RequireScope(auth.ScopeProductWrite), module.deduplication, middleware.JSONSchemaMiddleware(createProductSchemaPath), func(ctx *gin.Context) {
 		module.ProductController.CreateProduct(context.NewGinContextAdapter(ctx))
 	})
+	tag(http.MethodPost, "/products")
 
-	router.PUT("/products/:id", func(ctx *gin.Context) {
+	router.POST("/products/import", middleware.RequireScope(auth.ScopeProductWrite), module.deduplication, func(ctx *gin.Context) {
+		module.ProductController.ImportProducts(context.NewGinContextAdapter(ctx))
+	})
+	tag(http.MethodPost, "/products/import")
+
+	router.PUT("/products", middleware.RequireScope(auth.ScopeProductWrite), func(ctx *gin.Context) {
+		module.ProductController.UpsertProduct(context.NewGinContextAdapter(ctx))
+	})
+	tag(http.MethodPut, "/products")
+
+	router.PUT("/products/:id", middleware.RequireScope(auth.ScopeProductWrite), func(ctx *gin.Context) {
 		module.ProductController.UpdateProduct(context.NewGinContextAdapter(ctx))
 	})
+	tag(http.MethodPut, "/products/:id")
+
+	router.PATCH("/products/:id", middleware.RequireScope(auth.ScopeProductWrite), middleware.RequireUUID("id"), func(ctx *gin.Context) {
+		module.ProductController.PatchProduct(context.NewGinContextAdapter(ctx))
+	})
+	tag(http.MethodPatch, "/products/:id")
 
-	router.DELETE("/products/:id", func(ctx *gin.Context) {
+	router.DELETE("/products/:id", middleware.RequireScope(auth.ScopeProductWrite), func(ctx *gin.Context) {
 		module.ProductController.DeleteProduct(context.NewGinContextAdapter(ctx))
 	})
+	tag(http.MethodDelete, "/products/:id")
+
+	router.GET("/products/:id/price-history", middleware.RequireScope(auth.ScopeProductRead), middleware.RequireUUID("id"), func(ctx *gin.Context) {
+		module.ProductController.GetPriceHistory(context.NewGinContextAdapter(ctx))
+	})
+	tag(http.MethodGet, "/products/:id/price-history")
+
+	router.GET("/products/:id/subscribe", middleware.RequireScope(auth.ScopeProductRead), middleware.RequireUUID("id"), func(ctx *gin.Context) {
+		module.ProductController.SubscribeStock(context.NewGinContextAdapter(ctx))
+	})
+	tag(http.MethodGet, "/products/:id/subscribe")
+
+	router.POST("/products/:id/image", middleware.RequireScope(auth.ScopeProductWrite), middleware.RequireUUID("id"), module.deduplication, func(ctx *gin.Context) {
+		module.ProductController.UploadProductImage(context.NewGinContextAdapter(ctx))
+	})
+	tag(http.MethodPost, "/products/:id/image")
+
+	router.POST("/products/:id/stock-threshold", middleware.RequireScope(auth.ScopeProductWrite), middleware.RequireUUID("id"), func(ctx *gin.Context) {
+		module.ProductController.SetStockThreshold(context.NewGinContextAdapter(ctx))
+	})
+	tag(http.MethodPost, "/products/:id/stock-threshold")
+
+	router.POST("/products/:id/tags", middleware.RequireScope(auth.ScopeProductWrite), middleware.RequireUUID("id"), func(ctx *gin.Context) {
+		module.ProductController.AddProductTags(context.NewGinContextAdapter(ctx))
+	})
+	tag(http.MethodPost, "/products/:id/tags")
+
+	router.DELETE("/products/:id/tags/:tag", middleware.RequireScope(auth.ScopeProductWrite), middleware.RequireUUID("id"), func(ctx *gin.Context) {
+		module.ProductController.RemoveProductTag(context.NewGinContextAdapter(ctx))
+	})
+	tag(http.MethodDelete, "/products/:id/tags/:tag")
+
+	router.POST("/products/:id/variants", middleware.RequireScope(auth.ScopeProductWrite), middleware.RequireUUID("id"), module.deduplication, func(ctx *gin.Context) {
+		module.ProductController.AddProductVariant(context.NewGinContextAdapter(ctx))
+	})
+	tag(http.MethodPost, "/products/:id/variants")
+
+	router.GET("/products/:id/variants", middleware.RequireScope(auth.ScopeProductRead), middleware.RequireUUID("id"), func(ctx *gin.Context) {
+		module.ProductController.ListProductVariants(context.NewGinContextAdapter(ctx))
+	})
+	tag(http.MethodGet, "/products/:id/variants")
+
+	router.DELETE("/products/:id/variants/:variantId", middleware.RequireScope(auth.ScopeProductWrite), middleware.RequireUUID("id"), func(ctx *gin.Context) {
+		module.ProductController.DeleteProductVariant(context.NewGinContextAdapter(ctx))
+	})
+	tag(http.MethodDelete, "/products/:id/variants/:variantId")
 }
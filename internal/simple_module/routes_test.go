@@ -0,0 +1,421 @@
+//go:build sqlite
+
+package simple_module
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/refortunato/go_app_base/configs"
+	"github.com/refortunato/go_app_base/internal/shared/auth"
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+	"github.com/refortunato/go_app_base/internal/shared/web/middleware"
+)
+
+// newTestRouter mounts the module routes over an in-memory database, like the API does
+func newTestRouter(t *testing.T, deduplicationWindow int) *gin.Engine {
+	t.Helper()
+	return newTestRouterWith(t, deduplicationWindow, middleware.AnonymousAuth())
+}
+
+// newTestRouterWith is newTestRouter running middlewares (authentication included) before the routes
+func newTestRouterWith(t *testing.T, deduplicationWindow int, middlewares ...gin.HandlerFunc) *gin.Engine {
+	t.Helper()
+	// The JSON schemas are resolved from the repository root
+	t.Chdir("../..")
+
+	cfg := &configs.Conf{
+		DeduplicationWindow:             deduplicationWindow,
+		PaginationMaxLimit:              100,
+		MaxUploadSizeMB:                 1,
+		UploadDirectory:                 t.TempDir(),
+		IDVersion:                       "v7",
+		DBCircuitBreakerMaxRequests:     1,
+		DBCircuitBreakerIntervalSeconds: 60,
+		DBCircuitBreakerTimeoutSeconds:  30,
+	}
+	module := NewSimpleModule(testhelpers.NewSQLiteForTest(t), cfg, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares...)
+	RegisterRoutes(router, module)
+	return router
+}
+
+func sendRequest(router http.Handler, method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+const testProductBody = `{"name":"Keyboard","description":"Mechanical keyboard","price":99.9,"stock":10}`
+
+func TestRoutes_DeduplicatesProductCreation(t *testing.T) {
+	router := newTestRouter(t, 10)
+
+	if w := sendRequest(router, http.MethodPost, "/products", testProductBody, nil); w.Code != http.StatusCreated {
+		t.Fatalf("first POST: status = %d, want 201: %s", w.Code, w.Body.String())
+	}
+	w := sendRequest(router, http.MethodPost, "/products", testProductBody, nil)
+	if w.Code != http.StatusConflict || w.Header().Get("X-Duplicate-Request") != "true" {
+		t.Errorf("retried POST: status = %d, want 409 with X-Duplicate-Request", w.Code)
+	}
+
+	// A distinct Idempotency-Key marks an intentional second product with the same content
+	headers := map[string]string{middleware.IdempotencyKeyHeader: "second-keyboard"}
+	if w := sendRequest(router, http.MethodPost, "/products", testProductBody, headers); w.Code != http.StatusCreated {
+		t.Errorf("POST with a new Idempotency-Key: status = %d, want 201", w.Code)
+	}
+	if w := sendRequest(router, http.MethodPost, "/products", testProductBody, headers); w.Code != http.StatusConflict {
+		t.Errorf("POST repeating the Idempotency-Key: status = %d, want 409", w.Code)
+	}
+}
+
+func TestRoutes_IdempotentMethodsAreNotDeduplicated(t *testing.T) {
+	router := newTestRouter(t, 10)
+
+	w := sendRequest(router, http.MethodPost, "/products", testProductBody, nil)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("POST: status = %d, want 201: %s", w.Code, w.Body.String())
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || created.ID == "" {
+		t.Fatalf("invalid create response %s: %v", w.Body.String(), err)
+	}
+
+	update := `{"name":"Keyboard","description":"Mechanical keyboard","price":89.9,"stock":8}`
+	threshold := `{"threshold":3}`
+	for i := 0; i < 2; i++ {
+		if w := sendRequest(router, http.MethodPut, "/products/"+created.ID, update, nil); w.Code != http.StatusOK {
+			t.Errorf("PUT /products/:id attempt %d: status = %d, want 200: %s", i, w.Code, w.Body.String())
+		}
+		// Setting the threshold is a POST, but repeating it has the same effect
+		if w := sendRequest(router, http.MethodPost, "/products/"+created.ID+"/stock-threshold", threshold, nil); w.Code != http.StatusOK {
+			t.Errorf("POST /products/:id/stock-threshold attempt %d: status = %d, want 200: %s", i, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestRoutes_DeduplicationDisabled(t *testing.T) {
+	router := newTestRouter(t, 0)
+
+	for i := 0; i < 2; i++ {
+		if w := sendRequest(router, http.MethodPost, "/products", testProductBody, nil); w.Code != http.StatusCreated {
+			t.Errorf("POST %d: status = %d, want 201 with deduplication disabled", i, w.Code)
+		}
+	}
+}
+
+func TestRoutes_RejectNonUUIDIds(t *testing.T) {
+	router := newTestRouter(t, 0)
+	const (
+		unknownV4 = "550e8400-e29b-41d4-a716-446655440000"
+		version0  = "550e8400-e29b-01d4-a716-446655440000"
+		version9  = "550e8400-e29b-91d4-a716-446655440000"
+	)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{name: "get abc", method: http.MethodGet, path: "/products/abc", want: http.StatusBadRequest},
+		{name: "get version 0", method: http.MethodGet, path: "/products/" + version0, want: http.StatusBadRequest},
+		{name: "get unknown UUID", method: http.MethodGet, path: "/products/" + unknownV4, want: http.StatusNotFound},
+		{name: "update abc", method: http.MethodPut, path: "/products/abc", body: testProductBody, want: http.StatusBadRequest},
+		{name: "update version 9", method: http.MethodPut, path: "/products/" + version9, body: testProductBody, want: http.StatusBadRequest},
+		{name: "update unknown UUID", method: http.MethodPut, path: "/products/" + unknownV4, body: testProductBody, want: http.StatusNotFound},
+		{name: "delete abc", method: http.MethodDelete, path: "/products/abc", want: http.StatusBadRequest},
+		{name: "delete unknown UUID", method: http.MethodDelete, path: "/products/" + unknownV4, want: http.StatusNotFound},
+		// Routes guarded by middleware.RequireUUID
+		{name: "price history abc", method: http.MethodGet, path: "/products/abc/price-history", want: http.StatusBadRequest},
+		{name: "stock threshold version 0", method: http.MethodPost, path: "/products/" + version0 + "/stock-threshold", body: `{"threshold":3}`, want: http.StatusBadRequest},
+		// Upsert with a client-chosen ID
+		{name: "upsert abc", method: http.MethodPut, path: "/products", body: `{"id":"abc","name":"Keyboard","price":99.9,"stock":10}`, want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := sendRequest(router, tt.method, tt.path, tt.body, nil)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+			if tt.want != http.StatusBadRequest {
+				return
+			}
+			var problem map[string]any
+			json.Unmarshal(w.Body.Bytes(), &problem)
+			if problem["code"] != "VAL0002" || problem["detail"] != "Invalid ID format, expected UUID" {
+				t.Errorf("problem = %v, want VAL0002", problem)
+			}
+		})
+	}
+}
+
+func TestRoutes_RequireScopes(t *testing.T) {
+	keys := auth.ParseAPIKeys("reader=product:read,writer=product:read|product:write")
+	router := newTestRouterWith(t, 0, middleware.APIKeyAuth(keys))
+
+	w := sendRequest(router, http.MethodPost, "/products", testProductBody, map[string]string{middleware.APIKeyHeader: "writer"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("POST with product:write: status = %d, want 201: %s", w.Code, w.Body.String())
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &created)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		apiKey string
+		want   int
+	}{
+		{name: "list without auth", method: http.MethodGet, path: "/products", want: http.StatusUnauthorized},
+		{name: "list with product:read", method: http.MethodGet, path: "/products", apiKey: "reader", want: http.StatusOK},
+		{name: "get with product:read", method: http.MethodGet, path: "/products/" + created.ID, apiKey: "reader", want: http.StatusOK},
+		{name: "create without auth", method: http.MethodPost, path: "/products", body: testProductBody, want: http.StatusUnauthorized},
+		{name: "create with product:read", method: http.MethodPost, path: "/products", body: testProductBody, apiKey: "reader", want: http.StatusForbidden},
+		{name: "update with product:read", method: http.MethodPut, path: "/products/" + created.ID, body: testProductBody, apiKey: "reader", want: http.StatusForbidden},
+		{name: "delete with product:read", method: http.MethodDelete, path: "/products/" + created.ID, apiKey: "reader", want: http.StatusForbidden},
+		{name: "delete with product:write", method: http.MethodDelete, path: "/products/" + created.ID, apiKey: "writer", want: http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := map[string]string{}
+			if tt.apiKey != "" {
+				headers[middleware.APIKeyHeader] = tt.apiKey
+			}
+			if w := sendRequest(router, tt.method, tt.path, tt.body, headers); w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRoutes_ResponseEnvelope(t *testing.T) {
+	router := newTestRouterWith(t, 0, middleware.AnonymousAuth(), middleware.ResponseEnvelopeMiddleware())
+
+	w := sendRequest(router, http.MethodPost, "/products", testProductBody, nil)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("POST: status = %d, want 201: %s", w.Code, w.Body.String())
+	}
+	var created struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || created.Data.ID == "" {
+		t.Fatalf("invalid create response %s: %v", w.Body.String(), err)
+	}
+
+	raw := map[string]string{middleware.RawResponseHeader: "true"}
+	for _, path := range []string{"/products/" + created.Data.ID, "/products"} {
+		t.Run(path, func(t *testing.T) {
+			unwrapped := sendRequest(router, http.MethodGet, path, "", raw)
+			wrapped := sendRequest(router, http.MethodGet, path, "", nil)
+			if unwrapped.Code != http.StatusOK || wrapped.Code != http.StatusOK {
+				t.Fatalf("status = %d wrapped, %d raw, want 200", wrapped.Code, unwrapped.Code)
+			}
+
+			var envelope struct {
+				Data json.RawMessage                 `json:"data"`
+				Meta middleware.ResponseEnvelopeMeta `json:"meta"`
+			}
+			if err := json.Unmarshal(wrapped.Body.Bytes(), &envelope); err != nil {
+				t.Fatalf("invalid envelope %s: %v", wrapped.Body.String(), err)
+			}
+			if envelope.Meta.Timestamp == "" || envelope.Meta.RequestID == "" {
+				t.Errorf("meta = %+v, want requestId and timestamp", envelope.Meta)
+			}
+
+			// The envelope data is exactly the unwrapped response
+			var data, rawBody any
+			json.Unmarshal(envelope.Data, &data)
+			json.Unmarshal(unwrapped.Body.Bytes(), &rawBody)
+			if !reflect.DeepEqual(data, rawBody) {
+				t.Errorf("data = %s, want the raw response %s", envelope.Data, unwrapped.Body.String())
+			}
+		})
+	}
+
+	// Errors keep the ProblemDetails shape
+	w = sendRequest(router, http.MethodGet, "/products/0190a5e8-0000-7000-8000-000000000000", "", nil)
+	if w.Code != http.StatusNotFound || strings.Contains(w.Body.String(), `"data"`) {
+		t.Errorf("404 = %d %s, want an unwrapped problem", w.Code, w.Body.String())
+	}
+}
+
+func TestRoutes_ListProductsLinkHeader(t *testing.T) {
+	router := newTestRouter(t, 0)
+	for i := 0; i < 3; i++ {
+		body := strings.Replace(testProductBody, "Keyboard", "Keyboard "+string(rune('A'+i)), 1)
+		if w := sendRequest(router, http.MethodPost, "/products", body, nil); w.Code != http.StatusCreated {
+			t.Fatalf("POST %d: status = %d, want 201: %s", i, w.Code, w.Body.String())
+		}
+	}
+
+	tests := []struct {
+		page        string
+		wantRels    []string
+		notWantRels []string
+	}{
+		{page: "1", wantRels: []string{"first", "next", "last"}, notWantRels: []string{"prev"}},
+		{page: "2", wantRels: []string{"first", "prev", "next", "last"}},
+		{page: "3", wantRels: []string{"first", "prev", "last"}, notWantRels: []string{"next"}},
+	}
+	for _, tt := range tests {
+		t.Run("page "+tt.page+" of 3", func(t *testing.T) {
+			w := sendRequest(router, http.MethodGet, "/products?page="+tt.page+"&limit=1", "", nil)
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+			}
+			link := w.Header().Get("Link")
+			for _, rel := range tt.wantRels {
+				if !strings.Contains(link, `rel="`+rel+`"`) {
+					t.Errorf("Link = %q, want rel=%s", link, rel)
+				}
+			}
+			for _, rel := range tt.notWantRels {
+				if strings.Contains(link, `rel="`+rel+`"`) {
+					t.Errorf("Link = %q, want no rel=%s", link, rel)
+				}
+			}
+			if !strings.Contains(link, `</products?page=3&limit=1>; rel="last"`) {
+				t.Errorf("Link = %q, want the last page URL", link)
+			}
+		})
+	}
+}
+
+func TestRoutes_PriceHistory(t *testing.T) {
+	router := newTestRouter(t, 0)
+	w := sendRequest(router, http.MethodPost, "/products", testProductBody, nil)
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || created.ID == "" {
+		t.Fatalf("invalid create response %s: %v", w.Body.String(), err)
+	}
+
+	for _, price := range []string{"89.9", "79.9", "69.9"} {
+		update := `{"name":"Keyboard","description":"Mechanical keyboard","price":` + price + `,"stock":10}`
+		if w := sendRequest(router, http.MethodPut, "/products/"+created.ID, update, nil); w.Code != http.StatusOK {
+			t.Fatalf("PUT price %s: status = %d, want 200: %s", price, w.Code, w.Body.String())
+		}
+	}
+
+	w = sendRequest(router, http.MethodGet, "/products/"+created.ID+"/price-history?page=1&limit=2", "", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET price-history: status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	var history struct {
+		Items []struct {
+			OldPrice float64 `json:"old_price"`
+			NewPrice float64 `json:"new_price"`
+		} `json:"items"`
+		Pagination struct {
+			TotalItems int `json:"total_items"`
+		} `json:"pagination"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &history); err != nil {
+		t.Fatalf("invalid history response %s: %v", w.Body.String(), err)
+	}
+	if history.Pagination.TotalItems != 3 || len(history.Items) != 2 || history.Items[0].NewPrice != 69.9 {
+		t.Errorf("history = %s, want 3 changes, newest first, 2 per page", w.Body.String())
+	}
+	if link := w.Header().Get("Link"); !strings.Contains(link, `rel="next"`) {
+		t.Errorf("Link = %q, want a next page", link)
+	}
+
+	if w := sendRequest(router, http.MethodGet, "/products/0190a5e8-0000-7000-8000-000000000000/price-history", "", nil); w.Code != http.StatusNotFound {
+		t.Errorf("unknown product: status = %d, want 404", w.Code)
+	}
+}
+
+func TestRoutes_CreateProductSchema(t *testing.T) {
+	router := newTestRouter(t, 0)
+
+	w := sendRequest(router, http.MethodPost, "/products", `{"name":"Keyboard","price":-5,"stock":"ten"}`, nil)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want 422: %s", w.Code, w.Body.String())
+	}
+	var problem struct {
+		ValidationErrors []struct {
+			Field string `json:"field"`
+		} `json:"validation_errors"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("body is not a problem: %v", err)
+	}
+	var fields []string
+	for _, validationErr := range problem.ValidationErrors {
+		fields = append(fields, validationErr.Field)
+	}
+	if want := []string{"/price", "/stock"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields = %q, want %q", fields, want)
+	}
+}
+
+func TestRoutes_SubscribeStock(t *testing.T) {
+	router := newTestRouter(t, 0)
+	w := sendRequest(router, http.MethodPost, "/products", testProductBody, nil)
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || created.ID == "" {
+		t.Fatalf("invalid create response %d %s: %v", w.Code, w.Body.String(), err)
+	}
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL+"/products/"+created.ID+"/subscribe", nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+
+	patch := sendRequest(router, http.MethodPatch, "/products/"+created.ID, `{"stock":4}`,
+		map[string]string{"Content-Type": "application/merge-patch+json"})
+	if patch.Code != http.StatusOK {
+		t.Fatalf("PATCH: status = %d, want 200: %s", patch.Code, patch.Body.String())
+	}
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var change struct {
+		Event         string `json:"event"`
+		ProductID     string `json:"product_id"`
+		Stock         int    `json:"stock"`
+		PreviousStock int    `json:"previous_stock"`
+	}
+	if err := conn.ReadJSON(&change); err != nil {
+		t.Fatalf("ReadJSON: %v", err)
+	}
+	if change.Event != "product.stock_changed" || change.ProductID != created.ID || change.Stock != 4 || change.PreviousStock != 10 {
+		t.Errorf("message = %+v, want the stock change from 10 to 4", change)
+	}
+
+	_, resp, err := websocket.DefaultDialer.Dial(wsURL+"/products/0190a5e8-0000-7000-8000-000000000000/subscribe", nil)
+	if err == nil || resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown product: err = %v, response %v, want a 404 handshake failure", err, resp)
+	}
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/shared/messaging/eventbus"
+	"github.com/refortunato/go_app_base/internal/shared/messaging/outbox"
+	"github.com/refortunato/go_app_base/internal/simple_module/models"
+)
+
+// productAggregateType is the outbox aggregate type (and Kafka topic) of product events
+const productAggregateType = "product"
+
+// Product event types written to the outbox
+const (
+	ProductCreatedEvent = "product.created"
+	ProductUpdatedEvent = "product.updated"
+	ProductDeletedEvent = "product.deleted"
+)
+
+// ProductStockChangedEvent is written to the outbox, together with product.updated, when an
+// update changes a product stock. Once committed it is also published on the in-process event
+// bus, on a topic per product (see SubscribeStockChanges) and on this topic for all products
+// (see SubscribeAllStockChanges)
+const ProductStockChangedEvent = "product.stock_changed"
+
+// stockSubscriberBuffer is how many stock changes a slow subscriber may lag behind before missing some
+const stockSubscriberBuffer = 16
+
+// ProductDeletedPayload is the payload of product.deleted events
+type ProductDeletedPayload struct {
+	ID string `json:"id"`
+}
+
+// ProductStockChangedPayload is the payload of product.stock_changed events
+type ProductStockChangedPayload struct {
+	Event         string    `json:"event" example:"product.stock_changed"`
+	ProductID     string    `json:"product_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Stock         int       `json:"stock" example:"8"`
+	PreviousStock int       `json:"previous_stock" example:"10"`
+	ChangedAt     time.Time `json:"changed_at" example:"2024-01-01T10:00:00Z"`
+}
+
+// saveOutboxEvent stores a product event in the outbox inside tx, so it is only
+// published if the change commits (no-op when the service has no outbox)
+func (s *ProductService) saveOutboxEvent(ctx context.Context, tx *sql.Tx, eventType, productID string, payload any) error {
+	if s.outbox == nil {
+		return nil
+	}
+
+	event, err := outbox.NewOutboxEvent(productAggregateType, productID, eventType, payload)
+	if err != nil {
+		return err
+	}
+	return s.outbox.Save(ctx, event, tx)
+}
+
+// saveUpdateEvents stores product.updated, and product.stock_changed when the stock differs
+// from previousStock, in the outbox inside tx
+func (s *ProductService) saveUpdateEvents(ctx context.Context, tx *sql.Tx, product *models.Product, previousStock int) error {
+	if err := s.saveOutboxEvent(ctx, tx, ProductUpdatedEvent, product.ID, product); err != nil {
+		return err
+	}
+	if product.Stock == previousStock {
+		return nil
+	}
+	payload := newStockChangedPayload(product.ID, previousStock, product.Stock, product.UpdatedAt)
+	return s.saveOutboxEvent(ctx, tx, ProductStockChangedEvent, product.ID, payload)
+}
+
+// newStockChangedPayload builds the payload of a product.stock_changed event
+func newStockChangedPayload(productID string, previousStock, stock int, changedAt time.Time) ProductStockChangedPayload {
+	return ProductStockChangedPayload{
+		Event:         ProductStockChangedEvent,
+		ProductID:     productID,
+		Stock:         stock,
+		PreviousStock: previousStock,
+		ChangedAt:     changedAt.UTC(),
+	}
+}
+
+// productStockTopic is the event bus topic of one product's stock changes
+func productStockTopic(productID string) string {
+	return ProductStockChangedEvent + ":" + productID
+}
+
+// publishStockChanged notifies the subscribers of the product (no-op without an event bus or a change)
+func (s *ProductService) publishStockChanged(productID string, previousStock, stock int) {
+	if s.events == nil || previousStock == stock {
+		return
+	}
+	payload := newStockChangedPayload(productID, previousStock, stock, time.Now())
+	s.events.Publish(productStockTopic(productID), payload)
+	s.events.Publish(ProductStockChangedEvent, payload)
+}
+
+// SubscribeStockChanges returns the stock changes of a product (payload ProductStockChangedPayload)
+// and a function that must be called to unsubscribe, which also closes the channel
+// Changes are only seen when made through this instance
+func (s *ProductService) SubscribeStockChanges(productID string) (<-chan eventbus.Event, func()) {
+	if s.events == nil {
+		ch := make(chan eventbus.Event)
+		var once sync.Once
+		return ch, func() { once.Do(func() { close(ch) }) }
+	}
+	return s.events.Subscribe(productStockTopic(productID), stockSubscriberBuffer)
+}
+
+// SubscribeAllStockChanges is like SubscribeStockChanges for the stock changes of every product
+// buffer is how many changes the subscriber may lag behind before missing some
+func (s *ProductService) SubscribeAllStockChanges(buffer int) (<-chan eventbus.Event, func()) {
+	if s.events == nil {
+		ch := make(chan eventbus.Event)
+		var once sync.Once
+		return ch, func() { once.Do(func() { close(ch) }) }
+	}
+	return s.events.Subscribe(ProductStockChangedEvent, buffer)
+}
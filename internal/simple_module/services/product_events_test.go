@@ -0,0 +1,148 @@
+//go:build sqlite
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/refortunato/go_app_base/internal/shared/messaging/outbox"
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+	"github.com/refortunato/go_app_base/internal/simple_module/repositories"
+)
+
+func newOutboxTestService(t *testing.T) (*ProductService, *outbox.OutboxRepository) {
+	t.Helper()
+	db := testhelpers.NewSQLiteForTest(t)
+	outboxRepo := outbox.NewOutboxRepository(db)
+	svc := NewProductService(
+		repositories.NewProductRepository(db),
+		repositories.NewPriceHistoryRepository(db),
+		repositories.NewProductVariantRepository(db),
+		outboxRepo, nil, nil, 0, 0, "", "v7",
+	)
+	return svc, outboxRepo
+}
+
+// pendingEvents returns the pending outbox events after the first skip ones
+func pendingEvents(t *testing.T, repo *outbox.OutboxRepository, skip int) []*outbox.OutboxEvent {
+	t.Helper()
+	events, err := repo.FindPending(context.Background(), 100)
+	if err != nil {
+		t.Fatalf("FindPending: %v", err)
+	}
+	if len(events) < skip {
+		t.Fatalf("got %d outbox events, want at least %d", len(events), skip)
+	}
+	return events[skip:]
+}
+
+func eventTypes(events []*outbox.OutboxEvent) []string {
+	types := make([]string, len(events))
+	for i, event := range events {
+		types[i] = event.EventType
+	}
+	return types
+}
+
+func TestOutbox_UpdateWithStockChangeWritesUpdatedAndStockChanged(t *testing.T) {
+	ctx := context.Background()
+	svc, outboxRepo := newOutboxTestService(t)
+
+	product, err := svc.CreateProduct(ctx, "Laptop", "", 100, 10, "", nil)
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if _, err := svc.UpdateProduct(ctx, product.ID, "Laptop", "", 100, 4, ""); err != nil {
+		t.Fatalf("UpdateProduct: %v", err)
+	}
+
+	events := pendingEvents(t, outboxRepo, 1)
+	if len(events) != 2 {
+		t.Fatalf("outbox events after update = %v, want product.updated and product.stock_changed", eventTypes(events))
+	}
+	types := map[string]*outbox.OutboxEvent{}
+	for _, event := range events {
+		if event.AggregateID != product.ID {
+			t.Errorf("%s aggregate = %q, want %q", event.EventType, event.AggregateID, product.ID)
+		}
+		types[event.EventType] = event
+	}
+	if types[ProductUpdatedEvent] == nil {
+		t.Fatalf("missing %s in %v", ProductUpdatedEvent, eventTypes(events))
+	}
+	stockChanged := types[ProductStockChangedEvent]
+	if stockChanged == nil {
+		t.Fatalf("missing %s in %v", ProductStockChangedEvent, eventTypes(events))
+	}
+
+	var payload ProductStockChangedPayload
+	if err := json.Unmarshal(stockChanged.Payload, &payload); err != nil {
+		t.Fatalf("stock changed payload: %v", err)
+	}
+	if payload.PreviousStock != 10 || payload.Stock != 4 || payload.ProductID != product.ID {
+		t.Errorf("stock changed payload = %+v", payload)
+	}
+}
+
+func TestOutbox_UpdatesWithoutStockChangeWriteOnlyUpdated(t *testing.T) {
+	ctx := context.Background()
+	svc, outboxRepo := newOutboxTestService(t)
+
+	product, err := svc.CreateProduct(ctx, "Laptop", "", 100, 10, "", nil)
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+
+	name := "Laptop Pro"
+	if _, err := svc.PatchProduct(ctx, product.ID, &PatchProductRequest{Name: &name}); err != nil {
+		t.Fatalf("PatchProduct: %v", err)
+	}
+	if _, err := svc.SetStockThreshold(ctx, product.ID, 3); err != nil {
+		t.Fatalf("SetStockThreshold: %v", err)
+	}
+
+	events := pendingEvents(t, outboxRepo, 1)
+	if len(events) != 2 || events[0].EventType != ProductUpdatedEvent || events[1].EventType != ProductUpdatedEvent {
+		t.Fatalf("outbox events = %v, want two %s", eventTypes(events), ProductUpdatedEvent)
+	}
+}
+
+func TestOutbox_PatchStockWritesStockChanged(t *testing.T) {
+	ctx := context.Background()
+	svc, outboxRepo := newOutboxTestService(t)
+
+	product, err := svc.CreateProduct(ctx, "Laptop", "", 100, 10, "", nil)
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	stock := 0
+	if _, err := svc.PatchProduct(ctx, product.ID, &PatchProductRequest{Stock: &stock}); err != nil {
+		t.Fatalf("PatchProduct: %v", err)
+	}
+
+	events := pendingEvents(t, outboxRepo, 1)
+	types := eventTypes(events)
+	if len(types) != 2 || !slices.Contains(types, ProductUpdatedEvent) || !slices.Contains(types, ProductStockChangedEvent) {
+		t.Fatalf("outbox events = %v", types)
+	}
+}
+
+func TestOutbox_RejectedUpdateWritesNothing(t *testing.T) {
+	ctx := context.Background()
+	svc, outboxRepo := newOutboxTestService(t)
+
+	product, err := svc.CreateProduct(ctx, "Laptop", "", 100, 10, "", nil)
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if _, err := svc.UpdateProduct(ctx, product.ID, "", "", 100, 1, ""); err == nil {
+		t.Fatal("expected a validation error for the empty name")
+	}
+
+	if events := pendingEvents(t, outboxRepo, 1); len(events) != 0 {
+		t.Fatalf("outbox events after a rejected update = %v", eventTypes(events))
+	}
+}
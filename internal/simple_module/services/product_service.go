@@ -2,26 +2,103 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/refortunato/go_app_base/internal/shared"
+	"github.com/refortunato/go_app_base/internal/shared/db"
 	"github.com/refortunato/go_app_base/internal/shared/dto"
+	sharedErrors "github.com/refortunato/go_app_base/internal/shared/errors"
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"github.com/refortunato/go_app_base/internal/shared/messaging/eventbus"
+	"github.com/refortunato/go_app_base/internal/shared/messaging/outbox"
+	"github.com/refortunato/go_app_base/internal/shared/observability"
 	"github.com/refortunato/go_app_base/internal/simple_module/errors"
+	"github.com/refortunato/go_app_base/internal/simple_module/metrics"
 	"github.com/refortunato/go_app_base/internal/simple_module/models"
 	"github.com/refortunato/go_app_base/internal/simple_module/repositories"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// defaultImportBatchSize is used when no positive batch size is configured
+const defaultImportBatchSize = 100
+
+// defaultBatchLookupSize is used when no positive batch lookup size is configured
+const defaultBatchLookupSize = 100
+
 // ProductService handles business logic for products
 type ProductService struct {
-	repository *repositories.ProductRepository
+	repository         repositories.ProductStore
+	priceHistory       *repositories.PriceHistoryRepository
+	variants           *repositories.ProductVariantRepository
+	outbox             *outbox.OutboxRepository
+	events             *eventbus.EventBus
+	maxImportBatchSize int
+	maxBatchLookupSize int
+	cdnBaseURL         string
+	idVersion          string
+	stock              *stockMetrics
+	business           *metrics.BusinessMetrics
 }
 
 // NewProductService creates a new product service instance
-func NewProductService(repo *repositories.ProductRepository) *ProductService {
-	return &ProductService{repository: repo}
+// maxImportBatchSize controls how many imported rows are persisted per transaction
+// maxBatchLookupSize limits how many IDs can be fetched at once by GetProductsByIds
+// cdnBaseURL, when set, turns relative image paths into absolute CDN URLs on reads
+// outboxRepo, when not nil, receives product events in the same transaction as the change
+// events, when not nil, receives stock changes for in-process subscribers (e.g. WebSocket clients)
+// businessMetrics, when not nil, counts created and deleted products
+// idVersion selects the UUID version of new products (see shared.GenerateIdWithVersion)
+func NewProductService(repo repositories.ProductStore, priceHistory *repositories.PriceHistoryRepository, variants *repositories.ProductVariantRepository, outboxRepo *outbox.OutboxRepository, events *eventbus.EventBus, businessMetrics *metrics.BusinessMetrics, maxImportBatchSize, maxBatchLookupSize int, cdnBaseURL, idVersion string) *ProductService {
+	if maxImportBatchSize <= 0 {
+		maxImportBatchSize = defaultImportBatchSize
+	}
+	if maxBatchLookupSize <= 0 {
+		maxBatchLookupSize = defaultBatchLookupSize
+	}
+	return &ProductService{
+		repository:         repo,
+		priceHistory:       priceHistory,
+		variants:           variants,
+		outbox:             outboxRepo,
+		events:             events,
+		maxImportBatchSize: maxImportBatchSize,
+		maxBatchLookupSize: maxBatchLookupSize,
+		cdnBaseURL:         cdnBaseURL,
+		idVersion:          idVersion,
+		stock:              newStockMetrics(),
+		business:           businessMetrics,
+	}
+}
+
+// repositoryError keeps ErrCircuitOpen (503) visible to callers and hides any other
+// repository error behind ErrGeneric
+func repositoryError(err error) error {
+	if stdErrors.Is(err, sharedErrors.ErrCircuitOpen) {
+		return sharedErrors.ErrCircuitOpen
+	}
+	return errors.ErrGeneric
+}
+
+// CreateProductRequest represents the request body for creating a product
+type CreateProductRequest struct {
+	Name        string   `json:"name" example:"Laptop Dell XPS 15"`
+	Description string   `json:"description" example:"High-performance laptop"`
+	Price       float64  `json:"price" example:"5499.99"`
+	Stock       int      `json:"stock" example:"10"`
+	ImageURL    string   `json:"image_url,omitempty" example:"https://cdn.example.com/products/xps15.jpg"`
+	Tags        []string `json:"tags,omitempty" example:"electronics,laptops"`
+	// ExternalID is the product ID in the source system, only set by CSV imports (see BulkImport)
+	ExternalID string `json:"-"`
 }
 
-// GetProduct retrieves a product by ID
+// GetProduct retrieves a product by ID, with its image path as stored (see ResolveImageURL)
 func (s *ProductService) GetProduct(ctx context.Context, id string) (*models.Product, error) {
 	if id == "" {
 		return nil, errors.ErrProductIdRequired
@@ -29,7 +106,7 @@ func (s *ProductService) GetProduct(ctx context.Context, id string) (*models.Pro
 
 	product, err := s.repository.FindById(ctx, id)
 	if err != nil {
-		return nil, errors.ErrGeneric
+		return nil, repositoryError(err)
 	}
 
 	if product == nil {
@@ -39,14 +116,70 @@ func (s *ProductService) GetProduct(ctx context.Context, id string) (*models.Pro
 	return product, nil
 }
 
+// GetProductsByIds retrieves several products in a single query, in the order of ids
+// IDs that do not exist are skipped
+func (s *ProductService) GetProductsByIds(ctx context.Context, ids []string) ([]*models.Product, error) {
+	if err := s.validateBatchIds(ids); err != nil {
+		return nil, err
+	}
+
+	products, err := s.repository.FindByIds(ctx, ids)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+	for _, product := range products {
+		s.resolveImageURL(product)
+	}
+
+	return products, nil
+}
+
+// ProductsExist reports which of the given product IDs exist
+func (s *ProductService) ProductsExist(ctx context.Context, ids []string) (map[string]bool, error) {
+	if err := s.validateBatchIds(ids); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.repository.ExistsByIds(ctx, ids)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+
+	return exists, nil
+}
+
+// validateBatchIds checks the size of a batch lookup
+func (s *ProductService) validateBatchIds(ids []string) error {
+	if len(ids) == 0 {
+		return errors.ErrProductIdsRequired
+	}
+	if len(ids) > s.maxBatchLookupSize {
+		return errors.ErrProductIdsTooMany
+	}
+	return nil
+}
+
 // ListProductsResponse represents the paginated list of products
 type ListProductsResponse struct {
 	Items      []*models.Product          `json:"items"`
 	Pagination *dto.PaginationResponseDTO `json:"pagination"`
 }
 
-// ListProducts retrieves all products with pagination
-func (s *ProductService) ListProducts(ctx context.Context, page, limit int) (*ListProductsResponse, error) {
+// GetLatestProduct retrieves the most recently created product
+func (s *ProductService) GetLatestProduct(ctx context.Context) (*models.Product, error) {
+	products, err := s.repository.FindAll(ctx, 1, 0)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+	if len(products) == 0 {
+		return nil, errors.ErrProductNotFound
+	}
+	return products[0], nil
+}
+
+// ListProducts retrieves all products with pagination, with image paths as stored (see ResolveImageURL)
+// A non-empty tag restricts the results to the products labeled with it
+func (s *ProductService) ListProducts(ctx context.Context, page, limit int, tag string) (*ListProductsResponse, error) {
 	if limit <= 0 {
 		limit = 10
 	}
@@ -57,18 +190,33 @@ func (s *ProductService) ListProducts(ctx context.Context, page, limit int) (*Li
 	// Calculate offset
 	offset := (page - 1) * limit
 
-	// Get total count
-	totalCount, err := s.repository.Count(ctx)
-	if err != nil {
-		return nil, errors.ErrGeneric
+	var (
+		totalCount int
+		products   []*models.Product
+		err        error
+	)
+	if tag != "" {
+		tags, err := normalizeTags([]string{tag})
+		if err != nil {
+			return nil, err
+		}
+		if totalCount, err = s.repository.CountByTag(ctx, tags[0]); err != nil {
+			return nil, repositoryError(err)
+		}
+		if products, err = s.repository.FilterByTag(ctx, tags[0], limit, offset); err != nil {
+			return nil, repositoryError(err)
+		}
+	} else {
+		// The stats aggregator keeps the total count warm; count for real until its first run
+		if cached := s.repository.CountCached(); cached >= 0 {
+			totalCount = int(cached)
+		} else if totalCount, err = s.repository.CountWhere(ctx, nil); err != nil {
+			return nil, repositoryError(err)
+		}
+		if products, err = s.repository.FindAll(ctx, limit, offset); err != nil {
+			return nil, repositoryError(err)
+		}
 	}
-
-	// Get products
-	products, err := s.repository.FindAll(ctx, limit, offset)
-	if err != nil {
-		return nil, errors.ErrGeneric
-	}
-
 	// Build pagination
 	pagination := dto.NewPaginationResponseDTO(page, limit, totalCount)
 
@@ -79,7 +227,41 @@ func (s *ProductService) ListProducts(ctx context.Context, page, limit int) (*Li
 }
 
 // CreateProduct creates a new product
-func (s *ProductService) CreateProduct(ctx context.Context, name, description string, price float64, stock int) (*models.Product, error) {
+func (s *ProductService) CreateProduct(ctx context.Context, name, description string, price float64, stock int, imageURL string, tags []string) (*models.Product, error) {
+	product, err := s.newProduct(name, description, price, stock)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateImageURL(imageURL); err != nil {
+		return nil, err
+	}
+	if product.Tags, err = normalizeTags(tags); err != nil {
+		return nil, err
+	}
+	product.ImageURL = imageURL
+	observability.AddBusinessEvent(ctx, "product.validated", attribute.String("product.name", name))
+
+	err = s.repository.Transactional(ctx, func(repo *repositories.ProductRepository) error {
+		if err := repo.Save(ctx, product); err != nil {
+			return err
+		}
+		return s.saveOutboxEvent(ctx, repo.Tx(), ProductCreatedEvent, product.ID, product)
+	})
+	if err != nil {
+		if err == sharedErrors.ErrConflict {
+			return nil, err
+		}
+		return nil, repositoryError(err)
+	}
+	observability.AddBusinessEvent(ctx, "product.saved", attribute.String("product.id", product.ID))
+	s.business.RecordProductsCreated(ctx, 1)
+
+	s.resolveImageURL(product)
+	return product, nil
+}
+
+// newProduct validates the input and builds a new product model with a new ID
+func (s *ProductService) newProduct(name, description string, price float64, stock int) (*models.Product, error) {
 	if name == "" {
 		return nil, errors.ErrProductNameRequired
 	}
@@ -91,32 +273,236 @@ func (s *ProductService) CreateProduct(ctx context.Context, name, description st
 	}
 
 	now := time.Now().UTC()
-	product := &models.Product{
-		ID:          shared.GenerateId(),
+	return &models.Product{
+		ID:          shared.GenerateIdWithVersion(s.idVersion),
 		Name:        name,
 		Description: description,
 		Price:       price,
 		Stock:       stock,
 		CreatedAt:   now,
 		UpdatedAt:   now,
+	}, nil
+}
+
+// validateImageURL accepts an empty value, an absolute http(s) URL with a host, or,
+// when a CDN base URL is configured, a relative path served from the CDN
+func (s *ProductService) validateImageURL(imageURL string) error {
+	if imageURL == "" {
+		return nil
 	}
 
-	if err := s.repository.Save(ctx, product); err != nil {
-		return nil, errors.ErrGeneric
+	parsed, err := url.Parse(imageURL)
+	if err != nil {
+		return errors.ErrProductImageURLInvalid
+	}
+	if parsed.Scheme == "" && parsed.Host == "" {
+		if s.cdnBaseURL == "" || parsed.Path == "" {
+			return errors.ErrProductImageURLInvalid
+		}
+		return nil
 	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.ErrProductImageURLInvalid
+	}
+	return nil
+}
 
-	return product, nil
+// resolveImageURL rewrites a relative image path to an absolute URL under the CDN base URL
+func (s *ProductService) resolveImageURL(product *models.Product) {
+	product.ImageURL = ResolveImageURL(s.cdnBaseURL, product.ImageURL)
+}
+
+// ResolveImageURL returns imageURL under cdnBaseURL when it is a relative path
+// Empty values, absolute URLs and an empty cdnBaseURL leave imageURL unchanged
+func ResolveImageURL(cdnBaseURL, imageURL string) string {
+	if cdnBaseURL == "" || imageURL == "" {
+		return imageURL
+	}
+	if parsed, err := url.Parse(imageURL); err != nil || parsed.IsAbs() || parsed.Host != "" {
+		return imageURL
+	}
+	return strings.TrimSuffix(cdnBaseURL, "/") + "/" + strings.TrimPrefix(imageURL, "/")
+}
+
+// BulkImportError describes why a single imported row was skipped
+type BulkImportError struct {
+	Row     int    `json:"row" example:"3"`
+	Message string `json:"message" example:"Product name is required"`
+}
+
+// BulkImportResult summarizes the outcome of a bulk product import
+type BulkImportResult struct {
+	Created int               `json:"created" example:"98"`
+	Updated int               `json:"updated" example:"0"`
+	Skipped int               `json:"skipped" example:"2"`
+	Errors  []BulkImportError `json:"errors"`
+}
+
+// BulkImport validates and creates products in batches, each batch with a single multi-row insert
+// Rows with an ExternalID already imported update that product instead, so retrying an import
+// does not duplicate products (a repeated ExternalID within the same import is skipped)
+// Row numbers in the result are 1-based positions in the requests slice, and errors are sorted by row
+// Invalid rows are skipped; a failed batch insert skips all of the rows it was creating
+func (s *ProductService) BulkImport(ctx context.Context, requests []*CreateProductRequest) *BulkImportResult {
+	result := &BulkImportResult{Errors: []BulkImportError{}}
+
+	type pendingProduct struct {
+		row     int
+		product *models.Product
+	}
+
+	pending := make([]pendingProduct, 0, len(requests))
+	externalIDs := make(map[string]bool)
+	for i, request := range requests {
+		row := i + 1
+
+		product, err := s.newProduct(request.Name, request.Description, request.Price, request.Stock)
+		if err == nil {
+			err = s.validateImageURL(request.ImageURL)
+		}
+		if err == nil && request.ExternalID != "" {
+			if externalIDs[request.ExternalID] {
+				err = errors.ErrImportDuplicateExternalID
+			}
+			externalIDs[request.ExternalID] = true
+		}
+		if err != nil {
+			result.Skipped++
+			result.Errors = append(result.Errors, BulkImportError{
+				Row:     row,
+				Message: importErrorMessage(err),
+			})
+			continue
+		}
+
+		product.ImageURL = request.ImageURL
+		product.ExternalID = request.ExternalID
+		pending = append(pending, pendingProduct{row: row, product: product})
+	}
+
+	err := db.BatchProcess(ctx, pending, s.maxImportBatchSize, func(ctx context.Context, batch []pendingProduct) error {
+		var updateErrs []error
+		creates := make([]pendingProduct, 0, len(batch))
+		for _, p := range batch {
+			updated, err := s.updateImportedProduct(ctx, p.product)
+			if stdErrors.Is(err, errors.ErrProductStockManagedByVariants) {
+				result.Skipped++
+				result.Errors = append(result.Errors, BulkImportError{
+					Row:     p.row,
+					Message: importErrorMessage(err),
+				})
+				continue
+			}
+			if err != nil {
+				result.Skipped++
+				result.Errors = append(result.Errors, BulkImportError{
+					Row:     p.row,
+					Message: errors.ErrGeneric.Detail,
+				})
+				updateErrs = append(updateErrs, err)
+				continue
+			}
+			if updated {
+				result.Updated++
+				continue
+			}
+			creates = append(creates, p)
+		}
+		if len(creates) == 0 {
+			return stdErrors.Join(updateErrs...)
+		}
+
+		products := make([]*models.Product, 0, len(creates))
+		for _, p := range creates {
+			products = append(products, p.product)
+		}
+
+		if err := s.repository.BulkCreate(ctx, products); err != nil {
+			for _, p := range creates {
+				result.Skipped++
+				result.Errors = append(result.Errors, BulkImportError{
+					Row:     p.row,
+					Message: errors.ErrGeneric.Detail,
+				})
+			}
+			return stdErrors.Join(append(updateErrs, err)...)
+		}
+		result.Created += len(creates)
+		s.business.RecordProductsCreated(ctx, len(creates))
+		return stdErrors.Join(updateErrs...)
+	})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error(ctx, "Some product import batches failed", logger.CustomFields{
+			"skipped": result.Skipped,
+		})
+	}
+
+	sort.SliceStable(result.Errors, func(i, j int) bool {
+		return result.Errors[i].Row < result.Errors[j].Row
+	})
+	return result
+}
+
+// updateImportedProduct overwrites the product previously imported with the same ExternalID
+// (name, description, price, stock and image; tags and stock threshold are kept)
+// A stock change on a product with variants fails with ErrProductStockManagedByVariants
+// Returns false when the row has no ExternalID or no product has it yet
+func (s *ProductService) updateImportedProduct(ctx context.Context, product *models.Product) (bool, error) {
+	if product.ExternalID == "" {
+		return false, nil
+	}
+
+	id, err := s.repository.FindIDByExternalID(ctx, product.ExternalID)
+	if err != nil || id == "" {
+		return false, err
+	}
+	existing, err := s.repository.FindById(ctx, id)
+	if err != nil || existing == nil {
+		return false, err
+	}
+	if err := checkVariantStock(existing, product.Stock); err != nil {
+		return false, err
+	}
+
+	previousStock := existing.Stock
+	existing.Name = product.Name
+	existing.Description = product.Description
+	existing.Price = product.Price
+	existing.Stock = product.Stock
+	existing.ImageURL = product.ImageURL
+	existing.UpdatedAt = product.UpdatedAt
+	if err := s.updateProduct(ctx, existing, previousStock); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// importErrorMessage extracts a human readable message from a validation error
+func importErrorMessage(err error) string {
+	if pd, ok := err.(*sharedErrors.ProblemDetails); ok {
+		return pd.Detail
+	}
+	return err.Error()
+}
+
+// checkVariantStock rejects a direct stock change on a product with variants, whose stock is
+// the sum of the variant stock; sending the current value (e.g. in a full PUT) is accepted
+func checkVariantStock(product *models.Product, stock int) error {
+	if len(product.Variants) > 0 && stock != product.Stock {
+		return errors.ErrProductStockManagedByVariants
+	}
+	return nil
 }
 
 // UpdateProduct updates an existing product
-func (s *ProductService) UpdateProduct(ctx context.Context, id, name, description string, price float64, stock int) (*models.Product, error) {
+func (s *ProductService) UpdateProduct(ctx context.Context, id, name, description string, price float64, stock int, imageURL string) (*models.Product, error) {
 	if id == "" {
 		return nil, errors.ErrProductIdRequired
 	}
 
 	existing, err := s.repository.FindById(ctx, id)
 	if err != nil {
-		return nil, errors.ErrGeneric
+		return nil, repositoryError(err)
 	}
 	if existing == nil {
 		return nil, errors.ErrProductNotFound
@@ -131,20 +517,446 @@ func (s *ProductService) UpdateProduct(ctx context.Context, id, name, descriptio
 	if stock < 0 {
 		return nil, errors.ErrProductStockInvalid
 	}
+	if err := checkVariantStock(existing, stock); err != nil {
+		return nil, err
+	}
+	if err := s.validateImageURL(imageURL); err != nil {
+		return nil, err
+	}
 
+	if existing.Price != price {
+		observability.AddBusinessEvent(ctx, "product.price_changed",
+			attribute.Float64("old_price", existing.Price),
+			attribute.Float64("new_price", price),
+		)
+	}
+
+	previousStock := existing.Stock
 	existing.Name = name
 	existing.Description = description
 	existing.Price = price
 	existing.Stock = stock
+	existing.ImageURL = imageURL
+	existing.UpdatedAt = time.Now().UTC()
+
+	if err := s.updateProduct(ctx, existing, previousStock); err != nil {
+		return nil, repositoryError(err)
+	}
+
+	s.resolveImageURL(existing)
+	return existing, nil
+}
+
+// updateProduct stores the changes of product together with its outbox events in a single
+// transaction, then reports the stock change (previousStock is the stock before the changes)
+func (s *ProductService) updateProduct(ctx context.Context, product *models.Product, previousStock int) error {
+	err := s.repository.Transactional(ctx, func(repo *repositories.ProductRepository) error {
+		if err := repo.Update(ctx, product); err != nil {
+			return err
+		}
+		return s.saveUpdateEvents(ctx, repo.Tx(), product, previousStock)
+	})
+	if err != nil {
+		return err
+	}
+	s.stockChanged(ctx, product, previousStock)
+	return nil
+}
+
+// stockChanged reports a committed stock change: low-stock alert when it dropped
+// and event bus subscribers (no-op when the stock did not change)
+func (s *ProductService) stockChanged(ctx context.Context, product *models.Product, previousStock int) {
+	if product.Stock < previousStock {
+		s.reportLowStock(ctx, product)
+	}
+	s.publishStockChanged(product.ID, previousStock, product.Stock)
+}
+
+// UpsertProductRequest represents the request body for creating or replacing a product
+// When ID is empty a new product is created with a generated ID
+// Every field is replaced: an omitted image_url or stock_threshold clears it
+type UpsertProductRequest struct {
+	ID             string  `json:"id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name           string  `json:"name" example:"Laptop Dell XPS 15"`
+	Description    string  `json:"description" example:"High-performance laptop"`
+	Price          float64 `json:"price" example:"5499.99"`
+	Stock          int     `json:"stock" example:"10"`
+	StockThreshold int     `json:"stock_threshold" example:"5"`
+	ImageURL       string  `json:"image_url,omitempty" example:"https://cdn.example.com/products/xps15.jpg"`
+}
+
+// UpsertProduct creates the product or replaces the existing one with the same ID
+// A replacement has the side effects of UpdateProduct (price history, outbox events,
+// low-stock alert and stock subscribers) and a creation those of CreateProduct
+// Returns whether the product was created
+func (s *ProductService) UpsertProduct(ctx context.Context, req *UpsertProductRequest) (*models.Product, bool, error) {
+	product, err := s.newProduct(req.Name, req.Description, req.Price, req.Stock)
+	if err != nil {
+		return nil, false, err
+	}
+	if req.StockThreshold < 0 {
+		return nil, false, errors.ErrProductStockThresholdInvalid
+	}
+	if err := s.validateImageURL(req.ImageURL); err != nil {
+		return nil, false, err
+	}
+	if req.ID != "" {
+		product.ID = req.ID
+	}
+	product.StockThreshold = req.StockThreshold
+	product.ImageURL = req.ImageURL
+
+	var created bool
+	previousStock := product.Stock
+	err = s.repository.Transactional(ctx, func(repo *repositories.ProductRepository) error {
+		// The row stays locked until the upsert commits, so the stock and variants checked
+		// here are the ones being replaced
+		existing, err := repo.FindByIdForUpdate(ctx, product.ID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			if err := checkVariantStock(existing, product.Stock); err != nil {
+				return err
+			}
+			previousStock = existing.Stock
+			product.CreatedAt = existing.CreatedAt
+			product.Tags = existing.Tags
+			product.Variants = existing.Variants
+		}
+
+		result, err := repo.Upsert(ctx, product)
+		if err != nil {
+			return err
+		}
+		created = result.WasInserted
+		if created {
+			return s.saveOutboxEvent(ctx, repo.Tx(), ProductCreatedEvent, product.ID, product)
+		}
+		return s.saveUpdateEvents(ctx, repo.Tx(), product, previousStock)
+	})
+	if err == errors.ErrProductStockManagedByVariants {
+		return nil, false, err
+	}
+	if err != nil {
+		return nil, false, repositoryError(err)
+	}
+
+	if created {
+		s.business.RecordProductsCreated(ctx, 1)
+	} else {
+		s.stockChanged(ctx, product, previousStock)
+	}
+
+	s.resolveImageURL(product)
+	return product, created, nil
+}
+
+// PatchProductRequest represents a JSON Merge Patch (RFC 7396) body for a product
+// Absent fields are left untouched; explicit nulls clear the field to its zero value
+type PatchProductRequest struct {
+	Name        *string  `json:"name,omitempty" example:"Laptop Dell XPS 15 (Patched)"`
+	Description *string  `json:"description,omitempty" example:"Patched description"`
+	Price       *float64 `json:"price,omitempty" example:"4799.99"`
+	Stock       *int     `json:"stock,omitempty" example:"20"`
+	ImageURL    *string  `json:"image_url,omitempty" example:"/products/xps15-patched.jpg"`
+}
+
+// UnmarshalJSON decodes the patch keeping track of explicit nulls,
+// which the default decoder would otherwise treat as absent fields
+func (r *PatchProductRequest) UnmarshalJSON(data []byte) error {
+	type patchAlias PatchProductRequest
+	var alias patchAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	isNull := func(key string) bool {
+		value, ok := raw[key]
+		return ok && string(value) == "null"
+	}
+
+	if isNull("name") {
+		alias.Name = new(string)
+	}
+	if isNull("description") {
+		alias.Description = new(string)
+	}
+	if isNull("price") {
+		alias.Price = new(float64)
+	}
+	if isNull("stock") {
+		alias.Stock = new(int)
+	}
+	if isNull("image_url") {
+		alias.ImageURL = new(string)
+	}
+
+	*r = PatchProductRequest(alias)
+	return nil
+}
+
+// PatchProduct partially updates an existing product, applying only the fields present in the patch
+func (s *ProductService) PatchProduct(ctx context.Context, id string, req *PatchProductRequest) (*models.Product, error) {
+	if id == "" {
+		return nil, errors.ErrProductIdRequired
+	}
+
+	existing, err := s.repository.FindById(ctx, id)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+	if existing == nil {
+		return nil, errors.ErrProductNotFound
+	}
+
+	if req.Name != nil {
+		existing.Name = *req.Name
+	}
+	if req.Description != nil {
+		existing.Description = *req.Description
+	}
+	if req.Price != nil {
+		existing.Price = *req.Price
+	}
+	previousStock := existing.Stock
+	if req.Stock != nil {
+		if err := checkVariantStock(existing, *req.Stock); err != nil {
+			return nil, err
+		}
+		existing.Stock = *req.Stock
+	}
+	if req.ImageURL != nil {
+		if err := s.validateImageURL(*req.ImageURL); err != nil {
+			return nil, err
+		}
+		existing.ImageURL = *req.ImageURL
+	}
+
+	if existing.Name == "" {
+		return nil, errors.ErrProductNameRequired
+	}
+	if existing.Price < 0 {
+		return nil, errors.ErrProductPriceInvalid
+	}
+	if existing.Stock < 0 {
+		return nil, errors.ErrProductStockInvalid
+	}
+
 	existing.UpdatedAt = time.Now().UTC()
 
-	if err := s.repository.Update(ctx, existing); err != nil {
-		return nil, errors.ErrGeneric
+	if err := s.updateProduct(ctx, existing, previousStock); err != nil {
+		return nil, repositoryError(err)
 	}
 
+	s.resolveImageURL(existing)
 	return existing, nil
 }
 
+// SetStockThresholdRequest represents the request body for setting a product stock threshold
+type SetStockThresholdRequest struct {
+	Threshold int `json:"threshold" example:"5"`
+}
+
+// SetStockThreshold sets the stock level below which the product raises low-stock alerts (0 disables them)
+func (s *ProductService) SetStockThreshold(ctx context.Context, id string, threshold int) (*models.Product, error) {
+	if id == "" {
+		return nil, errors.ErrProductIdRequired
+	}
+	if threshold < 0 {
+		return nil, errors.ErrProductStockThresholdInvalid
+	}
+
+	existing, err := s.repository.FindById(ctx, id)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+	if existing == nil {
+		return nil, errors.ErrProductNotFound
+	}
+
+	existing.StockThreshold = threshold
+	existing.UpdatedAt = time.Now().UTC()
+
+	if err := s.updateProduct(ctx, existing, existing.Stock); err != nil {
+		return nil, repositoryError(err)
+	}
+
+	s.resolveImageURL(existing)
+	return existing, nil
+}
+
+// maxTagLength is the longest tag name accepted (tags.name column size)
+const maxTagLength = 50
+
+// normalizeTags lowercases and trims tags, dropping duplicates
+// Returns ErrProductTagInvalid when a tag is empty or longer than maxTagLength
+func normalizeTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || len([]rune(tag)) > maxTagLength {
+			return nil, errors.ErrProductTagInvalid
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+	return normalized, nil
+}
+
+// AddProductTagsRequest represents the request body for tagging a product
+type AddProductTagsRequest struct {
+	Tags []string `json:"tags" example:"electronics,laptops"`
+}
+
+// AddProductTags labels an existing product with tags and returns the updated product
+func (s *ProductService) AddProductTags(ctx context.Context, id string, tags []string) (*models.Product, error) {
+	if id == "" {
+		return nil, errors.ErrProductIdRequired
+	}
+
+	normalized, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(normalized) == 0 {
+		return nil, errors.ErrProductTagInvalid
+	}
+
+	existing, err := s.repository.FindById(ctx, id)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+	if existing == nil {
+		return nil, errors.ErrProductNotFound
+	}
+
+	if err := s.repository.AddTags(ctx, id, normalized); err != nil {
+		return nil, repositoryError(err)
+	}
+
+	product, err := s.GetProduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.resolveImageURL(product)
+	return product, nil
+}
+
+// RemoveProductTag removes a tag from an existing product; removing a tag the product does not have is a no-op
+func (s *ProductService) RemoveProductTag(ctx context.Context, id, tag string) error {
+	if id == "" {
+		return errors.ErrProductIdRequired
+	}
+
+	normalized, err := normalizeTags([]string{tag})
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.repository.FindById(ctx, id)
+	if err != nil {
+		return repositoryError(err)
+	}
+	if existing == nil {
+		return errors.ErrProductNotFound
+	}
+
+	if err := s.repository.RemoveTag(ctx, id, normalized[0]); err != nil {
+		return repositoryError(err)
+	}
+	return nil
+}
+
+// PriceHistoryResponse represents a paginated list of price changes
+type PriceHistoryResponse struct {
+	Items      []*models.PriceHistory     `json:"items"`
+	Pagination *dto.PaginationResponseDTO `json:"pagination"`
+}
+
+// GetPriceHistory retrieves the price changes of an existing product, newest first, with pagination
+func (s *ProductService) GetPriceHistory(ctx context.Context, id string, page, limit int) (*PriceHistoryResponse, error) {
+	if id == "" {
+		return nil, errors.ErrProductIdRequired
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if page <= 0 {
+		page = 1
+	}
+
+	existing, err := s.repository.FindById(ctx, id)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+	if existing == nil {
+		return nil, errors.ErrProductNotFound
+	}
+
+	totalCount, err := s.priceHistory.CountByProductId(ctx, id)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+
+	history, err := s.priceHistory.FindByProductId(ctx, id, limit, (page-1)*limit)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+
+	return &PriceHistoryResponse{
+		Items:      history,
+		Pagination: dto.NewPaginationResponseDTO(page, limit, totalCount),
+	}, nil
+}
+
+// exportBatchSize is how many products ExportAll reads per query
+const exportBatchSize = 100
+
+// ExportAll writes every product to w as NDJSON (one JSON object per line), reading the
+// database in batches with keyset pagination so memory use does not grow with the catalog
+func (s *ProductService) ExportAll(ctx context.Context, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	afterID := ""
+
+	for {
+		products, err := s.repository.FindAfterID(ctx, afterID, exportBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, product := range products {
+			s.resolveImageURL(product)
+			if err := encoder.Encode(product); err != nil {
+				return err
+			}
+		}
+
+		// Send each batch to the client instead of waiting for the buffer to fill
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+
+		if len(products) < exportBatchSize {
+			return nil
+		}
+		afterID = products[len(products)-1].ID
+	}
+}
+
 // DeleteProduct removes a product by ID
 func (s *ProductService) DeleteProduct(ctx context.Context, id string) error {
 	if id == "" {
@@ -153,15 +965,22 @@ func (s *ProductService) DeleteProduct(ctx context.Context, id string) error {
 
 	existing, err := s.repository.FindById(ctx, id)
 	if err != nil {
-		return errors.ErrGeneric
+		return repositoryError(err)
 	}
 	if existing == nil {
 		return errors.ErrProductNotFound
 	}
 
-	if err := s.repository.Delete(ctx, id); err != nil {
-		return errors.ErrGeneric
+	err = s.repository.Transactional(ctx, func(repo *repositories.ProductRepository) error {
+		if err := repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.saveOutboxEvent(ctx, repo.Tx(), ProductDeletedEvent, id, ProductDeletedPayload{ID: id})
+	})
+	if err != nil {
+		return repositoryError(err)
 	}
+	s.business.RecordProductDeleted(ctx)
 
 	return nil
 }
@@ -0,0 +1,583 @@
+//go:build sqlite
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/refortunato/go_app_base/internal/shared"
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+	"github.com/refortunato/go_app_base/internal/simple_module/errors"
+	"github.com/refortunato/go_app_base/internal/simple_module/models"
+	"github.com/refortunato/go_app_base/internal/simple_module/repositories"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newTestService returns a service over an in-memory database, without cache, events or metrics
+func newTestService(t *testing.T) *ProductService {
+	t.Helper()
+	db := testhelpers.NewSQLiteForTest(t)
+	return NewProductService(
+		repositories.NewProductRepository(db),
+		repositories.NewPriceHistoryRepository(db),
+		repositories.NewProductVariantRepository(db),
+		nil, nil, nil, 0, 0, "", "v7",
+	)
+}
+
+func createProduct(t *testing.T, svc *ProductService) *models.Product {
+	t.Helper()
+	product, err := svc.CreateProduct(context.Background(), "Keyboard", "Mechanical keyboard", 100, 10, "", nil)
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	return product
+}
+
+func TestCreateProduct_IdVersion(t *testing.T) {
+	for version, want := range map[string]uuid.Version{shared.IdVersionV4: 4, shared.IdVersionV7: 7} {
+		db := testhelpers.NewSQLiteForTest(t)
+		svc := NewProductService(
+			repositories.NewProductRepository(db),
+			repositories.NewPriceHistoryRepository(db),
+			repositories.NewProductVariantRepository(db),
+			nil, nil, nil, 0, 0, "", version,
+		)
+		id, err := shared.ParseId(createProduct(t, svc).ID)
+		if err != nil || id.Version() != want {
+			t.Errorf("%s: product ID version = %d, %v, want %d", version, id.Version(), err, want)
+		}
+	}
+}
+
+// decodePatch decodes body the way the controller binds a merge patch
+func decodePatch(t *testing.T, body string) *PatchProductRequest {
+	t.Helper()
+	var req PatchProductRequest
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		t.Fatalf("Unmarshal(%s): %v", body, err)
+	}
+	return &req
+}
+
+func TestPatchProduct(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		check func(t *testing.T, p *models.Product)
+	}{
+		{
+			name:  "only price",
+			patch: `{"price": 79.5}`,
+			check: func(t *testing.T, p *models.Product) {
+				if p.Price != 79.5 || p.Name != "Keyboard" || p.Description != "Mechanical keyboard" || p.Stock != 10 {
+					t.Errorf("product = %+v, want only the price changed", p)
+				}
+			},
+		},
+		{
+			name:  "only stock",
+			patch: `{"stock": 3}`,
+			check: func(t *testing.T, p *models.Product) {
+				if p.Stock != 3 || p.Price != 100 || p.Name != "Keyboard" {
+					t.Errorf("product = %+v, want only the stock changed", p)
+				}
+			},
+		},
+		{
+			name:  "null clears description",
+			patch: `{"description": null}`,
+			check: func(t *testing.T, p *models.Product) {
+				if p.Description != "" || p.Name != "Keyboard" || p.Price != 100 {
+					t.Errorf("product = %+v, want only the description cleared", p)
+				}
+			},
+		},
+		{
+			name:  "empty patch",
+			patch: `{}`,
+			check: func(t *testing.T, p *models.Product) {
+				if p.Name != "Keyboard" || p.Description != "Mechanical keyboard" || p.Price != 100 || p.Stock != 10 {
+					t.Errorf("product = %+v, want it unchanged", p)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			svc := newTestService(t)
+			product := createProduct(t, svc)
+
+			patched, err := svc.PatchProduct(ctx, product.ID, decodePatch(t, tt.patch))
+			if err != nil {
+				t.Fatalf("PatchProduct: %v", err)
+			}
+			tt.check(t, patched)
+
+			// The patch is persisted
+			stored, err := svc.GetProduct(ctx, product.ID)
+			if err != nil {
+				t.Fatalf("GetProduct: %v", err)
+			}
+			tt.check(t, stored)
+		})
+	}
+}
+
+func TestPatchProduct_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		patch   string
+		wantErr error
+	}{
+		{name: "null name", patch: `{"name": null}`, wantErr: errors.ErrProductNameRequired},
+		{name: "negative price", patch: `{"price": -1}`, wantErr: errors.ErrProductPriceInvalid},
+		{name: "negative stock", patch: `{"stock": -1}`, wantErr: errors.ErrProductStockInvalid},
+		{name: "unknown product", id: "0190a5e8-0000-7000-8000-000000000000", patch: `{"price": 1}`, wantErr: errors.ErrProductNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			svc := newTestService(t)
+			product := createProduct(t, svc)
+			id := product.ID
+			if tt.id != "" {
+				id = tt.id
+			}
+
+			_, err := svc.PatchProduct(ctx, id, decodePatch(t, tt.patch))
+			if !stdErrors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			stored, err := svc.GetProduct(ctx, product.ID)
+			if err != nil {
+				t.Fatalf("GetProduct: %v", err)
+			}
+			if stored.Name != "Keyboard" || stored.Price != 100 || stored.Stock != 10 {
+				t.Errorf("stored product = %+v, want it unchanged", stored)
+			}
+		})
+	}
+}
+
+func TestPatchProductRequest_UnmarshalJSON(t *testing.T) {
+	req := decodePatch(t, `{"price": 5, "description": null}`)
+
+	if req.Price == nil || *req.Price != 5 {
+		t.Errorf("Price = %v, want 5", req.Price)
+	}
+	if req.Description == nil || *req.Description != "" {
+		t.Errorf("Description = %v, want an explicit empty value for null", req.Description)
+	}
+	if req.Name != nil || req.Stock != nil || req.ImageURL != nil {
+		t.Errorf("absent fields set: %+v", req)
+	}
+}
+
+func TestBulkImport_ProcessesEveryBatch(t *testing.T) {
+	ctx := context.Background()
+	db := testhelpers.NewSQLiteForTest(t)
+	svc := NewProductService(
+		repositories.NewProductRepository(db),
+		repositories.NewPriceHistoryRepository(db),
+		repositories.NewProductVariantRepository(db),
+		nil, nil, nil, 2, 0, "", "v7",
+	)
+
+	requests := []*CreateProductRequest{
+		{Name: "Keyboard", Price: 99.9, Stock: 10},
+		{Name: "", Price: 10, Stock: 1},
+		{Name: "Mouse", Price: -5, Stock: 1},
+		{Name: "Monitor", Price: 1299, Stock: 3, ExternalID: "ext-1"},
+		{Name: "Monitor copy", Price: 1299, Stock: 3, ExternalID: "ext-1"},
+		{Name: "Cable", Price: 9.9, Stock: -1},
+		{Name: "Headset", Price: 199, Stock: 4},
+		{Name: "Webcam", Price: 149, Stock: 2},
+	}
+
+	result := svc.BulkImport(ctx, requests)
+
+	if result.Created != 4 || result.Updated != 0 || result.Skipped != 4 {
+		t.Errorf("result = %+v, want 4 created and 4 skipped", result)
+	}
+	wantErrors := []BulkImportError{
+		{Row: 2, Message: errors.ErrProductNameRequired.Detail},
+		{Row: 3, Message: errors.ErrProductPriceInvalid.Detail},
+		{Row: 5, Message: errors.ErrImportDuplicateExternalID.Detail},
+		{Row: 6, Message: errors.ErrProductStockInvalid.Detail},
+	}
+	if len(result.Errors) != len(wantErrors) {
+		t.Fatalf("errors = %+v, want %+v", result.Errors, wantErrors)
+	}
+	for i, want := range wantErrors {
+		if result.Errors[i] != want {
+			t.Errorf("errors[%d] = %+v, want %+v", i, result.Errors[i], want)
+		}
+	}
+
+	// With a batch size of 2 the valid rows span two batches, all of them stored
+	list, err := svc.ListProducts(ctx, 1, 100, "")
+	if err != nil {
+		t.Fatalf("ListProducts: %v", err)
+	}
+	if len(list.Items) != 4 {
+		t.Errorf("stored products = %d, want 4", len(list.Items))
+	}
+}
+
+func TestBulkImport_SameRowsTwice(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+	requests := func(price float64) []*CreateProductRequest {
+		return []*CreateProductRequest{
+			{Name: "Keyboard", Price: price, Stock: 10, ExternalID: "ext-1"},
+			{Name: "Mouse", Price: price, Stock: 5, ExternalID: "ext-2"},
+			{Name: "Cable", Price: price, Stock: 1},
+		}
+	}
+
+	if result := svc.BulkImport(ctx, requests(10)); result.Created != 3 || result.Updated != 0 {
+		t.Fatalf("first import = %+v, want 3 created", result)
+	}
+	list, _ := svc.ListProducts(ctx, 1, 100, "")
+	var keyboard *models.Product
+	for _, p := range list.Items {
+		if p.ExternalID == "ext-1" {
+			keyboard = p
+		}
+	}
+	if keyboard == nil {
+		t.Fatal("imported product with external ID ext-1 not found")
+	}
+	if _, err := svc.SetStockThreshold(ctx, keyboard.ID, 3); err != nil {
+		t.Fatalf("SetStockThreshold: %v", err)
+	}
+
+	// Rows with an external ID update their product, the row without one is created again
+	if result := svc.BulkImport(ctx, requests(20)); result.Created != 1 || result.Updated != 2 || result.Skipped != 0 {
+		t.Errorf("second import = %+v, want 1 created and 2 updated", result)
+	}
+	list, _ = svc.ListProducts(ctx, 1, 100, "")
+	if len(list.Items) != 4 {
+		t.Errorf("products = %d, want 4 after importing 3 rows twice", len(list.Items))
+	}
+	updated, err := svc.GetProduct(ctx, keyboard.ID)
+	if err != nil {
+		t.Fatalf("GetProduct: %v", err)
+	}
+	if updated.Price != 20 || updated.StockThreshold != 3 {
+		t.Errorf("updated product = %+v, want the new price and the kept stock threshold", updated)
+	}
+}
+
+// spanEvents runs fn inside a span and returns the events recorded on it, by name
+func spanEvents(t *testing.T, fn func(ctx context.Context)) map[string]map[attribute.Key]attribute.Value {
+	t.Helper()
+	spans := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)).Tracer("test")
+
+	ctx, span := tracer.Start(context.Background(), "operation")
+	fn(ctx)
+	span.End()
+
+	events := map[string]map[attribute.Key]attribute.Value{}
+	for _, s := range spans.Ended() {
+		if s.Name() != "operation" {
+			continue
+		}
+		for _, event := range s.Events() {
+			attrs := map[attribute.Key]attribute.Value{}
+			for _, kv := range event.Attributes {
+				attrs[kv.Key] = kv.Value
+			}
+			events[event.Name] = attrs
+		}
+	}
+	return events
+}
+
+func TestCreateProduct_SpanEvents(t *testing.T) {
+	svc := newTestService(t)
+
+	var product *models.Product
+	events := spanEvents(t, func(ctx context.Context) {
+		var err error
+		product, err = svc.CreateProduct(ctx, "Keyboard", "Mechanical keyboard", 100, 10, "", nil)
+		if err != nil {
+			t.Fatalf("CreateProduct: %v", err)
+		}
+	})
+
+	if validated, ok := events["product.validated"]; !ok || validated["product.name"].AsString() != "Keyboard" {
+		t.Errorf("product.validated = %v, want product.name=Keyboard", validated)
+	}
+	if saved, ok := events["product.saved"]; !ok || saved["product.id"].AsString() != product.ID {
+		t.Errorf("product.saved = %v, want product.id=%s", saved, product.ID)
+	}
+
+	// A rejected product is neither validated nor saved
+	events = spanEvents(t, func(ctx context.Context) {
+		svc.CreateProduct(ctx, "", "", 100, 10, "", nil)
+	})
+	if len(events) != 0 {
+		t.Errorf("events for an invalid product = %v, want none", events)
+	}
+}
+
+func TestUpdateProduct_PriceChangedSpanEvent(t *testing.T) {
+	svc := newTestService(t)
+	product := createProduct(t, svc)
+
+	events := spanEvents(t, func(ctx context.Context) {
+		if _, err := svc.UpdateProduct(ctx, product.ID, "Keyboard", "Mechanical keyboard", 79.9, 10, ""); err != nil {
+			t.Fatalf("UpdateProduct: %v", err)
+		}
+	})
+	changed, ok := events["product.price_changed"]
+	if !ok || changed["old_price"].AsFloat64() != 100 || changed["new_price"].AsFloat64() != 79.9 {
+		t.Errorf("product.price_changed = %v, want old_price=100 new_price=79.9", changed)
+	}
+
+	// Updates keeping the price do not record the event
+	events = spanEvents(t, func(ctx context.Context) {
+		if _, err := svc.UpdateProduct(ctx, product.ID, "Keyboard", "Wireless keyboard", 79.9, 10, ""); err != nil {
+			t.Fatalf("UpdateProduct: %v", err)
+		}
+	})
+	if _, ok := events["product.price_changed"]; ok {
+		t.Error("product.price_changed recorded without a price change")
+	}
+}
+
+func TestBatchLookup_ValidatesIds(t *testing.T) {
+	db := testhelpers.NewSQLiteForTest(t)
+	svc := NewProductService(repositories.NewProductRepository(db), nil, nil, nil, nil, nil, 0, 2, "", "v7")
+	product := createProduct(t, svc)
+
+	tests := []struct {
+		name    string
+		ids     []string
+		wantErr error
+	}{
+		{name: "empty", ids: nil, wantErr: errors.ErrProductIdsRequired},
+		{name: "over the limit", ids: []string{"a", "b", "c"}, wantErr: errors.ErrProductIdsTooMany},
+		{name: "at the limit", ids: []string{product.ID, "unknown"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.GetProductsByIds(context.Background(), tt.ids)
+			if err != tt.wantErr {
+				t.Errorf("GetProductsByIds: err = %v, want %v", err, tt.wantErr)
+			}
+			_, err = svc.ProductsExist(context.Background(), tt.ids)
+			if err != tt.wantErr {
+				t.Errorf("ProductsExist: err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateProduct_ImageURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		cdnBaseURL string
+		imageURL   string
+		wantErr    error
+		want       string
+	}{
+		{name: "empty", imageURL: ""},
+		{name: "https", imageURL: "https://images.example.com/keyboard.jpg", want: "https://images.example.com/keyboard.jpg"},
+		{name: "http", imageURL: "http://images.example.com/keyboard.jpg", want: "http://images.example.com/keyboard.jpg"},
+		{name: "unsupported scheme", imageURL: "ftp://images.example.com/keyboard.jpg", wantErr: errors.ErrProductImageURLInvalid},
+		{name: "missing host", imageURL: "https:///keyboard.jpg", wantErr: errors.ErrProductImageURLInvalid},
+		{name: "unparsable", imageURL: "https://images example.com/%zz", wantErr: errors.ErrProductImageURLInvalid},
+		{name: "relative without CDN", imageURL: "products/keyboard.jpg", wantErr: errors.ErrProductImageURLInvalid},
+		{name: "relative with CDN", cdnBaseURL: "https://cdn.example.com/", imageURL: "/products/keyboard.jpg", want: "https://cdn.example.com/products/keyboard.jpg"},
+		{name: "absolute with CDN", cdnBaseURL: "https://cdn.example.com", imageURL: "https://images.example.com/keyboard.jpg", want: "https://images.example.com/keyboard.jpg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testhelpers.NewSQLiteForTest(t)
+			svc := NewProductService(repositories.NewProductRepository(db), nil, nil, nil, nil, nil, 0, 0, tt.cdnBaseURL, "v7")
+
+			product, err := svc.CreateProduct(context.Background(), "Keyboard", "Mechanical keyboard", 100, 10, tt.imageURL, nil)
+			if !stdErrors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateProduct: err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if product.ImageURL != tt.want {
+				t.Errorf("created ImageURL = %q, want %q", product.ImageURL, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductReads_KeepStoredImagePaths(t *testing.T) {
+	db := testhelpers.NewSQLiteForTest(t)
+	svc := NewProductService(repositories.NewProductRepository(db), nil, nil, nil, nil, nil, 0, 0, "https://cdn.example.com", "v7")
+	ctx := context.Background()
+
+	product, err := svc.CreateProduct(ctx, "Keyboard", "Mechanical keyboard", 100, 10, "products/keyboard.jpg", nil)
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+
+	// Reads return the relative path; the controller response rewrites it under the CDN
+	if got, err := svc.GetProduct(ctx, product.ID); err != nil || got.ImageURL != "products/keyboard.jpg" {
+		t.Errorf("GetProduct ImageURL = %q, %v, want the stored relative path", got.ImageURL, err)
+	}
+	list, err := svc.ListProducts(ctx, 1, 10, "")
+	if err != nil || len(list.Items) != 1 || list.Items[0].ImageURL != "products/keyboard.jpg" {
+		t.Errorf("ListProducts = %+v, %v, want the stored relative path", list, err)
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr error
+	}{
+		{name: "none", tags: nil, want: nil},
+		{name: "trimmed, lowercased and deduplicated", tags: []string{" Sale ", "sale", "NEW"}, want: []string{"sale", "new"}},
+		{name: "blank", tags: []string{"sale", "  "}, wantErr: errors.ErrProductTagInvalid},
+		{name: "too long", tags: []string{strings.Repeat("x", maxTagLength+1)}, wantErr: errors.ErrProductTagInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeTags(tt.tags)
+			if err != tt.wantErr {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("normalizeTags = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Storing a tag needs MySQL (see product_tags_integration_test.go); the rest runs on SQLite
+func TestProductTags_WithoutStoredTags(t *testing.T) {
+	svc := newTestService(t)
+	product := createProduct(t, svc)
+	ctx := context.Background()
+
+	if _, err := svc.AddProductTags(ctx, product.ID, nil); err != errors.ErrProductTagInvalid {
+		t.Errorf("AddProductTags(no tags): err = %v, want ErrProductTagInvalid", err)
+	}
+	if _, err := svc.AddProductTags(ctx, "unknown", []string{"sale"}); err != errors.ErrProductNotFound {
+		t.Errorf("AddProductTags(unknown product): err = %v, want ErrProductNotFound", err)
+	}
+	if err := svc.RemoveProductTag(ctx, product.ID, "sale"); err != nil {
+		t.Errorf("RemoveProductTag(tag not set): err = %v, want a no-op", err)
+	}
+	if err := svc.RemoveProductTag(ctx, "unknown", "sale"); err != errors.ErrProductNotFound {
+		t.Errorf("RemoveProductTag(unknown product): err = %v, want ErrProductNotFound", err)
+	}
+
+	list, err := svc.ListProducts(ctx, 1, 10, "sale")
+	if err != nil {
+		t.Fatalf("ListProducts(tag=sale): %v", err)
+	}
+	if len(list.Items) != 0 || list.Pagination.TotalItems != 0 {
+		t.Errorf("tag=sale = %d items, total %d, want none", len(list.Items), list.Pagination.TotalItems)
+	}
+	if _, err := svc.ListProducts(ctx, 1, 10, " "); err != errors.ErrProductTagInvalid {
+		t.Errorf("ListProducts(blank tag): err = %v, want ErrProductTagInvalid", err)
+	}
+}
+
+func TestGetPriceHistory_RecordsEachPriceChange(t *testing.T) {
+	svc := newTestService(t)
+	product := createProduct(t, svc)
+	ctx := context.Background()
+
+	// Three price changes, then an update that keeps the price
+	for _, price := range []float64{110, 95, 120, 120} {
+		if _, err := svc.UpdateProduct(ctx, product.ID, product.Name, product.Description, price, product.Stock, ""); err != nil {
+			t.Fatalf("UpdateProduct(price=%v): %v", price, err)
+		}
+	}
+
+	history, err := svc.GetPriceHistory(ctx, product.ID, 1, 10)
+	if err != nil {
+		t.Fatalf("GetPriceHistory: %v", err)
+	}
+	if len(history.Items) != 3 || history.Pagination.TotalItems != 3 {
+		t.Fatalf("history = %d items, total %d, want 3", len(history.Items), history.Pagination.TotalItems)
+	}
+	// Newest first
+	want := [][2]float64{{95, 120}, {110, 95}, {100, 110}}
+	for i, entry := range history.Items {
+		if entry.ProductID != product.ID || entry.OldPrice != want[i][0] || entry.NewPrice != want[i][1] {
+			t.Errorf("entry %d = %+v, want %v -> %v", i, entry, want[i][0], want[i][1])
+		}
+	}
+
+	page, err := svc.GetPriceHistory(ctx, product.ID, 2, 2)
+	if err != nil {
+		t.Fatalf("GetPriceHistory(page 2): %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].NewPrice != 110 {
+		t.Errorf("page 2 = %+v, want the oldest change", page.Items)
+	}
+
+	if _, err := svc.GetPriceHistory(ctx, "unknown", 1, 10); err != errors.ErrProductNotFound {
+		t.Errorf("GetPriceHistory(unknown): err = %v, want ErrProductNotFound", err)
+	}
+}
+
+func TestListProducts_CountCache(t *testing.T) {
+	ctx := context.Background()
+	db := testhelpers.NewSQLiteForTest(t)
+	repo := repositories.NewProductRepository(db)
+	svc := NewProductService(repo, repositories.NewPriceHistoryRepository(db), repositories.NewProductVariantRepository(db), nil, nil, nil, 0, 0, "", "v7")
+
+	total := func() (int, int) {
+		t.Helper()
+		list, err := svc.ListProducts(ctx, 1, 10, "")
+		if err != nil {
+			t.Fatalf("ListProducts: %v", err)
+		}
+		return list.Pagination.TotalItems, len(list.Items)
+	}
+
+	// Cold cache: every list counts for real
+	createProduct(t, svc)
+	if got, _ := total(); got != 1 {
+		t.Errorf("cold cache: total = %d, want 1", got)
+	}
+	createProduct(t, svc)
+	if got, _ := total(); got != 2 {
+		t.Errorf("cold cache after a create: total = %d, want 2", got)
+	}
+
+	// Warm cache: the total is the cached count even if it lags behind the table
+	repo.StoreCachedCount(7)
+	if got, items := total(); got != 7 || items != 2 {
+		t.Errorf("warm cache: total = %d with %d items, want the cached 7 with 2 items", got, items)
+	}
+
+	// The tag filter always counts for real
+	list, err := svc.ListProducts(ctx, 1, 10, "sale")
+	if err != nil {
+		t.Fatalf("ListProducts(tag=sale): %v", err)
+	}
+	if list.Pagination.TotalItems != 0 {
+		t.Errorf("tag=sale: total = %d, want the real count 0", list.Pagination.TotalItems)
+	}
+}
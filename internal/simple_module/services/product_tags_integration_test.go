@@ -0,0 +1,80 @@
+//go:build integration
+
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+	"github.com/refortunato/go_app_base/internal/simple_module/repositories"
+)
+
+// Tagging relies on MySQL's INSERT ... ON DUPLICATE KEY UPDATE, so it is tested against a MySQL container
+func TestProductTags_TagFilterAndRemove(t *testing.T) {
+	db := testhelpers.NewMySQLForTest(t)
+	svc := NewProductService(repositories.NewProductRepository(db), nil, nil, nil, nil, nil, 0, 0, "", "v7")
+	ctx := context.Background()
+
+	laptop, err := svc.CreateProduct(ctx, "Laptop", "", 5000, 3, "", []string{" Electronics ", "electronics", "Sale"})
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if !slices.Equal(laptop.Tags, []string{"electronics", "sale"}) {
+		t.Errorf("created tags = %v, want normalized and deduplicated", laptop.Tags)
+	}
+	mouse, err := svc.CreateProduct(ctx, "Mouse", "", 50, 10, "", []string{"electronics"})
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+
+	// Tagging twice with the same tag keeps a single association
+	for i := 0; i < 2; i++ {
+		if _, err := svc.AddProductTags(ctx, mouse.ID, []string{"Sale"}); err != nil {
+			t.Fatalf("AddProductTags: %v", err)
+		}
+	}
+	if got, _ := svc.GetProduct(ctx, mouse.ID); !slices.Equal(got.Tags, []string{"electronics", "sale"}) {
+		t.Errorf("mouse tags = %v, want electronics and sale", got.Tags)
+	}
+
+	// filter returns the names of the products listed for tag
+	filter := func(tag string) []string {
+		t.Helper()
+		list, err := svc.ListProducts(ctx, 1, 10, tag)
+		if err != nil {
+			t.Fatalf("ListProducts(tag=%s): %v", tag, err)
+		}
+		var names []string
+		for _, product := range list.Items {
+			names = append(names, product.Name)
+		}
+		slices.Sort(names)
+		if list.Pagination.TotalItems != len(names) {
+			t.Errorf("tag=%s: total = %d, want %d", tag, list.Pagination.TotalItems, len(names))
+		}
+		return names
+	}
+	if got := filter("SALE"); !slices.Equal(got, []string{"Laptop", "Mouse"}) {
+		t.Errorf("tag=sale: %v, want Laptop and Mouse", got)
+	}
+
+	if err := svc.RemoveProductTag(ctx, laptop.ID, "sale"); err != nil {
+		t.Fatalf("RemoveProductTag: %v", err)
+	}
+	if got := filter("sale"); !slices.Equal(got, []string{"Mouse"}) {
+		t.Errorf("tag=sale after removal: %v, want Mouse", got)
+	}
+	if got, _ := svc.GetProduct(ctx, laptop.ID); !slices.Equal(got.Tags, []string{"electronics"}) {
+		t.Errorf("laptop tags = %v, want electronics", got.Tags)
+	}
+
+	// A full update rewrites the tag associations it keeps
+	if _, err := svc.UpdateProduct(ctx, mouse.ID, "Mouse", "", 45, 10, ""); err != nil {
+		t.Fatalf("UpdateProduct: %v", err)
+	}
+	if got := filter("electronics"); !slices.Equal(got, []string{"Laptop", "Mouse"}) {
+		t.Errorf("tag=electronics after update: %v, want Laptop and Mouse", got)
+	}
+}
@@ -0,0 +1,175 @@
+//go:build integration
+
+package services
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/refortunato/go_app_base/internal/shared/messaging/eventbus"
+	"github.com/refortunato/go_app_base/internal/shared/messaging/outbox"
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+	"github.com/refortunato/go_app_base/internal/simple_module/errors"
+	"github.com/refortunato/go_app_base/internal/simple_module/repositories"
+)
+
+// UpsertProduct relies on MySQL's INSERT ... ON DUPLICATE KEY UPDATE, so it is tested against
+// a MySQL container; the subtests share it and use their own product IDs
+func TestUpsertProduct(t *testing.T) {
+	db := testhelpers.NewMySQLForTest(t)
+	outboxRepo := outbox.NewOutboxRepository(db)
+	priceHistory := repositories.NewPriceHistoryRepository(db)
+	svc := NewProductService(
+		repositories.NewProductRepository(db),
+		priceHistory,
+		repositories.NewProductVariantRepository(db),
+		outboxRepo, eventbus.New(), nil, 0, 0, "", "v7",
+	)
+	ctx := context.Background()
+
+	// outboxEventTypes returns the event types stored in the outbox for productID, oldest first
+	outboxEventTypes := func(t *testing.T, productID string) []string {
+		t.Helper()
+		events, err := outboxRepo.FindPending(ctx, 1000)
+		if err != nil {
+			t.Fatalf("FindPending: %v", err)
+		}
+		var types []string
+		for _, event := range events {
+			if event.AggregateID == productID {
+				types = append(types, event.EventType)
+			}
+		}
+		return types
+	}
+
+	t.Run("creates the product", func(t *testing.T) {
+		id := uuid.NewString()
+		product, created, err := svc.UpsertProduct(ctx, &UpsertProductRequest{ID: id, Name: "Laptop", Price: 100, Stock: 10, StockThreshold: 2})
+		if err != nil {
+			t.Fatalf("UpsertProduct: %v", err)
+		}
+		if !created || product.ID != id {
+			t.Fatalf("created = %v, id = %q", created, product.ID)
+		}
+		if types := outboxEventTypes(t, id); len(types) != 1 || types[0] != ProductCreatedEvent {
+			t.Errorf("outbox events = %v, want [%s]", types, ProductCreatedEvent)
+		}
+	})
+
+	t.Run("creates a product with a generated ID", func(t *testing.T) {
+		product, created, err := svc.UpsertProduct(ctx, &UpsertProductRequest{Name: "Mouse", Price: 10, Stock: 1})
+		if err != nil {
+			t.Fatalf("UpsertProduct: %v", err)
+		}
+		if !created || uuid.Validate(product.ID) != nil {
+			t.Fatalf("created = %v, id = %q", created, product.ID)
+		}
+	})
+
+	t.Run("replacement has the update side effects", func(t *testing.T) {
+		id := uuid.NewString()
+		original, _, err := svc.UpsertProduct(ctx, &UpsertProductRequest{ID: id, Name: "Laptop", Price: 100, Stock: 10, StockThreshold: 5, ImageURL: "https://cdn.example.com/a.jpg"})
+		if err != nil {
+			t.Fatalf("UpsertProduct: %v", err)
+		}
+		changes, unsubscribe := svc.SubscribeStockChanges(id)
+		defer unsubscribe()
+
+		product, created, err := svc.UpsertProduct(ctx, &UpsertProductRequest{ID: id, Name: "Laptop", Price: 90, Stock: 0, StockThreshold: 5})
+		if err != nil {
+			t.Fatalf("UpsertProduct: %v", err)
+		}
+		if created {
+			t.Fatal("expected a replacement")
+		}
+		if product.ImageURL != "" || product.StockThreshold != 5 {
+			t.Errorf("image_url, stock_threshold = %q, %d", product.ImageURL, product.StockThreshold)
+		}
+		if !product.CreatedAt.Equal(original.CreatedAt) {
+			t.Errorf("created_at = %v, want the original %v", product.CreatedAt, original.CreatedAt)
+		}
+
+		// Stock subscribers (WebSocket, email notifier) see the drop to 0
+		select {
+		case event := <-changes:
+			payload := event.Payload.(ProductStockChangedPayload)
+			if payload.PreviousStock != 10 || payload.Stock != 0 {
+				t.Errorf("stock change = %+v, want 10 -> 0", payload)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("no stock change published")
+		}
+
+		types := outboxEventTypes(t, id)
+		if len(types) != 3 || types[0] != ProductCreatedEvent || !slices.Contains(types[1:], ProductUpdatedEvent) || !slices.Contains(types[1:], ProductStockChangedEvent) {
+			t.Errorf("outbox events = %v", types)
+		}
+
+		history, err := priceHistory.FindByProductId(ctx, id, 10, 0)
+		if err != nil {
+			t.Fatalf("FindByProductId: %v", err)
+		}
+		if len(history) != 1 || history[0].OldPrice != 100 || history[0].NewPrice != 90 {
+			t.Errorf("price history = %+v, want one 100 -> 90 entry", history)
+		}
+	})
+
+	t.Run("rejects a stock change on a product with variants", func(t *testing.T) {
+		product, err := svc.CreateProduct(ctx, "T-shirt", "", 50, 10, "", nil)
+		if err != nil {
+			t.Fatalf("CreateProduct: %v", err)
+		}
+		if _, err := svc.AddVariant(ctx, product.ID, map[string]string{"color": "black"}, 50, 4); err != nil {
+			t.Fatalf("AddVariant: %v", err)
+		}
+
+		_, _, err = svc.UpsertProduct(ctx, &UpsertProductRequest{ID: product.ID, Name: "T-shirt", Price: 50, Stock: 9})
+		if err != errors.ErrProductStockManagedByVariants {
+			t.Fatalf("expected ErrProductStockManagedByVariants, got %v", err)
+		}
+		if _, _, err := svc.UpsertProduct(ctx, &UpsertProductRequest{ID: product.ID, Name: "T-shirt v2", Price: 50, Stock: 4}); err != nil {
+			t.Fatalf("upsert with the variant total: %v", err)
+		}
+	})
+
+	t.Run("concurrent replacements record consecutive prices", func(t *testing.T) {
+		id := uuid.NewString()
+		if _, _, err := svc.UpsertProduct(ctx, &UpsertProductRequest{ID: id, Name: "Laptop", Price: 100, Stock: 10}); err != nil {
+			t.Fatalf("UpsertProduct: %v", err)
+		}
+
+		const writers = 8
+		var wg sync.WaitGroup
+		for i := 1; i <= writers; i++ {
+			wg.Add(1)
+			go func(price float64) {
+				defer wg.Done()
+				if _, _, err := svc.UpsertProduct(ctx, &UpsertProductRequest{ID: id, Name: "Laptop", Price: price, Stock: 10}); err != nil {
+					t.Errorf("UpsertProduct(%v): %v", price, err)
+				}
+			}(float64(100 + i))
+		}
+		wg.Wait()
+
+		history, err := priceHistory.FindByProductId(ctx, id, 100, 0)
+		if err != nil {
+			t.Fatalf("FindByProductId: %v", err)
+		}
+		if len(history) != writers {
+			t.Fatalf("price history = %d entries, want %d", len(history), writers)
+		}
+		// Each change starts from the price the previous one set
+		oldPrices := map[float64]bool{}
+		for _, entry := range history {
+			if oldPrices[entry.OldPrice] {
+				t.Fatalf("two changes from the same old price %v: %+v", entry.OldPrice, history)
+			}
+			oldPrices[entry.OldPrice] = true
+		}
+	})
+}
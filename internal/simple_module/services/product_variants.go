@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/shared"
+	"github.com/refortunato/go_app_base/internal/simple_module/errors"
+	"github.com/refortunato/go_app_base/internal/simple_module/models"
+)
+
+// AddVariantRequest represents the request body for adding a product variant
+type AddVariantRequest struct {
+	Attributes map[string]string `json:"attributes" swaggertype:"object,string" example:"color:black,size:15-inch"`
+	Price      float64           `json:"price" example:"5699.99"`
+	Stock      int               `json:"stock" example:"4"`
+}
+
+// AddVariant adds a variant to an existing product; the product stock becomes the sum of its variant stock
+func (s *ProductService) AddVariant(ctx context.Context, productID string, attributes map[string]string, price float64, stock int) (*models.ProductVariant, error) {
+	if productID == "" {
+		return nil, errors.ErrProductIdRequired
+	}
+	normalized, err := normalizeVariantAttributes(attributes)
+	if err != nil {
+		return nil, err
+	}
+	if price < 0 {
+		return nil, errors.ErrProductPriceInvalid
+	}
+	if stock < 0 {
+		return nil, errors.ErrProductStockInvalid
+	}
+
+	product, err := s.repository.FindById(ctx, productID)
+	if err != nil {
+		return nil, errors.ErrGeneric
+	}
+	if product == nil {
+		return nil, errors.ErrProductNotFound
+	}
+
+	variant := &models.ProductVariant{
+		ID:         shared.GenerateId(),
+		ProductID:  productID,
+		Attributes: normalized,
+		Price:      price,
+		Stock:      stock,
+		CreatedAt:  time.Now().UTC(),
+	}
+	totalStock, err := s.variants.Create(ctx, variant)
+	if err != nil {
+		return nil, errors.ErrGeneric
+	}
+	s.variantStockChanged(ctx, product, totalStock)
+
+	return variant, nil
+}
+
+// ListVariants returns the variants of an existing product, oldest first
+func (s *ProductService) ListVariants(ctx context.Context, productID string) ([]*models.ProductVariant, error) {
+	if productID == "" {
+		return nil, errors.ErrProductIdRequired
+	}
+
+	product, err := s.repository.FindById(ctx, productID)
+	if err != nil {
+		return nil, errors.ErrGeneric
+	}
+	if product == nil {
+		return nil, errors.ErrProductNotFound
+	}
+
+	// FindById already loaded them; return an empty list rather than null
+	if product.Variants == nil {
+		return []*models.ProductVariant{}, nil
+	}
+	return product.Variants, nil
+}
+
+// DeleteVariant removes a variant of a product; the product stock becomes the sum of the remaining variants
+func (s *ProductService) DeleteVariant(ctx context.Context, productID, variantID string) error {
+	if productID == "" {
+		return errors.ErrProductIdRequired
+	}
+
+	product, err := s.repository.FindById(ctx, productID)
+	if err != nil {
+		return errors.ErrGeneric
+	}
+	if product == nil {
+		return errors.ErrProductNotFound
+	}
+
+	variant, err := s.variants.FindById(ctx, productID, variantID)
+	if err != nil {
+		return errors.ErrGeneric
+	}
+	if variant == nil {
+		return errors.ErrVariantNotFound
+	}
+
+	totalStock, err := s.variants.Delete(ctx, productID, variantID)
+	if err != nil {
+		return errors.ErrGeneric
+	}
+	s.variantStockChanged(ctx, product, totalStock)
+
+	return nil
+}
+
+// variantStockChanged reports the new aggregate stock of product like a direct stock update:
+// low-stock alert and WebSocket subscribers
+func (s *ProductService) variantStockChanged(ctx context.Context, product *models.Product, totalStock int) {
+	previousStock := product.Stock
+	product.Stock = totalStock
+	s.stockChanged(ctx, product, previousStock)
+}
+
+// normalizeVariantAttributes trims attribute names and values, rejecting an empty set or empty entries
+func normalizeVariantAttributes(attributes map[string]string) (models.VariantAttributes, error) {
+	if len(attributes) == 0 {
+		return nil, errors.ErrVariantAttributesInvalid
+	}
+
+	normalized := make(models.VariantAttributes, len(attributes))
+	for name, value := range attributes {
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if name == "" || value == "" {
+			return nil, errors.ErrVariantAttributesInvalid
+		}
+		normalized[name] = value
+	}
+	return normalized, nil
+}
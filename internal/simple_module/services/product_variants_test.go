@@ -0,0 +1,111 @@
+//go:build sqlite
+
+package services
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+
+	"github.com/refortunato/go_app_base/internal/shared/testhelpers"
+	"github.com/refortunato/go_app_base/internal/simple_module/errors"
+	"github.com/refortunato/go_app_base/internal/simple_module/repositories"
+)
+
+func newVariantTestService(t *testing.T) *ProductService {
+	t.Helper()
+	db := testhelpers.NewSQLiteForTest(t)
+	return NewProductService(
+		repositories.NewProductRepository(db),
+		repositories.NewPriceHistoryRepository(db),
+		repositories.NewProductVariantRepository(db),
+		nil, nil, nil, 0, 0, "", "v7",
+	)
+}
+
+func productStock(t *testing.T, svc *ProductService, id string) int {
+	t.Helper()
+	product, err := svc.GetProduct(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetProduct: %v", err)
+	}
+	return product.Stock
+}
+
+func TestVariants_StockIsTheSumAndRestoredAfterLastDelete(t *testing.T) {
+	ctx := context.Background()
+	svc := newVariantTestService(t)
+
+	product, err := svc.CreateProduct(ctx, "T-shirt", "Cotton", 50, 10, "", nil)
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+
+	black, err := svc.AddVariant(ctx, product.ID, map[string]string{"color": "black"}, 50, 4)
+	if err != nil {
+		t.Fatalf("AddVariant: %v", err)
+	}
+	white, err := svc.AddVariant(ctx, product.ID, map[string]string{"color": "white"}, 50, 3)
+	if err != nil {
+		t.Fatalf("AddVariant: %v", err)
+	}
+	if got := productStock(t, svc, product.ID); got != 7 {
+		t.Errorf("stock with two variants = %d, want 7", got)
+	}
+
+	if err := svc.DeleteVariant(ctx, product.ID, black.ID); err != nil {
+		t.Fatalf("DeleteVariant: %v", err)
+	}
+	if got := productStock(t, svc, product.ID); got != 3 {
+		t.Errorf("stock after deleting a variant = %d, want 3", got)
+	}
+
+	if err := svc.DeleteVariant(ctx, product.ID, white.ID); err != nil {
+		t.Fatalf("DeleteVariant: %v", err)
+	}
+	if got := productStock(t, svc, product.ID); got != 10 {
+		t.Errorf("stock after deleting the last variant = %d, want the original 10", got)
+	}
+
+	// Without variants the stock is managed directly again
+	stock := 12
+	if _, err := svc.PatchProduct(ctx, product.ID, &PatchProductRequest{Stock: &stock}); err != nil {
+		t.Fatalf("PatchProduct without variants: %v", err)
+	}
+}
+
+func TestVariants_DirectStockWritesAreRejected(t *testing.T) {
+	ctx := context.Background()
+	svc := newVariantTestService(t)
+
+	product, err := svc.CreateProduct(ctx, "Laptop", "15 inch", 5000, 10, "", nil)
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if _, err := svc.AddVariant(ctx, product.ID, map[string]string{"size": "15"}, 5000, 4); err != nil {
+		t.Fatalf("AddVariant: %v", err)
+	}
+
+	stock := 20
+	if _, err := svc.PatchProduct(ctx, product.ID, &PatchProductRequest{Stock: &stock}); !stdErrors.Is(err, errors.ErrProductStockManagedByVariants) {
+		t.Errorf("PatchProduct stock: err = %v, want ErrProductStockManagedByVariants", err)
+	}
+	if _, err := svc.UpdateProduct(ctx, product.ID, "Laptop", "15 inch", 5000, 20, ""); !stdErrors.Is(err, errors.ErrProductStockManagedByVariants) {
+		t.Errorf("UpdateProduct stock: err = %v, want ErrProductStockManagedByVariants", err)
+	}
+	if _, _, err := svc.UpsertProduct(ctx, &UpsertProductRequest{ID: product.ID, Name: "Laptop", Price: 5000, Stock: 20}); !stdErrors.Is(err, errors.ErrProductStockManagedByVariants) {
+		t.Errorf("UpsertProduct stock: err = %v, want ErrProductStockManagedByVariants", err)
+	}
+
+	// A full update sending the current (aggregate) stock is accepted
+	updated, err := svc.UpdateProduct(ctx, product.ID, "Laptop Pro", "15 inch", 5500, 4, "")
+	if err != nil {
+		t.Fatalf("UpdateProduct with the aggregate stock: %v", err)
+	}
+	if updated.Stock != 4 {
+		t.Errorf("stock = %d, want 4", updated.Stock)
+	}
+	if got := productStock(t, svc, product.ID); got != 4 {
+		t.Errorf("stored stock = %d, want 4", got)
+	}
+}
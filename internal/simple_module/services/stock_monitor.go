@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/refortunato/go_app_base/internal/shared/logger"
+	"github.com/refortunato/go_app_base/internal/simple_module/models"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// stockCheckBatchSize is the page size used by CheckStockLevels to walk every product
+const stockCheckBatchSize = 100
+
+// stockMetrics holds the low-stock counter and the last stock levels observed by CheckStockLevels
+type stockMetrics struct {
+	lowThreshold metric.Int64Counter
+
+	mu     sync.RWMutex
+	levels map[string]int
+}
+
+// newStockMetrics registers the product.stock.low_threshold counter and the
+// product.stock.levels gauge, which reports the levels of the last check
+func newStockMetrics() *stockMetrics {
+	meter := otel.Meter("simple_module")
+	m := &stockMetrics{levels: make(map[string]int)}
+
+	m.lowThreshold, _ = meter.Int64Counter(
+		"product.stock.low_threshold",
+		metric.WithDescription("Number of times a product stock was found below its threshold"),
+		metric.WithUnit("{event}"),
+	)
+
+	_, _ = meter.Int64ObservableGauge(
+		"product.stock.levels",
+		metric.WithDescription("Product stock levels observed by the last stock check"),
+		metric.WithUnit("{item}"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			m.mu.RLock()
+			defer m.mu.RUnlock()
+			for id, stock := range m.levels {
+				o.Observe(int64(stock), metric.WithAttributes(attribute.String("product.id", id)))
+			}
+			return nil
+		}),
+	)
+
+	return m
+}
+
+// setLevels replaces the stock levels reported by the gauge
+func (m *stockMetrics) setLevels(levels map[string]int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.levels = levels
+}
+
+// reportLowStock increments the low-stock counter and logs a warning when the
+// product has a threshold and its stock is below it; returns whether it did
+func (s *ProductService) reportLowStock(ctx context.Context, product *models.Product) bool {
+	if product.StockThreshold <= 0 || product.Stock >= product.StockThreshold {
+		return false
+	}
+
+	s.stock.lowThreshold.Add(ctx, 1, metric.WithAttributes(attribute.String("product.id", product.ID)))
+	logger.FromContext(ctx).Warn(ctx, "Product stock below threshold", logger.CustomFields{
+		"product.id":      product.ID,
+		"current_stock":   product.Stock,
+		"stock_threshold": product.StockThreshold,
+	})
+	return true
+}
+
+// CheckStockLevels walks every product in batches, refreshing the product.stock.levels
+// gauge and reporting the products below their threshold
+// Returns how many products are below their threshold
+func (s *ProductService) CheckStockLevels(ctx context.Context) (int, error) {
+	levels := make(map[string]int)
+	low := 0
+
+	for offset := 0; ; offset += stockCheckBatchSize {
+		products, err := s.repository.FindAll(ctx, stockCheckBatchSize, offset)
+		if err != nil {
+			return low, err
+		}
+
+		for _, product := range products {
+			levels[product.ID] = product.Stock
+			if s.reportLowStock(ctx, product) {
+				low++
+			}
+		}
+
+		if len(products) < stockCheckBatchSize {
+			break
+		}
+	}
+
+	s.stock.setLevels(levels)
+	return low, nil
+}
+
+// StockMonitor periodically runs ProductService.CheckStockLevels in the background
+type StockMonitor struct {
+	service  *ProductService
+	interval time.Duration
+
+	cancel   context.CancelFunc
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewStockMonitor creates a monitor checking stock levels every interval (defaults to one hour)
+func NewStockMonitor(service *ProductService, interval time.Duration) *StockMonitor {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &StockMonitor{service: service, interval: interval}
+}
+
+// Start runs the check loop in a background goroutine until ctx is done or Stop is called
+func (m *StockMonitor) Start(ctx context.Context) {
+	ctx, m.cancel = context.WithCancel(ctx)
+	m.done = make(chan struct{})
+
+	go func() {
+		defer close(m.done)
+
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.check(ctx)
+			}
+		}
+	}()
+
+	logger.Info(ctx, "Stock monitor started", logger.CustomFields{"interval": m.interval.String()})
+}
+
+// Stop stops the check loop and waits for a running check to finish
+func (m *StockMonitor) Stop() {
+	m.stopOnce.Do(func() {
+		if m.cancel != nil {
+			m.cancel()
+			<-m.done
+		}
+	})
+}
+
+// check runs a single stock check, logging failures (never stops the loop)
+func (m *StockMonitor) check(ctx context.Context) {
+	low, err := m.service.CheckStockLevels(ctx)
+	if err != nil {
+		logger.WithError(err).Error(ctx, "Stock check failed")
+		return
+	}
+	logger.Debug(ctx, "Stock check completed", logger.CustomFields{"lowStockProducts": low})
+}
@@ -0,0 +1,142 @@
+//go:build sqlite
+
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// newStockMetricsReader installs a meter provider collecting on demand; services created
+// afterwards register their stock metrics on it
+func newStockMetricsReader(t *testing.T) *sdkmetric.ManualReader {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	previous := otel.GetMeterProvider()
+	otel.SetMeterProvider(provider)
+	t.Cleanup(func() {
+		otel.SetMeterProvider(previous)
+		provider.Shutdown(context.Background())
+	})
+	return reader
+}
+
+// stockDataPoints returns the value per product.id of the named int64 counter or gauge
+func stockDataPoints(t *testing.T, reader *sdkmetric.ManualReader, name string) map[string]int64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+
+	values := make(map[string]int64)
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != name {
+				continue
+			}
+			var points []metricdata.DataPoint[int64]
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				points = data.DataPoints
+			case metricdata.Gauge[int64]:
+				points = data.DataPoints
+			}
+			for _, point := range points {
+				id, _ := point.Attributes.Value(attribute.Key("product.id"))
+				values[id.AsString()] = point.Value
+			}
+		}
+	}
+	return values
+}
+
+func TestStockThreshold_LowStockCounter(t *testing.T) {
+	reader := newStockMetricsReader(t)
+	svc := newTestService(t)
+	ctx := context.Background()
+	product := createProduct(t, svc)
+
+	if _, err := svc.SetStockThreshold(ctx, product.ID, 5); err != nil {
+		t.Fatalf("SetStockThreshold: %v", err)
+	}
+	if got := stockDataPoints(t, reader, "product.stock.low_threshold")[product.ID]; got != 0 {
+		t.Errorf("counter after setting the threshold = %d, want 0 while stock is 10", got)
+	}
+
+	// Dropping below the threshold raises the alert; staying at or above it doesn't
+	for _, stock := range []int{5, 3} {
+		if _, err := svc.UpdateProduct(ctx, product.ID, product.Name, product.Description, product.Price, stock, ""); err != nil {
+			t.Fatalf("UpdateProduct(stock=%d): %v", stock, err)
+		}
+	}
+	if got := stockDataPoints(t, reader, "product.stock.low_threshold")[product.ID]; got != 1 {
+		t.Errorf("counter after the stock dropped to 3 = %d, want 1", got)
+	}
+
+	// The periodic check reports it again
+	low, err := svc.CheckStockLevels(ctx)
+	if err != nil || low != 1 {
+		t.Fatalf("CheckStockLevels = %d, %v, want 1 product below its threshold", low, err)
+	}
+	if got := stockDataPoints(t, reader, "product.stock.low_threshold")[product.ID]; got != 2 {
+		t.Errorf("counter after the stock check = %d, want 2", got)
+	}
+}
+
+func TestCheckStockLevels_StockGauge(t *testing.T) {
+	reader := newStockMetricsReader(t)
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	want := map[string]int64{}
+	// More products than a batch, so the check pages through FindAll
+	for i := 0; i < stockCheckBatchSize+5; i++ {
+		product, err := svc.CreateProduct(ctx, "Product", "", 10, i, "", nil)
+		if err != nil {
+			t.Fatalf("CreateProduct: %v", err)
+		}
+		want[product.ID] = int64(i)
+	}
+
+	if low, err := svc.CheckStockLevels(ctx); err != nil || low != 0 {
+		t.Fatalf("CheckStockLevels = %d, %v, want 0 without thresholds", low, err)
+	}
+	levels := stockDataPoints(t, reader, "product.stock.levels")
+	if len(levels) != len(want) {
+		t.Fatalf("gauge has %d products, want %d", len(levels), len(want))
+	}
+	for id, stock := range want {
+		if levels[id] != stock {
+			t.Errorf("product.stock.levels{%s} = %d, want %d", id, levels[id], stock)
+		}
+	}
+}
+
+func TestStockMonitor_RunsPeriodically(t *testing.T) {
+	reader := newStockMetricsReader(t)
+	svc := newTestService(t)
+	product := createProduct(t, svc)
+
+	monitor := NewStockMonitor(svc, 10*time.Millisecond)
+	monitor.Start(context.Background())
+	defer monitor.Stop()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for stockDataPoints(t, reader, "product.stock.levels")[product.ID] != 10 {
+		if time.Now().After(deadline) {
+			t.Fatal("the monitor did not refresh the stock gauge")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	monitor.Stop()
+	// Stop is idempotent
+	monitor.Stop()
+}
@@ -9,9 +9,13 @@ CREATE TABLE IF NOT EXISTS examples (
     id VARCHAR(36) PRIMARY KEY,
     description TEXT NOT NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
 
+-- Existing databases: add the soft-delete column
+-- ALTER TABLE examples ADD COLUMN deleted_at TIMESTAMP NULL DEFAULT NULL;
+
 -- Insert sample data
 INSERT INTO examples (id, description, created_at, updated_at) 
 VALUES 
@@ -25,6 +29,75 @@ CREATE TABLE IF NOT EXISTS products (
     description TEXT NOT NULL,
     price DECIMAL(10,2),
     stock INT,
+    stock_threshold INT NOT NULL DEFAULT 0,
+    image_url VARCHAR(2048) NOT NULL DEFAULT '',
+    external_id VARCHAR(100) NULL DEFAULT NULL,
+    base_stock INT NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_products_external_id (external_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Existing databases: add the product image column
+-- ALTER TABLE products ADD COLUMN image_url VARCHAR(2048) NOT NULL DEFAULT '' AFTER stock;
+-- Existing databases: add the low-stock alert threshold
+-- ALTER TABLE products ADD COLUMN stock_threshold INT NOT NULL DEFAULT 0 AFTER stock;
+-- Existing databases: add the import idempotency key (NULL for products created through the API)
+-- ALTER TABLE products ADD COLUMN external_id VARCHAR(100) NULL DEFAULT NULL AFTER image_url, ADD UNIQUE KEY uq_products_external_id (external_id);
+-- Existing databases: add the stock saved while a product has variants (restored when the last one is deleted)
+-- ALTER TABLE products ADD COLUMN base_stock INT NULL DEFAULT NULL AFTER external_id;
+-- UPDATE products SET base_stock = 0 WHERE id IN (SELECT DISTINCT product_id FROM product_variants);
+
+-- Product tags (many-to-many)
+CREATE TABLE IF NOT EXISTS tags (
+    id VARCHAR(40) PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+    UNIQUE KEY uk_tags_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS product_tags (
+    product_id VARCHAR(40) NOT NULL,
+    tag_id VARCHAR(40) NOT NULL,
+    PRIMARY KEY (product_id, tag_id),
+    KEY idx_product_tags_tag_id (tag_id),
+    CONSTRAINT fk_product_tags_product FOREIGN KEY (product_id) REFERENCES products (id) ON DELETE CASCADE,
+    CONSTRAINT fk_product_tags_tag FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Product price changes, recorded by ProductRepository.Update
+CREATE TABLE IF NOT EXISTS product_price_history (
+    id VARCHAR(40) PRIMARY KEY,
+    product_id VARCHAR(40) NOT NULL,
+    old_price DECIMAL(10,2),
+    new_price DECIMAL(10,2),
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_price_history_product_changed (product_id, changed_at),
+    CONSTRAINT fk_price_history_product FOREIGN KEY (product_id) REFERENCES products (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Product variants (e.g. color and size); the product stock is the sum of its variant stock
+-- (products.base_stock keeps the product's own stock until the last variant is deleted)
+CREATE TABLE IF NOT EXISTS product_variants (
+    id VARCHAR(40) PRIMARY KEY,
+    product_id VARCHAR(40) NOT NULL,
+    attributes JSON NOT NULL,
+    price DECIMAL(10,2),
+    stock INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_product_variants_product (product_id, created_at),
+    CONSTRAINT fk_product_variants_product FOREIGN KEY (product_id) REFERENCES products (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Transactional outbox: events written with the business change, published by OutboxPoller
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id VARCHAR(40) PRIMARY KEY,
+    aggregate_type VARCHAR(100) NOT NULL,
+    aggregate_id VARCHAR(40) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSON NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP(6) DEFAULT CURRENT_TIMESTAMP(6),
+    sent_at TIMESTAMP(6) NULL,
+    KEY idx_outbox_events_status_created (status, created_at)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
\ No newline at end of file
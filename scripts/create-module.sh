@@ -141,6 +141,7 @@ EOF
 package ${MODULE_NAME}
 
 import (
+	"context"
 	"database/sql"
 
 	"${MODULE_PATH}/internal/${MODULE_NAME}/controllers"
@@ -152,6 +153,8 @@ import (
 type ${MODULE_NAME_CAPITALIZED}Module struct {
 	// TODO: Add your controllers here
 	// Example: ProductController *controllers.ProductController
+
+	db *sql.DB
 }
 
 // New${MODULE_NAME_CAPITALIZED}Module creates and wires all dependencies for the ${MODULE_NAME} module
@@ -164,8 +167,24 @@ func New${MODULE_NAME_CAPITALIZED}Module(db *sql.DB) *${MODULE_NAME_CAPITALIZED}
 	
 	return &${MODULE_NAME_CAPITALIZED}Module{
 		// TODO: Initialize your dependencies
+		db: db,
 	}
 }
+
+// Name identifies the module in configuration (implements module.Module)
+func (m *${MODULE_NAME_CAPITALIZED}Module) Name() string {
+	return "${MODULE_NAME}"
+}
+
+// RoutePrefix is the default prefix for the module routes (implements module.Module)
+func (m *${MODULE_NAME_CAPITALIZED}Module) RoutePrefix() string {
+	return "/"
+}
+
+// HealthCheck reports whether the module's database is reachable (implements module.Module)
+func (m *${MODULE_NAME_CAPITALIZED}Module) HealthCheck(ctx context.Context) error {
+	return m.db.PingContext(ctx)
+}
 EOF
     
     print_success "Created module.go"
@@ -179,8 +198,13 @@ import (
 	"${MODULE_PATH}/internal/shared/web/context"
 )
 
+// RegisterRoutes registers the module routes on group (implements module.Module)
+func (m *${MODULE_NAME_CAPITALIZED}Module) RegisterRoutes(group *gin.RouterGroup) {
+	RegisterRoutes(group, m)
+}
+
 // RegisterRoutes registers all routes for the ${MODULE_NAME} module (4-tier architecture)
-func RegisterRoutes(router *gin.Engine, module *${MODULE_NAME_CAPITALIZED}Module) {
+func RegisterRoutes(router gin.IRoutes, module *${MODULE_NAME_CAPITALIZED}Module) {
 	// TODO: Add your routes here
 	// Example:
 	// router.GET("/${MODULE_NAME}/:id", func(ctx *gin.Context) {
@@ -208,6 +232,7 @@ else
 package infra
 
 import (
+	"context"
 	"database/sql"
 
 	"${MODULE_PATH}/internal/${MODULE_NAME}/core/application/usecases"
@@ -220,6 +245,8 @@ type ${MODULE_NAME_CAPITALIZED}Module struct {
 	// TODO: Add your controllers and use cases here
 	// Example: GetExampleUseCase *usecases.GetExampleUseCase
 	// Example: ExampleController *controllers.ExampleController
+
+	db *sql.DB
 }
 
 // New${MODULE_NAME_CAPITALIZED}Module creates and wires all dependencies for the ${MODULE_NAME} module
@@ -232,8 +259,24 @@ func New${MODULE_NAME_CAPITALIZED}Module(db *sql.DB) *${MODULE_NAME_CAPITALIZED}
 	
 	return &${MODULE_NAME_CAPITALIZED}Module{
 		// TODO: Initialize your dependencies
+		db: db,
 	}
 }
+
+// Name identifies the module in configuration (implements module.Module)
+func (m *${MODULE_NAME_CAPITALIZED}Module) Name() string {
+	return "${MODULE_NAME}"
+}
+
+// RoutePrefix is the default prefix for the module routes (implements module.Module)
+func (m *${MODULE_NAME_CAPITALIZED}Module) RoutePrefix() string {
+	return "/"
+}
+
+// HealthCheck reports whether the module's database is reachable (implements module.Module)
+func (m *${MODULE_NAME_CAPITALIZED}Module) HealthCheck(ctx context.Context) error {
+	return m.db.PingContext(ctx)
+}
 EOF
     
     print_success "Created infra/module.go"
@@ -249,7 +292,7 @@ import (
 )
 
 // RegisterRoutes registers all routes for the ${MODULE_NAME} module
-func RegisterRoutes(router *gin.Engine, module *infra.${MODULE_NAME_CAPITALIZED}Module) {
+func RegisterRoutes(router gin.IRoutes, module *infra.${MODULE_NAME_CAPITALIZED}Module) {
 	// TODO: Add your routes here
 	// Example:
 	// router.GET("/${MODULE_NAME}/:id", func(ctx *gin.Context) {
@@ -259,6 +302,34 @@ func RegisterRoutes(router *gin.Engine, module *infra.${MODULE_NAME_CAPITALIZED}
 EOF
     
     print_success "Created infra/web/routes.go"
+    
+    # Create web module adapter (infra cannot import infra/web without a cycle)
+    cat > "$MODULE_DIR/infra/web/module.go" <<EOF
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"${MODULE_PATH}/internal/${MODULE_NAME}/infra"
+)
+
+// Module adapts infra.${MODULE_NAME_CAPITALIZED}Module to module.Module
+// Routes live in this package, which infra cannot import without a cycle
+type Module struct {
+	*infra.${MODULE_NAME_CAPITALIZED}Module
+}
+
+// NewModule wraps the ${MODULE_NAME} module so it can be registered in the container
+func NewModule(m *infra.${MODULE_NAME_CAPITALIZED}Module) *Module {
+	return &Module{${MODULE_NAME_CAPITALIZED}Module: m}
+}
+
+// RegisterRoutes registers the module routes on group
+func (m *Module) RegisterRoutes(group *gin.RouterGroup) {
+	RegisterRoutes(group, m.${MODULE_NAME_CAPITALIZED}Module)
+}
+EOF
+    
+    print_success "Created infra/web/module.go"
 fi
 
 echo ""
@@ -326,50 +397,31 @@ else
     print_warning "Field already exists in Container return statement"
 fi
 
-# Remove backup file
-rm -f "${CONTAINER_FILE}.bak"
-
-echo ""
-print_info "Updating register_routes.go..."
-
-# Update register_routes.go
-ROUTES_FILE="$PROJECT_ROOT/internal/infra/web/register_routes.go"
-
-# Determine the import path and function call based on architecture
+# Register the module so its routes are mounted by register_routes.go
 if [ "$ARCH_TYPE" = "1" ]; then
-    ROUTE_IMPORT_ALIAS="${MODULE_NAME}"
-    ROUTE_IMPORT_PATH="${MODULE_PATH}/internal/${MODULE_NAME}"
-    ROUTE_CALL="${MODULE_NAME}.RegisterRoutes(router, c.${STRUCT_NAME})"
+    REGISTER_CALL="c.RegisterModule(${MODULE_NAME}Module)"
 else
-    ROUTE_IMPORT_ALIAS="${MODULE_NAME}Web"
-    ROUTE_IMPORT_PATH="${MODULE_PATH}/internal/${MODULE_NAME}/infra/web"
-    ROUTE_CALL="${MODULE_NAME}Web.RegisterRoutes(router, c.${STRUCT_NAME})"
-fi
+    WEB_IMPORT_PATH="${MODULE_PATH}/internal/${MODULE_NAME}/infra/web"
+    REGISTER_CALL="c.RegisterModule(${MODULE_NAME}Web.NewModule(${MODULE_NAME}Module))"
 
-# Add import
-if ! grep -q "\"$ROUTE_IMPORT_PATH\"" "$ROUTES_FILE"; then
-    # Find the last import line and add the new import after it
-    sed -i.bak "/^import (/a\\
-	${ROUTE_IMPORT_ALIAS} \"${ROUTE_IMPORT_PATH}\"
-" "$ROUTES_FILE"
-    print_success "Added import to register_routes.go"
-else
-    print_warning "Import already exists in register_routes.go"
+    if ! grep -q "\"$WEB_IMPORT_PATH\"" "$CONTAINER_FILE"; then
+        sed -i.bak "/^import (/a\\
+	${MODULE_NAME}Web \"${WEB_IMPORT_PATH}\"
+" "$CONTAINER_FILE"
+    fi
 fi
 
-# Add route registration
-if ! grep -q "${ROUTE_CALL}" "$ROUTES_FILE"; then
-    # Find the line with "Register routes for each module" comment and add the registration
-    sed -i.bak "/Register routes for each module/a\\
-		${ROUTE_CALL}
-" "$ROUTES_FILE"
-    print_success "Added route registration to register_routes.go"
+if ! grep -q "${REGISTER_CALL}" "$CONTAINER_FILE"; then
+    sed -i.bak "/Register modules so their routes and health checks are picked up automatically/a\\
+	${REGISTER_CALL}
+" "$CONTAINER_FILE"
+    print_success "Registered module in container.go"
 else
-    print_warning "Route registration already exists in register_routes.go"
+    print_warning "Module already registered in container.go"
 fi
 
 # Remove backup file
-rm -f "${ROUTES_FILE}.bak"
+rm -f "${CONTAINER_FILE}.bak"
 
 echo ""
 print_success "Module '$MODULE_NAME' created successfully!"
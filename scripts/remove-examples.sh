@@ -54,8 +54,8 @@ echo -e "${BLUE}🔧 Updating container.go...${NC}"
 # Update container.go
 container_file="cmd/server/container/container.go"
 if [ -f "$container_file" ]; then
-    # Create a temporary file without Example lines
-    grep -v "Example" "$container_file" > "$container_file.tmp"
+    # Create a temporary file without Example lines and example module imports
+    grep -v -e "Example" -e "/internal/example/" "$container_file" > "$container_file.tmp"
     mv "$container_file.tmp" "$container_file"
     
     # Format the file